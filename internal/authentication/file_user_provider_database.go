@@ -208,6 +208,7 @@ type DatabaseUserDetails struct {
 	DisplayName string
 	Email       string
 	Groups      []string
+	Extra       map[string]any
 }
 
 // ToUserDetails converts DatabaseUserDetails into a *UserDetails given a username.
@@ -217,6 +218,7 @@ func (m DatabaseUserDetails) ToUserDetails() (details *UserDetails) {
 		DisplayName: m.DisplayName,
 		Emails:      []string{m.Email},
 		Groups:      m.Groups,
+		Extra:       m.Extra,
 	}
 }
 
@@ -227,6 +229,7 @@ func (m DatabaseUserDetails) ToUserDetailsModel() (model UserDetailsModel) {
 		DisplayName:    m.DisplayName,
 		Email:          m.Email,
 		Groups:         m.Groups,
+		Extra:          m.Extra,
 	}
 }
 
@@ -299,11 +302,12 @@ func (m *DatabaseModel) Write(fileName string) (err error) {
 
 // UserDetailsModel is the model of user details in the file database.
 type UserDetailsModel struct {
-	HashedPassword string   `yaml:"password" valid:"required"`
-	DisplayName    string   `yaml:"displayname" valid:"required"`
-	Email          string   `yaml:"email"`
-	Groups         []string `yaml:"groups"`
-	Disabled       bool     `yaml:"disabled"`
+	HashedPassword string         `yaml:"password" valid:"required"`
+	DisplayName    string         `yaml:"displayname" valid:"required"`
+	Email          string         `yaml:"email"`
+	Groups         []string       `yaml:"groups"`
+	Disabled       bool           `yaml:"disabled"`
+	Extra          map[string]any `yaml:"extra,omitempty"`
 }
 
 // ToDatabaseUserDetailsModel converts a UserDetailsModel into a *DatabaseUserDetails.
@@ -321,5 +325,6 @@ func (m UserDetailsModel) ToDatabaseUserDetailsModel(username string) (model *Da
 		DisplayName: m.DisplayName,
 		Email:       m.Email,
 		Groups:      m.Groups,
+		Extra:       m.Extra,
 	}, nil
 }
@@ -162,6 +162,7 @@ func (p *LDAPUserProvider) GetDetails(username string) (details *UserDetails, er
 		DisplayName: profile.DisplayName,
 		Emails:      profile.Emails,
 		Groups:      groups,
+		Extra:       profile.Extra,
 	}, nil
 }
 
@@ -371,6 +372,22 @@ func (p *LDAPUserProvider) getUserProfile(client LDAPClient, username string) (p
 		if attr.Name == p.config.DisplayNameAttribute {
 			userProfile.DisplayName = attr.Values[0]
 		}
+
+		for _, extra := range p.config.ExtraAttributes {
+			if attr.Name != extra.Attribute {
+				continue
+			}
+
+			if userProfile.Extra == nil {
+				userProfile.Extra = map[string]any{}
+			}
+
+			if extra.MultiValued {
+				userProfile.Extra[extra.Name] = attr.Values
+			} else {
+				userProfile.Extra[extra.Name] = attr.Values[0]
+			}
+		}
 	}
 
 	if userProfile.Username == "" {
@@ -108,6 +108,12 @@ func (p *LDAPUserProvider) parseDynamicUsersConfiguration() {
 		p.usersAttributes = append(p.usersAttributes, p.config.DisplayNameAttribute)
 	}
 
+	for _, extra := range p.config.ExtraAttributes {
+		if !utils.IsStringInSlice(extra.Attribute, p.usersAttributes) {
+			p.usersAttributes = append(p.usersAttributes, extra.Attribute)
+		}
+	}
+
 	if p.config.AdditionalUsersDN != "" {
 		p.usersBaseDN = p.config.AdditionalUsersDN + "," + p.config.BaseDN
 	} else {
@@ -35,6 +35,9 @@ type UserDetails struct {
 	DisplayName string
 	Emails      []string
 	Groups      []string
+
+	// Extra contains the additional attributes of the user which are not part of the standard attributes above.
+	Extra map[string]any
 }
 
 // Addresses returns the Emails []string as []mail.Address formatted with DisplayName as the Name attribute.
@@ -60,6 +63,7 @@ type ldapUserProfile struct {
 	Emails      []string
 	DisplayName string
 	Username    string
+	Extra       map[string]any
 }
 
 // LDAPSupportedFeatures represents features which a server may support which are implemented in code.
@@ -12,6 +12,7 @@ type Authorizer struct {
 	defaultPolicy Level
 	rules         []*AccessControlRule
 	mfa           bool
+	oidc          map[string]*OpenIDConnectAuthorizationPolicy
 	config        *schema.Configuration
 	log           *logrus.Logger
 }
@@ -21,6 +22,7 @@ func NewAuthorizer(config *schema.Configuration) (authorizer *Authorizer) {
 	authorizer = &Authorizer{
 		defaultPolicy: StringToLevel(config.AccessControl.DefaultPolicy),
 		rules:         NewAccessControlRules(config.AccessControl),
+		oidc:          NewOpenIDConnectAuthorizationPolicies(config),
 		config:        config,
 		log:           logging.Logger(),
 	}
@@ -46,6 +48,12 @@ func NewAuthorizer(config *schema.Configuration) (authorizer *Authorizer) {
 
 				return authorizer
 			}
+
+			if policy, ok := authorizer.oidc[client.Policy]; ok && policy.IsTwoFactor() {
+				authorizer.mfa = true
+
+				return authorizer
+			}
 		}
 	}
 
@@ -77,6 +85,22 @@ func (p Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubject
 	return false, p.defaultPolicy
 }
 
+// GetRequiredLevelOpenIDConnect retrieves the required level of authorization for the subject to authorize an OpenID
+// Connect client with the provided authorization policy. The fallback level is returned if the policy is not one of
+// the configured OpenID Connect authorization policies.
+func (p Authorizer) GetRequiredLevelOpenIDConnect(policy string, fallback Level, subject Subject) (level Level) {
+	oidcPolicy, ok := p.oidc[policy]
+	if !ok {
+		return fallback
+	}
+
+	level = oidcPolicy.GetRequiredLevel(subject)
+
+	p.log.Debugf("Check OpenID Connect authorization policy '%s' of subject %s resulted in the level '%s'.", policy, subject.String(), LevelToString(level))
+
+	return level
+}
+
 // GetRuleMatchResults iterates through the rules and produces a list of RuleMatchResult provided a subject and object.
 func (p Authorizer) GetRuleMatchResults(subject Subject, object Object) (results []RuleMatchResult) {
 	skipped := false
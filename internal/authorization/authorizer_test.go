@@ -1103,3 +1103,86 @@ func TestAuthorizerIsSecondFactorEnabledRuleWithOIDC(t *testing.T) {
 	authorizer = NewAuthorizer(config)
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 }
+
+func TestAuthorizerIsSecondFactorEnabledOIDCAuthorizationPolicy(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: oneFactor,
+		},
+		IdentityProviders: schema.IdentityProvidersConfiguration{
+			OIDC: &schema.OpenIDConnectConfiguration{
+				AuthorizationPolicies: []schema.OpenIDConnectAuthorizationPolicy{
+					{
+						Name:          "admins",
+						DefaultPolicy: deny,
+						Rules: []schema.OpenIDConnectAuthorizationPolicyRule{
+							{Policy: oneFactor, Subjects: [][]string{{"group:admins"}}},
+						},
+					},
+				},
+				Clients: []schema.OpenIDConnectClientConfiguration{
+					{
+						Policy: "admins",
+					},
+				},
+			},
+		},
+	}
+
+	authorizer := NewAuthorizer(config)
+	assert.False(t, authorizer.IsSecondFactorEnabled())
+
+	config.IdentityProviders.OIDC.AuthorizationPolicies[0].Rules[0].Policy = twoFactor
+	authorizer = NewAuthorizer(config)
+	assert.True(t, authorizer.IsSecondFactorEnabled())
+}
+
+func TestAuthorizerGetRequiredLevelOpenIDConnect(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: deny,
+			Networks: []schema.ACLNetwork{
+				{Name: "internal", Networks: []string{"10.0.0.0/8"}},
+			},
+		},
+		IdentityProviders: schema.IdentityProvidersConfiguration{
+			OIDC: &schema.OpenIDConnectConfiguration{
+				AuthorizationPolicies: []schema.OpenIDConnectAuthorizationPolicy{
+					{
+						Name:          "admins",
+						DefaultPolicy: deny,
+						Rules: []schema.OpenIDConnectAuthorizationPolicyRule{
+							{Policy: deny, Subjects: [][]string{{"user:john"}}},
+							{Policy: oneFactor, Subjects: [][]string{{"group:admins"}}, Networks: []string{"internal"}},
+							{Policy: twoFactor, Subjects: [][]string{{"group:admins"}}},
+							{Policy: oneFactor, Networks: []string{"192.168.1.0/24"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	authorizer := NewAuthorizer(config)
+
+	testCases := []struct {
+		name     string
+		policy   string
+		subject  Subject
+		expected Level
+	}{
+		{"ShouldReturnFallbackForUnknownPolicy", twoFactor, Subject{Username: "john", IP: net.ParseIP("10.0.0.1")}, TwoFactor},
+		{"ShouldDenyUser", "admins", Subject{Username: "john", Groups: []string{"admins"}, IP: net.ParseIP("10.0.0.1")}, Denied},
+		{"ShouldRequireOneFactorForGroupOnNamedNetwork", "admins", Subject{Username: "harry", Groups: []string{"admins"}, IP: net.ParseIP("10.0.0.1")}, OneFactor},
+		{"ShouldRequireTwoFactorForGroupOffNamedNetwork", "admins", Subject{Username: "harry", Groups: []string{"admins"}, IP: net.ParseIP("172.16.0.1")}, TwoFactor},
+		{"ShouldRequireOneFactorOnNetwork", "admins", Subject{Username: "bob", Groups: []string{"users"}, IP: net.ParseIP("192.168.1.20")}, OneFactor},
+		{"ShouldApplyDefaultPolicy", "admins", Subject{Username: "bob", Groups: []string{"users"}, IP: net.ParseIP("172.16.0.1")}, Denied},
+		{"ShouldRequireOneFactorForAnonymousWhenRulesHaveSubjects", "admins", Subject{IP: net.ParseIP("172.16.0.1")}, OneFactor},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, authorizer.GetRequiredLevelOpenIDConnect(tc.policy, TwoFactor, tc.subject))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package authorization
+
+import (
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewOpenIDConnectAuthorizationPolicies converts the schema.OpenIDConnectAuthorizationPolicy slice into a map of
+// OpenIDConnectAuthorizationPolicy keyed by the policy name. The networks of the rules may refer to the named networks
+// of the schema.AccessControlConfiguration.
+func NewOpenIDConnectAuthorizationPolicies(config *schema.Configuration) (policies map[string]*OpenIDConnectAuthorizationPolicy) {
+	policies = map[string]*OpenIDConnectAuthorizationPolicy{}
+
+	if config.IdentityProviders.OIDC == nil {
+		return policies
+	}
+
+	networksMap, networksCacheMap := parseSchemaNetworks(config.AccessControl.Networks)
+
+	for _, schemaPolicy := range config.IdentityProviders.OIDC.AuthorizationPolicies {
+		policy := &OpenIDConnectAuthorizationPolicy{
+			Name:          schemaPolicy.Name,
+			DefaultPolicy: StringToLevel(schemaPolicy.DefaultPolicy),
+		}
+
+		for i, schemaRule := range schemaPolicy.Rules {
+			rule := &AccessControlRule{
+				Position: i + 1,
+				Networks: schemaNetworksToACL(schemaRule.Networks, networksMap, networksCacheMap),
+				Subjects: schemaSubjectsToACL(schemaRule.Subjects),
+				Policy:   StringToLevel(schemaRule.Policy),
+			}
+
+			rule.HasSubjects = len(rule.Subjects) != 0
+
+			policy.Rules = append(policy.Rules, rule)
+		}
+
+		policies[policy.Name] = policy
+	}
+
+	return policies
+}
+
+// OpenIDConnectAuthorizationPolicy represents a named authorization policy for OpenID Connect clients.
+type OpenIDConnectAuthorizationPolicy struct {
+	Name          string
+	DefaultPolicy Level
+	Rules         []*AccessControlRule
+}
+
+// GetRequiredLevel returns the Level the Subject requires to authorize a client with this policy. The first rule which
+// matches both the networks and the subjects applies, otherwise the default policy applies. Anonymous subjects which
+// match the networks of a rule with subjects require OneFactor so they can be identified before the rule is evaluated.
+func (p *OpenIDConnectAuthorizationPolicy) GetRequiredLevel(subject Subject) Level {
+	for _, rule := range p.Rules {
+		if !rule.MatchesNetworks(subject) {
+			continue
+		}
+
+		if rule.HasSubjects && subject.IsAnonymous() {
+			return OneFactor
+		}
+
+		if rule.MatchesSubjectExact(subject) {
+			return rule.Policy
+		}
+	}
+
+	return p.DefaultPolicy
+}
+
+// IsTwoFactor returns true if the default policy or any of the rules of this policy require TwoFactor.
+func (p *OpenIDConnectAuthorizationPolicy) IsTwoFactor() bool {
+	if p.DefaultPolicy == TwoFactor {
+		return true
+	}
+
+	for _, rule := range p.Rules {
+		if rule.Policy == TwoFactor {
+			return true
+		}
+	}
+
+	return false
+}
@@ -266,6 +266,19 @@ This subcommand shows advanced information about the storage schema useful in so
 authelia storage schema-info --config config.yml
 authelia storage schema-info --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
 
+	cmdAutheliaStorageCleanupShort = "Purge the expired data from the storage"
+
+	cmdAutheliaStorageCleanupLong = `Purge the expired data from the storage.
+
+This subcommand purges the expired OAuth2 sessions, stale OAuth2 consent sessions, expired OAuth2 blacklisted JTIs,
+expired or used OAuth2 device codes and pushed authorization requests, finished OpenID Connect back-channel logouts,
+expired identity verifications, and old authentication logs according to the storage cleanup retention configuration.
+This is the same cleanup the server performs periodically unless it's disabled.`
+
+	cmdAutheliaStorageCleanupExample = `authelia storage cleanup
+authelia storage cleanup --config config.yml
+authelia storage cleanup --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
 	cmdAutheliaStorageMigrateShort = "Perform or list migrations"
 
 	cmdAutheliaStorageMigrateLong = `Perform or list migrations.
@@ -464,6 +477,94 @@ This subcommand allows generating an %s key pair.`
 
 	cmdAutheliaCryptoPairEd25519GenerateExample = `authelia crypto pair ed25519 generate --help`
 
+	cmdAutheliaOpenIDConnectShort = "Manage the OpenID Connect 1.0 provider"
+
+	cmdAutheliaOpenIDConnectLong = `Manage the OpenID Connect 1.0 provider.
+
+This subcommand allows managing the OpenID Connect 1.0 provider.`
+
+	cmdAutheliaOpenIDConnectExample = `authelia oidc --help`
+
+	cmdAutheliaOpenIDConnectClientsShort = "Manage OpenID Connect 1.0 clients"
+
+	cmdAutheliaOpenIDConnectClientsLong = `Manage OpenID Connect 1.0 clients.
+
+This subcommand allows managing the OpenID Connect 1.0 clients which are stored in the database. These clients are loaded
+alongside the clients in the configuration, which always take precedence over a client in the database with the same id.`
+
+	cmdAutheliaOpenIDConnectClientsExample = `authelia oidc clients --help`
+
+	cmdAutheliaOpenIDConnectClientsCreateShort = "Create an OpenID Connect 1.0 client"
+
+	cmdAutheliaOpenIDConnectClientsCreateLong = `Create an OpenID Connect 1.0 client.
+
+This subcommand allows creating an OpenID Connect 1.0 client in the database. The generated client secret is only
+displayed once, as only the digest of the client secret is stored.`
+
+	cmdAutheliaOpenIDConnectClientsCreateExample = `authelia oidc clients create myapp --redirect-uris https://app.example.com/oauth2/callback
+authelia oidc clients create myapp --description "My Application" --policy one_factor --scopes openid,profile,email,groups --redirect-uris https://app.example.com/oauth2/callback
+authelia oidc clients create myapp --public --redirect-uris https://app.example.com/oauth2/callback --config config.yml
+authelia oidc clients create myapp --redirect-uris https://app.example.com/oauth2/callback --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaOpenIDConnectClientsListShort = "List OpenID Connect 1.0 clients"
+
+	cmdAutheliaOpenIDConnectClientsListLong = `List OpenID Connect 1.0 clients.
+
+This subcommand allows listing the OpenID Connect 1.0 clients in the database.`
+
+	cmdAutheliaOpenIDConnectClientsListExample = `authelia oidc clients list
+authelia oidc clients list --config config.yml
+authelia oidc clients list --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaOpenIDConnectClientsUpdateShort = "Update an OpenID Connect 1.0 client"
+
+	cmdAutheliaOpenIDConnectClientsUpdateLong = `Update an OpenID Connect 1.0 client.
+
+This subcommand allows updating an OpenID Connect 1.0 client in the database. Only the options which are provided are
+updated. A client secret is generated and displayed once if the client was previously a public client.`
+
+	cmdAutheliaOpenIDConnectClientsUpdateExample = `authelia oidc clients update myapp --policy two_factor
+authelia oidc clients update myapp --redirect-uris https://app.example.com/oauth2/callback,https://app.example.com/callback
+authelia oidc clients update myapp --scopes openid,profile --config config.yml
+authelia oidc clients update myapp --description "My Application" --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaOpenIDConnectClientsRotateSecretShort = "Rotate the secret of an OpenID Connect 1.0 client"
+
+	cmdAutheliaOpenIDConnectClientsRotateSecretLong = `Rotate the secret of an OpenID Connect 1.0 client.
+
+This subcommand allows generating a new client secret for an OpenID Connect 1.0 client in the database. The generated
+client secret is only displayed once and the previous client secret is immediately invalidated.`
+
+	cmdAutheliaOpenIDConnectClientsRotateSecretExample = `authelia oidc clients rotate-secret myapp
+authelia oidc clients rotate-secret myapp --config config.yml
+authelia oidc clients rotate-secret myapp --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaOpenIDConnectClientsDeleteShort = "Delete an OpenID Connect 1.0 client"
+
+	cmdAutheliaOpenIDConnectClientsDeleteLong = `Delete an OpenID Connect 1.0 client.
+
+This subcommand allows deleting an OpenID Connect 1.0 client from the database.`
+
+	cmdAutheliaOpenIDConnectClientsDeleteExample = `authelia oidc clients delete myapp
+authelia oidc clients delete myapp --config config.yml
+authelia oidc clients delete myapp --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaOpenIDConnectRotateHMACSecretShort = "Generate and stage a new OpenID Connect 1.0 HMAC secret"
+
+	cmdAutheliaOpenIDConnectRotateHMACSecretLong = `Generate and stage a new OpenID Connect 1.0 HMAC secret.
+
+This subcommand allows generating a new HMAC secret for the OpenID Connect 1.0 provider. The new HMAC secret and the
+rotated HMAC secrets are written to the configuration file provided with the --file flag, which must be a file that only
+contains these options and is loaded as one of the configuration files. The secrets are never displayed.
+
+The current HMAC secret is moved to the rotated HMAC secrets so the authorization codes, access tokens, and refresh
+tokens issued before the rotation remain valid. Only the number of rotated HMAC secrets provided with the --keep flag are
+kept, and they can be removed once the longest of the authorization code, access token, and refresh token lifespans has
+elapsed after the new secret is staged.`
+
+	cmdAutheliaOpenIDConnectRotateHMACSecretExample = `authelia oidc rotate-hmac-secret --file oidc_hmac.yml --config config.yml --config oidc_hmac.yml
+authelia oidc rotate-hmac-secret --length 128 --keep 2 --file oidc_hmac.yml --config config.yml --config oidc_hmac.yml`
+
 	cmdAutheliaHashPasswordShort = "Hash a password to be used in file-based users database"
 
 	cmdAutheliaHashPasswordLong = `Hash a password to be used in file-based users database.`
@@ -477,6 +578,11 @@ authelia hash-password --parallelism=1 -- 'mypass'
 authelia hash-password --key-length=64 -- 'mypass'`
 )
 
+const (
+	policyOneFactor = "one_factor"
+	policyTwoFactor = "two_factor"
+)
+
 const (
 	storageMigrateDirectionUp   = "up"
 	storageMigrateDirectionDown = "down"
@@ -545,9 +651,21 @@ const (
 	cmdFlagNameSHA512       = "sha512"
 	cmdFlagNameConfig       = "config"
 
+	cmdFlagNameDescription             = "description"
+	cmdFlagNamePolicy                  = "policy"
+	cmdFlagNamePublic                  = "public"
+	cmdFlagNameRedirectURIs            = "redirect-uris"
+	cmdFlagNameScopes                  = "scopes"
+	cmdFlagNameGrantTypes              = "grant-types"
+	cmdFlagNameResponseTypes           = "response-types"
+	cmdFlagNameTokenEndpointAuthMethod = "token-endpoint-auth-method"
+
 	cmdFlagNameCharSet    = "charset"
 	cmdFlagNameCharacters = "characters"
 	cmdFlagNameLength     = "length"
+
+	cmdFlagNameFile = "file"
+	cmdFlagNameKeep = "keep"
 )
 
 const (
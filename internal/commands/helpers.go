@@ -85,12 +85,18 @@ func getProviders() (providers middlewares.Providers, warnings []error, errors [
 		metricsProvider = metrics.NewPrometheus()
 	}
 
+	var storageCleaner *storage.Cleaner
+	if !config.Storage.Cleanup.Disable && storageProvider != nil {
+		storageCleaner = storage.NewCleaner(config.Storage.Cleanup, storageProvider, metricsProvider)
+	}
+
 	return middlewares.Providers{
 		Authorizer:      authorizer,
 		UserProvider:    userProvider,
 		Regulator:       regulator,
 		OpenIDConnect:   oidcProvider,
 		StorageProvider: storageProvider,
+		StorageCleaner:  storageCleaner,
 		Metrics:         metricsProvider,
 		NTP:             ntpProvider,
 		Notifier:        notifier,
@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func newOpenIDConnectCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:               "oidc",
+		Short:             cmdAutheliaOpenIDConnectShort,
+		Long:              cmdAutheliaOpenIDConnectLong,
+		Example:           cmdAutheliaOpenIDConnectExample,
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: storagePersistentPreRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmdWithStorageFlags(cmd)
+
+	cmd.AddCommand(
+		newOpenIDConnectClientsCmd(),
+		newOpenIDConnectRotateHMACSecretCmd(),
+	)
+
+	return cmd
+}
+
+func newOpenIDConnectRotateHMACSecretCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "rotate-hmac-secret",
+		Short:   cmdAutheliaOpenIDConnectRotateHMACSecretShort,
+		Long:    cmdAutheliaOpenIDConnectRotateHMACSecretLong,
+		Example: cmdAutheliaOpenIDConnectRotateHMACSecretExample,
+		Args:    cobra.NoArgs,
+		RunE:    openIDConnectRotateHMACSecretRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().IntP(cmdFlagNameLength, "n", 64, "the length of the generated HMAC secret")
+	cmd.Flags().StringP(cmdFlagNameFile, "f", "", "the configuration file which the HMAC secrets are written to")
+	cmd.Flags().Int(cmdFlagNameKeep, 1, "the number of previous HMAC secrets to keep as rotated HMAC secrets")
+
+	return cmd
+}
+
+func newOpenIDConnectClientsCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "clients",
+		Short:   cmdAutheliaOpenIDConnectClientsShort,
+		Long:    cmdAutheliaOpenIDConnectClientsLong,
+		Example: cmdAutheliaOpenIDConnectClientsExample,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newOpenIDConnectClientsCreateCmd(),
+		newOpenIDConnectClientsListCmd(),
+		newOpenIDConnectClientsUpdateCmd(),
+		newOpenIDConnectClientsRotateSecretCmd(),
+		newOpenIDConnectClientsDeleteCmd(),
+	)
+
+	return cmd
+}
+
+func newOpenIDConnectClientsCreateCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "create <id>",
+		Short:   cmdAutheliaOpenIDConnectClientsCreateShort,
+		Long:    cmdAutheliaOpenIDConnectClientsCreateLong,
+		Example: cmdAutheliaOpenIDConnectClientsCreateExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    openIDConnectClientsCreateRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmdOpenIDConnectClientsFlags(cmd)
+
+	return cmd
+}
+
+func newOpenIDConnectClientsListCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list",
+		Short:   cmdAutheliaOpenIDConnectClientsListShort,
+		Long:    cmdAutheliaOpenIDConnectClientsListLong,
+		Example: cmdAutheliaOpenIDConnectClientsListExample,
+		Args:    cobra.NoArgs,
+		RunE:    openIDConnectClientsListRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newOpenIDConnectClientsUpdateCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "update <id>",
+		Short:   cmdAutheliaOpenIDConnectClientsUpdateShort,
+		Long:    cmdAutheliaOpenIDConnectClientsUpdateLong,
+		Example: cmdAutheliaOpenIDConnectClientsUpdateExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    openIDConnectClientsUpdateRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmdOpenIDConnectClientsFlags(cmd)
+
+	return cmd
+}
+
+func newOpenIDConnectClientsRotateSecretCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "rotate-secret <id>",
+		Short:   cmdAutheliaOpenIDConnectClientsRotateSecretShort,
+		Long:    cmdAutheliaOpenIDConnectClientsRotateSecretLong,
+		Example: cmdAutheliaOpenIDConnectClientsRotateSecretExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    openIDConnectClientsRotateSecretRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newOpenIDConnectClientsDeleteCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "delete <id>",
+		Short:   cmdAutheliaOpenIDConnectClientsDeleteShort,
+		Long:    cmdAutheliaOpenIDConnectClientsDeleteLong,
+		Example: cmdAutheliaOpenIDConnectClientsDeleteExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    openIDConnectClientsDeleteRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func cmdOpenIDConnectClientsFlags(cmd *cobra.Command) {
+	defaults := schema.DefaultOpenIDConnectClientConfiguration
+
+	cmd.Flags().String(cmdFlagNameDescription, "", "the description of the client which is displayed to users, defaults to the id")
+	cmd.Flags().String(cmdFlagNamePolicy, defaults.Policy, "the authorization policy of the client, valid values are: one_factor, two_factor")
+	cmd.Flags().Bool(cmdFlagNamePublic, false, "configures the client as a public client which does not have a client secret")
+	cmd.Flags().StringSlice(cmdFlagNameRedirectURIs, nil, "the redirect uris of the client")
+	cmd.Flags().StringSlice(cmdFlagNameScopes, defaults.Scopes, "the scopes the client is allowed to request")
+	cmd.Flags().StringSlice(cmdFlagNameGrantTypes, defaults.GrantTypes, "the grant types the client is allowed to use")
+	cmd.Flags().StringSlice(cmdFlagNameResponseTypes, defaults.ResponseTypes, "the response types the client is allowed to use")
+	cmd.Flags().String(cmdFlagNameTokenEndpointAuthMethod, "client_secret_basic", "the token endpoint authentication method of a confidential client, valid values are: client_secret_basic, client_secret_post")
+}
@@ -0,0 +1,512 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+func openIDConnectClientsCreateRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		provider storage.Provider
+		policy   string
+		metadata oidc.ClientRegistrationMetadata
+
+		ctx = context.Background()
+		id  = args[0]
+	)
+
+	for _, client := range getOpenIDConnectConfiguration().Clients {
+		if client.ID == id {
+			return fmt.Errorf("the client id '%s' is already in use by a client in the configuration", id)
+		}
+	}
+
+	if policy, err = openIDConnectClientsApplyFlags(cmd.Flags(), true, &metadata); err != nil {
+		return err
+	}
+
+	if err = oidc.ValidateClientRegistrationMetadata(getOpenIDConnectConfiguration(), &metadata); err != nil {
+		return fmt.Errorf("the client options are invalid: %s", fosite.ErrorToRFC6749Error(err).GetDescription())
+	}
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return err
+	}
+
+	if _, err = provider.LoadOAuth2Client(ctx, id); err == nil {
+		return fmt.Errorf("the client id '%s' is already in use by a client in the database", id)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("error checking if the client id '%s' is in use: %w", id, err)
+	}
+
+	now := time.Now().UTC()
+
+	client := model.OAuth2Client{
+		ClientID:  id,
+		Policy:    policy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	var secret string
+
+	if metadata.TokenEndpointAuthMethod != oidc.ClientAuthMethodNone {
+		if client.Secret, secret, err = oidc.NewRegisteredClientSecret(); err != nil {
+			return fmt.Errorf("error generating the client secret: %w", err)
+		}
+	}
+
+	if client.Metadata, err = oidc.EncodeClientRegistrationMetadata(metadata); err != nil {
+		return err
+	}
+
+	if err = provider.SaveOAuth2Client(ctx, client); err != nil {
+		return fmt.Errorf("error saving the client: %w", err)
+	}
+
+	fmt.Printf("Created OpenID Connect 1.0 client:\n\tID: %s\n\tPolicy: %s\n\tToken Endpoint Auth Method: %s\n", client.ClientID, client.Policy, metadata.TokenEndpointAuthMethod)
+
+	printOpenIDConnectClientSecret(secret)
+
+	return nil
+}
+
+func openIDConnectClientsListRunE(_ *cobra.Command, _ []string) (err error) {
+	var (
+		provider storage.Provider
+		clients  []model.OAuth2Client
+
+		ctx = context.Background()
+	)
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return err
+	}
+
+	limit := 10
+
+	output := strings.Builder{}
+
+	for page := 0; true; page++ {
+		if clients, err = provider.LoadOAuth2Clients(ctx, limit, page); err != nil {
+			return fmt.Errorf("failed to list clients: %w", err)
+		}
+
+		if page == 0 && len(clients) == 0 {
+			return errors.New("no clients in database")
+		}
+
+		for _, client := range clients {
+			metadata := oidc.ClientRegistrationMetadata{}
+
+			if err = json.Unmarshal([]byte(client.Metadata), &metadata); err != nil {
+				return fmt.Errorf("error decoding the metadata of the client with id '%s': %w", client.ClientID, err)
+			}
+
+			policy, managed := client.Policy, "cli"
+
+			if policy == "" {
+				policy = "default"
+			}
+
+			if client.RegistrationAccessTokenSignature != "" {
+				managed = "registration"
+			}
+
+			output.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\n", client.ClientID, metadata.ClientName, policy, metadata.TokenEndpointAuthMethod, managed, client.CreatedAt.Format(time.RFC3339)))
+		}
+
+		if len(clients) < limit {
+			break
+		}
+	}
+
+	fmt.Printf("OpenID Connect 1.0 Clients:\n\nID\tDescription\tPolicy\tToken Endpoint Auth Method\tManaged By\tCreated At\n")
+	fmt.Println(output.String())
+
+	return nil
+}
+
+func openIDConnectClientsUpdateRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		provider storage.Provider
+		client   *model.OAuth2Client
+		policy   string
+		metadata oidc.ClientRegistrationMetadata
+
+		ctx = context.Background()
+	)
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if client, metadata, err = loadOpenIDConnectClient(ctx, provider, args[0]); err != nil {
+		return err
+	}
+
+	if policy, err = openIDConnectClientsApplyFlags(cmd.Flags(), false, &metadata); err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed(cmdFlagNamePolicy) {
+		client.Policy = policy
+	}
+
+	if err = oidc.ValidateClientRegistrationMetadata(getOpenIDConnectConfiguration(), &metadata); err != nil {
+		return fmt.Errorf("the client options are invalid: %s", fosite.ErrorToRFC6749Error(err).GetDescription())
+	}
+
+	var secret string
+
+	switch {
+	case metadata.TokenEndpointAuthMethod == oidc.ClientAuthMethodNone:
+		client.Secret = sql.NullString{}
+	case !client.Secret.Valid:
+		if client.Secret, secret, err = oidc.NewRegisteredClientSecret(); err != nil {
+			return fmt.Errorf("error generating the client secret: %w", err)
+		}
+	}
+
+	if err = saveOpenIDConnectClient(ctx, provider, client, metadata); err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated OpenID Connect 1.0 client with id '%s'\n", client.ClientID)
+
+	printOpenIDConnectClientSecret(secret)
+
+	return nil
+}
+
+func openIDConnectClientsRotateSecretRunE(_ *cobra.Command, args []string) (err error) {
+	var (
+		provider storage.Provider
+		client   *model.OAuth2Client
+		metadata oidc.ClientRegistrationMetadata
+		secret   string
+
+		ctx = context.Background()
+	)
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if client, metadata, err = loadOpenIDConnectClient(ctx, provider, args[0]); err != nil {
+		return err
+	}
+
+	if metadata.TokenEndpointAuthMethod == oidc.ClientAuthMethodNone {
+		return fmt.Errorf("the client with id '%s' is a public client which does not have a client secret", client.ClientID)
+	}
+
+	if client.Secret, secret, err = oidc.NewRegisteredClientSecret(); err != nil {
+		return fmt.Errorf("error generating the client secret: %w", err)
+	}
+
+	if err = saveOpenIDConnectClient(ctx, provider, client, metadata); err != nil {
+		return err
+	}
+
+	fmt.Printf("Rotated the secret of the OpenID Connect 1.0 client with id '%s'\n", client.ClientID)
+
+	printOpenIDConnectClientSecret(secret)
+
+	return nil
+}
+
+func openIDConnectClientsDeleteRunE(_ *cobra.Command, args []string) (err error) {
+	var (
+		provider storage.Provider
+
+		ctx = context.Background()
+	)
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if _, _, err = loadOpenIDConnectClient(ctx, provider, args[0]); err != nil {
+		return err
+	}
+
+	if err = provider.DeleteOAuth2Client(ctx, args[0]); err != nil {
+		return fmt.Errorf("error deleting the client with id '%s': %w", args[0], err)
+	}
+
+	fmt.Printf("Deleted OpenID Connect 1.0 client with id '%s'\n", args[0])
+
+	return nil
+}
+
+// openIDConnectClientsApplyFlags applies the flags to the metadata. When all is true every flag is applied, otherwise
+// only the flags which were changed are applied.
+func openIDConnectClientsApplyFlags(flags *pflag.FlagSet, all bool, metadata *oidc.ClientRegistrationMetadata) (policy string, err error) {
+	var (
+		public bool
+		method string
+		values []string
+	)
+
+	if policy, err = flags.GetString(cmdFlagNamePolicy); err != nil {
+		return "", err
+	}
+
+	if !utils.IsStringInSlice(policy, []string{policyOneFactor, policyTwoFactor}) {
+		return "", fmt.Errorf("the policy '%s' is invalid, the valid values are: '%s', '%s'", policy, policyOneFactor, policyTwoFactor)
+	}
+
+	if all || flags.Changed(cmdFlagNameDescription) {
+		if metadata.ClientName, err = flags.GetString(cmdFlagNameDescription); err != nil {
+			return "", err
+		}
+	}
+
+	if all || flags.Changed(cmdFlagNameRedirectURIs) {
+		if metadata.RedirectURIs, err = flags.GetStringSlice(cmdFlagNameRedirectURIs); err != nil {
+			return "", err
+		}
+	}
+
+	if all || flags.Changed(cmdFlagNameScopes) {
+		if values, err = flags.GetStringSlice(cmdFlagNameScopes); err != nil {
+			return "", err
+		}
+
+		metadata.Scope = strings.Join(values, " ")
+	}
+
+	if all || flags.Changed(cmdFlagNameGrantTypes) {
+		if metadata.GrantTypes, err = flags.GetStringSlice(cmdFlagNameGrantTypes); err != nil {
+			return "", err
+		}
+	}
+
+	if all || flags.Changed(cmdFlagNameResponseTypes) {
+		if metadata.ResponseTypes, err = flags.GetStringSlice(cmdFlagNameResponseTypes); err != nil {
+			return "", err
+		}
+	}
+
+	if public, err = flags.GetBool(cmdFlagNamePublic); err != nil {
+		return "", err
+	}
+
+	if method, err = flags.GetString(cmdFlagNameTokenEndpointAuthMethod); err != nil {
+		return "", err
+	}
+
+	switch {
+	case public:
+		metadata.TokenEndpointAuthMethod = oidc.ClientAuthMethodNone
+	case all || flags.Changed(cmdFlagNamePublic) || flags.Changed(cmdFlagNameTokenEndpointAuthMethod):
+		if method != oidc.ClientAuthMethodClientSecretBasic && method != oidc.ClientAuthMethodClientSecretPost {
+			return "", fmt.Errorf("the token endpoint auth method '%s' is invalid, the valid values are: '%s', '%s'", method, oidc.ClientAuthMethodClientSecretBasic, oidc.ClientAuthMethodClientSecretPost)
+		}
+
+		metadata.TokenEndpointAuthMethod = method
+	}
+
+	return policy, nil
+}
+
+func loadOpenIDConnectClient(ctx context.Context, provider storage.Provider, id string) (client *model.OAuth2Client, metadata oidc.ClientRegistrationMetadata, err error) {
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return nil, metadata, err
+	}
+
+	if client, err = provider.LoadOAuth2Client(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, metadata, fmt.Errorf("the client with id '%s' does not exist in the database", id)
+		}
+
+		return nil, metadata, fmt.Errorf("error loading the client with id '%s': %w", id, err)
+	}
+
+	if err = json.Unmarshal([]byte(client.Metadata), &metadata); err != nil {
+		return nil, metadata, fmt.Errorf("error decoding the metadata of the client with id '%s': %w", id, err)
+	}
+
+	return client, metadata, nil
+}
+
+func saveOpenIDConnectClient(ctx context.Context, provider storage.Provider, client *model.OAuth2Client, metadata oidc.ClientRegistrationMetadata) (err error) {
+	if client.Metadata, err = oidc.EncodeClientRegistrationMetadata(metadata); err != nil {
+		return err
+	}
+
+	client.UpdatedAt = time.Now().UTC()
+
+	if err = provider.UpdateOAuth2Client(ctx, *client); err != nil {
+		return fmt.Errorf("error updating the client with id '%s': %w", client.ClientID, err)
+	}
+
+	return nil
+}
+
+func openIDConnectRotateHMACSecretRunE(cmd *cobra.Command, _ []string) (err error) {
+	var (
+		n, keep int
+		file    string
+		buf     bytes.Buffer
+	)
+
+	if n, err = cmd.Flags().GetInt(cmdFlagNameLength); err != nil {
+		return err
+	}
+
+	if keep, err = cmd.Flags().GetInt(cmdFlagNameKeep); err != nil {
+		return err
+	}
+
+	if file, err = cmd.Flags().GetString(cmdFlagNameFile); err != nil {
+		return err
+	}
+
+	switch {
+	case n < 32:
+		return fmt.Errorf("the length of the HMAC secret must be at least 32 but it's %d", n)
+	case keep < 1:
+		return fmt.Errorf("the number of rotated HMAC secrets to keep must be at least 1 but it's %d", keep)
+	case file == "":
+		return fmt.Errorf("the file to write the HMAC secrets to must be provided using the --%s flag", cmdFlagNameFile)
+	}
+
+	current := getOpenIDConnectConfiguration()
+
+	stage := openIDConnectHMACSecretStage{}
+	stage.IdentityProviders.OIDC.HMACSecret = utils.RandomString(n, utils.CharSetAlphaNumeric, true)
+
+	for _, secret := range append([]string{current.HMACSecret}, current.RotatedHMACSecrets...) {
+		if secret == "" || utils.IsStringInSlice(secret, stage.IdentityProviders.OIDC.RotatedHMACSecrets) {
+			continue
+		}
+
+		stage.IdentityProviders.OIDC.RotatedHMACSecrets = append(stage.IdentityProviders.OIDC.RotatedHMACSecrets, secret)
+	}
+
+	dropped := 0
+
+	if len(stage.IdentityProviders.OIDC.RotatedHMACSecrets) > keep {
+		dropped = len(stage.IdentityProviders.OIDC.RotatedHMACSecrets) - keep
+		stage.IdentityProviders.OIDC.RotatedHMACSecrets = stage.IdentityProviders.OIDC.RotatedHMACSecrets[:keep]
+	}
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err = encoder.Encode(&stage); err != nil {
+		return fmt.Errorf("error marshalling the configuration: %w", err)
+	}
+
+	if err = writeFileAtomic(file, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("error occurred writing the HMAC secrets to file '%s': %w", file, err)
+	}
+
+	fmt.Printf("Staged a new OpenID Connect 1.0 HMAC secret in the file '%s'. Restart Authelia with this file as one of the configuration files to use it.\n", file)
+
+	if dropped != 0 {
+		fmt.Printf("Removed the %d oldest rotated HMAC secrets as only %d rotated HMAC secrets are kept.\n", dropped, keep)
+	}
+
+	if len(stage.IdentityProviders.OIDC.RotatedHMACSecrets) != 0 {
+		fmt.Printf("The rotated HMAC secrets can be removed once %s has elapsed after restarting, which is the longest of the authorization code, access token, and refresh token lifespans.\n", getOpenIDConnectHMACSecretRetention(current))
+	}
+
+	return nil
+}
+
+// getOpenIDConnectHMACSecretRetention returns the longest lifespan of the tokens which are signed with the HMAC secret,
+// which is the duration a rotated HMAC secret must be retained for.
+func getOpenIDConnectHMACSecretRetention(config *schema.OpenIDConnectConfiguration) (retention time.Duration) {
+	retention = config.AuthorizeCodeLifespan
+
+	lifespans := []schema.OpenIDConnectLifespans{
+		{AccessToken: config.AccessTokenLifespan, RefreshToken: config.RefreshTokenLifespan},
+	}
+
+	for _, client := range config.Clients {
+		lifespans = append(lifespans,
+			client.Lifespans.OpenIDConnectLifespans,
+			client.Lifespans.Grants.AuthorizationCode,
+			client.Lifespans.Grants.Implicit,
+			client.Lifespans.Grants.ClientCredentials,
+			client.Lifespans.Grants.RefreshToken,
+			client.Lifespans.Grants.DeviceCode,
+			client.Lifespans.Grants.TokenExchange,
+		)
+	}
+
+	for _, lifespan := range lifespans {
+		if lifespan.AccessToken > retention {
+			retention = lifespan.AccessToken
+		}
+
+		if lifespan.RefreshToken > retention {
+			retention = lifespan.RefreshToken
+		}
+	}
+
+	return retention
+}
+
+type openIDConnectHMACSecretStage struct {
+	IdentityProviders struct {
+		OIDC struct {
+			HMACSecret         string   `yaml:"hmac_secret"`
+			RotatedHMACSecrets []string `yaml:"rotated_hmac_secrets,omitempty"`
+		} `yaml:"oidc"`
+	} `yaml:"identity_providers"`
+}
+
+func getOpenIDConnectConfiguration() *schema.OpenIDConnectConfiguration {
+	if config.IdentityProviders.OIDC == nil {
+		return &schema.OpenIDConnectConfiguration{}
+	}
+
+	return config.IdentityProviders.OIDC
+}
+
+func printOpenIDConnectClientSecret(secret string) {
+	if secret == "" {
+		return
+	}
+
+	fmt.Printf("\tSecret: %s\n\nThe client secret is only displayed once as only the digest of the client secret is stored.\n", secret)
+}
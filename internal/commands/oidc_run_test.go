@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestOpenIDConnectRotateHMACSecretRunE(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "oidc_hmac.yml")
+
+	config = &schema.Configuration{
+		IdentityProviders: schema.IdentityProvidersConfiguration{
+			OIDC: &schema.OpenIDConnectConfiguration{
+				HMACSecret:         "current_secret_abc123abc123abc123",
+				RotatedHMACSecrets: []string{"previous_secret_abc123abc123abc12", "oldest_secret_abc123abc123abc1234"},
+			},
+		},
+	}
+
+	defer func() {
+		config = nil
+	}()
+
+	cmd := newOpenIDConnectRotateHMACSecretCmd()
+
+	require.NoError(t, cmd.Flags().Set(cmdFlagNameFile, file))
+	require.NoError(t, cmd.Flags().Set(cmdFlagNameKeep, "2"))
+	require.NoError(t, openIDConnectRotateHMACSecretRunE(cmd, nil))
+
+	info, err := os.Stat(file)
+
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	data, err := os.ReadFile(file)
+
+	require.NoError(t, err)
+
+	stage := openIDConnectHMACSecretStage{}
+
+	require.NoError(t, yaml.Unmarshal(data, &stage))
+
+	assert.Len(t, stage.IdentityProviders.OIDC.HMACSecret, 64)
+	assert.NotEqual(t, "current_secret_abc123abc123abc123", stage.IdentityProviders.OIDC.HMACSecret)
+	assert.Equal(t, []string{"current_secret_abc123abc123abc123", "previous_secret_abc123abc123abc12"}, stage.IdentityProviders.OIDC.RotatedHMACSecrets)
+}
+
+func TestOpenIDConnectRotateHMACSecretRunEShouldRequireFile(t *testing.T) {
+	cmd := newOpenIDConnectRotateHMACSecretCmd()
+
+	assert.EqualError(t, openIDConnectRotateHMACSecretRunE(cmd, nil), "the file to write the HMAC secrets to must be provided using the --file flag")
+
+	require.NoError(t, cmd.Flags().Set(cmdFlagNameKeep, "0"))
+
+	assert.EqualError(t, openIDConnectRotateHMACSecretRunE(cmd, nil), "the number of rotated HMAC secrets to keep must be at least 1 but it's 0")
+}
+
+func TestGetOpenIDConnectHMACSecretRetention(t *testing.T) {
+	config := &schema.OpenIDConnectConfiguration{
+		AccessTokenLifespan:   time.Hour,
+		AuthorizeCodeLifespan: time.Minute,
+		RefreshTokenLifespan:  time.Hour * 2,
+	}
+
+	assert.Equal(t, time.Hour*2, getOpenIDConnectHMACSecretRetention(config))
+
+	config.Clients = []schema.OpenIDConnectClientConfiguration{
+		{ID: "app"},
+	}
+
+	config.Clients[0].Lifespans.Grants.RefreshToken.RefreshToken = time.Hour * 24
+
+	assert.Equal(t, time.Hour*24, getOpenIDConnectHMACSecretRetention(config))
+}
@@ -49,6 +49,7 @@ func NewRootCmd() (cmd *cobra.Command) {
 		newBuildInfoCmd(),
 		newCryptoCmd(),
 		newHashPasswordCmd(),
+		newOpenIDConnectCmd(),
 		newStorageCmd(),
 		newValidateConfigCmd(),
 	)
@@ -154,6 +155,48 @@ func runServices(config *schema.Configuration, providers middlewares.Providers,
 		return nil
 	})
 
+	if providers.OpenIDConnect != nil && providers.OpenIDConnect.KeyRotator != nil {
+		g.Go(func() (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.WithError(recoverErr(r)).Errorf("Critical error in OpenID Connect key rotation caught (recovered)")
+				}
+			}()
+
+			providers.OpenIDConnect.KeyRotator.Run(ctx)
+
+			return nil
+		})
+	}
+
+	if providers.OpenIDConnect != nil {
+		g.Go(func() (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.WithError(recoverErr(r)).Errorf("Critical error in OpenID Connect back-channel logout resumption caught (recovered)")
+				}
+			}()
+
+			providers.OpenIDConnect.ResumeBackChannelLogouts(ctx)
+
+			return nil
+		})
+	}
+
+	if providers.StorageCleaner != nil {
+		g.Go(func() (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.WithError(recoverErr(r)).Errorf("Critical error in storage cleanup caught (recovered)")
+				}
+			}()
+
+			providers.StorageCleaner.Run(ctx)
+
+			return nil
+		})
+	}
+
 	if config.AuthenticationBackend.File != nil && config.AuthenticationBackend.File.Watch {
 		provider := providers.UserProvider.(*authentication.FileUserProvider)
 		if watcher, err := runServiceFileWatcher(g, log, config.AuthenticationBackend.File.Path, provider); err != nil {
@@ -279,6 +322,14 @@ func doStartupChecks(config *schema.Configuration, providers *middlewares.Provid
 		failures = append(failures, "storage")
 	}
 
+	if providers.OpenIDConnect != nil {
+		if err = doStartupCheck(log, "openid connect", providers.OpenIDConnect, false); err != nil {
+			log.Errorf("Failure running the openid connect provider startup check: %+v", err)
+
+			failures = append(failures, "openid connect")
+		}
+	}
+
 	if err = doStartupCheck(log, "user", providers.UserProvider, false); err != nil {
 		log.Errorf("Failure running the user provider startup check: %+v", err)
 
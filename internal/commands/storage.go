@@ -21,6 +21,21 @@ func newStorageCmd() (cmd *cobra.Command) {
 		DisableAutoGenTag: true,
 	}
 
+	cmdWithStorageFlags(cmd)
+
+	cmd.AddCommand(
+		newStorageMigrateCmd(),
+		newStorageSchemaInfoCmd(),
+		newStorageEncryptionCmd(),
+		newStorageUserCmd(),
+		newStorageCleanupCmd(),
+	)
+
+	return cmd
+}
+
+// cmdWithStorageFlags adds the configuration flags and the persistent storage flags to the provided command.
+func cmdWithStorageFlags(cmd *cobra.Command) {
 	cmdWithConfigFlags(cmd, true, []string{"configuration.yml"})
 
 	cmd.PersistentFlags().String("encryption-key", "", "the storage encryption key to use")
@@ -43,15 +58,6 @@ func newStorageCmd() (cmd *cobra.Command) {
 	cmd.PersistentFlags().String("postgres.ssl.root_certificate", "", "the PostgreSQL ssl root certificate file location")
 	cmd.PersistentFlags().String("postgres.ssl.certificate", "", "the PostgreSQL ssl certificate file location")
 	cmd.PersistentFlags().String("postgres.ssl.key", "", "the PostgreSQL ssl key file location")
-
-	cmd.AddCommand(
-		newStorageMigrateCmd(),
-		newStorageSchemaInfoCmd(),
-		newStorageEncryptionCmd(),
-		newStorageUserCmd(),
-	)
-
-	return cmd
 }
 
 func newStorageEncryptionCmd() (cmd *cobra.Command) {
@@ -353,6 +359,21 @@ func newStorageSchemaInfoCmd() (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageCleanupCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "cleanup",
+		Short:   cmdAutheliaStorageCleanupShort,
+		Long:    cmdAutheliaStorageCleanupLong,
+		Example: cmdAutheliaStorageCleanupExample,
+		Args:    cobra.NoArgs,
+		RunE:    storageCleanupRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
 // NewMigrationCmd returns a new Migration Cmd.
 func newStorageMigrateCmd() (cmd *cobra.Command) {
 	cmd = &cobra.Command{
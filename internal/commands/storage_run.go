@@ -102,7 +102,7 @@ func storagePersistentPreRunE(cmd *cobra.Command, _ []string) (err error) {
 		return finalErr
 	}
 
-	validator.ValidateStorage(config.Storage, val)
+	validator.ValidateStorage(&config.Storage, val)
 
 	validator.ValidateTOTP(config, val)
 
@@ -820,6 +820,33 @@ func storageSchemaInfoRunE(_ *cobra.Command, _ []string) (err error) {
 	return nil
 }
 
+func storageCleanupRunE(_ *cobra.Command, _ []string) (err error) {
+	var (
+		provider storage.Provider
+		results  []storage.CleanupResult
+
+		ctx = context.Background()
+	)
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return err
+	}
+
+	results, err = storage.NewCleaner(config.Storage.Cleanup, provider, nil).Cleanup(ctx)
+
+	for _, result := range results {
+		fmt.Printf("Purged %d rows from the '%s' table\n", result.Purged, result.Table)
+	}
+
+	return err
+}
+
 func checkStorageSchemaUpToDate(ctx context.Context, provider storage.Provider) (err error) {
 	var version, latest int
 
@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 )
 
 func recoverErr(i any) error {
@@ -29,3 +30,37 @@ func configFilterExisting(configs []string) (finalConfigs []string) {
 
 	return finalConfigs
 }
+
+// writeFileAtomic writes the data to a temporary file in the same directory as the named file and then renames it to
+// the named file, so the named file either contains the previous or the new data even if writing fails.
+func writeFileAtomic(name string, data []byte, perm os.FileMode) (err error) {
+	var f *os.File
+
+	if f, err = os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*"); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	if err = f.Chmod(perm); err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), name)
+}
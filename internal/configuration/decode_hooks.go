@@ -2,6 +2,7 @@ package configuration
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"fmt"
@@ -415,6 +416,85 @@ func StringToPrivateKeyHookFunc() mapstructure.DecodeHookFuncType {
 	}
 }
 
+// StringToCryptographicPrivateKeyHookFunc decodes a string into a schema.CryptographicPrivateKey.
+func StringToCryptographicPrivateKeyHookFunc() mapstructure.DecodeHookFuncType {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (value interface{}, err error) {
+		if f.Kind() != reflect.String {
+			return data, nil
+		}
+
+		expectedType := reflect.TypeOf((*schema.CryptographicPrivateKey)(nil)).Elem()
+
+		if t != expectedType {
+			return data, nil
+		}
+
+		dataStr := data.(string)
+
+		if dataStr == "" {
+			return nil, nil
+		}
+
+		var i any
+
+		if i, err = utils.ParseX509FromPEM([]byte(dataStr)); err != nil {
+			return nil, fmt.Errorf(errFmtDecodeHookCouldNotParseBasic, "", expectedType, err)
+		}
+
+		switch r := i.(type) {
+		case *rsa.PrivateKey:
+			if err = r.Validate(); err != nil {
+				return nil, fmt.Errorf(errFmtDecodeHookCouldNotParseBasic, "", expectedType, err)
+			}
+
+			return r, nil
+		case *ecdsa.PrivateKey, ed25519.PrivateKey:
+			return r, nil
+		default:
+			return nil, fmt.Errorf(errFmtDecodeHookCouldNotParseBasic, "", expectedType, fmt.Errorf("the data is for a %T not a %s", r, expectedType))
+		}
+	}
+}
+
+// StringToCryptographicPublicKeyHookFunc decodes strings to schema.CryptographicPublicKey's. The string may be a PEM
+// encoded public key or certificate.
+func StringToCryptographicPublicKeyHookFunc() mapstructure.DecodeHookFuncType {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (value interface{}, err error) {
+		if f.Kind() != reflect.String {
+			return data, nil
+		}
+
+		expectedType := reflect.TypeOf((*schema.CryptographicPublicKey)(nil)).Elem()
+
+		if t != expectedType {
+			return data, nil
+		}
+
+		dataStr := data.(string)
+
+		if dataStr == "" {
+			return nil, nil
+		}
+
+		var i any
+
+		if i, err = utils.ParseX509FromPEM([]byte(dataStr)); err != nil {
+			return nil, fmt.Errorf(errFmtDecodeHookCouldNotParseBasic, "", expectedType, err)
+		}
+
+		if cert, ok := i.(*x509.Certificate); ok {
+			i = cert.PublicKey
+		}
+
+		switch r := i.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+			return r, nil
+		default:
+			return nil, fmt.Errorf(errFmtDecodeHookCouldNotParseBasic, "", expectedType, fmt.Errorf("the data is for a %T not a %s", r, expectedType))
+		}
+	}
+}
+
 // StringToPasswordDigestHookFunc decodes a string into a crypt.Digest.
 func StringToPasswordDigestHookFunc(plaintext bool) mapstructure.DecodeHookFuncType {
 	return func(f reflect.Type, t reflect.Type, data interface{}) (value interface{}, err error) {
@@ -113,6 +113,8 @@ type LDAPAuthenticationBackend struct {
 	MailAttribute        string `koanf:"mail_attribute"`
 	DisplayNameAttribute string `koanf:"display_name_attribute"`
 
+	ExtraAttributes []LDAPExtraAttribute `koanf:"extra_attributes"`
+
 	PermitReferrals               bool `koanf:"permit_referrals"`
 	PermitUnauthenticatedBind     bool `koanf:"permit_unauthenticated_bind"`
 	PermitFeatureDetectionFailure bool `koanf:"permit_feature_detection_failure"`
@@ -121,6 +123,14 @@ type LDAPAuthenticationBackend struct {
 	Password string `koanf:"password"`
 }
 
+// LDAPExtraAttribute represents an additional LDAP attribute which is retrieved for users and made available as a user
+// attribute with the given name.
+type LDAPExtraAttribute struct {
+	Name        string `koanf:"name"`
+	Attribute   string `koanf:"attribute"`
+	MultiValued bool   `koanf:"multi_valued"`
+}
+
 // DefaultPasswordConfig represents the default configuration related to Argon2id hashing.
 var DefaultPasswordConfig = Password{
 	Algorithm: argon2,
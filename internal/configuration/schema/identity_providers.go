@@ -14,14 +14,21 @@ type IdentityProvidersConfiguration struct {
 // OpenIDConnectConfiguration configuration for OpenID Connect.
 type OpenIDConnectConfiguration struct {
 	HMACSecret             string               `koanf:"hmac_secret"`
+	RotatedHMACSecrets     []string             `koanf:"rotated_hmac_secrets"`
 	IssuerCertificateChain X509CertificateChain `koanf:"issuer_certificate_chain"`
 	IssuerPrivateKey       *rsa.PrivateKey      `koanf:"issuer_private_key"`
 
+	IssuerPrivateKeys []JWK `koanf:"issuer_private_keys"`
+
+	KeyRotation OpenIDConnectKeyRotationConfiguration `koanf:"key_rotation"`
+
 	AccessTokenLifespan   time.Duration `koanf:"access_token_lifespan"`
 	AuthorizeCodeLifespan time.Duration `koanf:"authorize_code_lifespan"`
 	IDTokenLifespan       time.Duration `koanf:"id_token_lifespan"`
 	RefreshTokenLifespan  time.Duration `koanf:"refresh_token_lifespan"`
 
+	RefreshTokenGracePeriod time.Duration `koanf:"refresh_token_grace_period"`
+
 	EnableClientDebugMessages bool `koanf:"enable_client_debug_messages"`
 	MinimumParameterEntropy   int  `koanf:"minimum_parameter_entropy"`
 
@@ -30,9 +37,50 @@ type OpenIDConnectConfiguration struct {
 
 	CORS OpenIDConnectCORSConfiguration `koanf:"cors"`
 
+	PAR OpenIDConnectPARConfiguration `koanf:"pushed_authorizations"`
+
+	DeviceAuthorization OpenIDConnectDeviceAuthorizationConfiguration `koanf:"device_authorizations"`
+
+	DynamicClientRegistration OpenIDConnectDynamicClientRegistrationConfiguration `koanf:"dynamic_client_registration"`
+
+	MutualTLS OpenIDConnectMutualTLSConfiguration `koanf:"mutual_tls"`
+
+	AuthorizationPolicies []OpenIDConnectAuthorizationPolicy `koanf:"authorization_policies"`
+
+	ClaimsPolicies []OpenIDConnectClaimsPolicy `koanf:"claims_policies"`
+	Scopes         []OpenIDConnectScope        `koanf:"scopes"`
+
+	ACRValues []OpenIDConnectACRValue `koanf:"acr_values"`
+
 	Clients []OpenIDConnectClientConfiguration `koanf:"clients"`
 }
 
+// JWK represents a JSON Web Key used by the OpenID Connect issuer to sign tokens.
+type JWK struct {
+	KeyID            string                  `koanf:"key_id"`
+	Use              string                  `koanf:"use"`
+	Algorithm        string                  `koanf:"algorithm"`
+	Key              CryptographicPrivateKey `koanf:"key"`
+	CertificateChain X509CertificateChain    `koanf:"certificate_chain"`
+}
+
+// OpenIDConnectClientJWK represents a JSON Web Key registered by an OpenID Connect client to authenticate with.
+type OpenIDConnectClientJWK struct {
+	KeyID     string                 `koanf:"key_id"`
+	Use       string                 `koanf:"use"`
+	Algorithm string                 `koanf:"algorithm"`
+	Key       CryptographicPublicKey `koanf:"key"`
+}
+
+// OpenIDConnectKeyRotationConfiguration represents the OpenID Connect issuer key rotation configuration.
+type OpenIDConnectKeyRotationConfiguration struct {
+	Enable     bool          `koanf:"enable"`
+	Algorithm  string        `koanf:"algorithm"`
+	KeySize    int           `koanf:"key_size"`
+	Interval   time.Duration `koanf:"interval"`
+	PrePublish time.Duration `koanf:"pre_publish"`
+}
+
 // OpenIDConnectCORSConfiguration represents an OpenID Connect CORS config.
 type OpenIDConnectCORSConfiguration struct {
 	Endpoints      []string  `koanf:"endpoints"`
@@ -41,6 +89,76 @@ type OpenIDConnectCORSConfiguration struct {
 	AllowedOriginsFromClientRedirectURIs bool `koanf:"allowed_origins_from_client_redirect_uris"`
 }
 
+// OpenIDConnectPARConfiguration represents an OpenID Connect PAR config.
+type OpenIDConnectPARConfiguration struct {
+	Enforce         bool          `koanf:"enforce"`
+	ContextLifespan time.Duration `koanf:"context_lifespan"`
+}
+
+// OpenIDConnectDeviceAuthorizationConfiguration represents an OpenID Connect Device Authorization config.
+type OpenIDConnectDeviceAuthorizationConfiguration struct {
+	CodeLifespan    time.Duration `koanf:"code_lifespan"`
+	PollingInterval time.Duration `koanf:"polling_interval"`
+}
+
+// OpenIDConnectDynamicClientRegistrationConfiguration represents an OpenID Connect Dynamic Client Registration config.
+type OpenIDConnectDynamicClientRegistrationConfiguration struct {
+	Enable             bool   `koanf:"enable"`
+	InitialAccessToken string `koanf:"initial_access_token"`
+	Policy             string `koanf:"authorization_policy"`
+}
+
+// OpenIDConnectMutualTLSConfiguration represents the OAuth 2.0 Mutual-TLS Client Authentication and Certificate-Bound
+// Access Tokens config.
+type OpenIDConnectMutualTLSConfiguration struct {
+	CertificateAuthorities     X509CertificateChain `koanf:"certificate_authorities"`
+	ForwardedCertificateHeader string               `koanf:"forwarded_certificate_header"`
+	TrustedProxies             []string             `koanf:"trusted_proxies"`
+}
+
+// OpenIDConnectAuthorizationPolicy represents a named policy which determines the authorization level a user requires
+// to authorize a client based on their username, groups, and remote network.
+type OpenIDConnectAuthorizationPolicy struct {
+	Name          string                                 `koanf:"name"`
+	DefaultPolicy string                                 `koanf:"default_policy"`
+	Rules         []OpenIDConnectAuthorizationPolicyRule `koanf:"rules"`
+}
+
+// OpenIDConnectAuthorizationPolicyRule represents an individual rule of an OpenIDConnectAuthorizationPolicy.
+type OpenIDConnectAuthorizationPolicyRule struct {
+	Policy   string     `koanf:"policy"`
+	Subjects [][]string `koanf:"subject"`
+	Networks []string   `koanf:"networks"`
+}
+
+// OpenIDConnectClaimsPolicy represents a named policy which maps user attributes to custom claims, and determines which
+// of the custom claims are included in the ID Token in addition to the UserInfo response.
+type OpenIDConnectClaimsPolicy struct {
+	Name         string                     `koanf:"name"`
+	IDToken      []string                   `koanf:"id_token"`
+	CustomClaims []OpenIDConnectCustomClaim `koanf:"custom_claims"`
+}
+
+// OpenIDConnectCustomClaim represents a custom claim and the user attribute it's mapped from.
+type OpenIDConnectCustomClaim struct {
+	Name      string `koanf:"name"`
+	Attribute string `koanf:"attribute"`
+}
+
+// OpenIDConnectScope represents a custom scope and the custom claims which are released when it's granted.
+type OpenIDConnectScope struct {
+	Name   string   `koanf:"name"`
+	Claims []string `koanf:"claims"`
+}
+
+// OpenIDConnectACRValue represents an Authentication Context Class Reference value and the authentication level and
+// Authentication Method Reference values a user must satisfy to be assigned it.
+type OpenIDConnectACRValue struct {
+	Value  string   `koanf:"value"`
+	Policy string   `koanf:"policy"`
+	AMR    []string `koanf:"amr"`
+}
+
 // OpenIDConnectClientConfiguration configuration for an OpenID Connect client.
 type OpenIDConnectClientConfiguration struct {
 	ID               string          `koanf:"id"`
@@ -49,7 +167,28 @@ type OpenIDConnectClientConfiguration struct {
 	SectorIdentifier url.URL         `koanf:"sector_identifier"`
 	Public           bool            `koanf:"public"`
 
-	RedirectURIs []string `koanf:"redirect_uris"`
+	RedirectURIs           []string `koanf:"redirect_uris"`
+	PostLogoutRedirectURIs []string `koanf:"post_logout_redirect_uris"`
+
+	BackChannelLogoutURI string `koanf:"backchannel_logout_uri"`
+
+	TokenEndpointAuthMethod     string                   `koanf:"token_endpoint_auth_method"`
+	TokenEndpointAuthSigningAlg string                   `koanf:"token_endpoint_auth_signing_alg"`
+	JSONWebKeysURI              string                   `koanf:"jwks_uri"`
+	JSONWebKeys                 []OpenIDConnectClientJWK `koanf:"jwks"`
+
+	RequestURIs                []string `koanf:"request_uris"`
+	RequestObjectSigningAlg    string   `koanf:"request_object_signing_alg"`
+	RequireSignedRequestObject bool     `koanf:"require_signed_request_object"`
+
+	DPoPBoundAccessTokens bool `koanf:"dpop_bound_access_tokens"`
+
+	TLSClientAuthSubjectDN                string `koanf:"tls_client_auth_subject_dn"`
+	TLSClientAuthSANDNS                   string `koanf:"tls_client_auth_san_dns"`
+	TLSClientAuthSANURI                   string `koanf:"tls_client_auth_san_uri"`
+	TLSClientAuthSANIP                    string `koanf:"tls_client_auth_san_ip"`
+	TLSClientAuthSANEmail                 string `koanf:"tls_client_auth_san_email"`
+	TLSClientCertificateBoundAccessTokens bool   `koanf:"tls_client_certificate_bound_access_tokens"`
 
 	Audience      []string `koanf:"audience"`
 	Scopes        []string `koanf:"scopes"`
@@ -57,14 +196,68 @@ type OpenIDConnectClientConfiguration struct {
 	ResponseTypes []string `koanf:"response_types"`
 	ResponseModes []string `koanf:"response_modes"`
 
-	UserinfoSigningAlgorithm string `koanf:"userinfo_signing_algorithm"`
+	RequirePushedAuthorizationRequests bool `koanf:"require_pushed_authorization_requests"`
+
+	EnforcePKCE string `koanf:"enforce_pkce"`
+
+	Lifespans OpenIDConnectClientLifespans `koanf:"lifespans"`
+
+	ClientCredentials OpenIDConnectClientClientCredentialsConfiguration `koanf:"client_credentials"`
+	TokenExchange     OpenIDConnectClientTokenExchangeConfiguration     `koanf:"token_exchange"`
+
+	IDTokenSignedResponseAlg     string `koanf:"id_token_signed_response_alg"`
+	AccessTokenSignedResponseAlg string `koanf:"access_token_signed_response_alg"`
+	UserinfoSigningAlgorithm     string `koanf:"userinfo_signing_algorithm"`
+
+	AuthorizationSignedResponseAlg string `koanf:"authorization_signed_response_alg"`
 
 	Policy string `koanf:"authorization_policy"`
 
+	ClaimsPolicy string `koanf:"claims_policy"`
+
 	ConsentMode                  string         `koanf:"consent_mode"`
 	ConsentPreConfiguredDuration *time.Duration `koanf:"pre_configured_consent_duration"`
 }
 
+// OpenIDConnectLifespans represents the lifespans of the tokens issued to an OpenID Connect client.
+type OpenIDConnectLifespans struct {
+	AccessToken  time.Duration `koanf:"access_token"`
+	RefreshToken time.Duration `koanf:"refresh_token"`
+	IDToken      time.Duration `koanf:"id_token"`
+}
+
+// OpenIDConnectClientLifespans represents the lifespans of the tokens issued to an OpenID Connect client which take
+// precedence over the global lifespans. The lifespans of a grant type take precedence over the client lifespans.
+type OpenIDConnectClientLifespans struct {
+	OpenIDConnectLifespans `koanf:",squash"`
+
+	Grants OpenIDConnectClientGrantLifespans `koanf:"grants"`
+}
+
+// OpenIDConnectClientGrantLifespans represents the lifespans of the tokens issued to an OpenID Connect client for each
+// grant type.
+type OpenIDConnectClientGrantLifespans struct {
+	AuthorizationCode OpenIDConnectLifespans `koanf:"authorization_code"`
+	Implicit          OpenIDConnectLifespans `koanf:"implicit"`
+	ClientCredentials OpenIDConnectLifespans `koanf:"client_credentials"`
+	RefreshToken      OpenIDConnectLifespans `koanf:"refresh_token"`
+	DeviceCode        OpenIDConnectLifespans `koanf:"device_code"`
+	TokenExchange     OpenIDConnectLifespans `koanf:"token_exchange"`
+}
+
+// OpenIDConnectClientClientCredentialsConfiguration represents an OpenID Connect client Client Credentials policy.
+type OpenIDConnectClientClientCredentialsConfiguration struct {
+	Subject  string   `koanf:"subject"`
+	Audience []string `koanf:"audience"`
+	Scopes   []string `koanf:"scopes"`
+}
+
+// OpenIDConnectClientTokenExchangeConfiguration represents an OpenID Connect client Token Exchange policy.
+type OpenIDConnectClientTokenExchangeConfiguration struct {
+	Audience []string `koanf:"audience"`
+	Scopes   []string `koanf:"scopes"`
+}
+
 // DefaultOpenIDConnectConfiguration contains defaults for OIDC.
 var DefaultOpenIDConnectConfiguration = OpenIDConnectConfiguration{
 	AccessTokenLifespan:   time.Hour,
@@ -72,6 +265,22 @@ var DefaultOpenIDConnectConfiguration = OpenIDConnectConfiguration{
 	IDTokenLifespan:       time.Hour,
 	RefreshTokenLifespan:  time.Minute * 90,
 	EnforcePKCE:           "public_clients_only",
+	KeyRotation: OpenIDConnectKeyRotationConfiguration{
+		Algorithm:  "RS256",
+		KeySize:    2048,
+		Interval:   time.Hour * 24 * 90,
+		PrePublish: time.Hour * 24 * 7,
+	},
+	PAR: OpenIDConnectPARConfiguration{
+		ContextLifespan: time.Minute * 5,
+	},
+	DeviceAuthorization: OpenIDConnectDeviceAuthorizationConfiguration{
+		CodeLifespan:    time.Minute * 10,
+		PollingInterval: time.Second * 5,
+	},
+	DynamicClientRegistration: OpenIDConnectDynamicClientRegistrationConfiguration{
+		Policy: "two_factor",
+	},
 }
 
 var defaultOIDCClientConsentPreConfiguredDuration = time.Hour * 24 * 7
@@ -84,7 +293,11 @@ var DefaultOpenIDConnectClientConfiguration = OpenIDConnectClientConfiguration{
 	ResponseTypes: []string{"code"},
 	ResponseModes: []string{"form_post", "query", "fragment"},
 
+	IDTokenSignedResponseAlg:     "RS256",
+	AccessTokenSignedResponseAlg: "none",
 	UserinfoSigningAlgorithm:     "none",
-	ConsentMode:                  "auto",
-	ConsentPreConfiguredDuration: &defaultOIDCClientConsentPreConfiguredDuration,
+
+	AuthorizationSignedResponseAlg: "RS256",
+	ConsentMode:                    "auto",
+	ConsentPreConfiguredDuration:   &defaultOIDCClientConsentPreConfiguredDuration,
 }
@@ -18,12 +18,25 @@ var Keys = []string{
 	"log.file_path",
 	"log.keep_stdout",
 	"identity_providers.oidc.hmac_secret",
+	"identity_providers.oidc.rotated_hmac_secrets",
 	"identity_providers.oidc.issuer_certificate_chain",
 	"identity_providers.oidc.issuer_private_key",
+	"identity_providers.oidc.issuer_private_keys",
+	"identity_providers.oidc.issuer_private_keys[].key_id",
+	"identity_providers.oidc.issuer_private_keys[].use",
+	"identity_providers.oidc.issuer_private_keys[].algorithm",
+	"identity_providers.oidc.issuer_private_keys[].key",
+	"identity_providers.oidc.issuer_private_keys[].certificate_chain",
+	"identity_providers.oidc.key_rotation.enable",
+	"identity_providers.oidc.key_rotation.algorithm",
+	"identity_providers.oidc.key_rotation.key_size",
+	"identity_providers.oidc.key_rotation.interval",
+	"identity_providers.oidc.key_rotation.pre_publish",
 	"identity_providers.oidc.access_token_lifespan",
 	"identity_providers.oidc.authorize_code_lifespan",
 	"identity_providers.oidc.id_token_lifespan",
 	"identity_providers.oidc.refresh_token_lifespan",
+	"identity_providers.oidc.refresh_token_grace_period",
 	"identity_providers.oidc.enable_client_debug_messages",
 	"identity_providers.oidc.minimum_parameter_entropy",
 	"identity_providers.oidc.enforce_pkce",
@@ -31,6 +44,36 @@ var Keys = []string{
 	"identity_providers.oidc.cors.endpoints",
 	"identity_providers.oidc.cors.allowed_origins",
 	"identity_providers.oidc.cors.allowed_origins_from_client_redirect_uris",
+	"identity_providers.oidc.pushed_authorizations.enforce",
+	"identity_providers.oidc.pushed_authorizations.context_lifespan",
+	"identity_providers.oidc.device_authorizations.code_lifespan",
+	"identity_providers.oidc.device_authorizations.polling_interval",
+	"identity_providers.oidc.dynamic_client_registration.enable",
+	"identity_providers.oidc.dynamic_client_registration.initial_access_token",
+	"identity_providers.oidc.dynamic_client_registration.authorization_policy",
+	"identity_providers.oidc.mutual_tls.certificate_authorities",
+	"identity_providers.oidc.mutual_tls.forwarded_certificate_header",
+	"identity_providers.oidc.mutual_tls.trusted_proxies",
+	"identity_providers.oidc.authorization_policies",
+	"identity_providers.oidc.authorization_policies[].name",
+	"identity_providers.oidc.authorization_policies[].default_policy",
+	"identity_providers.oidc.authorization_policies[].rules",
+	"identity_providers.oidc.authorization_policies[].rules[].policy",
+	"identity_providers.oidc.authorization_policies[].rules[].subject",
+	"identity_providers.oidc.authorization_policies[].rules[].networks",
+	"identity_providers.oidc.claims_policies",
+	"identity_providers.oidc.claims_policies[].name",
+	"identity_providers.oidc.claims_policies[].id_token",
+	"identity_providers.oidc.claims_policies[].custom_claims",
+	"identity_providers.oidc.claims_policies[].custom_claims[].name",
+	"identity_providers.oidc.claims_policies[].custom_claims[].attribute",
+	"identity_providers.oidc.scopes",
+	"identity_providers.oidc.scopes[].name",
+	"identity_providers.oidc.scopes[].claims",
+	"identity_providers.oidc.acr_values",
+	"identity_providers.oidc.acr_values[].value",
+	"identity_providers.oidc.acr_values[].policy",
+	"identity_providers.oidc.acr_values[].amr",
 	"identity_providers.oidc.clients",
 	"identity_providers.oidc.clients[].id",
 	"identity_providers.oidc.clients[].description",
@@ -38,13 +81,65 @@ var Keys = []string{
 	"identity_providers.oidc.clients[].sector_identifier",
 	"identity_providers.oidc.clients[].public",
 	"identity_providers.oidc.clients[].redirect_uris",
+	"identity_providers.oidc.clients[].post_logout_redirect_uris",
+	"identity_providers.oidc.clients[].backchannel_logout_uri",
+	"identity_providers.oidc.clients[].token_endpoint_auth_method",
+	"identity_providers.oidc.clients[].token_endpoint_auth_signing_alg",
+	"identity_providers.oidc.clients[].jwks_uri",
+	"identity_providers.oidc.clients[].jwks",
+	"identity_providers.oidc.clients[].jwks[].key_id",
+	"identity_providers.oidc.clients[].jwks[].use",
+	"identity_providers.oidc.clients[].jwks[].algorithm",
+	"identity_providers.oidc.clients[].jwks[].key",
+	"identity_providers.oidc.clients[].request_uris",
+	"identity_providers.oidc.clients[].request_object_signing_alg",
+	"identity_providers.oidc.clients[].require_signed_request_object",
+	"identity_providers.oidc.clients[].dpop_bound_access_tokens",
+	"identity_providers.oidc.clients[].tls_client_auth_subject_dn",
+	"identity_providers.oidc.clients[].tls_client_auth_san_dns",
+	"identity_providers.oidc.clients[].tls_client_auth_san_uri",
+	"identity_providers.oidc.clients[].tls_client_auth_san_ip",
+	"identity_providers.oidc.clients[].tls_client_auth_san_email",
+	"identity_providers.oidc.clients[].tls_client_certificate_bound_access_tokens",
 	"identity_providers.oidc.clients[].audience",
 	"identity_providers.oidc.clients[].scopes",
 	"identity_providers.oidc.clients[].grant_types",
 	"identity_providers.oidc.clients[].response_types",
 	"identity_providers.oidc.clients[].response_modes",
+	"identity_providers.oidc.clients[].require_pushed_authorization_requests",
+	"identity_providers.oidc.clients[].enforce_pkce",
+	"identity_providers.oidc.clients[].lifespans.access_token",
+	"identity_providers.oidc.clients[].lifespans.refresh_token",
+	"identity_providers.oidc.clients[].lifespans.id_token",
+	"identity_providers.oidc.clients[].lifespans.grants.authorization_code.access_token",
+	"identity_providers.oidc.clients[].lifespans.grants.authorization_code.refresh_token",
+	"identity_providers.oidc.clients[].lifespans.grants.authorization_code.id_token",
+	"identity_providers.oidc.clients[].lifespans.grants.implicit.access_token",
+	"identity_providers.oidc.clients[].lifespans.grants.implicit.refresh_token",
+	"identity_providers.oidc.clients[].lifespans.grants.implicit.id_token",
+	"identity_providers.oidc.clients[].lifespans.grants.client_credentials.access_token",
+	"identity_providers.oidc.clients[].lifespans.grants.client_credentials.refresh_token",
+	"identity_providers.oidc.clients[].lifespans.grants.client_credentials.id_token",
+	"identity_providers.oidc.clients[].lifespans.grants.refresh_token.access_token",
+	"identity_providers.oidc.clients[].lifespans.grants.refresh_token.refresh_token",
+	"identity_providers.oidc.clients[].lifespans.grants.refresh_token.id_token",
+	"identity_providers.oidc.clients[].lifespans.grants.device_code.access_token",
+	"identity_providers.oidc.clients[].lifespans.grants.device_code.refresh_token",
+	"identity_providers.oidc.clients[].lifespans.grants.device_code.id_token",
+	"identity_providers.oidc.clients[].lifespans.grants.token_exchange.access_token",
+	"identity_providers.oidc.clients[].lifespans.grants.token_exchange.refresh_token",
+	"identity_providers.oidc.clients[].lifespans.grants.token_exchange.id_token",
+	"identity_providers.oidc.clients[].client_credentials.subject",
+	"identity_providers.oidc.clients[].client_credentials.audience",
+	"identity_providers.oidc.clients[].client_credentials.scopes",
+	"identity_providers.oidc.clients[].token_exchange.audience",
+	"identity_providers.oidc.clients[].token_exchange.scopes",
+	"identity_providers.oidc.clients[].id_token_signed_response_alg",
+	"identity_providers.oidc.clients[].access_token_signed_response_alg",
 	"identity_providers.oidc.clients[].userinfo_signing_algorithm",
+	"identity_providers.oidc.clients[].authorization_signed_response_alg",
 	"identity_providers.oidc.clients[].authorization_policy",
+	"identity_providers.oidc.clients[].claims_policy",
 	"identity_providers.oidc.clients[].consent_mode",
 	"identity_providers.oidc.clients[].pre_configured_consent_duration",
 	"authentication_backend.password_reset.disable",
@@ -95,6 +190,10 @@ var Keys = []string{
 	"authentication_backend.ldap.username_attribute",
 	"authentication_backend.ldap.mail_attribute",
 	"authentication_backend.ldap.display_name_attribute",
+	"authentication_backend.ldap.extra_attributes",
+	"authentication_backend.ldap.extra_attributes[].name",
+	"authentication_backend.ldap.extra_attributes[].attribute",
+	"authentication_backend.ldap.extra_attributes[].multi_valued",
 	"authentication_backend.ldap.permit_referrals",
 	"authentication_backend.ldap.permit_unauthenticated_bind",
 	"authentication_backend.ldap.permit_feature_detection_failure",
@@ -180,6 +279,13 @@ var Keys = []string{
 	"storage.postgres.ssl.certificate",
 	"storage.postgres.ssl.key",
 	"storage.encryption_key",
+	"storage.cleanup.disable",
+	"storage.cleanup.interval",
+	"storage.cleanup.retention.authentication_logs",
+	"storage.cleanup.retention.identity_verifications",
+	"storage.cleanup.retention.oauth2_sessions",
+	"storage.cleanup.retention.oauth2_consent_sessions",
+	"storage.cleanup.retention.oauth2_backchannel_logouts",
 	"notifier.disable_startup_check",
 	"notifier.filesystem.filename",
 	"notifier.smtp.host",
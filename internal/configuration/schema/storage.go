@@ -45,6 +45,39 @@ type StorageConfiguration struct {
 	PostgreSQL *PostgreSQLStorageConfiguration `koanf:"postgres"`
 
 	EncryptionKey string `koanf:"encryption_key"`
+
+	Cleanup StorageCleanupConfiguration `koanf:"cleanup"`
+}
+
+// StorageCleanupConfiguration represents the configuration of the periodic purge of expired data from the storage.
+type StorageCleanupConfiguration struct {
+	Disable  bool          `koanf:"disable"`
+	Interval time.Duration `koanf:"interval"`
+
+	Retention StorageCleanupRetentionConfiguration `koanf:"retention"`
+}
+
+// StorageCleanupRetentionConfiguration represents how long data is retained in the storage before it's purged.
+type StorageCleanupRetentionConfiguration struct {
+	AuthenticationLogs       time.Duration `koanf:"authentication_logs"`
+	IdentityVerifications    time.Duration `koanf:"identity_verifications"`
+	OAuth2Sessions           time.Duration `koanf:"oauth2_sessions"`
+	OAuth2ConsentSessions    time.Duration `koanf:"oauth2_consent_sessions"`
+	OAuth2BackChannelLogouts time.Duration `koanf:"oauth2_backchannel_logouts"`
+}
+
+// DefaultStorageConfiguration represents the default storage configuration.
+var DefaultStorageConfiguration = StorageConfiguration{
+	Cleanup: StorageCleanupConfiguration{
+		Interval: time.Hour,
+		Retention: StorageCleanupRetentionConfiguration{
+			AuthenticationLogs:       time.Hour * 24 * 365,
+			IdentityVerifications:    time.Hour * 24 * 7,
+			OAuth2Sessions:           time.Hour * 24 * 30,
+			OAuth2ConsentSessions:    time.Hour * 24,
+			OAuth2BackChannelLogouts: time.Hour * 24,
+		},
+	},
 }
 
 // DefaultSQLStorageConfiguration represents the default SQL configuration.
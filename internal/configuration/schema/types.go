@@ -132,6 +132,32 @@ type PasswordDigest struct {
 	crypt.Digest
 }
 
+// IsPlainText returns true if the underlying crypt.Digest is a plaintext digest.
+func (d *PasswordDigest) IsPlainText() bool {
+	if d == nil || d.Digest == nil {
+		return false
+	}
+
+	_, ok := d.Digest.(*crypt.PlainTextDigest)
+
+	return ok
+}
+
+// PlainText returns the plaintext key of the underlying crypt.Digest if it's a plaintext digest.
+func (d *PasswordDigest) PlainText() (key []byte, err error) {
+	if !d.IsPlainText() {
+		return nil, fmt.Errorf("the digest is not a plaintext digest")
+	}
+
+	parts := strings.Split(d.Encode(), crypt.StorageDelimiter)
+
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("the plaintext digest has an invalid format")
+	}
+
+	return crypt.NewPlainTextVariant(parts[1]).Decode(parts[2])
+}
+
 // NewX509CertificateChain creates a new *X509CertificateChain from a given string, parsing each PEM block one by one.
 func NewX509CertificateChain(in string) (chain *X509CertificateChain, err error) {
 	if in == "" {
@@ -174,6 +200,19 @@ func NewX509CertificateChain(in string) (chain *X509CertificateChain, err error)
 	return chain, nil
 }
 
+// CryptographicPrivateKey represents any cryptographic private key such as a *rsa.PrivateKey, *ecdsa.PrivateKey, or
+// ed25519.PrivateKey.
+type CryptographicPrivateKey interface {
+	Public() crypto.PublicKey
+	Equal(x crypto.PrivateKey) bool
+}
+
+// CryptographicPublicKey represents any cryptographic public key such as a *rsa.PublicKey, *ecdsa.PublicKey, or
+// ed25519.PublicKey.
+type CryptographicPublicKey interface {
+	Equal(x crypto.PublicKey) bool
+}
+
 // X509CertificateChain is a helper struct that holds a list of *x509.Certificate's.
 type X509CertificateChain struct {
 	certs []*x509.Certificate
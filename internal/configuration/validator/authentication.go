@@ -353,6 +353,26 @@ func validateLDAPAuthenticationBackend(config *schema.AuthenticationBackend, val
 	}
 
 	validateLDAPRequiredParameters(config, validator)
+	validateLDAPExtraAttributes(config.LDAP, validator)
+}
+
+func validateLDAPExtraAttributes(config *schema.LDAPAuthenticationBackend, validator *schema.StructValidator) {
+	var names []string
+
+	for i, attribute := range config.ExtraAttributes {
+		switch {
+		case attribute.Name == "":
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendExtraAttributeMissingOption, i+1, "name"))
+		case utils.IsStringInSlice(attribute.Name, names) || utils.IsStringInSlice(attribute.Name, reservedUserAttributes):
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendExtraAttributeInvalidName, i+1, attribute.Name))
+		default:
+			names = append(names, attribute.Name)
+		}
+
+		if attribute.Attribute == "" {
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendExtraAttributeMissingOption, i+1, "attribute"))
+		}
+	}
 }
 
 func setDefaultImplementationLDAPAuthenticationBackendProfileMisc(config *schema.LDAPAuthenticationBackend, implementation *schema.LDAPAuthenticationBackend) {
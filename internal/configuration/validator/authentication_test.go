@@ -806,6 +806,26 @@ func (suite *LDAPAuthenticationBackendSuite) TestShouldNotAllowInvalidTLSValue()
 	suite.Assert().EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: tls: option 'minimum_tls_version' is invalid: SSL2.0: supplied tls version isn't supported")
 }
 
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnInvalidExtraAttributes() {
+	suite.config.LDAP.ExtraAttributes = []schema.LDAPExtraAttribute{
+		{Name: "phone", Attribute: "telephoneNumber"},
+		{Name: "phone", Attribute: "mobile"},
+		{Name: "email", Attribute: "mail"},
+		{Attribute: "employeeNumber"},
+		{Name: "tenant"},
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: extra_attributes: attribute #2: option 'name' must be unique and must not be one of the standard user attributes but it's configured as 'phone'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "authentication_backend: ldap: extra_attributes: attribute #3: option 'name' must be unique and must not be one of the standard user attributes but it's configured as 'email'")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "authentication_backend: ldap: extra_attributes: attribute #4: option 'name' is required")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "authentication_backend: ldap: extra_attributes: attribute #5: option 'attribute' is required")
+}
+
 func TestLdapAuthenticationBackend(t *testing.T) {
 	suite.Run(t, new(LDAPAuthenticationBackendSuite))
 }
@@ -59,12 +59,16 @@ func ValidateConfiguration(config *schema.Configuration, validator *schema.Struc
 
 	ValidateTelemetry(config, validator)
 
-	ValidateStorage(config.Storage, validator)
+	ValidateStorage(&config.Storage, validator)
 
 	ValidateNotifier(&config.Notifier, validator)
 
 	ValidateIdentityProviders(&config.IdentityProviders, validator)
 
+	validateOIDCAuthorizationPolicyNetworks(config, validator)
+
+	validateStorageCleanupRetention(config, validator)
+
 	ValidateNTP(config, validator)
 
 	ValidatePasswordPolicy(&config.PasswordPolicy, validator)
@@ -47,6 +47,7 @@ const (
 	schemeLDAPS = "ldaps"
 	schemeHTTP  = "http"
 	schemeHTTPS = "https"
+	schemeFile  = "file"
 )
 
 // Notifier Error constants.
@@ -106,6 +107,9 @@ const (
 		"'%s' must contain enclosing parenthesis: '%s' should probably be '(%s)'"
 	errFmtLDAPAuthBackendFilterMissingPlaceholder = "authentication_backend: ldap: option " +
 		"'%s' must contain the placeholder '{%s}' but it is required"
+	errFmtLDAPAuthBackendExtraAttributeMissingOption = "authentication_backend: ldap: extra_attributes: attribute #%d: option '%s' is required"
+	errFmtLDAPAuthBackendExtraAttributeInvalidName   = "authentication_backend: ldap: extra_attributes: attribute #%d: option " +
+		"'name' must be unique and must not be one of the standard user attributes but it's configured as '%s'"
 )
 
 // TOTP Error constants.
@@ -124,6 +128,9 @@ const (
 	errFmtStorageUserPassMustBeProvided      = "storage: %s: option 'username' and 'password' are required" //nolint:gosec
 	errFmtStorageOptionMustBeProvided        = "storage: %s: option '%s' is required"
 	errFmtStoragePostgreSQLInvalidSSLMode    = "storage: postgres: ssl: option 'mode' must be one of '%s' but it is configured as '%s'"
+	errFmtStorageCleanupInvalidDuration      = "storage: cleanup: %soption '%s' must be 0 or more but it's configured as '%s'"
+	errFmtStorageCleanupRetentionTooShort    = "storage: cleanup: retention: option '%s' must be greater than or equal to the %s but it's configured as '%s' and the %s is configured as '%s'"
+	errFmtStorageCleanupRetentionNoExpiry    = "storage: cleanup: retention: option 'oauth2_sessions' is configured as '%s' but the option 'identity_providers: oidc: refresh_token_lifespan' is configured as '%s' which never expires; refresh tokens older than the retention will be purged"
 )
 
 // Telemetry Error constants.
@@ -133,13 +140,54 @@ const (
 
 // OpenID Error constants.
 const (
-	errFmtOIDCNoClientsConfigured = "identity_providers: oidc: option 'clients' must have one or " +
-		"more clients configured"
-	errFmtOIDCNoPrivateKey             = "identity_providers: oidc: option 'issuer_private_key' is required"
+	errFmtOIDCNoPrivateKey             = "identity_providers: oidc: option 'issuer_private_keys' or 'issuer_private_key' is required"
 	errFmtOIDCInvalidPrivateKeyBitSize = "identity_providers: oidc: option 'issuer_private_key' must be an RSA private key with %d bits or more but it only has %d bits"
 	errFmtOIDCCertificateMismatch      = "identity_providers: oidc: option 'issuer_private_key' does not appear to be the private key the certificate provided by option 'issuer_certificate_chain'"
 	errFmtOIDCCertificateChain         = "identity_providers: oidc: option 'issuer_certificate_chain' produced an error during validation of the chain: %w"
-	errFmtOIDCEnforcePKCEInvalidValue  = "identity_providers: oidc: option 'enforce_pkce' must be 'never', " +
+	errFmtOIDCIssuerPrivateKeysNoRS256 = "identity_providers: oidc: option 'issuer_private_keys' must have at " +
+		"least one key with the algorithm '%s' but only has keys with the algorithms '%s'"
+	errFmtOIDCIssuerPrivateKeysNoKey                = "identity_providers: oidc: issuer_private_keys: key #%d: option 'key' is required"
+	errFmtOIDCIssuerPrivateKeysUnsupportedKey       = "identity_providers: oidc: issuer_private_keys: key #%d: option 'key' must be a RSA private key, ECDSA private key with the P-256, P-384, or P-521 curve, or an Ed25519 private key but it's a %T"
+	errFmtOIDCIssuerPrivateKeysRSAKeyBitSize        = "identity_providers: oidc: issuer_private_keys: key #%d: option 'key' must be an RSA private key with %d bits or more but it only has %d bits"
+	errFmtOIDCIssuerPrivateKeysKeyIDInvalid         = "identity_providers: oidc: issuer_private_keys: key #%d: option 'key_id' must only contain alphanumeric characters, hyphens, and underscores, and must be no more than 100 characters but it's configured as '%s'"
+	errFmtOIDCIssuerPrivateKeysKeyIDDuplicate       = "identity_providers: oidc: issuer_private_keys: key #%d: option 'key_id' must be unique but '%s' is used by another key"
+	errFmtOIDCIssuerPrivateKeysInvalidOptionOneOf   = "identity_providers: oidc: issuer_private_keys: key #%d: option '%s' must be one of '%s' but it's configured as '%s'"
+	errFmtOIDCIssuerPrivateKeysAlgorithmKeyMismatch = "identity_providers: oidc: issuer_private_keys: key #%d: option 'algorithm' " +
+		"with value '%s' can't be used with the configured key which is a %T"
+	errFmtOIDCIssuerPrivateKeysCertificateMismatch          = "identity_providers: oidc: issuer_private_keys: key #%d: option 'key' does not appear to be the private key the certificate provided by option 'certificate_chain'"
+	errFmtOIDCIssuerPrivateKeysCertificateChain             = "identity_providers: oidc: issuer_private_keys: key #%d: option 'certificate_chain' produced an error during validation of the chain: %w"
+	errFmtOIDCKeyRotationInvalidAlgorithm                   = "identity_providers: oidc: key_rotation: option 'algorithm' must be one of '%s' but it's configured as '%s'"
+	errFmtOIDCKeyRotationInvalidKeySize                     = "identity_providers: oidc: key_rotation: option 'key_size' must be %d or more but it's configured as %d"
+	errFmtOIDCKeyRotationInvalidPrePublish                  = "identity_providers: oidc: key_rotation: option 'pre_publish' must be less than the option 'interval' but it's configured as '%s' and the 'interval' is configured as '%s'"
+	errFmtOIDCRefreshTokenInvalidGracePeriod                = "identity_providers: oidc: option 'refresh_token_grace_period' must be 0 or more and less than the option 'refresh_token_lifespan' but it's configured as '%s' and the 'refresh_token_lifespan' is configured as '%s'"
+	errFmtOIDCDeviceAuthorizationInvalidPollingInterval     = "identity_providers: oidc: device_authorizations: option 'polling_interval' must be less than the option 'code_lifespan' but it's configured as '%s' and the 'code_lifespan' is configured as '%s'"
+	errFmtOIDCRotatedHMACSecretEmpty                        = "identity_providers: oidc: option 'rotated_hmac_secrets' must not contain empty values but secret #%d is empty"
+	errFmtOIDCRotatedHMACSecretInvalid                      = "identity_providers: oidc: option 'rotated_hmac_secrets' must only contain unique values which are not the same as the option 'hmac_secret' but secret #%d is a duplicate"
+	errFmtOIDCDynamicClientRegistrationNoInitialAccessToken = "identity_providers: oidc: dynamic_client_registration: option 'initial_access_token' is required when dynamic client registration is enabled"
+	errFmtOIDCDynamicClientRegistrationInvalidPolicy        = "identity_providers: oidc: dynamic_client_registration: option 'authorization_policy' must be 'one_factor' or 'two_factor' but it is configured as '%s'"
+	errFmtOIDCMutualTLSInvalidTrustedProxy                  = "identity_providers: oidc: mutual_tls: option 'trusted_proxies' must only contain IP addresses or CIDR notation networks but it contains '%s'"
+	errFmtOIDCMutualTLSNoTrustedProxies                     = "identity_providers: oidc: mutual_tls: option 'trusted_proxies' is required when option 'forwarded_certificate_header' is configured"
+	errFmtOIDCAuthorizationPolicyNoName                     = "identity_providers: oidc: authorization_policies: policy #%d: option 'name' is required"
+	errFmtOIDCAuthorizationPolicyInvalidName                = "identity_providers: oidc: authorization_policies: policy '%s': option 'name' must be unique and must not be one of '%s'"
+	errFmtOIDCAuthorizationPolicyInvalidDefaultPolicy       = "identity_providers: oidc: authorization_policies: policy '%s': option 'default_policy' must be one of '%s' but it's configured as '%s'"
+	errFmtOIDCAuthorizationPolicyRuleNoCriteria             = "identity_providers: oidc: authorization_policies: policy '%s': rules: rule #%d: at least one of the options 'subject' or 'networks' is required"
+	errFmtOIDCAuthorizationPolicyRuleInvalidPolicy          = "identity_providers: oidc: authorization_policies: policy '%s': rules: rule #%d: option 'policy' must be one of '%s' but it's configured as '%s'"
+	errFmtOIDCAuthorizationPolicyRuleInvalidSubject         = "identity_providers: oidc: authorization_policies: policy '%s': rules: rule #%d: option 'subject' must only contain values prefixed with 'user:' or 'group:' but it contains '%s'"
+	errFmtOIDCAuthorizationPolicyRuleInvalidNetwork         = "identity_providers: oidc: authorization_policies: policy '%s': rules: rule #%d: option 'networks' must only contain IP addresses, CIDR notation networks, or the names of networks configured in the 'access_control' section but it contains '%s'"
+	errFmtOIDCClaimsPolicyNoName                            = "identity_providers: oidc: claims_policies: policy #%d: option 'name' is required"
+	errFmtOIDCClaimsPolicyDuplicateName                     = "identity_providers: oidc: claims_policies: policy '%s': option 'name' must be unique but it's configured more than once"
+	errFmtOIDCClaimsPolicyCustomClaimMissingOption          = "identity_providers: oidc: claims_policies: policy '%s': custom_claims: claim #%d: option '%s' is required"
+	errFmtOIDCClaimsPolicyCustomClaimInvalidName            = "identity_providers: oidc: claims_policies: policy '%s': custom_claims: claim #%d: option 'name' must be unique within the policy and must not be one of '%s' but it's configured as '%s'"
+	errFmtOIDCClaimsPolicyInvalidIDToken                    = "identity_providers: oidc: claims_policies: policy '%s': option 'id_token' must only contain custom claims of the policy but it contains '%s'"
+	errFmtOIDCScopeNoName                                   = "identity_providers: oidc: scopes: scope #%d: option 'name' is required"
+	errFmtOIDCScopeInvalidName                              = "identity_providers: oidc: scopes: scope '%s': option 'name' must be unique and must not be one of '%s'"
+	errFmtOIDCScopeNoClaims                                 = "identity_providers: oidc: scopes: scope '%s': option 'claims' must have at least one value"
+	errFmtOIDCScopeInvalidClaim                             = "identity_providers: oidc: scopes: scope '%s': option 'claims' must only contain custom claims configured in a claims policy but it contains '%s'"
+	errFmtOIDCACRValueNoValue                               = "identity_providers: oidc: acr_values: value #%d: option 'value' is required"
+	errFmtOIDCACRValueDuplicateValue                        = "identity_providers: oidc: acr_values: value '%s': option 'value' must be unique but it's configured more than once"
+	errFmtOIDCACRValueInvalidPolicy                         = "identity_providers: oidc: acr_values: value '%s': option 'policy' must be one of 'one_factor' or 'two_factor' but it's configured as '%s'"
+	errFmtOIDCACRValueInvalidAMR                            = "identity_providers: oidc: acr_values: value '%s': option 'amr' must only contain values from '%s' but it contains '%s'"
+	errFmtOIDCEnforcePKCEInvalidValue                       = "identity_providers: oidc: option 'enforce_pkce' must be 'never', " +
 		"'public_clients_only' or 'always', but it is configured as '%s'"
 
 	errFmtOIDCCORSInvalidOrigin                    = "identity_providers: oidc: cors: option 'allowed_origins' contains an invalid value '%s' as it has a %s: origins must only be scheme, hostname, and an optional port"
@@ -164,12 +212,69 @@ const (
 		"for the openid connect confidential client type"
 	errFmtOIDCClientRedirectURIAbsolute = "identity_providers: oidc: client '%s': option 'redirect_uris' has an " +
 		"invalid value: redirect uri '%s' must have the scheme 'http' or 'https' but it has no scheme"
-	errFmtOIDCClientInvalidPolicy = "identity_providers: oidc: client '%s': option 'policy' must be 'one_factor' " +
-		"or 'two_factor' but it is configured as '%s'"
+	errFmtOIDCClientPostLogoutRedirectURI = "identity_providers: oidc: client '%s': option 'post_logout_redirect_uris' has an " +
+		"invalid value: redirect uri '%s' must have a scheme of 'http' or 'https' but '%s' is configured"
+	errFmtOIDCClientPostLogoutRedirectURICantBeParsed = "identity_providers: oidc: client '%s': option 'post_logout_redirect_uris' has an " +
+		"invalid value: redirect uri '%s' could not be parsed: %v"
+	errFmtOIDCClientPostLogoutRedirectURIAbsolute = "identity_providers: oidc: client '%s': option 'post_logout_redirect_uris' has an " +
+		"invalid value: redirect uri '%s' must have the scheme 'http' or 'https' but it has no scheme"
+	errFmtOIDCClientPostLogoutRedirectURIFragment = "identity_providers: oidc: client '%s': option 'post_logout_redirect_uris' has an " +
+		"invalid value: redirect uri '%s' must not have a fragment"
+	errFmtOIDCClientBackChannelLogoutURI = "identity_providers: oidc: client '%s': option 'backchannel_logout_uri' has an " +
+		"invalid value: uri '%s' must have a scheme of 'http' or 'https' but '%s' is configured"
+	errFmtOIDCClientBackChannelLogoutURICantBeParsed = "identity_providers: oidc: client '%s': option 'backchannel_logout_uri' has an " +
+		"invalid value: uri '%s' could not be parsed: %v"
+	errFmtOIDCClientBackChannelLogoutURIAbsolute = "identity_providers: oidc: client '%s': option 'backchannel_logout_uri' has an " +
+		"invalid value: uri '%s' must have the scheme 'http' or 'https' but it has no scheme"
+	errFmtOIDCClientBackChannelLogoutURIFragment = "identity_providers: oidc: client '%s': option 'backchannel_logout_uri' has an " +
+		"invalid value: uri '%s' must not have a fragment"
+	errFmtOIDCClientInvalidPolicy = "identity_providers: oidc: client '%s': option 'authorization_policy' must be one of " +
+		"'%s' but it is configured as '%s'"
+	errFmtOIDCClientInvalidClaimsPolicy = "identity_providers: oidc: client '%s': option 'claims_policy' must be one of " +
+		"the configured claims policies '%s' but it is configured as '%s'"
 	errFmtOIDCClientInvalidConsentMode = "identity_providers: oidc: client '%s': consent: option 'mode' must be one of " +
 		"'%s' but it is configured as '%s'"
 	errFmtOIDCClientInvalidEntry = "identity_providers: oidc: client '%s': option '%s' must only have the values " +
 		"'%s' but one option is configured as '%s'"
+	errFmtOIDCClientInvalidIDTokenAlgorithm = "identity_providers: oidc: client '%s': option " +
+		"'id_token_signed_response_alg' must be one of '%s' but it is configured as '%s'"
+	errFmtOIDCClientInvalidAuthorizationAlgorithm = "identity_providers: oidc: client '%s': option " +
+		"'authorization_signed_response_alg' must be one of '%s' but it is configured as '%s'"
+	errFmtOIDCClientInvalidAccessTokenAlgorithm = "identity_providers: oidc: client '%s': option " +
+		"'access_token_signed_response_alg' must be one of '%s' but it is configured as '%s'"
+	errFmtOIDCClientInvalidTokenEndpointAuthMethod = "identity_providers: oidc: client '%s': option " +
+		"'token_endpoint_auth_method' must be one of '%s' when configured as the %s client type but it's configured as '%s'"
+	errFmtOIDCClientInvalidTokenEndpointAuthSigningAlg = "identity_providers: oidc: client '%s': option " +
+		"'token_endpoint_auth_signing_alg' must be one of '%s' when option 'token_endpoint_auth_method' is configured as '%s' but it's configured as '%s'"
+	errFmtOIDCClientInvalidTokenEndpointAuthSecretPlainText = "identity_providers: oidc: client '%s': option " +
+		"'secret' must be a plaintext secret when option 'token_endpoint_auth_method' is configured as '%s'"
+	errFmtOIDCClientInvalidJWKSNotConfigured = "identity_providers: oidc: client '%s': option " +
+		"'jwks' or 'jwks_uri' is required when option 'token_endpoint_auth_method' is configured as '%s'"
+	errFmtOIDCClientInvalidTLSClientAuthSubject = "identity_providers: oidc: client '%s': option " +
+		"'tls_client_auth_subject_dn', 'tls_client_auth_san_dns', 'tls_client_auth_san_uri', 'tls_client_auth_san_ip', or 'tls_client_auth_san_email' " +
+		"must be configured exactly once when option 'token_endpoint_auth_method' is configured as '%s' but %d are configured"
+	errFmtOIDCClientInvalidTLSClientAuthSANIP = "identity_providers: oidc: client '%s': option " +
+		"'tls_client_auth_san_ip' must be an IP address but it's configured as '%s'"
+	errFmtOIDCClientInvalidJWKSBothConfigured = "identity_providers: oidc: client '%s': options " +
+		"'jwks' and 'jwks_uri' must not both be configured"
+	errFmtOIDCClientInvalidJWKSURI = "identity_providers: oidc: client '%s': option " +
+		"'jwks_uri' must be an absolute URL with the 'https' or 'file' scheme but it's configured as '%s'"
+	errFmtOIDCClientJWKSNoKey = "identity_providers: oidc: client '%s': jwks: key #%d: option " +
+		"'key' is required"
+	errFmtOIDCClientJWKSKeyIDInvalid = "identity_providers: oidc: client '%s': jwks: key #%d: option " +
+		"'key_id' must only contain alphanumeric characters, hyphens, and underscores, and must be no more than 100 characters but it's configured as '%s'"
+	errFmtOIDCClientJWKSInvalidOptionOneOf = "identity_providers: oidc: client '%s': jwks: key #%d: option " +
+		"'%s' must be one of '%s' but it's configured as '%s'"
+	errFmtOIDCClientJWKSAlgorithmKeyMismatch = "identity_providers: oidc: client '%s': jwks: key #%d: option " +
+		"'algorithm' must be compatible with the key but it's configured as '%s' and the key is a %T"
+	errFmtOIDCClientInvalidRequestObjectSigningAlg = "identity_providers: oidc: client '%s': option " +
+		"'request_object_signing_alg' must be one of '%s' but it's configured as '%s'"
+	errFmtOIDCClientInvalidRequestObjectSigningAlgUnsigned = "identity_providers: oidc: client '%s': option " +
+		"'request_object_signing_alg' must not be 'none' when option 'require_signed_request_object' is enabled"
+	errFmtOIDCClientInvalidRequestObjectJWKSNotConfigured = "identity_providers: oidc: client '%s': option " +
+		"'jwks' or 'jwks_uri' is required when option 'request_object_signing_alg' is configured or option 'require_signed_request_object' is enabled"
+	errFmtOIDCClientInvalidRequestURI = "identity_providers: oidc: client '%s': option " +
+		"'request_uris' must only contain absolute URLs with the 'https' scheme but it contains '%s'"
 	errFmtOIDCClientInvalidUserinfoAlgorithm = "identity_providers: oidc: client '%s': option " +
 		"'userinfo_signing_algorithm' must be one of '%s' but it is configured as '%s'"
 	errFmtOIDCClientInvalidSectorIdentifier = "identity_providers: oidc: client '%s': option " +
@@ -178,6 +283,20 @@ const (
 		"'sector_identifier' with value '%s': must be a URL with only the host component for example '%s' but it has a %s"
 	errFmtOIDCClientInvalidSectorIdentifierHost = "identity_providers: oidc: client '%s': option " +
 		"'sector_identifier' with value '%s': must be a URL with only the host component but appears to be invalid"
+	errFmtOIDCClientInvalidClientCredentialsPublic = "identity_providers: oidc: client '%s': option " +
+		"'grant_types' must not include '%s' when option 'public' is true"
+	errFmtOIDCClientInvalidClientCredentialsEntry = "identity_providers: oidc: client '%s': client_credentials: option " +
+		"'%s' must only have values which are also configured in the client option '%s' but one option is configured as '%s'"
+	errFmtOIDCClientInvalidClientCredentialsSubject = "identity_providers: oidc: client '%s': client_credentials: option " +
+		"'subject' must start with '%s' followed by the name of the service account but it's configured as '%s'"
+	errFmtOIDCClientInvalidTokenExchangeAudience = "identity_providers: oidc: client '%s': token_exchange: option " +
+		"'audience' must have at least one value when option 'grant_types' includes '%s'"
+	errFmtOIDCClientInvalidTokenExchangeScopes = "identity_providers: oidc: client '%s': token_exchange: option " +
+		"'scopes' must only have the values '%s' but one option is configured as '%s'"
+	errFmtOIDCClientInvalidEnforcePKCE = "identity_providers: oidc: client '%s': option 'enforce_pkce' must be 'never', " +
+		"'public_clients_only' or 'always', but it is configured as '%s'"
+	errFmtOIDCClientInvalidLifespan = "identity_providers: oidc: client '%s': lifespans: %soption " +
+		"'%s' must be 0 or more but it's configured as '%s'"
 	errFmtOIDCServerInsecureParameterEntropy = "openid connect provider: SECURITY ISSUE - minimum parameter entropy is " +
 		"configured to an unsafe value, it should be above 8 but it's configured to %d"
 )
@@ -342,24 +461,59 @@ const (
 )
 
 var (
-	validACLHTTPMethodVerbs = append(validRFC7231HTTPMethodVerbs, validRFC4918HTTPMethodVerbs...)
-	validACLRulePolicies    = []string{policyBypass, policyOneFactor, policyTwoFactor, policyDeny}
-	validACLRuleOperators   = []string{operatorPresent, operatorAbsent, operatorEqual, operatorNotEqual, operatorPattern, operatorNotPattern}
+	validACLHTTPMethodVerbs              = append(validRFC7231HTTPMethodVerbs, validRFC4918HTTPMethodVerbs...)
+	validACLRulePolicies                 = []string{policyBypass, policyOneFactor, policyTwoFactor, policyDeny}
+	validOIDCAuthorizationPolicyPolicies = []string{policyOneFactor, policyTwoFactor, policyDeny}
+	validACLRuleOperators                = []string{operatorPresent, operatorAbsent, operatorEqual, operatorNotEqual, operatorPattern, operatorNotPattern}
 )
 
 var validDefault2FAMethods = []string{"totp", "webauthn", "mobile_push"}
 
 var (
-	validOIDCScopes             = []string{oidc.ScopeOpenID, oidc.ScopeEmail, oidc.ScopeProfile, oidc.ScopeGroups, oidc.ScopeOfflineAccess}
-	validOIDCGrantTypes         = []string{oidc.GrantTypeImplicit, oidc.GrantTypeRefreshToken, oidc.GrantTypeAuthorizationCode, oidc.GrantTypePassword, oidc.GrantTypeClientCredentials}
-	validOIDCResponseModes      = []string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery, oidc.ResponseModeFragment}
-	validOIDCUserinfoAlgorithms = []string{oidc.SigningAlgorithmNone, oidc.SigningAlgorithmRSAWithSHA256}
-	validOIDCCORSEndpoints      = []string{oidc.EndpointAuthorization, oidc.EndpointToken, oidc.EndpointIntrospection, oidc.EndpointRevocation, oidc.EndpointUserinfo}
-	validOIDCClientConsentModes = []string{"auto", oidc.ClientConsentModeImplicit.String(), oidc.ClientConsentModeExplicit.String(), oidc.ClientConsentModePreConfigured.String()}
+	validOIDCScopes                     = []string{oidc.ScopeOpenID, oidc.ScopeEmail, oidc.ScopeProfile, oidc.ScopeGroups, oidc.ScopeOfflineAccess}
+	validOIDCGrantTypes                 = []string{oidc.GrantTypeImplicit, oidc.GrantTypeRefreshToken, oidc.GrantTypeAuthorizationCode, oidc.GrantTypePassword, oidc.GrantTypeClientCredentials, oidc.GrantTypeDeviceCode, oidc.GrantTypeTokenExchange}
+	validOIDCResponseModes              = []string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery, oidc.ResponseModeFragment, oidc.ResponseModeJWT, oidc.ResponseModeFormPostJWT, oidc.ResponseModeQueryJWT, oidc.ResponseModeFragmentJWT}
+	validOIDCIssuerJWKSigningAlgorithms = []string{oidc.SigningAlgorithmRSAWithSHA256, oidc.SigningAlgorithmRSAWithSHA384, oidc.SigningAlgorithmRSAWithSHA512,
+		oidc.SigningAlgorithmECDSAWithSHA256, oidc.SigningAlgorithmECDSAWithSHA384, oidc.SigningAlgorithmECDSAWithSHA512, oidc.SigningAlgorithmEdDSA}
+	validOIDCCORSEndpoints                  = []string{oidc.EndpointAuthorization, oidc.EndpointToken, oidc.EndpointIntrospection, oidc.EndpointRevocation, oidc.EndpointUserinfo, oidc.EndpointPushedAuthorizationRequest, oidc.EndpointDeviceAuthorization, oidc.EndpointRegistration}
+	validOIDCClientTokenEndpointAuthMethods = []string{oidc.ClientAuthMethodClientSecretBasic, oidc.ClientAuthMethodClientSecretPost,
+		oidc.ClientAuthMethodClientSecretJWT, oidc.ClientAuthMethodPrivateKeyJWT, oidc.ClientAuthMethodTLSClientAuth,
+		oidc.ClientAuthMethodSelfSignedTLSClientAuth, oidc.ClientAuthMethodNone}
+	validOIDCClientTokenEndpointAuthSigningAlgsClientSecretJWT = []string{oidc.SigningAlgorithmHMACWithSHA256,
+		oidc.SigningAlgorithmHMACWithSHA384, oidc.SigningAlgorithmHMACWithSHA512}
+	validOIDCClientTokenEndpointAuthSigningAlgsPrivateKeyJWT = []string{oidc.SigningAlgorithmRSAWithSHA256,
+		oidc.SigningAlgorithmRSAWithSHA384, oidc.SigningAlgorithmRSAWithSHA512, oidc.SigningAlgorithmRSAPSSWithSHA256,
+		oidc.SigningAlgorithmRSAPSSWithSHA384, oidc.SigningAlgorithmRSAPSSWithSHA512, oidc.SigningAlgorithmECDSAWithSHA256,
+		oidc.SigningAlgorithmECDSAWithSHA384, oidc.SigningAlgorithmECDSAWithSHA512, oidc.SigningAlgorithmEdDSA}
+	validOIDCClientRequestObjectSigningAlgs = append([]string{oidc.SigningAlgorithmNone}, validOIDCClientTokenEndpointAuthSigningAlgsPrivateKeyJWT...)
+	validOIDCClientConsentModes             = []string{"auto", oidc.ClientConsentModeImplicit.String(), oidc.ClientConsentModeExplicit.String(), oidc.ClientConsentModePreConfigured.String()}
+	validOIDCACRValueAMRs                   = []string{oidc.AMRMultiFactorAuthentication, oidc.AMRMultiChannelAuthentication, oidc.AMRUserPresence,
+		oidc.AMRPersonalIdentificationNumber, oidc.AMRPasswordBasedAuthentication, oidc.AMROneTimePassword, oidc.AMRHardwareSecuredKey,
+		oidc.AMRShortMessageService}
+)
+
+var (
+	// reservedOIDCClaims are the claims which are either registered claims issued by the provider or are the standard
+	// claims already released by the standard scopes and therefore can't be used as custom claims.
+	reservedOIDCClaims = []string{oidc.ClaimJWTID, oidc.ClaimSessionID, oidc.ClaimAccessTokenHash, oidc.ClaimCodeHash,
+		oidc.ClaimIssuedAt, oidc.ClaimNotBefore, oidc.ClaimRequestedAt, oidc.ClaimExpirationTime, oidc.ClaimAuthenticationTime,
+		oidc.ClaimIssuer, oidc.ClaimSubject, oidc.ClaimNonce, oidc.ClaimAudience, oidc.ClaimGroups, oidc.ClaimFullName,
+		oidc.ClaimPreferredUsername, oidc.ClaimPreferredEmail, oidc.ClaimEmailVerified, oidc.ClaimAuthorizedParty,
+		oidc.ClaimAuthenticationContextClassReference, oidc.ClaimAuthenticationMethodsReference, oidc.ClaimClientIdentifier,
+		oidc.ClaimScope, oidc.ClaimEvents, oidc.ClaimEmailAlts}
+
+	// reservedOIDCScopes are the standard scopes which can't have custom claims attached to them.
+	reservedOIDCScopes = []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess}
+
+	// reservedUserAttributes are the names of the standard user attributes which can't be used for extra attributes.
+	reservedUserAttributes = []string{oidc.UserAttributeUsername, oidc.UserAttributeDisplayName, oidc.UserAttributeEmail,
+		oidc.UserAttributeEmails, oidc.UserAttributeGroups}
 )
 
 var reKeyReplacer = regexp.MustCompile(`\[\d+]`)
 
+var reOpenIDConnectKeyID = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
 var replacedKeys = map[string]string{
 	"authentication_backend.ldap.skip_verify":         "authentication_backend.ldap.tls.skip_verify",
 	"authentication_backend.ldap.minimum_tls_version": "authentication_backend.ldap.tls.minimum_version",
@@ -1,7 +1,11 @@
 package validator
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"fmt"
+	"net"
 	"net/url"
 	"strings"
 	"time"
@@ -23,39 +27,194 @@ func validateOIDC(config *schema.OpenIDConnectConfiguration, validator *schema.S
 
 	setOIDCDefaults(config)
 
-	switch {
-	case config.IssuerPrivateKey == nil:
+	validateOIDCIssuer(config, validator)
+
+	if config.MinimumParameterEntropy != 0 && config.MinimumParameterEntropy < 8 {
+		validator.PushWarning(fmt.Errorf(errFmtOIDCServerInsecureParameterEntropy, config.MinimumParameterEntropy))
+	}
+
+	if config.EnforcePKCE != "never" && config.EnforcePKCE != "public_clients_only" && config.EnforcePKCE != "always" {
+		validator.Push(fmt.Errorf(errFmtOIDCEnforcePKCEInvalidValue, config.EnforcePKCE))
+	}
+
+	if config.RefreshTokenGracePeriod < 0 || (config.RefreshTokenLifespan > 0 && config.RefreshTokenGracePeriod >= config.RefreshTokenLifespan) {
+		validator.Push(fmt.Errorf(errFmtOIDCRefreshTokenInvalidGracePeriod, config.RefreshTokenGracePeriod, config.RefreshTokenLifespan))
+	}
+
+	if config.DeviceAuthorization.PollingInterval >= config.DeviceAuthorization.CodeLifespan {
+		validator.Push(fmt.Errorf(errFmtOIDCDeviceAuthorizationInvalidPollingInterval, config.DeviceAuthorization.PollingInterval, config.DeviceAuthorization.CodeLifespan))
+	}
+
+	validateOIDCRotatedHMACSecrets(config, validator)
+	validateOIDCOptionsCORS(config, validator)
+	validateOIDCDynamicClientRegistration(config, validator)
+	validateOIDCMutualTLS(config, validator)
+	validateOIDCAuthorizationPolicies(config, validator)
+	validateOIDCClaimsPolicies(config, validator)
+	validateOIDCScopes(config, validator)
+	validateOIDCACRValues(config, validator)
+
+	// The clients may be omitted entirely as they can instead be registered in the storage backend either via the CLI
+	// or the dynamic client registration endpoint.
+	if len(config.Clients) != 0 {
+		validateOIDCClients(config, validator)
+	}
+}
+
+func validateOIDCIssuer(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	if config.IssuerPrivateKey == nil && len(config.IssuerPrivateKeys) == 0 && !config.KeyRotation.Enable {
 		validator.Push(fmt.Errorf(errFmtOIDCNoPrivateKey))
-	default:
-		if config.IssuerCertificateChain.HasCertificates() {
-			if !config.IssuerCertificateChain.EqualKey(config.IssuerPrivateKey) {
-				validator.Push(fmt.Errorf(errFmtOIDCCertificateMismatch))
-			}
 
-			if err := config.IssuerCertificateChain.Validate(); err != nil {
-				validator.Push(fmt.Errorf(errFmtOIDCCertificateChain, err))
+		return
+	}
+
+	kids := make([]string, 0, len(config.IssuerPrivateKeys)+1)
+	algs := make([]string, 0, len(config.IssuerPrivateKeys)+1)
+
+	if config.IssuerPrivateKey != nil {
+		validateOIDCIssuerPrivateKey(config, validator)
+
+		if kid, err := oidc.NewJWKKeyID(config.IssuerPrivateKey); err == nil {
+			kids = append(kids, kid)
+		}
+
+		algs = append(algs, oidc.SigningAlgorithmRSAWithSHA256)
+	}
+
+	for i := range config.IssuerPrivateKeys {
+		validateOIDCIssuerPrivateKeysKey(i, config, validator)
+
+		key := &config.IssuerPrivateKeys[i]
+
+		if key.KeyID != "" {
+			if utils.IsStringInSlice(key.KeyID, kids) {
+				validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysKeyIDDuplicate, i+1, key.KeyID))
 			}
+
+			kids = append(kids, key.KeyID)
 		}
 
-		if config.IssuerPrivateKey.Size()*8 < 2048 {
-			validator.Push(fmt.Errorf(errFmtOIDCInvalidPrivateKeyBitSize, 2048, config.IssuerPrivateKey.Size()*8))
+		if key.Algorithm != "" && !utils.IsStringInSlice(key.Algorithm, algs) {
+			algs = append(algs, key.Algorithm)
 		}
 	}
 
-	if config.MinimumParameterEntropy != 0 && config.MinimumParameterEntropy < 8 {
-		validator.PushWarning(fmt.Errorf(errFmtOIDCServerInsecureParameterEntropy, config.MinimumParameterEntropy))
+	if config.KeyRotation.Enable {
+		validateOIDCKeyRotation(config, validator)
+
+		if !utils.IsStringInSlice(config.KeyRotation.Algorithm, algs) {
+			algs = append(algs, config.KeyRotation.Algorithm)
+		}
 	}
 
-	if config.EnforcePKCE != "never" && config.EnforcePKCE != "public_clients_only" && config.EnforcePKCE != "always" {
-		validator.Push(fmt.Errorf(errFmtOIDCEnforcePKCEInvalidValue, config.EnforcePKCE))
+	if !utils.IsStringInSlice(oidc.SigningAlgorithmRSAWithSHA256, algs) {
+		validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysNoRS256, oidc.SigningAlgorithmRSAWithSHA256, strings.Join(algs, "', '")))
 	}
+}
 
-	validateOIDCOptionsCORS(config, validator)
+func validateOIDCIssuerPrivateKey(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	if config.IssuerCertificateChain.HasCertificates() {
+		if !config.IssuerCertificateChain.EqualKey(config.IssuerPrivateKey) {
+			validator.Push(fmt.Errorf(errFmtOIDCCertificateMismatch))
+		}
 
-	if len(config.Clients) == 0 {
-		validator.Push(fmt.Errorf(errFmtOIDCNoClientsConfigured))
-	} else {
-		validateOIDCClients(config, validator)
+		if err := config.IssuerCertificateChain.Validate(); err != nil {
+			validator.Push(fmt.Errorf(errFmtOIDCCertificateChain, err))
+		}
+	}
+
+	if config.IssuerPrivateKey.Size()*8 < 2048 {
+		validator.Push(fmt.Errorf(errFmtOIDCInvalidPrivateKeyBitSize, 2048, config.IssuerPrivateKey.Size()*8))
+	}
+}
+
+//nolint:gocyclo // Complexity is necessary to validate every option of the key.
+func validateOIDCIssuerPrivateKeysKey(i int, config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	key := &config.IssuerPrivateKeys[i]
+
+	if key.Key == nil {
+		validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysNoKey, i+1))
+
+		return
+	}
+
+	switch k := key.Key.(type) {
+	case *rsa.PrivateKey:
+		if k.Size()*8 < 2048 {
+			validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysRSAKeyBitSize, i+1, 2048, k.Size()*8))
+		}
+	case *ecdsa.PrivateKey, ed25519.PrivateKey:
+		if oidc.DefaultJWKSigningAlgorithm(k) == "" {
+			validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysUnsupportedKey, i+1, k))
+
+			return
+		}
+	default:
+		validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysUnsupportedKey, i+1, k))
+
+		return
+	}
+
+	if key.KeyID == "" {
+		key.KeyID, _ = oidc.NewJWKKeyID(key.Key)
+	} else if len(key.KeyID) > 100 || !reOpenIDConnectKeyID.MatchString(key.KeyID) {
+		validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysKeyIDInvalid, i+1, key.KeyID))
+	}
+
+	switch key.Use {
+	case "":
+		key.Use = oidc.KeyUseSignature
+	case oidc.KeyUseSignature:
+		break
+	default:
+		validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysInvalidOptionOneOf, i+1, "use", oidc.KeyUseSignature, key.Use))
+	}
+
+	switch {
+	case key.Algorithm == "":
+		key.Algorithm = oidc.DefaultJWKSigningAlgorithm(key.Key)
+	case !utils.IsStringInSlice(key.Algorithm, validOIDCIssuerJWKSigningAlgorithms):
+		validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysInvalidOptionOneOf, i+1, "algorithm", strings.Join(validOIDCIssuerJWKSigningAlgorithms, "', '"), key.Algorithm))
+	case !oidc.IsJWKSigningAlgorithmValid(key.Key, key.Algorithm):
+		validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysAlgorithmKeyMismatch, i+1, key.Algorithm, key.Key))
+	}
+
+	if key.CertificateChain.HasCertificates() {
+		if !key.CertificateChain.EqualKey(key.Key) {
+			validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysCertificateMismatch, i+1))
+		}
+
+		if err := key.CertificateChain.Validate(); err != nil {
+			validator.Push(fmt.Errorf(errFmtOIDCIssuerPrivateKeysCertificateChain, i+1, err))
+		}
+	}
+}
+
+func validateOIDCKeyRotation(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	switch {
+	case config.KeyRotation.Algorithm == "":
+		config.KeyRotation.Algorithm = schema.DefaultOpenIDConnectConfiguration.KeyRotation.Algorithm
+	case !utils.IsStringInSlice(config.KeyRotation.Algorithm, validOIDCIssuerJWKSigningAlgorithms):
+		validator.Push(fmt.Errorf(errFmtOIDCKeyRotationInvalidAlgorithm, strings.Join(validOIDCIssuerJWKSigningAlgorithms, "', '"), config.KeyRotation.Algorithm))
+	}
+
+	switch {
+	case config.KeyRotation.KeySize == 0:
+		config.KeyRotation.KeySize = schema.DefaultOpenIDConnectConfiguration.KeyRotation.KeySize
+	case strings.HasPrefix(config.KeyRotation.Algorithm, "RS") && config.KeyRotation.KeySize < 2048:
+		validator.Push(fmt.Errorf(errFmtOIDCKeyRotationInvalidKeySize, 2048, config.KeyRotation.KeySize))
+	}
+
+	if config.KeyRotation.Interval <= 0 {
+		config.KeyRotation.Interval = schema.DefaultOpenIDConnectConfiguration.KeyRotation.Interval
+	}
+
+	if config.KeyRotation.PrePublish <= 0 {
+		config.KeyRotation.PrePublish = schema.DefaultOpenIDConnectConfiguration.KeyRotation.PrePublish
+	}
+
+	if config.KeyRotation.PrePublish >= config.KeyRotation.Interval {
+		validator.Push(fmt.Errorf(errFmtOIDCKeyRotationInvalidPrePublish, config.KeyRotation.PrePublish, config.KeyRotation.Interval))
 	}
 }
 
@@ -79,6 +238,68 @@ func setOIDCDefaults(config *schema.OpenIDConnectConfiguration) {
 	if config.EnforcePKCE == "" {
 		config.EnforcePKCE = schema.DefaultOpenIDConnectConfiguration.EnforcePKCE
 	}
+
+	if config.PAR.ContextLifespan == time.Duration(0) {
+		config.PAR.ContextLifespan = schema.DefaultOpenIDConnectConfiguration.PAR.ContextLifespan
+	}
+
+	if config.DeviceAuthorization.CodeLifespan == time.Duration(0) {
+		config.DeviceAuthorization.CodeLifespan = schema.DefaultOpenIDConnectConfiguration.DeviceAuthorization.CodeLifespan
+	}
+
+	if config.DeviceAuthorization.PollingInterval == time.Duration(0) {
+		config.DeviceAuthorization.PollingInterval = schema.DefaultOpenIDConnectConfiguration.DeviceAuthorization.PollingInterval
+	}
+}
+
+func validateOIDCDynamicClientRegistration(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	if !config.DynamicClientRegistration.Enable {
+		return
+	}
+
+	if config.DynamicClientRegistration.InitialAccessToken == "" {
+		validator.Push(fmt.Errorf(errFmtOIDCDynamicClientRegistrationNoInitialAccessToken))
+	}
+
+	switch config.DynamicClientRegistration.Policy {
+	case "":
+		config.DynamicClientRegistration.Policy = schema.DefaultOpenIDConnectConfiguration.DynamicClientRegistration.Policy
+	case policyOneFactor, policyTwoFactor:
+		break
+	default:
+		validator.Push(fmt.Errorf(errFmtOIDCDynamicClientRegistrationInvalidPolicy, config.DynamicClientRegistration.Policy))
+	}
+}
+
+func validateOIDCMutualTLS(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	for _, proxy := range config.MutualTLS.TrustedProxies {
+		if !isIPOrNetwork(proxy) {
+			validator.Push(fmt.Errorf(errFmtOIDCMutualTLSInvalidTrustedProxy, proxy))
+		}
+	}
+
+	if config.MutualTLS.ForwardedCertificateHeader != "" && len(config.MutualTLS.TrustedProxies) == 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCMutualTLSNoTrustedProxies))
+	}
+}
+
+func isIPOrNetwork(value string) bool {
+	if net.ParseIP(value) != nil {
+		return true
+	}
+
+	_, _, err := net.ParseCIDR(value)
+
+	return err == nil
+}
+
+func isOIDCClientMutualTLS(client *schema.OpenIDConnectClientConfiguration) bool {
+	switch client.TokenEndpointAuthMethod {
+	case oidc.ClientAuthMethodTLSClientAuth, oidc.ClientAuthMethodSelfSignedTLSClientAuth:
+		return true
+	default:
+		return false
+	}
 }
 
 func validateOIDCOptionsCORS(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
@@ -140,7 +361,179 @@ func validateOIDCOptionsCORSEndpoints(config *schema.OpenIDConnectConfiguration,
 	}
 }
 
+func validateOIDCRotatedHMACSecrets(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	secrets := []string{config.HMACSecret}
+
+	for i, secret := range config.RotatedHMACSecrets {
+		switch {
+		case secret == "":
+			validator.Push(fmt.Errorf(errFmtOIDCRotatedHMACSecretEmpty, i+1))
+		case utils.IsStringInSlice(secret, secrets):
+			validator.Push(fmt.Errorf(errFmtOIDCRotatedHMACSecretInvalid, i+1))
+		default:
+			secrets = append(secrets, secret)
+		}
+	}
+}
+
+func validateOIDCAuthorizationPolicies(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	var names []string
+
+	for i, policy := range config.AuthorizationPolicies {
+		switch {
+		case policy.Name == "":
+			validator.Push(fmt.Errorf(errFmtOIDCAuthorizationPolicyNoName, i+1))
+		case utils.IsStringInSlice(policy.Name, names) || policy.Name == policyOneFactor || policy.Name == policyTwoFactor:
+			validator.Push(fmt.Errorf(errFmtOIDCAuthorizationPolicyInvalidName, policy.Name, strings.Join([]string{policyOneFactor, policyTwoFactor}, "', '")))
+		default:
+			names = append(names, policy.Name)
+		}
+
+		switch {
+		case policy.DefaultPolicy == "":
+			config.AuthorizationPolicies[i].DefaultPolicy = schema.DefaultOpenIDConnectClientConfiguration.Policy
+		case !utils.IsStringInSlice(policy.DefaultPolicy, validOIDCAuthorizationPolicyPolicies):
+			validator.Push(fmt.Errorf(errFmtOIDCAuthorizationPolicyInvalidDefaultPolicy, policy.Name, strings.Join(validOIDCAuthorizationPolicyPolicies, "', '"), policy.DefaultPolicy))
+		}
+
+		for j, rule := range policy.Rules {
+			if len(rule.Subjects) == 0 && len(rule.Networks) == 0 {
+				validator.Push(fmt.Errorf(errFmtOIDCAuthorizationPolicyRuleNoCriteria, policy.Name, j+1))
+			}
+
+			if !utils.IsStringInSlice(rule.Policy, validOIDCAuthorizationPolicyPolicies) {
+				validator.Push(fmt.Errorf(errFmtOIDCAuthorizationPolicyRuleInvalidPolicy, policy.Name, j+1, strings.Join(validOIDCAuthorizationPolicyPolicies, "', '"), rule.Policy))
+			}
+
+			for _, subjects := range rule.Subjects {
+				for _, subject := range subjects {
+					if subject == "" || !IsSubjectValid(subject) {
+						validator.Push(fmt.Errorf(errFmtOIDCAuthorizationPolicyRuleInvalidSubject, policy.Name, j+1, subject))
+					}
+				}
+			}
+		}
+	}
+}
+
+// validateOIDCAuthorizationPolicyNetworks ensures the networks of the OpenID Connect authorization policy rules are
+// either valid networks or the names of networks configured in the access control section.
+func validateOIDCAuthorizationPolicyNetworks(config *schema.Configuration, validator *schema.StructValidator) {
+	if config.IdentityProviders.OIDC == nil {
+		return
+	}
+
+	for _, policy := range config.IdentityProviders.OIDC.AuthorizationPolicies {
+		for j, rule := range policy.Rules {
+			for _, network := range rule.Networks {
+				if !IsNetworkValid(network) && !IsNetworkGroupValid(config.AccessControl, network) {
+					validator.Push(fmt.Errorf(errFmtOIDCAuthorizationPolicyRuleInvalidNetwork, policy.Name, j+1, network))
+				}
+			}
+		}
+	}
+}
+
 //nolint:gocyclo // TODO: Refactor.
+func validateOIDCClaimsPolicies(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	var names []string
+
+	for i, policy := range config.ClaimsPolicies {
+		switch {
+		case policy.Name == "":
+			validator.Push(fmt.Errorf(errFmtOIDCClaimsPolicyNoName, i+1))
+		case utils.IsStringInSlice(policy.Name, names):
+			validator.Push(fmt.Errorf(errFmtOIDCClaimsPolicyDuplicateName, policy.Name))
+		default:
+			names = append(names, policy.Name)
+		}
+
+		var claims []string
+
+		for j, claim := range policy.CustomClaims {
+			switch {
+			case claim.Name == "":
+				validator.Push(fmt.Errorf(errFmtOIDCClaimsPolicyCustomClaimMissingOption, policy.Name, j+1, "name"))
+			case utils.IsStringInSlice(claim.Name, claims) || utils.IsStringInSlice(claim.Name, reservedOIDCClaims):
+				validator.Push(fmt.Errorf(errFmtOIDCClaimsPolicyCustomClaimInvalidName, policy.Name, j+1, strings.Join(reservedOIDCClaims, "', '"), claim.Name))
+			default:
+				claims = append(claims, claim.Name)
+			}
+
+			if claim.Attribute == "" {
+				validator.Push(fmt.Errorf(errFmtOIDCClaimsPolicyCustomClaimMissingOption, policy.Name, j+1, "attribute"))
+			}
+		}
+
+		for _, claim := range policy.IDToken {
+			if !utils.IsStringInSlice(claim, claims) {
+				validator.Push(fmt.Errorf(errFmtOIDCClaimsPolicyInvalidIDToken, policy.Name, claim))
+			}
+		}
+	}
+}
+
+func validateOIDCACRValues(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	var values []string
+
+	for i, value := range config.ACRValues {
+		switch {
+		case value.Value == "":
+			validator.Push(fmt.Errorf(errFmtOIDCACRValueNoValue, i+1))
+		case utils.IsStringInSlice(value.Value, values):
+			validator.Push(fmt.Errorf(errFmtOIDCACRValueDuplicateValue, value.Value))
+		default:
+			values = append(values, value.Value)
+		}
+
+		switch value.Policy {
+		case "":
+			config.ACRValues[i].Policy = policyOneFactor
+		case policyOneFactor, policyTwoFactor:
+			break
+		default:
+			validator.Push(fmt.Errorf(errFmtOIDCACRValueInvalidPolicy, value.Value, value.Policy))
+		}
+
+		for _, amr := range value.AMR {
+			if !utils.IsStringInSlice(amr, validOIDCACRValueAMRs) {
+				validator.Push(fmt.Errorf(errFmtOIDCACRValueInvalidAMR, value.Value, strings.Join(validOIDCACRValueAMRs, "', '"), amr))
+			}
+		}
+	}
+}
+
+func validateOIDCScopes(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	var names, claims []string
+
+	for _, policy := range config.ClaimsPolicies {
+		for _, claim := range policy.CustomClaims {
+			claims = append(claims, claim.Name)
+		}
+	}
+
+	for i, scope := range config.Scopes {
+		switch {
+		case scope.Name == "":
+			validator.Push(fmt.Errorf(errFmtOIDCScopeNoName, i+1))
+		case utils.IsStringInSlice(scope.Name, names) || utils.IsStringInSlice(scope.Name, reservedOIDCScopes):
+			validator.Push(fmt.Errorf(errFmtOIDCScopeInvalidName, scope.Name, strings.Join(reservedOIDCScopes, "', '")))
+		default:
+			names = append(names, scope.Name)
+		}
+
+		if len(scope.Claims) == 0 {
+			validator.Push(fmt.Errorf(errFmtOIDCScopeNoClaims, scope.Name))
+		}
+
+		for _, claim := range scope.Claims {
+			if !utils.IsStringInSlice(claim, claims) {
+				validator.Push(fmt.Errorf(errFmtOIDCScopeInvalidClaim, scope.Name, claim))
+			}
+		}
+	}
+}
+
 func validateOIDCClients(config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
 	invalidID, duplicateIDs := false, false
 
@@ -165,17 +558,19 @@ func validateOIDCClients(config *schema.OpenIDConnectConfiguration, validator *s
 				validator.Push(fmt.Errorf(errFmtOIDCClientPublicInvalidSecret, client.ID))
 			}
 		} else {
-			if client.Secret == nil {
+			if client.Secret == nil && !isOIDCClientMutualTLS(&client) {
 				validator.Push(fmt.Errorf(errFmtOIDCClientInvalidSecret, client.ID))
 			}
 		}
 
 		if client.Policy == "" {
 			config.Clients[c].Policy = schema.DefaultOpenIDConnectClientConfiguration.Policy
-		} else if client.Policy != policyOneFactor && client.Policy != policyTwoFactor {
-			validator.Push(fmt.Errorf(errFmtOIDCClientInvalidPolicy, client.ID, client.Policy))
+		} else {
+			validateOIDCClientAuthorizationPolicy(client, config, validator)
 		}
 
+		validateOIDCClientClaimsPolicy(client, config, validator)
+
 		switch {
 		case utils.IsStringInSlice(client.ConsentMode, []string{"", "auto"}):
 			if client.ConsentPreConfiguredDuration != nil {
@@ -196,10 +591,20 @@ func validateOIDCClients(config *schema.OpenIDConnectConfiguration, validator *s
 		validateOIDCClientSectorIdentifier(client, validator)
 		validateOIDCClientScopes(c, config, validator)
 		validateOIDCClientGrantTypes(c, config, validator)
+		validateOIDCClientClientCredentials(c, config, validator)
+		validateOIDCClientTokenExchange(c, config, validator)
+		validateOIDCClientLifespans(c, config, validator)
 		validateOIDCClientResponseTypes(c, config, validator)
 		validateOIDCClientResponseModes(c, config, validator)
+		validateOIDCClientIDTokenAlgorithm(c, config, validator)
+		validateOIDCClientAccessTokenAlgorithm(c, config, validator)
 		validateOIDDClientUserinfoAlgorithm(c, config, validator)
+		validateOIDCClientAuthorizationAlgorithm(c, config, validator)
+		validateOIDCClientTokenEndpointAuth(c, config, validator)
+		validateOIDCClientRequestObject(client, validator)
 		validateOIDCClientRedirectURIs(client, validator)
+		validateOIDCClientPostLogoutRedirectURIs(client, validator)
+		validateOIDCClientBackChannelLogoutURI(client, validator)
 	}
 
 	if invalidID {
@@ -211,6 +616,44 @@ func validateOIDCClients(config *schema.OpenIDConnectConfiguration, validator *s
 	}
 }
 
+func validateOIDCClientAuthorizationPolicy(client schema.OpenIDConnectClientConfiguration, config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	names := []string{policyOneFactor, policyTwoFactor}
+
+	for _, policy := range config.AuthorizationPolicies {
+		if policy.Name == "" || utils.IsStringInSlice(policy.Name, names) {
+			continue
+		}
+
+		names = append(names, policy.Name)
+	}
+
+	if utils.IsStringInSlice(client.Policy, names) {
+		return
+	}
+
+	validator.Push(fmt.Errorf(errFmtOIDCClientInvalidPolicy, client.ID, strings.Join(names, "', '"), client.Policy))
+}
+
+func validateOIDCClientClaimsPolicy(client schema.OpenIDConnectClientConfiguration, config *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	if client.ClaimsPolicy == "" {
+		return
+	}
+
+	var names []string
+
+	for _, policy := range config.ClaimsPolicies {
+		if policy.Name == client.ClaimsPolicy {
+			return
+		}
+
+		if policy.Name != "" && !utils.IsStringInSlice(policy.Name, names) {
+			names = append(names, policy.Name)
+		}
+	}
+
+	validator.Push(fmt.Errorf(errFmtOIDCClientInvalidClaimsPolicy, client.ID, strings.Join(names, "', '"), client.ClaimsPolicy))
+}
+
 func validateOIDCClientSectorIdentifier(client schema.OpenIDConnectClientConfiguration, validator *schema.StructValidator) {
 	if client.SectorIdentifier.String() != "" {
 		if utils.IsURLHostComponent(client.SectorIdentifier) || utils.IsURLHostComponentWithPort(client.SectorIdentifier) {
@@ -257,11 +700,19 @@ func validateOIDCClientScopes(c int, configuration *schema.OpenIDConnectConfigur
 		configuration.Clients[c].Scopes = append(configuration.Clients[c].Scopes, oidc.ScopeOpenID)
 	}
 
+	scopes := append([]string{}, validOIDCScopes...)
+
+	for _, scope := range configuration.Scopes {
+		if !utils.IsStringInSlice(scope.Name, scopes) {
+			scopes = append(scopes, scope.Name)
+		}
+	}
+
 	for _, scope := range configuration.Clients[c].Scopes {
-		if !utils.IsStringInSlice(scope, validOIDCScopes) {
+		if !utils.IsStringInSlice(scope, scopes) {
 			validator.Push(fmt.Errorf(
 				errFmtOIDCClientInvalidEntry,
-				configuration.Clients[c].ID, "scopes", strings.Join(validOIDCScopes, "', '"), scope))
+				configuration.Clients[c].ID, "scopes", strings.Join(scopes, "', '"), scope))
 		}
 	}
 }
@@ -281,6 +732,86 @@ func validateOIDCClientGrantTypes(c int, configuration *schema.OpenIDConnectConf
 	}
 }
 
+func validateOIDCClientClientCredentials(c int, configuration *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	client := &configuration.Clients[c]
+
+	if !utils.IsStringInSlice(oidc.GrantTypeClientCredentials, client.GrantTypes) {
+		return
+	}
+
+	if client.Public {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidClientCredentialsPublic, client.ID, oidc.GrantTypeClientCredentials))
+	}
+
+	if subject := client.ClientCredentials.Subject; subject != "" &&
+		(!strings.HasPrefix(subject, oidc.SubjectPrefixClientCredentials) || subject == oidc.SubjectPrefixClientCredentials) {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidClientCredentialsSubject, client.ID, oidc.SubjectPrefixClientCredentials, subject))
+	}
+
+	for _, audience := range client.ClientCredentials.Audience {
+		if !utils.IsStringInSlice(audience, client.Audience) {
+			validator.Push(fmt.Errorf(errFmtOIDCClientInvalidClientCredentialsEntry, client.ID, "audience", "audience", audience))
+		}
+	}
+
+	for _, scope := range client.ClientCredentials.Scopes {
+		if !utils.IsStringInSlice(scope, client.Scopes) {
+			validator.Push(fmt.Errorf(errFmtOIDCClientInvalidClientCredentialsEntry, client.ID, "scopes", "scopes", scope))
+		}
+	}
+}
+
+func validateOIDCClientTokenExchange(c int, configuration *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	client := &configuration.Clients[c]
+
+	if !utils.IsStringInSlice(oidc.GrantTypeTokenExchange, client.GrantTypes) {
+		return
+	}
+
+	if len(client.TokenExchange.Audience) == 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidTokenExchangeAudience, client.ID, oidc.GrantTypeTokenExchange))
+	}
+
+	for _, scope := range client.TokenExchange.Scopes {
+		if !utils.IsStringInSlice(scope, validOIDCScopes) {
+			validator.Push(fmt.Errorf(errFmtOIDCClientInvalidTokenExchangeScopes, client.ID, strings.Join(validOIDCScopes, "', '"), scope))
+		}
+	}
+}
+
+func validateOIDCClientLifespans(c int, configuration *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	client := &configuration.Clients[c]
+
+	switch client.EnforcePKCE {
+	case "", "never", "public_clients_only", "always":
+		break
+	default:
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidEnforcePKCE, client.ID, client.EnforcePKCE))
+	}
+
+	validateOIDCClientLifespan(client.ID, "", client.Lifespans.OpenIDConnectLifespans, validator)
+	validateOIDCClientLifespan(client.ID, "grants: authorization_code: ", client.Lifespans.Grants.AuthorizationCode, validator)
+	validateOIDCClientLifespan(client.ID, "grants: implicit: ", client.Lifespans.Grants.Implicit, validator)
+	validateOIDCClientLifespan(client.ID, "grants: client_credentials: ", client.Lifespans.Grants.ClientCredentials, validator)
+	validateOIDCClientLifespan(client.ID, "grants: refresh_token: ", client.Lifespans.Grants.RefreshToken, validator)
+	validateOIDCClientLifespan(client.ID, "grants: device_code: ", client.Lifespans.Grants.DeviceCode, validator)
+	validateOIDCClientLifespan(client.ID, "grants: token_exchange: ", client.Lifespans.Grants.TokenExchange, validator)
+}
+
+func validateOIDCClientLifespan(id, prefix string, lifespans schema.OpenIDConnectLifespans, validator *schema.StructValidator) {
+	if lifespans.AccessToken < 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidLifespan, id, prefix, "access_token", lifespans.AccessToken))
+	}
+
+	if lifespans.RefreshToken < 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidLifespan, id, prefix, "refresh_token", lifespans.RefreshToken))
+	}
+
+	if lifespans.IDToken < 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidLifespan, id, prefix, "id_token", lifespans.IDToken))
+	}
+}
+
 func validateOIDCClientResponseTypes(c int, configuration *schema.OpenIDConnectConfiguration, _ *schema.StructValidator) {
 	if len(configuration.Clients[c].ResponseTypes) == 0 {
 		configuration.Clients[c].ResponseTypes = schema.DefaultOpenIDConnectClientConfiguration.ResponseTypes
@@ -304,12 +835,208 @@ func validateOIDCClientResponseModes(c int, configuration *schema.OpenIDConnectC
 }
 
 func validateOIDDClientUserinfoAlgorithm(c int, configuration *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	algs := append([]string{oidc.SigningAlgorithmNone}, getOIDCIssuerSigningAlgorithms(configuration)...)
+
 	if configuration.Clients[c].UserinfoSigningAlgorithm == "" {
 		configuration.Clients[c].UserinfoSigningAlgorithm = schema.DefaultOpenIDConnectClientConfiguration.UserinfoSigningAlgorithm
-	} else if !utils.IsStringInSlice(configuration.Clients[c].UserinfoSigningAlgorithm, validOIDCUserinfoAlgorithms) {
+	} else if !utils.IsStringInSlice(configuration.Clients[c].UserinfoSigningAlgorithm, algs) {
 		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidUserinfoAlgorithm,
-			configuration.Clients[c].ID, strings.Join(validOIDCUserinfoAlgorithms, ", "), configuration.Clients[c].UserinfoSigningAlgorithm))
+			configuration.Clients[c].ID, strings.Join(algs, ", "), configuration.Clients[c].UserinfoSigningAlgorithm))
+	}
+}
+
+func validateOIDCClientIDTokenAlgorithm(c int, configuration *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	algs := getOIDCIssuerSigningAlgorithms(configuration)
+
+	if configuration.Clients[c].IDTokenSignedResponseAlg == "" {
+		configuration.Clients[c].IDTokenSignedResponseAlg = schema.DefaultOpenIDConnectClientConfiguration.IDTokenSignedResponseAlg
+	} else if !utils.IsStringInSlice(configuration.Clients[c].IDTokenSignedResponseAlg, algs) {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidIDTokenAlgorithm,
+			configuration.Clients[c].ID, strings.Join(algs, ", "), configuration.Clients[c].IDTokenSignedResponseAlg))
+	}
+}
+
+func validateOIDCClientAuthorizationAlgorithm(c int, configuration *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	algs := getOIDCIssuerSigningAlgorithms(configuration)
+
+	if configuration.Clients[c].AuthorizationSignedResponseAlg == "" {
+		configuration.Clients[c].AuthorizationSignedResponseAlg = schema.DefaultOpenIDConnectClientConfiguration.AuthorizationSignedResponseAlg
+	} else if !utils.IsStringInSlice(configuration.Clients[c].AuthorizationSignedResponseAlg, algs) {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidAuthorizationAlgorithm,
+			configuration.Clients[c].ID, strings.Join(algs, ", "), configuration.Clients[c].AuthorizationSignedResponseAlg))
+	}
+}
+
+func validateOIDCClientAccessTokenAlgorithm(c int, configuration *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	algs := append([]string{oidc.SigningAlgorithmNone}, getOIDCIssuerSigningAlgorithms(configuration)...)
+
+	if configuration.Clients[c].AccessTokenSignedResponseAlg == "" {
+		configuration.Clients[c].AccessTokenSignedResponseAlg = schema.DefaultOpenIDConnectClientConfiguration.AccessTokenSignedResponseAlg
+	} else if !utils.IsStringInSlice(configuration.Clients[c].AccessTokenSignedResponseAlg, algs) {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidAccessTokenAlgorithm,
+			configuration.Clients[c].ID, strings.Join(algs, ", "), configuration.Clients[c].AccessTokenSignedResponseAlg))
+	}
+}
+
+func validateOIDCClientTokenEndpointAuth(c int, configuration *schema.OpenIDConnectConfiguration, validator *schema.StructValidator) {
+	client := &configuration.Clients[c]
+
+	switch {
+	case client.TokenEndpointAuthMethod == "":
+		break
+	case !utils.IsStringInSlice(client.TokenEndpointAuthMethod, validOIDCClientTokenEndpointAuthMethods):
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidTokenEndpointAuthMethod,
+			client.ID, strings.Join(validOIDCClientTokenEndpointAuthMethods, "', '"), getOIDCClientType(client), client.TokenEndpointAuthMethod))
+	case client.Public && client.TokenEndpointAuthMethod != oidc.ClientAuthMethodNone:
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidTokenEndpointAuthMethod,
+			client.ID, oidc.ClientAuthMethodNone, getOIDCClientType(client), client.TokenEndpointAuthMethod))
+	case !client.Public && client.TokenEndpointAuthMethod == oidc.ClientAuthMethodNone:
+		methods := validOIDCClientTokenEndpointAuthMethods[:len(validOIDCClientTokenEndpointAuthMethods)-1]
+
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidTokenEndpointAuthMethod,
+			client.ID, strings.Join(methods, "', '"), getOIDCClientType(client), client.TokenEndpointAuthMethod))
+	}
+
+	switch client.TokenEndpointAuthMethod {
+	case oidc.ClientAuthMethodClientSecretJWT:
+		validateOIDCClientTokenEndpointAuthSigningAlg(client, oidc.SigningAlgorithmHMACWithSHA256, validOIDCClientTokenEndpointAuthSigningAlgsClientSecretJWT, validator)
+
+		if client.Secret != nil && !client.Secret.IsPlainText() {
+			validator.Push(fmt.Errorf(errFmtOIDCClientInvalidTokenEndpointAuthSecretPlainText, client.ID, client.TokenEndpointAuthMethod))
+		}
+	case oidc.ClientAuthMethodTLSClientAuth:
+		validateOIDCClientTLSClientAuth(client, validator)
+	case oidc.ClientAuthMethodPrivateKeyJWT, oidc.ClientAuthMethodSelfSignedTLSClientAuth:
+		if client.TokenEndpointAuthMethod == oidc.ClientAuthMethodPrivateKeyJWT {
+			validateOIDCClientTokenEndpointAuthSigningAlg(client, oidc.SigningAlgorithmRSAWithSHA256, validOIDCClientTokenEndpointAuthSigningAlgsPrivateKeyJWT, validator)
+		}
+
+		switch {
+		case client.JSONWebKeysURI == "" && len(client.JSONWebKeys) == 0:
+			validator.Push(fmt.Errorf(errFmtOIDCClientInvalidJWKSNotConfigured, client.ID, client.TokenEndpointAuthMethod))
+		case client.JSONWebKeysURI != "" && len(client.JSONWebKeys) != 0:
+			validator.Push(fmt.Errorf(errFmtOIDCClientInvalidJWKSBothConfigured, client.ID))
+		}
+	}
+
+	validateOIDCClientJSONWebKeys(client, validator)
+}
+
+func validateOIDCClientTLSClientAuth(client *schema.OpenIDConnectClientConfiguration, validator *schema.StructValidator) {
+	n := 0
+
+	for _, value := range []string{client.TLSClientAuthSubjectDN, client.TLSClientAuthSANDNS, client.TLSClientAuthSANURI,
+		client.TLSClientAuthSANIP, client.TLSClientAuthSANEmail} {
+		if value != "" {
+			n++
+		}
+	}
+
+	if n != 1 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidTLSClientAuthSubject, client.ID, client.TokenEndpointAuthMethod, n))
+	}
+
+	if client.TLSClientAuthSANIP != "" && net.ParseIP(client.TLSClientAuthSANIP) == nil {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidTLSClientAuthSANIP, client.ID, client.TLSClientAuthSANIP))
+	}
+}
+
+func validateOIDCClientTokenEndpointAuthSigningAlg(client *schema.OpenIDConnectClientConfiguration, alg string, algs []string, validator *schema.StructValidator) {
+	if client.TokenEndpointAuthSigningAlg == "" {
+		client.TokenEndpointAuthSigningAlg = alg
+	} else if !utils.IsStringInSlice(client.TokenEndpointAuthSigningAlg, algs) {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidTokenEndpointAuthSigningAlg,
+			client.ID, strings.Join(algs, "', '"), client.TokenEndpointAuthMethod, client.TokenEndpointAuthSigningAlg))
+	}
+}
+
+func validateOIDCClientJSONWebKeys(client *schema.OpenIDConnectClientConfiguration, validator *schema.StructValidator) {
+	if client.JSONWebKeysURI != "" {
+		if uri, err := url.Parse(client.JSONWebKeysURI); err != nil || !uri.IsAbs() || (uri.Scheme != schemeHTTPS && uri.Scheme != schemeFile) {
+			validator.Push(fmt.Errorf(errFmtOIDCClientInvalidJWKSURI, client.ID, client.JSONWebKeysURI))
+		}
+	}
+
+	for i := range client.JSONWebKeys {
+		key := &client.JSONWebKeys[i]
+
+		if key.Key == nil {
+			validator.Push(fmt.Errorf(errFmtOIDCClientJWKSNoKey, client.ID, i+1))
+
+			continue
+		}
+
+		if key.KeyID != "" && (len(key.KeyID) > 100 || !reOpenIDConnectKeyID.MatchString(key.KeyID)) {
+			validator.Push(fmt.Errorf(errFmtOIDCClientJWKSKeyIDInvalid, client.ID, i+1, key.KeyID))
+		}
+
+		switch key.Use {
+		case "":
+			key.Use = oidc.KeyUseSignature
+		case oidc.KeyUseSignature:
+			break
+		default:
+			validator.Push(fmt.Errorf(errFmtOIDCClientJWKSInvalidOptionOneOf, client.ID, i+1, "use", oidc.KeyUseSignature, key.Use))
+		}
+
+		switch {
+		case key.Algorithm == "":
+			break
+		case !utils.IsStringInSlice(key.Algorithm, validOIDCClientTokenEndpointAuthSigningAlgsPrivateKeyJWT):
+			validator.Push(fmt.Errorf(errFmtOIDCClientJWKSInvalidOptionOneOf, client.ID, i+1, "algorithm", strings.Join(validOIDCClientTokenEndpointAuthSigningAlgsPrivateKeyJWT, "', '"), key.Algorithm))
+		case !oidc.IsPublicJWKSigningAlgorithmValid(key.Key, key.Algorithm):
+			validator.Push(fmt.Errorf(errFmtOIDCClientJWKSAlgorithmKeyMismatch, client.ID, i+1, key.Algorithm, key.Key))
+		}
+	}
+}
+
+func validateOIDCClientRequestObject(client schema.OpenIDConnectClientConfiguration, validator *schema.StructValidator) {
+	switch {
+	case client.RequestObjectSigningAlg == "":
+		break
+	case !utils.IsStringInSlice(client.RequestObjectSigningAlg, validOIDCClientRequestObjectSigningAlgs):
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidRequestObjectSigningAlg, client.ID, strings.Join(validOIDCClientRequestObjectSigningAlgs, "', '"), client.RequestObjectSigningAlg))
+	case client.RequireSignedRequestObject && client.RequestObjectSigningAlg == oidc.SigningAlgorithmNone:
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidRequestObjectSigningAlgUnsigned, client.ID))
 	}
+
+	signed := client.RequireSignedRequestObject || (client.RequestObjectSigningAlg != "" && client.RequestObjectSigningAlg != oidc.SigningAlgorithmNone)
+
+	if signed && client.JSONWebKeysURI == "" && len(client.JSONWebKeys) == 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidRequestObjectJWKSNotConfigured, client.ID))
+	}
+
+	for _, requestURI := range client.RequestURIs {
+		if uri, err := url.Parse(requestURI); err != nil || !uri.IsAbs() || uri.Scheme != schemeHTTPS {
+			validator.Push(fmt.Errorf(errFmtOIDCClientInvalidRequestURI, client.ID, requestURI))
+		}
+	}
+}
+
+func getOIDCClientType(client *schema.OpenIDConnectClientConfiguration) string {
+	if client.Public {
+		return "public"
+	}
+
+	return "confidential"
+}
+
+// getOIDCIssuerSigningAlgorithms returns the signing algorithms of the configured issuer keys. The RS256 algorithm is
+// always included as it's mandatory and its absence is reported separately.
+func getOIDCIssuerSigningAlgorithms(config *schema.OpenIDConnectConfiguration) (algs []string) {
+	algs = []string{oidc.SigningAlgorithmRSAWithSHA256}
+
+	for _, key := range config.IssuerPrivateKeys {
+		if key.Algorithm != "" && !utils.IsStringInSlice(key.Algorithm, algs) {
+			algs = append(algs, key.Algorithm)
+		}
+	}
+
+	if config.KeyRotation.Enable && config.KeyRotation.Algorithm != "" && !utils.IsStringInSlice(config.KeyRotation.Algorithm, algs) {
+		algs = append(algs, config.KeyRotation.Algorithm)
+	}
+
+	return algs
 }
 
 func validateOIDCClientRedirectURIs(client schema.OpenIDConnectClientConfiguration, validator *schema.StructValidator) {
@@ -340,3 +1067,51 @@ func validateOIDCClientRedirectURIs(client schema.OpenIDConnectClientConfigurati
 		}
 	}
 }
+
+func validateOIDCClientPostLogoutRedirectURIs(client schema.OpenIDConnectClientConfiguration, validator *schema.StructValidator) {
+	for _, redirectURI := range client.PostLogoutRedirectURIs {
+		parsedURL, err := url.Parse(redirectURI)
+		if err != nil {
+			validator.Push(fmt.Errorf(errFmtOIDCClientPostLogoutRedirectURICantBeParsed, client.ID, redirectURI, err))
+			continue
+		}
+
+		if !parsedURL.IsAbs() {
+			validator.Push(fmt.Errorf(errFmtOIDCClientPostLogoutRedirectURIAbsolute, client.ID, redirectURI))
+			continue
+		}
+
+		if parsedURL.Scheme != schemeHTTPS && parsedURL.Scheme != schemeHTTP {
+			validator.Push(fmt.Errorf(errFmtOIDCClientPostLogoutRedirectURI, client.ID, redirectURI, parsedURL.Scheme))
+		}
+
+		if parsedURL.Fragment != "" {
+			validator.Push(fmt.Errorf(errFmtOIDCClientPostLogoutRedirectURIFragment, client.ID, redirectURI))
+		}
+	}
+}
+
+func validateOIDCClientBackChannelLogoutURI(client schema.OpenIDConnectClientConfiguration, validator *schema.StructValidator) {
+	if client.BackChannelLogoutURI == "" {
+		return
+	}
+
+	parsedURL, err := url.Parse(client.BackChannelLogoutURI)
+	if err != nil {
+		validator.Push(fmt.Errorf(errFmtOIDCClientBackChannelLogoutURICantBeParsed, client.ID, client.BackChannelLogoutURI, err))
+		return
+	}
+
+	if !parsedURL.IsAbs() {
+		validator.Push(fmt.Errorf(errFmtOIDCClientBackChannelLogoutURIAbsolute, client.ID, client.BackChannelLogoutURI))
+		return
+	}
+
+	if parsedURL.Scheme != schemeHTTPS && parsedURL.Scheme != schemeHTTP {
+		validator.Push(fmt.Errorf(errFmtOIDCClientBackChannelLogoutURI, client.ID, client.BackChannelLogoutURI, parsedURL.Scheme))
+	}
+
+	if parsedURL.Fragment != "" {
+		validator.Push(fmt.Errorf(errFmtOIDCClientBackChannelLogoutURIFragment, client.ID, client.BackChannelLogoutURI))
+	}
+}
@@ -1,6 +1,10 @@
 package validator
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -28,10 +32,9 @@ func TestShouldRaiseErrorWhenInvalidOIDCServerConfiguration(t *testing.T) {
 
 	ValidateIdentityProviders(config, validator)
 
-	require.Len(t, validator.Errors(), 2)
+	require.Len(t, validator.Errors(), 1)
 
 	assert.EqualError(t, validator.Errors()[0], errFmtOIDCNoPrivateKey)
-	assert.EqualError(t, validator.Errors()[1], errFmtOIDCNoClientsConfigured)
 }
 
 func TestShouldNotRaiseErrorWhenCORSEndpointsValid(t *testing.T) {
@@ -79,7 +82,7 @@ func TestShouldRaiseErrorWhenCORSEndpointsNotValid(t *testing.T) {
 
 	require.Len(t, validator.Errors(), 1)
 
-	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: cors: option 'endpoints' contains an invalid value 'invalid_endpoint': must be one of 'authorization', 'token', 'introspection', 'revocation', 'userinfo'")
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: cors: option 'endpoints' contains an invalid value 'invalid_endpoint': must be one of 'authorization', 'token', 'introspection', 'revocation', 'userinfo', 'pushed-authorization-request', 'device-authorization', 'registration'")
 }
 
 func TestShouldRaiseErrorWhenOIDCPKCEEnforceValueInvalid(t *testing.T) {
@@ -94,10 +97,445 @@ func TestShouldRaiseErrorWhenOIDCPKCEEnforceValueInvalid(t *testing.T) {
 
 	ValidateIdentityProviders(config, validator)
 
-	require.Len(t, validator.Errors(), 2)
+	require.Len(t, validator.Errors(), 1)
 
 	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: option 'enforce_pkce' must be 'never', 'public_clients_only' or 'always', but it is configured as 'invalid'")
-	assert.EqualError(t, validator.Errors()[1], errFmtOIDCNoClientsConfigured)
+}
+
+func TestShouldRaiseErrorWhenOIDCDeviceAuthorizationPollingIntervalInvalid(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+			DeviceAuthorization: schema.OpenIDConnectDeviceAuthorizationConfiguration{
+				CodeLifespan:    time.Minute,
+				PollingInterval: time.Minute * 2,
+			},
+		},
+	}
+
+	ValidateIdentityProviders(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: device_authorizations: option 'polling_interval' must be less than the option 'code_lifespan' but it's configured as '2m0s' and the 'code_lifespan' is configured as '1m0s'")
+}
+
+func TestShouldRaiseErrorWhenOIDCRefreshTokenGracePeriodInvalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     time.Duration
+		expected string
+	}{
+		{"ShouldRaiseErrorWhenNegative", -time.Second, "identity_providers: oidc: option 'refresh_token_grace_period' must be 0 or more and less than the option 'refresh_token_lifespan' but it's configured as '-1s' and the 'refresh_token_lifespan' is configured as '1h30m0s'"},
+		{"ShouldRaiseErrorWhenNotLessThanLifespan", time.Hour * 2, "identity_providers: oidc: option 'refresh_token_grace_period' must be 0 or more and less than the option 'refresh_token_lifespan' but it's configured as '2h0m0s' and the 'refresh_token_lifespan' is configured as '1h30m0s'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := &schema.IdentityProvidersConfiguration{
+				OIDC: &schema.OpenIDConnectConfiguration{
+					HMACSecret:              "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+					IssuerPrivateKey:        MustParseRSAPrivateKey(testKey1),
+					RefreshTokenGracePeriod: tc.have,
+				},
+			}
+
+			ValidateIdentityProviders(config, validator)
+
+			require.Len(t, validator.Errors(), 1)
+
+			assert.EqualError(t, validator.Errors()[0], tc.expected)
+		})
+	}
+}
+
+func TestShouldRaiseErrorWhenOIDCACRValuesInvalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     []schema.OpenIDConnectACRValue
+		expected []string
+	}{
+		{
+			"ShouldRaiseErrorWhenNoValue",
+			[]schema.OpenIDConnectACRValue{{Policy: "one_factor"}},
+			[]string{"identity_providers: oidc: acr_values: value #1: option 'value' is required"},
+		},
+		{
+			"ShouldRaiseErrorWhenDuplicateValue",
+			[]schema.OpenIDConnectACRValue{{Value: "mfa"}, {Value: "mfa"}},
+			[]string{"identity_providers: oidc: acr_values: value 'mfa': option 'value' must be unique but it's configured more than once"},
+		},
+		{
+			"ShouldRaiseErrorWhenInvalidPolicy",
+			[]schema.OpenIDConnectACRValue{{Value: "mfa", Policy: "deny"}},
+			[]string{"identity_providers: oidc: acr_values: value 'mfa': option 'policy' must be one of 'one_factor' or 'two_factor' but it's configured as 'deny'"},
+		},
+		{
+			"ShouldRaiseErrorWhenInvalidAMR",
+			[]schema.OpenIDConnectACRValue{{Value: "mfa", Policy: "two_factor", AMR: []string{"pwd", "face"}}},
+			[]string{"identity_providers: oidc: acr_values: value 'mfa': option 'amr' must only contain values from 'mfa', 'mca', 'user', 'pin', 'pwd', 'otp', 'hwk', 'sms' but it contains 'face'"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := &schema.IdentityProvidersConfiguration{
+				OIDC: &schema.OpenIDConnectConfiguration{
+					HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+					IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+					ACRValues:        tc.have,
+				},
+			}
+
+			ValidateIdentityProviders(config, validator)
+
+			require.Len(t, validator.Errors(), len(tc.expected))
+
+			for i, expected := range tc.expected {
+				assert.EqualError(t, validator.Errors()[i], expected)
+			}
+		})
+	}
+}
+
+func TestShouldSetDefaultOIDCACRValuePolicy(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+			ACRValues:        []schema.OpenIDConnectACRValue{{Value: "pwd", AMR: []string{"pwd"}}},
+		},
+	}
+
+	ValidateIdentityProviders(config, validator)
+
+	require.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, "one_factor", config.OIDC.ACRValues[0].Policy)
+}
+
+func TestShouldRaiseErrorWhenOIDCRotatedHMACSecretsInvalid(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:         "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			RotatedHMACSecrets: []string{"zpeRfUVDVvmrqXgLCXAkdevCtdEkjvPA", "", "rLABDrx87et5KvRHVUgTm3pezWWd8LMN", "zpeRfUVDVvmrqXgLCXAkdevCtdEkjvPA"},
+			IssuerPrivateKey:   MustParseRSAPrivateKey(testKey1),
+		},
+	}
+
+	ValidateIdentityProviders(config, validator)
+
+	require.Len(t, validator.Errors(), 3)
+
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: option 'rotated_hmac_secrets' must not contain empty values but secret #2 is empty")
+	assert.EqualError(t, validator.Errors()[1], "identity_providers: oidc: option 'rotated_hmac_secrets' must only contain unique values which are not the same as the option 'hmac_secret' but secret #3 is a duplicate")
+	assert.EqualError(t, validator.Errors()[2], "identity_providers: oidc: option 'rotated_hmac_secrets' must only contain unique values which are not the same as the option 'hmac_secret' but secret #4 is a duplicate")
+}
+
+func TestShouldRaiseErrorWhenOIDCAuthorizationPoliciesInvalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     []schema.OpenIDConnectAuthorizationPolicy
+		expected []string
+	}{
+		{
+			"ShouldRaiseErrorWhenNoName",
+			[]schema.OpenIDConnectAuthorizationPolicy{{DefaultPolicy: "deny"}},
+			[]string{"identity_providers: oidc: authorization_policies: policy #1: option 'name' is required"},
+		},
+		{
+			"ShouldRaiseErrorWhenDuplicateOrReservedName",
+			[]schema.OpenIDConnectAuthorizationPolicy{{Name: "admins"}, {Name: "admins"}, {Name: "two_factor"}},
+			[]string{
+				"identity_providers: oidc: authorization_policies: policy 'admins': option 'name' must be unique and must not be one of 'one_factor', 'two_factor'",
+				"identity_providers: oidc: authorization_policies: policy 'two_factor': option 'name' must be unique and must not be one of 'one_factor', 'two_factor'",
+			},
+		},
+		{
+			"ShouldRaiseErrorWhenInvalidDefaultPolicy",
+			[]schema.OpenIDConnectAuthorizationPolicy{{Name: "admins", DefaultPolicy: "bypass"}},
+			[]string{"identity_providers: oidc: authorization_policies: policy 'admins': option 'default_policy' must be one of 'one_factor', 'two_factor', 'deny' but it's configured as 'bypass'"},
+		},
+		{
+			"ShouldRaiseErrorWhenInvalidRules",
+			[]schema.OpenIDConnectAuthorizationPolicy{
+				{
+					Name:          "admins",
+					DefaultPolicy: "deny",
+					Rules: []schema.OpenIDConnectAuthorizationPolicyRule{
+						{Policy: "two_factor"},
+						{Policy: "bypass", Subjects: [][]string{{"group:admins"}}},
+						{Policy: "one_factor", Subjects: [][]string{{"group:admins", "admins"}}},
+					},
+				},
+			},
+			[]string{
+				"identity_providers: oidc: authorization_policies: policy 'admins': rules: rule #1: at least one of the options 'subject' or 'networks' is required",
+				"identity_providers: oidc: authorization_policies: policy 'admins': rules: rule #2: option 'policy' must be one of 'one_factor', 'two_factor', 'deny' but it's configured as 'bypass'",
+				"identity_providers: oidc: authorization_policies: policy 'admins': rules: rule #3: option 'subject' must only contain values prefixed with 'user:' or 'group:' but it contains 'admins'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := &schema.IdentityProvidersConfiguration{
+				OIDC: &schema.OpenIDConnectConfiguration{
+					HMACSecret:            "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+					IssuerPrivateKey:      MustParseRSAPrivateKey(testKey1),
+					AuthorizationPolicies: tc.have,
+				},
+			}
+
+			ValidateIdentityProviders(config, validator)
+
+			require.Len(t, validator.Errors(), len(tc.expected))
+
+			for i, expected := range tc.expected {
+				assert.EqualError(t, validator.Errors()[i], expected)
+			}
+		})
+	}
+}
+
+func TestShouldValidateOIDCClientAuthorizationPolicy(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+			AuthorizationPolicies: []schema.OpenIDConnectAuthorizationPolicy{
+				{
+					Name: "admins",
+					Rules: []schema.OpenIDConnectAuthorizationPolicyRule{
+						{Policy: "one_factor", Subjects: [][]string{{"group:admins"}}, Networks: []string{"10.0.0.0/8"}},
+					},
+				},
+			},
+			Clients: []schema.OpenIDConnectClientConfiguration{
+				{
+					ID:           "good",
+					Secret:       MustDecodeSecret("$plaintext$good_secret"),
+					Policy:       "admins",
+					RedirectURIs: []string{"https://google.com/callback"},
+				},
+				{
+					ID:           "bad",
+					Secret:       MustDecodeSecret("$plaintext$good_secret"),
+					Policy:       "users",
+					RedirectURIs: []string{"https://google.com/callback"},
+				},
+			},
+		},
+	}
+
+	ValidateIdentityProviders(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: client 'bad': option 'authorization_policy' must be one of 'one_factor', 'two_factor', 'admins' but it is configured as 'users'")
+
+	assert.Equal(t, "two_factor", config.OIDC.AuthorizationPolicies[0].DefaultPolicy)
+}
+
+func TestShouldRaiseErrorWhenOIDCAuthorizationPolicyNetworksInvalid(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			Networks: []schema.ACLNetwork{{Name: "internal", Networks: []string{"10.0.0.0/8"}}},
+		},
+		IdentityProviders: schema.IdentityProvidersConfiguration{
+			OIDC: &schema.OpenIDConnectConfiguration{
+				AuthorizationPolicies: []schema.OpenIDConnectAuthorizationPolicy{
+					{
+						Name: "admins",
+						Rules: []schema.OpenIDConnectAuthorizationPolicyRule{
+							{Policy: "one_factor", Networks: []string{"internal", "192.168.1.0/24", "127.0.0.1", "external"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	validateOIDCAuthorizationPolicyNetworks(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: authorization_policies: policy 'admins': rules: rule #1: option 'networks' must only contain IP addresses, CIDR notation networks, or the names of networks configured in the 'access_control' section but it contains 'external'")
+}
+
+func TestShouldRaiseErrorWhenOIDCDynamicClientRegistrationInvalid(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+			DynamicClientRegistration: schema.OpenIDConnectDynamicClientRegistrationConfiguration{
+				Enable: true,
+				Policy: "bypass",
+			},
+		},
+	}
+
+	ValidateIdentityProviders(config, validator)
+
+	require.Len(t, validator.Errors(), 2)
+
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: dynamic_client_registration: option 'initial_access_token' is required when dynamic client registration is enabled")
+	assert.EqualError(t, validator.Errors()[1], "identity_providers: oidc: dynamic_client_registration: option 'authorization_policy' must be 'one_factor' or 'two_factor' but it is configured as 'bypass'")
+}
+
+func TestShouldNotRaiseErrorWhenOIDCDynamicClientRegistrationEnabledWithoutClients(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+			DynamicClientRegistration: schema.OpenIDConnectDynamicClientRegistrationConfiguration{
+				Enable:             true,
+				InitialAccessToken: "an-initial-access-token",
+			},
+		},
+	}
+
+	ValidateIdentityProviders(config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, "two_factor", config.OIDC.DynamicClientRegistration.Policy)
+}
+
+func TestShouldRaiseErrorWhenOIDCMutualTLSInvalid(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+			DynamicClientRegistration: schema.OpenIDConnectDynamicClientRegistrationConfiguration{
+				Enable:             true,
+				InitialAccessToken: "an-initial-access-token",
+			},
+			MutualTLS: schema.OpenIDConnectMutualTLSConfiguration{
+				ForwardedCertificateHeader: "X-Forwarded-Client-Cert",
+				TrustedProxies:             []string{"10.0.0.1", "192.168.0.0/16", "proxy.example.com"},
+			},
+		},
+	}
+
+	ValidateIdentityProviders(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: mutual_tls: option 'trusted_proxies' must only contain IP addresses or CIDR notation networks but it contains 'proxy.example.com'")
+
+	validator.Clear()
+
+	config.OIDC.MutualTLS.TrustedProxies = nil
+
+	ValidateIdentityProviders(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: mutual_tls: option 'trusted_proxies' is required when option 'forwarded_certificate_header' is configured")
+}
+
+func TestShouldRaiseErrorWhenOIDCClaimsPoliciesAndScopesInvalid(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+			ClaimsPolicies: []schema.OpenIDConnectClaimsPolicy{
+				{
+					Name:    "policy",
+					IDToken: []string{"tenant_id", "missing"},
+					CustomClaims: []schema.OpenIDConnectCustomClaim{
+						{Name: "tenant_id", Attribute: "tenant"},
+						{Name: "tenant_id", Attribute: "tenant"},
+						{Name: "sub", Attribute: "username"},
+						{Name: "phone_number"},
+					},
+				},
+				{
+					Name: "policy",
+				},
+				{},
+			},
+			Scopes: []schema.OpenIDConnectScope{
+				{Name: "tenant", Claims: []string{"tenant_id", "employee_number"}},
+				{Name: "openid", Claims: []string{"tenant_id"}},
+				{Name: "tenant"},
+			},
+			Clients: []schema.OpenIDConnectClientConfiguration{
+				{
+					ID:           "myclient",
+					Secret:       MustDecodeSecret("$plaintext$jk12nb3klqwmnelqkwenm"),
+					RedirectURIs: []string{"https://example.com/oauth2_callback"},
+					Scopes:       []string{"openid", "tenant", "bad_scope"},
+					ClaimsPolicy: "not_a_policy",
+				},
+			},
+		},
+	}
+
+	ValidateIdentityProviders(config, validator)
+
+	require.Len(t, validator.Errors(), 12)
+
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: claims_policies: policy 'policy': custom_claims: claim #2: option 'name' must be unique within the policy and must not be one of 'jti', 'sid', 'at_hash', 'c_hash', 'iat', 'nbf', 'rat', 'exp', 'auth_time', 'iss', 'sub', 'nonce', 'aud', 'groups', 'name', 'preferred_username', 'email', 'email_verified', 'azp', 'acr', 'amr', 'client_id', 'scope', 'events', 'alt_emails' but it's configured as 'tenant_id'")
+	assert.EqualError(t, validator.Errors()[1], "identity_providers: oidc: claims_policies: policy 'policy': custom_claims: claim #3: option 'name' must be unique within the policy and must not be one of 'jti', 'sid', 'at_hash', 'c_hash', 'iat', 'nbf', 'rat', 'exp', 'auth_time', 'iss', 'sub', 'nonce', 'aud', 'groups', 'name', 'preferred_username', 'email', 'email_verified', 'azp', 'acr', 'amr', 'client_id', 'scope', 'events', 'alt_emails' but it's configured as 'sub'")
+	assert.EqualError(t, validator.Errors()[2], "identity_providers: oidc: claims_policies: policy 'policy': custom_claims: claim #4: option 'attribute' is required")
+	assert.EqualError(t, validator.Errors()[3], "identity_providers: oidc: claims_policies: policy 'policy': option 'id_token' must only contain custom claims of the policy but it contains 'missing'")
+	assert.EqualError(t, validator.Errors()[4], "identity_providers: oidc: claims_policies: policy 'policy': option 'name' must be unique but it's configured more than once")
+	assert.EqualError(t, validator.Errors()[5], "identity_providers: oidc: claims_policies: policy #3: option 'name' is required")
+	assert.EqualError(t, validator.Errors()[6], "identity_providers: oidc: scopes: scope 'tenant': option 'claims' must only contain custom claims configured in a claims policy but it contains 'employee_number'")
+	assert.EqualError(t, validator.Errors()[7], "identity_providers: oidc: scopes: scope 'openid': option 'name' must be unique and must not be one of 'openid', 'offline_access'")
+	assert.EqualError(t, validator.Errors()[8], "identity_providers: oidc: scopes: scope 'tenant': option 'name' must be unique and must not be one of 'openid', 'offline_access'")
+	assert.EqualError(t, validator.Errors()[9], "identity_providers: oidc: scopes: scope 'tenant': option 'claims' must have at least one value")
+	assert.EqualError(t, validator.Errors()[10], "identity_providers: oidc: client 'myclient': option 'claims_policy' must be one of the configured claims policies 'policy' but it is configured as 'not_a_policy'")
+	assert.EqualError(t, validator.Errors()[11], "identity_providers: oidc: client 'myclient': option 'scopes' must only have the values 'openid', 'email', 'profile', 'groups', 'offline_access', 'tenant' but one option is configured as 'bad_scope'")
+}
+
+func TestShouldNotRaiseErrorWhenOIDCClaimsPoliciesAndScopesValid(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+			ClaimsPolicies: []schema.OpenIDConnectClaimsPolicy{
+				{
+					Name:    "policy",
+					IDToken: []string{"tenant_id"},
+					CustomClaims: []schema.OpenIDConnectCustomClaim{
+						{Name: "tenant_id", Attribute: "tenant"},
+						{Name: "phone_number", Attribute: "phone"},
+					},
+				},
+			},
+			Scopes: []schema.OpenIDConnectScope{
+				{Name: "tenant", Claims: []string{"tenant_id"}},
+				{Name: "profile", Claims: []string{"phone_number"}},
+			},
+			Clients: []schema.OpenIDConnectClientConfiguration{
+				{
+					ID:           "myclient",
+					Secret:       MustDecodeSecret("$plaintext$jk12nb3klqwmnelqkwenm"),
+					RedirectURIs: []string{"https://example.com/oauth2_callback"},
+					Scopes:       []string{"openid", "profile", "tenant"},
+					ClaimsPolicy: "policy",
+				},
+			},
+		},
+	}
+
+	ValidateIdentityProviders(config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
 }
 
 func TestShouldRaiseErrorWhenOIDCCORSOriginsHasInvalidValues(t *testing.T) {
@@ -137,7 +575,7 @@ func TestShouldRaiseErrorWhenOIDCCORSOriginsHasInvalidValues(t *testing.T) {
 	assert.Equal(t, "https://example.com", config.OIDC.CORS.AllowedOrigins[4].String())
 }
 
-func TestShouldRaiseErrorWhenOIDCServerNoClients(t *testing.T) {
+func TestShouldNotRaiseErrorWhenOIDCServerNoClients(t *testing.T) {
 	validator := schema.NewStructValidator()
 	config := &schema.IdentityProvidersConfiguration{
 		OIDC: &schema.OpenIDConnectConfiguration{
@@ -148,9 +586,8 @@ func TestShouldRaiseErrorWhenOIDCServerNoClients(t *testing.T) {
 
 	ValidateIdentityProviders(config, validator)
 
-	require.Len(t, validator.Errors(), 1)
-
-	assert.EqualError(t, validator.Errors()[0], errFmtOIDCNoClientsConfigured)
+	assert.Len(t, validator.Errors(), 0)
+	assert.Len(t, validator.Warnings(), 0)
 }
 
 func TestShouldRaiseErrorWhenOIDCServerClientBadValues(t *testing.T) {
@@ -195,7 +632,7 @@ func TestShouldRaiseErrorWhenOIDCServerClientBadValues(t *testing.T) {
 					},
 				},
 			},
-			Errors: []string{fmt.Sprintf(errFmtOIDCClientInvalidPolicy, "client-1", "a-policy")},
+			Errors: []string{fmt.Sprintf(errFmtOIDCClientInvalidPolicy, "client-1", "one_factor', 'two_factor", "a-policy")},
 		},
 		{
 			Name: "ClientIDDuplicated",
@@ -247,6 +684,69 @@ func TestShouldRaiseErrorWhenOIDCServerClientBadValues(t *testing.T) {
 				fmt.Sprintf(errFmtOIDCClientRedirectURIAbsolute, "client-check-uri-abs", "google.com"),
 			},
 		},
+		{
+			Name: "PostLogoutRedirectURIInvalid",
+			Clients: []schema.OpenIDConnectClientConfiguration{
+				{
+					ID:     "client-check-post-logout-uri",
+					Secret: MustDecodeSecret("$plaintext$a-secret"),
+					Policy: policyTwoFactor,
+					RedirectURIs: []string{
+						"https://google.com",
+					},
+					PostLogoutRedirectURIs: []string{
+						"http://abc@%two",
+						"google.com",
+						"oc://ios.owncloud.com",
+						"https://google.com/logout#fragment",
+						"https://google.com/logout",
+					},
+				},
+			},
+			Errors: []string{
+				fmt.Sprintf(errFmtOIDCClientPostLogoutRedirectURICantBeParsed, "client-check-post-logout-uri", "http://abc@%two", errors.New("parse \"http://abc@%two\": invalid URL escape \"%tw\"")),
+				fmt.Sprintf(errFmtOIDCClientPostLogoutRedirectURIAbsolute, "client-check-post-logout-uri", "google.com"),
+				fmt.Sprintf(errFmtOIDCClientPostLogoutRedirectURI, "client-check-post-logout-uri", "oc://ios.owncloud.com", "oc"),
+				fmt.Sprintf(errFmtOIDCClientPostLogoutRedirectURIFragment, "client-check-post-logout-uri", "https://google.com/logout#fragment"),
+			},
+		},
+		{
+			Name: "BackChannelLogoutURIInvalid",
+			Clients: []schema.OpenIDConnectClientConfiguration{
+				{
+					ID:     "client-check-bcl-scheme",
+					Secret: MustDecodeSecret("$plaintext$a-secret"),
+					Policy: policyTwoFactor,
+					RedirectURIs: []string{
+						"https://google.com",
+					},
+					BackChannelLogoutURI: "oc://ios.owncloud.com",
+				},
+				{
+					ID:     "client-check-bcl-abs",
+					Secret: MustDecodeSecret("$plaintext$a-secret"),
+					Policy: policyTwoFactor,
+					RedirectURIs: []string{
+						"https://google.com",
+					},
+					BackChannelLogoutURI: "google.com",
+				},
+				{
+					ID:     "client-check-bcl-fragment",
+					Secret: MustDecodeSecret("$plaintext$a-secret"),
+					Policy: policyTwoFactor,
+					RedirectURIs: []string{
+						"https://google.com",
+					},
+					BackChannelLogoutURI: "https://google.com/logout#fragment",
+				},
+			},
+			Errors: []string{
+				fmt.Sprintf(errFmtOIDCClientBackChannelLogoutURI, "client-check-bcl-scheme", "oc://ios.owncloud.com", "oc"),
+				fmt.Sprintf(errFmtOIDCClientBackChannelLogoutURIAbsolute, "client-check-bcl-abs", "google.com"),
+				fmt.Sprintf(errFmtOIDCClientBackChannelLogoutURIFragment, "client-check-bcl-fragment", "https://google.com/logout#fragment"),
+			},
+		},
 		{
 			Name: "ValidSectorIdentifier",
 			Clients: []schema.OpenIDConnectClientConfiguration{
@@ -301,47 +801,194 @@ func TestShouldRaiseErrorWhenOIDCServerClientBadValues(t *testing.T) {
 			Name: "InvalidSectorIdentifierInvalidHost",
 			Clients: []schema.OpenIDConnectClientConfiguration{
 				{
-					ID:     "client-invalid-sector",
-					Secret: MustDecodeSecret("$plaintext$a-secret"),
-					Policy: policyTwoFactor,
+					ID:     "client-invalid-sector",
+					Secret: MustDecodeSecret("$plaintext$a-secret"),
+					Policy: policyTwoFactor,
+					RedirectURIs: []string{
+						"https://google.com",
+					},
+					SectorIdentifier: mustParseURL("example.com/path?query=abc#fragment"),
+				},
+			},
+			Errors: []string{
+				fmt.Sprintf(errFmtOIDCClientInvalidSectorIdentifierHost, "client-invalid-sector", "example.com/path?query=abc#fragment"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := &schema.IdentityProvidersConfiguration{
+				OIDC: &schema.OpenIDConnectConfiguration{
+					HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+					IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+					Clients:          tc.Clients,
+				},
+			}
+
+			ValidateIdentityProviders(config, validator)
+
+			errs := validator.Errors()
+
+			require.Len(t, errs, len(tc.Errors))
+			for i, errStr := range tc.Errors {
+				t.Run(fmt.Sprintf("Error%d", i+1), func(t *testing.T) {
+					assert.EqualError(t, errs[i], errStr)
+				})
+			}
+		})
+	}
+}
+
+func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadScopes(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+			Clients: []schema.OpenIDConnectClientConfiguration{
+				{
+					ID:     "good_id",
+					Secret: MustDecodeSecret("$plaintext$good_secret"),
+					Policy: "two_factor",
+					Scopes: []string{"openid", "bad_scope"},
+					RedirectURIs: []string{
+						"https://google.com/callback",
+					},
+				},
+			},
+		},
+	}
+
+	ValidateIdentityProviders(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: client 'good_id': option 'scopes' must only have the values 'openid', 'email', 'profile', 'groups', 'offline_access' but one option is configured as 'bad_scope'")
+}
+
+func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadGrantTypes(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+			Clients: []schema.OpenIDConnectClientConfiguration{
+				{
+					ID:         "good_id",
+					Secret:     MustDecodeSecret("$plaintext$good_secret"),
+					Policy:     "two_factor",
+					GrantTypes: []string{"bad_grant_type"},
+					RedirectURIs: []string{
+						"https://google.com/callback",
+					},
+				},
+			},
+		},
+	}
+
+	ValidateIdentityProviders(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: client 'good_id': option 'grant_types' must only have the values 'implicit', 'refresh_token', 'authorization_code', 'password', 'client_credentials', 'urn:ietf:params:oauth:grant-type:device_code', 'urn:ietf:params:oauth:grant-type:token-exchange' but one option is configured as 'bad_grant_type'")
+}
+
+func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadClientCredentials(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProvidersConfiguration{
+		OIDC: &schema.OpenIDConnectConfiguration{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
+			Clients: []schema.OpenIDConnectClientConfiguration{
+				{
+					ID:         "good_id",
+					Secret:     MustDecodeSecret("$plaintext$good_secret"),
+					Policy:     "two_factor",
+					Scopes:     []string{"openid", "groups"},
+					Audience:   []string{"https://api.example.com"},
+					GrantTypes: []string{oidc.GrantTypeClientCredentials},
+					ClientCredentials: schema.OpenIDConnectClientClientCredentialsConfiguration{
+						Subject:  "client:backup",
+						Audience: []string{"https://api.example.com", "https://other.example.com"},
+						Scopes:   []string{"groups", "profile"},
+					},
 					RedirectURIs: []string{
-						"https://google.com",
+						"https://google.com/callback",
+					},
+				},
+				{
+					ID:                      "public_id",
+					Public:                  true,
+					TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+					Policy:                  "two_factor",
+					GrantTypes:              []string{oidc.GrantTypeClientCredentials},
+					RedirectURIs: []string{
+						"https://google.com/callback",
 					},
-					SectorIdentifier: mustParseURL("example.com/path?query=abc#fragment"),
 				},
-			},
-			Errors: []string{
-				fmt.Sprintf(errFmtOIDCClientInvalidSectorIdentifierHost, "client-invalid-sector", "example.com/path?query=abc#fragment"),
 			},
 		},
 	}
 
+	ValidateIdentityProviders(config, validator)
+
+	require.Len(t, validator.Errors(), 3)
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: client 'good_id': client_credentials: option 'audience' must only have values which are also configured in the client option 'audience' but one option is configured as 'https://other.example.com'")
+	assert.EqualError(t, validator.Errors()[1], "identity_providers: oidc: client 'good_id': client_credentials: option 'scopes' must only have values which are also configured in the client option 'scopes' but one option is configured as 'profile'")
+	assert.EqualError(t, validator.Errors()[2], "identity_providers: oidc: client 'public_id': option 'grant_types' must not include 'client_credentials' when option 'public' is true")
+}
+
+func TestShouldValidateOIDCClientClientCredentialsSubject(t *testing.T) {
+	testCases := []struct {
+		name     string
+		subject  string
+		expected string
+	}{
+		{"ShouldAllowNotConfigured", "", ""},
+		{"ShouldAllowPrefixed", "client:backup", ""},
+		{"ShouldRaiseErrorOnUsername", "john", "identity_providers: oidc: client 'good_id': client_credentials: option 'subject' must start with 'client:' followed by the name of the service account but it's configured as 'john'"},
+		{"ShouldRaiseErrorOnOpaqueIdentifier", "5c3b2f8e-7d41-4a6b-9f0e-1a2b3c4d5e6f", "identity_providers: oidc: client 'good_id': client_credentials: option 'subject' must start with 'client:' followed by the name of the service account but it's configured as '5c3b2f8e-7d41-4a6b-9f0e-1a2b3c4d5e6f'"},
+		{"ShouldRaiseErrorOnOtherPrefix", "service:backup", "identity_providers: oidc: client 'good_id': client_credentials: option 'subject' must start with 'client:' followed by the name of the service account but it's configured as 'service:backup'"},
+		{"ShouldRaiseErrorOnPrefixOnly", "client:", "identity_providers: oidc: client 'good_id': client_credentials: option 'subject' must start with 'client:' followed by the name of the service account but it's configured as 'client:'"},
+	}
+
 	for _, tc := range testCases {
-		t.Run(tc.Name, func(t *testing.T) {
+		t.Run(tc.name, func(t *testing.T) {
 			validator := schema.NewStructValidator()
 			config := &schema.IdentityProvidersConfiguration{
 				OIDC: &schema.OpenIDConnectConfiguration{
 					HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
 					IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
-					Clients:          tc.Clients,
+					Clients: []schema.OpenIDConnectClientConfiguration{
+						{
+							ID:         "good_id",
+							Secret:     MustDecodeSecret("$plaintext$good_secret"),
+							Policy:     "two_factor",
+							GrantTypes: []string{oidc.GrantTypeClientCredentials},
+							ClientCredentials: schema.OpenIDConnectClientClientCredentialsConfiguration{
+								Subject: tc.subject,
+							},
+							RedirectURIs: []string{
+								"https://google.com/callback",
+							},
+						},
+					},
 				},
 			}
 
 			ValidateIdentityProviders(config, validator)
 
-			errs := validator.Errors()
-
-			require.Len(t, errs, len(tc.Errors))
-			for i, errStr := range tc.Errors {
-				t.Run(fmt.Sprintf("Error%d", i+1), func(t *testing.T) {
-					assert.EqualError(t, errs[i], errStr)
-				})
+			if tc.expected == "" {
+				assert.Len(t, validator.Errors(), 0)
+			} else {
+				require.Len(t, validator.Errors(), 1)
+				assert.EqualError(t, validator.Errors()[0], tc.expected)
 			}
 		})
 	}
 }
 
-func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadScopes(t *testing.T) {
+func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadTokenExchange(t *testing.T) {
 	validator := schema.NewStructValidator()
 	config := &schema.IdentityProvidersConfiguration{
 		OIDC: &schema.OpenIDConnectConfiguration{
@@ -349,10 +996,13 @@ func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadScopes(t *testing.T) {
 			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
 			Clients: []schema.OpenIDConnectClientConfiguration{
 				{
-					ID:     "good_id",
-					Secret: MustDecodeSecret("$plaintext$good_secret"),
-					Policy: "two_factor",
-					Scopes: []string{"openid", "bad_scope"},
+					ID:         "good_id",
+					Secret:     MustDecodeSecret("$plaintext$good_secret"),
+					Policy:     "two_factor",
+					GrantTypes: []string{oidc.GrantTypeTokenExchange},
+					TokenExchange: schema.OpenIDConnectClientTokenExchangeConfiguration{
+						Scopes: []string{"openid", "bad_scope"},
+					},
 					RedirectURIs: []string{
 						"https://google.com/callback",
 					},
@@ -363,11 +1013,12 @@ func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadScopes(t *testing.T) {
 
 	ValidateIdentityProviders(config, validator)
 
-	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: client 'good_id': option 'scopes' must only have the values 'openid', 'email', 'profile', 'groups', 'offline_access' but one option is configured as 'bad_scope'")
+	require.Len(t, validator.Errors(), 2)
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: client 'good_id': token_exchange: option 'audience' must have at least one value when option 'grant_types' includes 'urn:ietf:params:oauth:grant-type:token-exchange'")
+	assert.EqualError(t, validator.Errors()[1], "identity_providers: oidc: client 'good_id': token_exchange: option 'scopes' must only have the values 'openid', 'email', 'profile', 'groups', 'offline_access' but one option is configured as 'bad_scope'")
 }
 
-func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadGrantTypes(t *testing.T) {
+func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadLifespansOrPKCE(t *testing.T) {
 	validator := schema.NewStructValidator()
 	config := &schema.IdentityProvidersConfiguration{
 		OIDC: &schema.OpenIDConnectConfiguration{
@@ -375,10 +1026,20 @@ func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadGrantTypes(t *testing.T)
 			IssuerPrivateKey: MustParseRSAPrivateKey(testKey1),
 			Clients: []schema.OpenIDConnectClientConfiguration{
 				{
-					ID:         "good_id",
-					Secret:     MustDecodeSecret("$plaintext$good_secret"),
-					Policy:     "two_factor",
-					GrantTypes: []string{"bad_grant_type"},
+					ID:          "good_id",
+					Secret:      MustDecodeSecret("$plaintext$good_secret"),
+					Policy:      "two_factor",
+					EnforcePKCE: "sometimes",
+					Lifespans: schema.OpenIDConnectClientLifespans{
+						OpenIDConnectLifespans: schema.OpenIDConnectLifespans{
+							AccessToken: -time.Minute,
+						},
+						Grants: schema.OpenIDConnectClientGrantLifespans{
+							ClientCredentials: schema.OpenIDConnectLifespans{
+								IDToken: -time.Second,
+							},
+						},
+					},
 					RedirectURIs: []string{
 						"https://google.com/callback",
 					},
@@ -389,8 +1050,10 @@ func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadGrantTypes(t *testing.T)
 
 	ValidateIdentityProviders(config, validator)
 
-	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: client 'good_id': option 'grant_types' must only have the values 'implicit', 'refresh_token', 'authorization_code', 'password', 'client_credentials' but one option is configured as 'bad_grant_type'")
+	require.Len(t, validator.Errors(), 3)
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: client 'good_id': option 'enforce_pkce' must be 'never', 'public_clients_only' or 'always', but it is configured as 'sometimes'")
+	assert.EqualError(t, validator.Errors()[1], "identity_providers: oidc: client 'good_id': lifespans: option 'access_token' must be 0 or more but it's configured as '-1m0s'")
+	assert.EqualError(t, validator.Errors()[2], "identity_providers: oidc: client 'good_id': lifespans: grants: client_credentials: option 'id_token' must be 0 or more but it's configured as '-1s'")
 }
 
 func TestShouldNotErrorOnCertificateValid(t *testing.T) {
@@ -497,7 +1160,7 @@ func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadResponseModes(t *testing
 	ValidateIdentityProviders(config, validator)
 
 	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: client 'good_id': option 'response_modes' must only have the values 'form_post', 'query', 'fragment' but one option is configured as 'bad_responsemode'")
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: client 'good_id': option 'response_modes' must only have the values 'form_post', 'query', 'fragment', 'jwt', 'form_post.jwt', 'query.jwt', 'fragment.jwt' but one option is configured as 'bad_responsemode'")
 }
 
 func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadUserinfoAlg(t *testing.T) {
@@ -526,6 +1189,472 @@ func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadUserinfoAlg(t *testing.T
 	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: client 'good_id': option 'userinfo_signing_algorithm' must be one of 'none, RS256' but it is configured as 'rs256'")
 }
 
+func TestValidateOIDCIssuerPrivateKeys(t *testing.T) {
+	keyRSA := MustParseRSAPrivateKey(testKey1)
+	keyRSASmall := MustParseRSAPrivateKey(testKey3)
+
+	keyECDSA, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keyECDSAP224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	require.NoError(t, err)
+
+	_, keyEd25519, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	kidRSA, err := oidc.NewJWKKeyID(keyRSA)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		legacy   *rsa.PrivateKey
+		have     []schema.JWK
+		client   schema.OpenIDConnectClientConfiguration
+		expected func(t *testing.T, config *schema.OpenIDConnectConfiguration)
+		errs     []string
+	}{
+		{
+			name: "ShouldSetDefaults",
+			have: []schema.JWK{
+				{Key: keyRSA},
+				{Key: keyECDSA},
+				{KeyID: "ed", Key: keyEd25519},
+			},
+			client: schema.OpenIDConnectClientConfiguration{IDTokenSignedResponseAlg: "EdDSA", UserinfoSigningAlgorithm: "ES256"},
+			expected: func(t *testing.T, config *schema.OpenIDConnectConfiguration) {
+				assert.Equal(t, kidRSA, config.IssuerPrivateKeys[0].KeyID)
+				assert.Equal(t, oidc.SigningAlgorithmRSAWithSHA256, config.IssuerPrivateKeys[0].Algorithm)
+				assert.Equal(t, oidc.KeyUseSignature, config.IssuerPrivateKeys[0].Use)
+				assert.Equal(t, oidc.SigningAlgorithmECDSAWithSHA256, config.IssuerPrivateKeys[1].Algorithm)
+				assert.Equal(t, "ed", config.IssuerPrivateKeys[2].KeyID)
+				assert.Equal(t, oidc.SigningAlgorithmEdDSA, config.IssuerPrivateKeys[2].Algorithm)
+			},
+		},
+		{
+			name:   "ShouldAllowLegacyKeyWithKeys",
+			legacy: keyRSA,
+			have: []schema.JWK{
+				{KeyID: "es", Key: keyECDSA},
+			},
+			client: schema.OpenIDConnectClientConfiguration{IDTokenSignedResponseAlg: "ES256"},
+		},
+		{
+			name: "ShouldRaiseErrorOnDuplicateKeyID",
+			have: []schema.JWK{
+				{KeyID: "abc", Key: keyRSA},
+				{KeyID: "abc", Key: keyECDSA},
+			},
+			errs: []string{
+				"identity_providers: oidc: issuer_private_keys: key #2: option 'key_id' must be unique but 'abc' is used by another key",
+			},
+		},
+		{
+			name:   "ShouldRaiseErrorOnDuplicateLegacyKeyID",
+			legacy: keyRSA,
+			have: []schema.JWK{
+				{Key: keyRSA},
+			},
+			errs: []string{
+				fmt.Sprintf("identity_providers: oidc: issuer_private_keys: key #1: option 'key_id' must be unique but '%s' is used by another key", kidRSA),
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnNoRS256",
+			have: []schema.JWK{
+				{Key: keyRSA, Algorithm: "RS384"},
+				{Key: keyEd25519},
+			},
+			errs: []string{
+				"identity_providers: oidc: option 'issuer_private_keys' must have at least one key with the algorithm 'RS256' but only has keys with the algorithms 'RS384', 'EdDSA'",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnBadOptions",
+			have: []schema.JWK{
+				{Key: keyRSA},
+				{KeyID: "a b", Use: "enc", Key: keyECDSA, Algorithm: "ES384"},
+				{Key: keyEd25519, Algorithm: "HS256"},
+				{Key: keyRSASmall},
+				{Key: keyECDSAP224},
+				{},
+			},
+			errs: []string{
+				"identity_providers: oidc: issuer_private_keys: key #2: option 'key_id' must only contain alphanumeric characters, hyphens, and underscores, and must be no more than 100 characters but it's configured as 'a b'",
+				"identity_providers: oidc: issuer_private_keys: key #2: option 'use' must be one of 'sig' but it's configured as 'enc'",
+				"identity_providers: oidc: issuer_private_keys: key #2: option 'algorithm' with value 'ES384' can't be used with the configured key which is a *ecdsa.PrivateKey",
+				"identity_providers: oidc: issuer_private_keys: key #3: option 'algorithm' must be one of 'RS256', 'RS384', 'RS512', 'ES256', 'ES384', 'ES512', 'EdDSA' but it's configured as 'HS256'",
+				"identity_providers: oidc: issuer_private_keys: key #4: option 'key' must be an RSA private key with 2048 bits or more but it only has 1024 bits",
+				"identity_providers: oidc: issuer_private_keys: key #5: option 'key' must be a RSA private key, ECDSA private key with the P-256, P-384, or P-521 curve, or an Ed25519 private key but it's a *ecdsa.PrivateKey",
+				"identity_providers: oidc: issuer_private_keys: key #6: option 'key' is required",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnClientAlgorithmWithoutKey",
+			have: []schema.JWK{
+				{Key: keyRSA},
+			},
+			client: schema.OpenIDConnectClientConfiguration{IDTokenSignedResponseAlg: "ES256", AccessTokenSignedResponseAlg: "ES384", UserinfoSigningAlgorithm: "EdDSA", AuthorizationSignedResponseAlg: "ES512"},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'id_token_signed_response_alg' must be one of 'RS256' but it is configured as 'ES256'",
+				"identity_providers: oidc: client 'good_id': option 'access_token_signed_response_alg' must be one of 'none, RS256' but it is configured as 'ES384'",
+				"identity_providers: oidc: client 'good_id': option 'userinfo_signing_algorithm' must be one of 'none, RS256' but it is configured as 'EdDSA'",
+				"identity_providers: oidc: client 'good_id': option 'authorization_signed_response_alg' must be one of 'RS256' but it is configured as 'ES512'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := tc.client
+
+			client.ID = "good_id"
+			client.Secret = MustDecodeSecret("$plaintext$good_secret")
+			client.RedirectURIs = []string{"https://google.com/callback"}
+
+			validator := schema.NewStructValidator()
+			config := &schema.IdentityProvidersConfiguration{
+				OIDC: &schema.OpenIDConnectConfiguration{
+					HMACSecret:        "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+					IssuerPrivateKey:  tc.legacy,
+					IssuerPrivateKeys: tc.have,
+					Clients:           []schema.OpenIDConnectClientConfiguration{client},
+				},
+			}
+
+			ValidateIdentityProviders(config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+			require.Len(t, validator.Errors(), len(tc.errs))
+
+			for i, err := range tc.errs {
+				assert.EqualError(t, validator.Errors()[i], err)
+			}
+
+			if tc.expected != nil {
+				tc.expected(t, config.OIDC)
+			}
+		})
+	}
+}
+
+func TestValidateOIDCKeyRotation(t *testing.T) {
+	_, keyEd25519, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		have     schema.OpenIDConnectKeyRotationConfiguration
+		keys     []schema.JWK
+		client   schema.OpenIDConnectClientConfiguration
+		expected schema.OpenIDConnectKeyRotationConfiguration
+		errs     []string
+	}{
+		{
+			name:     "ShouldSetDefaults",
+			have:     schema.OpenIDConnectKeyRotationConfiguration{Enable: true},
+			expected: schema.OpenIDConnectKeyRotationConfiguration{Enable: true, Algorithm: "RS256", KeySize: 2048, Interval: time.Hour * 24 * 90, PrePublish: time.Hour * 24 * 7},
+		},
+		{
+			name:     "ShouldAllowAlgorithmWithRS256Key",
+			have:     schema.OpenIDConnectKeyRotationConfiguration{Enable: true, Algorithm: "ES256", Interval: time.Hour * 24, PrePublish: time.Hour},
+			keys:     []schema.JWK{{Key: MustParseRSAPrivateKey(testKey1)}, {Key: keyEd25519}},
+			client:   schema.OpenIDConnectClientConfiguration{IDTokenSignedResponseAlg: "ES256"},
+			expected: schema.OpenIDConnectKeyRotationConfiguration{Enable: true, Algorithm: "ES256", KeySize: 2048, Interval: time.Hour * 24, PrePublish: time.Hour},
+		},
+		{
+			name: "ShouldRaiseErrorOnNoRS256",
+			have: schema.OpenIDConnectKeyRotationConfiguration{Enable: true, Algorithm: "EdDSA"},
+			errs: []string{
+				"identity_providers: oidc: option 'issuer_private_keys' must have at least one key with the algorithm 'RS256' but only has keys with the algorithms 'EdDSA'",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnBadOptions",
+			have: schema.OpenIDConnectKeyRotationConfiguration{Enable: true, Algorithm: "HS256", KeySize: 1024, Interval: time.Hour, PrePublish: time.Hour * 2},
+			keys: []schema.JWK{{Key: MustParseRSAPrivateKey(testKey1)}},
+			errs: []string{
+				"identity_providers: oidc: key_rotation: option 'algorithm' must be one of 'RS256', 'RS384', 'RS512', 'ES256', 'ES384', 'ES512', 'EdDSA' but it's configured as 'HS256'",
+				"identity_providers: oidc: key_rotation: option 'pre_publish' must be less than the option 'interval' but it's configured as '2h0m0s' and the 'interval' is configured as '1h0m0s'",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnSmallRSAKeySize",
+			have: schema.OpenIDConnectKeyRotationConfiguration{Enable: true, Algorithm: "RS512", KeySize: 1024},
+			keys: []schema.JWK{{Key: MustParseRSAPrivateKey(testKey1)}},
+			errs: []string{
+				"identity_providers: oidc: key_rotation: option 'key_size' must be 2048 or more but it's configured as 1024",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := tc.client
+
+			client.ID = "good_id"
+			client.Secret = MustDecodeSecret("$plaintext$good_secret")
+			client.RedirectURIs = []string{"https://google.com/callback"}
+
+			validator := schema.NewStructValidator()
+			config := &schema.IdentityProvidersConfiguration{
+				OIDC: &schema.OpenIDConnectConfiguration{
+					HMACSecret:        "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+					IssuerPrivateKeys: tc.keys,
+					KeyRotation:       tc.have,
+					Clients:           []schema.OpenIDConnectClientConfiguration{client},
+				},
+			}
+
+			ValidateIdentityProviders(config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+			require.Len(t, validator.Errors(), len(tc.errs))
+
+			for i, err := range tc.errs {
+				assert.EqualError(t, validator.Errors()[i], err)
+			}
+
+			if len(tc.errs) == 0 {
+				assert.Equal(t, tc.expected, config.OIDC.KeyRotation)
+			}
+		})
+	}
+}
+
+func TestValidateOIDCClientTokenEndpointAuth(t *testing.T) {
+	keyECDSA, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		have     schema.OpenIDConnectClientConfiguration
+		expected string
+		errs     []string
+	}{
+		{
+			name:     "ShouldAllowDefault",
+			have:     schema.OpenIDConnectClientConfiguration{},
+			expected: "",
+		},
+		{
+			name:     "ShouldSetDefaultSigningAlgClientSecretJWT",
+			have:     schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "client_secret_jwt"},
+			expected: "HS256",
+		},
+		{
+			name:     "ShouldSetDefaultSigningAlgPrivateKeyJWT",
+			have:     schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "private_key_jwt", JSONWebKeysURI: "https://app.example.com/jwks.json"},
+			expected: "RS256",
+		},
+		{
+			name: "ShouldAllowPrivateKeyJWTWithJWKS",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "private_key_jwt", TokenEndpointAuthSigningAlg: "ES256", JSONWebKeys: []schema.OpenIDConnectClientJWK{
+				{KeyID: "abc", Algorithm: "ES256", Key: &keyECDSA.PublicKey},
+			}},
+			expected: "ES256",
+		},
+		{
+			name:     "ShouldAllowPrivateKeyJWTWithFileJWKSURI",
+			have:     schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "private_key_jwt", JSONWebKeysURI: "file:///config/jwks.json"},
+			expected: "RS256",
+		},
+		{
+			name: "ShouldRaiseErrorOnInvalidMethod",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "client_secret_digest"},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'token_endpoint_auth_method' must be one of 'client_secret_basic', 'client_secret_post', 'client_secret_jwt', 'private_key_jwt', 'tls_client_auth', 'self_signed_tls_client_auth', 'none' when configured as the confidential client type but it's configured as 'client_secret_digest'",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnConfidentialNone",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "none"},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'token_endpoint_auth_method' must be one of 'client_secret_basic', 'client_secret_post', 'client_secret_jwt', 'private_key_jwt', 'tls_client_auth', 'self_signed_tls_client_auth' when configured as the confidential client type but it's configured as 'none'",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnBadSigningAlg",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "client_secret_jwt", TokenEndpointAuthSigningAlg: "RS256"},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'token_endpoint_auth_signing_alg' must be one of 'HS256', 'HS384', 'HS512' when option 'token_endpoint_auth_method' is configured as 'client_secret_jwt' but it's configured as 'RS256'",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnClientSecretJWTWithHashedSecret",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "client_secret_jwt", Secret: MustDecodeSecret("$pbkdf2-sha512$310000$c8p78n7pUMln0jzvd4aK4Q$JNRBzwAo0ek5qKn50cFzzvE9RXV88h1wJn5KGiHrD0YKtZaR/nCb2CJPOsKaPK0hjf.9yHxzQGZziziccp6Yng")},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'secret' must be a plaintext secret when option 'token_endpoint_auth_method' is configured as 'client_secret_jwt'",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnPrivateKeyJWTWithoutKeys",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "private_key_jwt"},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'jwks' or 'jwks_uri' is required when option 'token_endpoint_auth_method' is configured as 'private_key_jwt'",
+			},
+		},
+		{
+			name: "ShouldAllowTLSClientAuthWithSubjectDN",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "tls_client_auth", TLSClientAuthSubjectDN: "CN=app.example.com,O=Example"},
+		},
+		{
+			name: "ShouldAllowSelfSignedTLSClientAuthWithJWKSURI",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "self_signed_tls_client_auth", JSONWebKeysURI: "https://app.example.com/jwks.json"},
+		},
+		{
+			name: "ShouldRaiseErrorOnTLSClientAuthWithoutSubject",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "tls_client_auth"},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'tls_client_auth_subject_dn', 'tls_client_auth_san_dns', 'tls_client_auth_san_uri', 'tls_client_auth_san_ip', or 'tls_client_auth_san_email' must be configured exactly once when option 'token_endpoint_auth_method' is configured as 'tls_client_auth' but 0 are configured",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnTLSClientAuthWithMultipleSubjectsAndBadIP",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "tls_client_auth", TLSClientAuthSANDNS: "app.example.com", TLSClientAuthSANIP: "abc"},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'tls_client_auth_subject_dn', 'tls_client_auth_san_dns', 'tls_client_auth_san_uri', 'tls_client_auth_san_ip', or 'tls_client_auth_san_email' must be configured exactly once when option 'token_endpoint_auth_method' is configured as 'tls_client_auth' but 2 are configured",
+				"identity_providers: oidc: client 'good_id': option 'tls_client_auth_san_ip' must be an IP address but it's configured as 'abc'",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnSelfSignedTLSClientAuthWithoutKeys",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "self_signed_tls_client_auth"},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'jwks' or 'jwks_uri' is required when option 'token_endpoint_auth_method' is configured as 'self_signed_tls_client_auth'",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnPrivateKeyJWTWithBothKeys",
+			have: schema.OpenIDConnectClientConfiguration{TokenEndpointAuthMethod: "private_key_jwt", JSONWebKeysURI: "http://app.example.com/jwks.json", JSONWebKeys: []schema.OpenIDConnectClientJWK{
+				{KeyID: "abc!", Use: "enc", Algorithm: "RS256", Key: &keyECDSA.PublicKey},
+				{},
+			}},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': options 'jwks' and 'jwks_uri' must not both be configured",
+				"identity_providers: oidc: client 'good_id': option 'jwks_uri' must be an absolute URL with the 'https' or 'file' scheme but it's configured as 'http://app.example.com/jwks.json'",
+				"identity_providers: oidc: client 'good_id': jwks: key #1: option 'key_id' must only contain alphanumeric characters, hyphens, and underscores, and must be no more than 100 characters but it's configured as 'abc!'",
+				"identity_providers: oidc: client 'good_id': jwks: key #1: option 'use' must be one of 'sig' but it's configured as 'enc'",
+				"identity_providers: oidc: client 'good_id': jwks: key #1: option 'algorithm' must be compatible with the key but it's configured as 'RS256' and the key is a *ecdsa.PublicKey",
+				"identity_providers: oidc: client 'good_id': jwks: key #2: option 'key' is required",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := tc.have
+
+			client.ID = "good_id"
+			client.RedirectURIs = []string{"https://google.com/callback"}
+
+			if client.Secret == nil {
+				client.Secret = MustDecodeSecret("$plaintext$good_secret")
+			}
+
+			validator := schema.NewStructValidator()
+			config := &schema.IdentityProvidersConfiguration{
+				OIDC: &schema.OpenIDConnectConfiguration{
+					HMACSecret:        "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+					IssuerPrivateKeys: []schema.JWK{{Key: MustParseRSAPrivateKey(testKey1)}},
+					Clients:           []schema.OpenIDConnectClientConfiguration{client},
+				},
+			}
+
+			ValidateIdentityProviders(config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+			require.Len(t, validator.Errors(), len(tc.errs))
+
+			for i, err := range tc.errs {
+				assert.EqualError(t, validator.Errors()[i], err)
+			}
+
+			if len(tc.errs) == 0 {
+				assert.Equal(t, tc.expected, config.OIDC.Clients[0].TokenEndpointAuthSigningAlg)
+			}
+		})
+	}
+}
+
+func TestValidateOIDCClientRequestObject(t *testing.T) {
+	testCases := []struct {
+		name string
+		have schema.OpenIDConnectClientConfiguration
+		errs []string
+	}{
+		{
+			name: "ShouldAllowDefault",
+			have: schema.OpenIDConnectClientConfiguration{},
+		},
+		{
+			name: "ShouldAllowUnsignedWithoutKeys",
+			have: schema.OpenIDConnectClientConfiguration{RequestObjectSigningAlg: "none", RequestURIs: []string{"https://app.example.com/request.jwt"}},
+		},
+		{
+			name: "ShouldAllowSignedWithKeys",
+			have: schema.OpenIDConnectClientConfiguration{RequestObjectSigningAlg: "ES256", RequireSignedRequestObject: true, JSONWebKeysURI: "https://app.example.com/jwks.json"},
+		},
+		{
+			name: "ShouldRaiseErrorOnInvalidAlg",
+			have: schema.OpenIDConnectClientConfiguration{RequestObjectSigningAlg: "HS256"},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'request_object_signing_alg' must be one of 'none', 'RS256', 'RS384', 'RS512', 'PS256', 'PS384', 'PS512', 'ES256', 'ES384', 'ES512', 'EdDSA' but it's configured as 'HS256'",
+				"identity_providers: oidc: client 'good_id': option 'jwks' or 'jwks_uri' is required when option 'request_object_signing_alg' is configured or option 'require_signed_request_object' is enabled",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnRequireSignedWithNone",
+			have: schema.OpenIDConnectClientConfiguration{RequestObjectSigningAlg: "none", RequireSignedRequestObject: true, JSONWebKeysURI: "https://app.example.com/jwks.json"},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'request_object_signing_alg' must not be 'none' when option 'require_signed_request_object' is enabled",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnSignedWithoutKeys",
+			have: schema.OpenIDConnectClientConfiguration{RequireSignedRequestObject: true},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'jwks' or 'jwks_uri' is required when option 'request_object_signing_alg' is configured or option 'require_signed_request_object' is enabled",
+			},
+		},
+		{
+			name: "ShouldRaiseErrorOnInvalidRequestURIs",
+			have: schema.OpenIDConnectClientConfiguration{RequestURIs: []string{"http://app.example.com/request.jwt", "/request.jwt"}},
+			errs: []string{
+				"identity_providers: oidc: client 'good_id': option 'request_uris' must only contain absolute URLs with the 'https' scheme but it contains 'http://app.example.com/request.jwt'",
+				"identity_providers: oidc: client 'good_id': option 'request_uris' must only contain absolute URLs with the 'https' scheme but it contains '/request.jwt'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := tc.have
+
+			client.ID = "good_id"
+			client.Secret = MustDecodeSecret("$plaintext$good_secret")
+			client.RedirectURIs = []string{"https://google.com/callback"}
+
+			validator := schema.NewStructValidator()
+			config := &schema.IdentityProvidersConfiguration{
+				OIDC: &schema.OpenIDConnectConfiguration{
+					HMACSecret:        "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+					IssuerPrivateKeys: []schema.JWK{{Key: MustParseRSAPrivateKey(testKey1)}},
+					Clients:           []schema.OpenIDConnectClientConfiguration{client},
+				},
+			}
+
+			ValidateIdentityProviders(config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+			require.Len(t, validator.Errors(), len(tc.errs))
+
+			for i, err := range tc.errs {
+				assert.EqualError(t, validator.Errors()[i], err)
+			}
+		})
+	}
+}
+
 func TestValidateIdentityProvidersShouldRaiseWarningOnSecurityIssue(t *testing.T) {
 	validator := schema.NewStructValidator()
 	config := &schema.IdentityProvidersConfiguration{
@@ -686,6 +1815,12 @@ func TestValidateIdentityProvidersShouldSetDefaultValues(t *testing.T) {
 
 	assert.Equal(t, "none", config.OIDC.Clients[0].UserinfoSigningAlgorithm)
 	assert.Equal(t, "RS256", config.OIDC.Clients[1].UserinfoSigningAlgorithm)
+	assert.Equal(t, "RS256", config.OIDC.Clients[0].IDTokenSignedResponseAlg)
+	assert.Equal(t, "RS256", config.OIDC.Clients[1].IDTokenSignedResponseAlg)
+	assert.Equal(t, "RS256", config.OIDC.Clients[0].AuthorizationSignedResponseAlg)
+	assert.Equal(t, "RS256", config.OIDC.Clients[1].AuthorizationSignedResponseAlg)
+	assert.Equal(t, "none", config.OIDC.Clients[0].AccessTokenSignedResponseAlg)
+	assert.Equal(t, "none", config.OIDC.Clients[1].AccessTokenSignedResponseAlg)
 
 	// Assert Clients[0] Description is set to the Clients[0] ID, and Clients[1]'s Description is not overridden.
 	assert.Equal(t, config.OIDC.Clients[0].ID, config.OIDC.Clients[0].Description)
@@ -4,13 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // ValidateStorage validates storage configuration.
-func ValidateStorage(config schema.StorageConfiguration, validator *schema.StructValidator) {
+func ValidateStorage(config *schema.StorageConfiguration, validator *schema.StructValidator) {
 	if config.Local == nil && config.MySQL == nil && config.PostgreSQL == nil {
 		validator.Push(errors.New(errStrStorage))
 	}
@@ -29,6 +30,83 @@ func ValidateStorage(config schema.StorageConfiguration, validator *schema.Struc
 	} else if len(config.EncryptionKey) < 20 {
 		validator.Push(errors.New(errStrStorageEncryptionKeyTooShort))
 	}
+
+	validateStorageCleanup(&config.Cleanup, validator)
+}
+
+func validateStorageCleanup(config *schema.StorageCleanupConfiguration, validator *schema.StructValidator) {
+	defaults := schema.DefaultStorageConfiguration.Cleanup
+
+	validateStorageCleanupDuration("", "interval", &config.Interval, defaults.Interval, validator)
+	validateStorageCleanupDuration("retention: ", "authentication_logs", &config.Retention.AuthenticationLogs, defaults.Retention.AuthenticationLogs, validator)
+	validateStorageCleanupDuration("retention: ", "identity_verifications", &config.Retention.IdentityVerifications, defaults.Retention.IdentityVerifications, validator)
+	validateStorageCleanupDuration("retention: ", "oauth2_sessions", &config.Retention.OAuth2Sessions, defaults.Retention.OAuth2Sessions, validator)
+	validateStorageCleanupDuration("retention: ", "oauth2_consent_sessions", &config.Retention.OAuth2ConsentSessions, defaults.Retention.OAuth2ConsentSessions, validator)
+	validateStorageCleanupDuration("retention: ", "oauth2_backchannel_logouts", &config.Retention.OAuth2BackChannelLogouts, defaults.Retention.OAuth2BackChannelLogouts, validator)
+}
+
+func validateStorageCleanupDuration(prefix, name string, value *time.Duration, fallback time.Duration, validator *schema.StructValidator) {
+	switch {
+	case *value == 0:
+		*value = fallback
+	case *value < 0:
+		validator.Push(fmt.Errorf(errFmtStorageCleanupInvalidDuration, prefix, name, *value))
+	}
+}
+
+// validateStorageCleanupRetention ensures the retention of the storage cleanup doesn't purge data which is still in use
+// by the regulator or the OpenID Connect provider. It must be called after the regulation and identity providers are
+// validated so their defaults are applied.
+func validateStorageCleanupRetention(config *schema.Configuration, validator *schema.StructValidator) {
+	retention := config.Storage.Cleanup.Retention
+
+	if config.Storage.Cleanup.Disable {
+		return
+	}
+
+	if retention.AuthenticationLogs > 0 && retention.AuthenticationLogs < config.Regulation.BanTime {
+		validator.Push(fmt.Errorf(errFmtStorageCleanupRetentionTooShort, "authentication_logs", "option 'regulation: ban_time'", retention.AuthenticationLogs, "'ban_time'", config.Regulation.BanTime))
+	}
+
+	if config.IdentityProviders.OIDC == nil || retention.OAuth2Sessions <= 0 {
+		return
+	}
+
+	oidc := config.IdentityProviders.OIDC
+
+	if oidc.RefreshTokenLifespan < 0 {
+		validator.PushWarning(fmt.Errorf(errFmtStorageCleanupRetentionNoExpiry, retention.OAuth2Sessions, oidc.RefreshTokenLifespan))
+	}
+
+	if lifespan := getOIDCLongestLifespan(oidc); retention.OAuth2Sessions < lifespan {
+		validator.Push(fmt.Errorf(errFmtStorageCleanupRetentionTooShort, "oauth2_sessions", "longest OpenID Connect token lifespan", retention.OAuth2Sessions, "longest lifespan", lifespan))
+	}
+}
+
+func getOIDCLongestLifespan(config *schema.OpenIDConnectConfiguration) (lifespan time.Duration) {
+	durations := []time.Duration{config.AccessTokenLifespan, config.AuthorizeCodeLifespan, config.IDTokenLifespan, config.RefreshTokenLifespan}
+
+	for _, client := range config.Clients {
+		for _, lifespans := range []schema.OpenIDConnectLifespans{
+			client.Lifespans.OpenIDConnectLifespans,
+			client.Lifespans.Grants.AuthorizationCode,
+			client.Lifespans.Grants.Implicit,
+			client.Lifespans.Grants.ClientCredentials,
+			client.Lifespans.Grants.RefreshToken,
+			client.Lifespans.Grants.DeviceCode,
+			client.Lifespans.Grants.TokenExchange,
+		} {
+			durations = append(durations, lifespans.AccessToken, lifespans.RefreshToken, lifespans.IDToken)
+		}
+	}
+
+	for _, duration := range durations {
+		if duration > lifespan {
+			lifespan = duration
+		}
+	}
+
+	return lifespan
 }
 
 func validateSQLConfiguration(config *schema.SQLStorageConfiguration, validator *schema.StructValidator, provider string) {
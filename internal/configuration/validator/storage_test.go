@@ -2,7 +2,10 @@ package validator
 
 import (
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -20,6 +23,7 @@ func (suite *StorageSuite) SetupTest() {
 	suite.config.Local = nil
 	suite.config.PostgreSQL = nil
 	suite.config.MySQL = nil
+	suite.config.Cleanup = schema.StorageCleanupConfiguration{}
 }
 
 func (suite *StorageSuite) TestShouldValidateOneStorageIsConfigured() {
@@ -27,7 +31,7 @@ func (suite *StorageSuite) TestShouldValidateOneStorageIsConfigured() {
 	suite.config.PostgreSQL = nil
 	suite.config.MySQL = nil
 
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Require().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 1)
@@ -39,7 +43,7 @@ func (suite *StorageSuite) TestShouldValidateLocalPathIsProvided() {
 		Path: "",
 	}
 
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Require().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 1)
@@ -49,7 +53,7 @@ func (suite *StorageSuite) TestShouldValidateLocalPathIsProvided() {
 	suite.validator.Clear()
 	suite.config.Local.Path = "/myapth"
 
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Require().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 0)
@@ -57,7 +61,7 @@ func (suite *StorageSuite) TestShouldValidateLocalPathIsProvided() {
 
 func (suite *StorageSuite) TestShouldValidateMySQLHostUsernamePasswordAndDatabaseAreProvided() {
 	suite.config.MySQL = &schema.MySQLStorageConfiguration{}
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Require().Len(suite.validator.Errors(), 3)
 	suite.Assert().EqualError(suite.validator.Errors()[0], "storage: mysql: option 'host' is required")
@@ -73,7 +77,7 @@ func (suite *StorageSuite) TestShouldValidateMySQLHostUsernamePasswordAndDatabas
 			Database: "database",
 		},
 	}
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Require().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 0)
@@ -82,7 +86,7 @@ func (suite *StorageSuite) TestShouldValidateMySQLHostUsernamePasswordAndDatabas
 func (suite *StorageSuite) TestShouldValidatePostgreSQLHostUsernamePasswordAndDatabaseAreProvided() {
 	suite.config.PostgreSQL = &schema.PostgreSQLStorageConfiguration{}
 	suite.config.MySQL = nil
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Require().Len(suite.validator.Errors(), 3)
 	suite.Assert().EqualError(suite.validator.Errors()[0], "storage: postgres: option 'host' is required")
@@ -98,7 +102,7 @@ func (suite *StorageSuite) TestShouldValidatePostgreSQLHostUsernamePasswordAndDa
 			Database: "database",
 		},
 	}
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Assert().Len(suite.validator.Warnings(), 0)
 	suite.Assert().Len(suite.validator.Errors(), 0)
@@ -114,7 +118,7 @@ func (suite *StorageSuite) TestShouldValidatePostgresSSLModeAndSchemaDefaults()
 		},
 	}
 
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Assert().Len(suite.validator.Warnings(), 0)
 	suite.Assert().Len(suite.validator.Errors(), 0)
@@ -137,7 +141,7 @@ func (suite *StorageSuite) TestShouldValidatePostgresDefaultsDontOverrideConfigu
 		},
 	}
 
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Assert().Len(suite.validator.Warnings(), 0)
 	suite.Assert().Len(suite.validator.Errors(), 0)
@@ -159,7 +163,7 @@ func (suite *StorageSuite) TestShouldValidatePostgresSSLModeMustBeValid() {
 		},
 	}
 
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Assert().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 1)
@@ -172,7 +176,7 @@ func (suite *StorageSuite) TestShouldRaiseErrorOnNoEncryptionKey() {
 		Path: "/this/is/a/path",
 	}
 
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Require().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 1)
@@ -185,13 +189,152 @@ func (suite *StorageSuite) TestShouldRaiseErrorOnShortEncryptionKey() {
 		Path: "/this/is/a/path",
 	}
 
-	ValidateStorage(suite.config, suite.validator)
+	ValidateStorage(&suite.config, suite.validator)
 
 	suite.Require().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 1)
 	suite.Assert().EqualError(suite.validator.Errors()[0], "storage: option 'encryption_key' must be 20 characters or longer")
 }
 
+func (suite *StorageSuite) TestShouldSetDefaultCleanupValues() {
+	suite.config.Local = &schema.LocalStorageConfiguration{
+		Path: "/this/is/a/path",
+	}
+
+	ValidateStorage(&suite.config, suite.validator)
+
+	suite.Require().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 0)
+
+	suite.Assert().Equal(schema.DefaultStorageConfiguration.Cleanup, suite.config.Cleanup)
+}
+
+func (suite *StorageSuite) TestShouldRaiseErrorOnNegativeCleanupValues() {
+	suite.config.Local = &schema.LocalStorageConfiguration{
+		Path: "/this/is/a/path",
+	}
+
+	suite.config.Cleanup = schema.StorageCleanupConfiguration{
+		Interval: -time.Hour,
+		Retention: schema.StorageCleanupRetentionConfiguration{
+			AuthenticationLogs:       -time.Minute,
+			OAuth2Sessions:           time.Hour,
+			OAuth2BackChannelLogouts: -time.Second,
+		},
+	}
+
+	ValidateStorage(&suite.config, suite.validator)
+
+	suite.Require().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "storage: cleanup: option 'interval' must be 0 or more but it's configured as '-1h0m0s'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "storage: cleanup: retention: option 'authentication_logs' must be 0 or more but it's configured as '-1m0s'")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "storage: cleanup: retention: option 'oauth2_backchannel_logouts' must be 0 or more but it's configured as '-1s'")
+
+	suite.Assert().Equal(time.Hour, suite.config.Cleanup.Retention.OAuth2Sessions)
+	suite.Assert().Equal(schema.DefaultStorageConfiguration.Cleanup.Retention.IdentityVerifications, suite.config.Cleanup.Retention.IdentityVerifications)
+}
+
 func TestShouldRunStorageSuite(t *testing.T) {
 	suite.Run(t, new(StorageSuite))
 }
+
+func TestShouldValidateStorageCleanupRetention(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     func(config *schema.Configuration)
+		warnings []string
+		errors   []string
+	}{
+		{
+			"ShouldAllowDefaults",
+			func(config *schema.Configuration) {},
+			nil,
+			nil,
+		},
+		{
+			"ShouldRaiseErrorWhenAuthenticationLogsRetentionShorterThanBanTime",
+			func(config *schema.Configuration) {
+				config.Storage.Cleanup.Retention.AuthenticationLogs = time.Minute
+			},
+			nil,
+			[]string{
+				"storage: cleanup: retention: option 'authentication_logs' must be greater than or equal to the option 'regulation: ban_time' but it's configured as '1m0s' and the 'ban_time' is configured as '5m0s'",
+			},
+		},
+		{
+			"ShouldRaiseErrorWhenOAuth2SessionsRetentionShorterThanClientLifespan",
+			func(config *schema.Configuration) {
+				config.IdentityProviders.OIDC.Clients = []schema.OpenIDConnectClientConfiguration{
+					{
+						ID: "abc",
+						Lifespans: schema.OpenIDConnectClientLifespans{
+							Grants: schema.OpenIDConnectClientGrantLifespans{
+								RefreshToken: schema.OpenIDConnectLifespans{RefreshToken: time.Hour * 24 * 60},
+							},
+						},
+					},
+				}
+			},
+			nil,
+			[]string{
+				"storage: cleanup: retention: option 'oauth2_sessions' must be greater than or equal to the longest OpenID Connect token lifespan but it's configured as '720h0m0s' and the longest lifespan is configured as '1440h0m0s'",
+			},
+		},
+		{
+			"ShouldRaiseWarningWhenRefreshTokensNeverExpire",
+			func(config *schema.Configuration) {
+				config.IdentityProviders.OIDC.RefreshTokenLifespan = -1
+			},
+			[]string{
+				"storage: cleanup: retention: option 'oauth2_sessions' is configured as '720h0m0s' but the option 'identity_providers: oidc: refresh_token_lifespan' is configured as '-1ns' which never expires; refresh tokens older than the retention will be purged",
+			},
+			nil,
+		},
+		{
+			"ShouldNotValidateWhenDisabled",
+			func(config *schema.Configuration) {
+				config.Storage.Cleanup.Disable = true
+				config.Storage.Cleanup.Retention.AuthenticationLogs = time.Minute
+				config.IdentityProviders.OIDC.RefreshTokenLifespan = -1
+			},
+			nil,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &schema.Configuration{
+				Regulation: schema.DefaultRegulationConfiguration,
+				Storage:    schema.DefaultStorageConfiguration,
+				IdentityProviders: schema.IdentityProvidersConfiguration{
+					OIDC: &schema.OpenIDConnectConfiguration{
+						AccessTokenLifespan:   schema.DefaultOpenIDConnectConfiguration.AccessTokenLifespan,
+						AuthorizeCodeLifespan: schema.DefaultOpenIDConnectConfiguration.AuthorizeCodeLifespan,
+						IDTokenLifespan:       schema.DefaultOpenIDConnectConfiguration.IDTokenLifespan,
+						RefreshTokenLifespan:  schema.DefaultOpenIDConnectConfiguration.RefreshTokenLifespan,
+					},
+				},
+			}
+
+			tc.have(config)
+
+			validator := schema.NewStructValidator()
+
+			validateStorageCleanupRetention(config, validator)
+
+			require.Len(t, validator.Warnings(), len(tc.warnings))
+			require.Len(t, validator.Errors(), len(tc.errors))
+
+			for i, warning := range tc.warnings {
+				assert.EqualError(t, validator.Warnings()[i], warning)
+			}
+
+			for i, err := range tc.errors {
+				assert.EqualError(t, validator.Errors()[i], err)
+			}
+		})
+	}
+}
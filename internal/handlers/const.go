@@ -34,6 +34,15 @@ const (
 	queryArgConsentID  = "consent_id"
 	queryArgWorkflow   = "workflow"
 	queryArgWorkflowID = "workflow_id"
+	queryArgResult     = "result"
+	queryArgPrompt     = "prompt"
+)
+
+// Device Authorization Grant verification results displayed by the device page of the portal.
+const (
+	deviceResultApproved = "approved"
+	deviceResultDenied   = "denied"
+	deviceResultInvalid  = "invalid"
 )
 
 var (
@@ -89,9 +98,12 @@ const (
 	logFmtErrConsentCantGetSubject   = logFmtConsentPrefix + "could not be processed: error occurred retrieving subject identifier for user '%s' and sector identifier '%s': %+v"
 	logFmtErrConsentGenerateError    = logFmtConsentPrefix + "could not be processed: error occurred %s consent: %+v"
 
+	logFmtErrConsentAuthorizationPolicyDenied = logFmtConsentPrefix + "could not be processed: the authorization policy of the client denied access to user '%s'"
+
 	logFmtDbgConsentGenerate                  = logFmtConsentPrefix + "proceeding to generate a new consent session"
 	logFmtDbgConsentAuthenticationSufficiency = logFmtConsentPrefix + "authentication level '%s' is %s for client level '%s'"
 	logFmtDbgConsentRedirect                  = logFmtConsentPrefix + "is being redirected to '%s'"
+	logFmtDbgConsentReauthenticationRequired  = logFmtConsentPrefix + "requires user '%s' to authenticate again due to the prompt or max_age parameters"
 	logFmtDbgConsentPreConfSuccessfulLookup   = logFmtConsentPrefix + "successfully looked up pre-configured consent with signature of client id '%s' and subject '%s' and scopes '%s' with id '%d'"
 	logFmtDbgConsentPreConfUnsuccessfulLookup = logFmtConsentPrefix + "unsuccessfully looked up pre-configured consent with signature of client id '%s' and subject '%s' and scopes '%s'"
 	logFmtDbgConsentPreConfTryingLookup       = logFmtConsentPrefix + "attempting to discover pre-configurations with signature of client id '%s' and subject '%s' and scopes '%s'"
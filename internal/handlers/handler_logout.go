@@ -26,9 +26,13 @@ func LogoutPOST(ctx *middlewares.AutheliaCtx) {
 		ctx.Error(fmt.Errorf("unable to parse body during logout: %s", err), messageOperationFailed)
 	}
 
+	userSession := ctx.GetSession()
+
 	err = ctx.Providers.SessionProvider.DestroySession(ctx.RequestCtx)
 	if err != nil {
 		ctx.Error(fmt.Errorf("unable to destroy session during logout: %s", err), messageOperationFailed)
+	} else {
+		oidcBackChannelLogout(ctx, &userSession)
 	}
 
 	redirectionURL, err := url.ParseRequestURI(body.TargetURL)
@@ -23,31 +23,81 @@ func OpenIDConnectAuthorizationGET(ctx *middlewares.AutheliaCtx, rw http.Respons
 		client    *oidc.Client
 		authTime  time.Time
 		issuer    *url.URL
+		sid       string
+		uri       string
 		err       error
 	)
 
+	if uri, err = ctx.Providers.OpenIDConnect.ResolvePushedAuthorizeRequest(ctx, r); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("Authorization Request failed to resolve the Pushed Authorization Request with error: %s", rfc.WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, fosite.NewAuthorizeRequest(), err)
+
+		return
+	}
+
+	var object url.Values
+
+	if uri == "" {
+		if object, err = ctx.Providers.OpenIDConnect.ResolveRequestObject(ctx, r); err != nil {
+			rfc := fosite.ErrorToRFC6749Error(err)
+
+			ctx.Logger.Errorf("Authorization Request failed to resolve the Request Object with error: %s", rfc.WithExposeDebug(true).GetDescription())
+
+			ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, fosite.NewAuthorizeRequest(), err)
+
+			return
+		}
+	}
+
 	if requester, err = ctx.Providers.OpenIDConnect.NewAuthorizeRequest(ctx, r); err != nil {
 		rfc := fosite.ErrorToRFC6749Error(err)
 
 		ctx.Logger.Errorf("Authorization Request failed with error: %s", rfc.WithExposeDebug(true).GetDescription())
 
-		ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, err)
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, err)
 
 		return
 	}
 
+	if uri != "" {
+		requester.GetRequestForm().Set(oidc.FormParameterRequestURI, uri)
+	}
+
+	for key, values := range object {
+		requester.GetRequestForm()[key] = values
+	}
+
 	clientID := requester.GetClient().GetID()
 
 	ctx.Logger.Debugf("Authorization Request with id '%s' on client with id '%s' is being processed", requester.GetID(), clientID)
 
-	if client, err = ctx.Providers.OpenIDConnect.GetFullClient(clientID); err != nil {
+	if client, err = ctx.Providers.OpenIDConnect.GetFullClient(ctx, clientID); err != nil {
 		if errors.Is(err, fosite.ErrNotFound) {
 			ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: client was not found", requester.GetID(), clientID)
 		} else {
 			ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: failed to find client: %+v", requester.GetID(), clientID, err)
 		}
 
-		ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, err)
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, err)
+
+		return
+	}
+
+	if uri == "" && ctx.Providers.OpenIDConnect.IsPushedAuthorizeRequestRequired(client) {
+		ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: the client is required to use a Pushed Authorization Request", requester.GetID(), clientID)
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrPushedAuthorizeRequestRequired)
+
+		return
+	}
+
+	if _, err = oidc.NewClaimsRequests(requester.GetRequestForm()); err != nil {
+		ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: error occurred parsing the claims parameter: %+v", requester.GetID(), clientID, err)
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, err)
 
 		return
 	}
@@ -55,7 +105,7 @@ func OpenIDConnectAuthorizationGET(ctx *middlewares.AutheliaCtx, rw http.Respons
 	if issuer, err = ctx.IssuerURL(); err != nil {
 		ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: error occurred determining issuer: %+v", requester.GetID(), clientID, err)
 
-		ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, oidc.ErrIssuerCouldNotDerive)
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrIssuerCouldNotDerive)
 
 		return
 	}
@@ -73,19 +123,68 @@ func OpenIDConnectAuthorizationGET(ctx *middlewares.AutheliaCtx, rw http.Respons
 
 	extraClaims := oidcGrantRequests(requester, consent, &userSession)
 
-	if authTime, err = userSession.AuthenticatedTime(client.Policy); err != nil {
+	var userinfoClaims map[string]any
+
+	if userinfoClaims, err = oidcGrantCustomClaims(ctx, client, consent, &userSession, extraClaims); err != nil {
+		ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: error occurred retrieving the user details for the custom claims: %+v", requester.GetID(), client.GetID(), err)
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, fosite.ErrServerError.WithHint("Could not obtain the user details."))
+
+		return
+	}
+
+	var requestedUserinfoClaims map[string]any
+
+	if requestedUserinfoClaims, err = oidcGrantClaimsRequests(ctx, client, consent, &userSession, extraClaims); err != nil {
+		ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: error occurred granting the claims requested via the claims parameter: %+v", requester.GetID(), client.GetID(), err)
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, err)
+
+		return
+	}
+
+	if authTime, err = userSession.AuthenticatedTime(oidcClientRequiredLevel(ctx, client, &userSession)); err != nil {
 		ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: error occurred checking authentication time: %+v", requester.GetID(), client.GetID(), err)
 
-		ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, fosite.ErrServerError.WithHint("Could not obtain the authentication time."))
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, fosite.ErrServerError.WithHint("Could not obtain the authentication time."))
+
+		return
+	}
+
+	if sid, err = userSession.GetOpenIDConnectSessionID(); err != nil {
+		ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: error occurred generating the session id: %+v", requester.GetID(), client.GetID(), err)
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrSessionCouldNotSave)
+
+		return
+	}
+
+	userSession.AddOpenIDConnectClient(client.GetID(), consent.Subject.UUID.String())
+
+	if err = ctx.SaveSession(userSession); err != nil {
+		ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: error occurred saving the user session: %+v", requester.GetID(), client.GetID(), err)
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrSessionCouldNotSave)
 
 		return
 	}
 
 	ctx.Logger.Debugf("Authorization Request with id '%s' on client with id '%s' was successfully processed, proceeding to build Authorization Response", requester.GetID(), clientID)
 
-	oidcSession := oidc.NewSessionWithAuthorizeRequest(issuer, ctx.Providers.OpenIDConnect.KeyManager.GetActiveKeyID(),
+	alg := client.GetIDTokenSignedResponseAlg()
+
+	oidcSession := oidc.NewSessionWithAuthorizeRequest(issuer, ctx.Providers.OpenIDConnect.KeyManager.GetKeyIDFromAlg(alg), alg, sid,
 		userSession.Username, userSession.AuthenticationMethodRefs.MarshalRFC8176(), extraClaims, authTime, consent, requester)
 
+	oidcSession.Claims.AuthenticationContextClassReference = ctx.Providers.OpenIDConnect.GetAuthenticationContextClassReference(
+		userSession.AuthenticationLevel, oidcSession.Claims.AuthenticationMethodsReferences, requester.GetRequestForm())
+
+	for _, claims := range []map[string]any{userinfoClaims, requestedUserinfoClaims} {
+		for claim, value := range claims {
+			oidcSession.Extra[claim] = value
+		}
+	}
+
 	ctx.Logger.Tracef("Authorization Request with id '%s' on client with id '%s' creating session for Authorization Response for subject '%s' with username '%s' with claims: %+v",
 		requester.GetID(), oidcSession.ClientID, oidcSession.Subject, oidcSession.Username, oidcSession.Claims)
 
@@ -94,7 +193,7 @@ func OpenIDConnectAuthorizationGET(ctx *middlewares.AutheliaCtx, rw http.Respons
 
 		ctx.Logger.Errorf("Authorization Response for Request with id '%s' on client with id '%s' could not be created: %s", requester.GetID(), clientID, rfc.WithExposeDebug(true).GetDescription())
 
-		ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, err)
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, err)
 
 		return
 	}
@@ -102,10 +201,20 @@ func OpenIDConnectAuthorizationGET(ctx *middlewares.AutheliaCtx, rw http.Respons
 	if err = ctx.Providers.StorageProvider.SaveOAuth2ConsentSessionGranted(ctx, consent.ID); err != nil {
 		ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: error occurred saving consent session: %+v", requester.GetID(), client.GetID(), err)
 
-		ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, oidc.ErrConsentCouldNotSave)
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrConsentCouldNotSave)
 
 		return
 	}
 
-	ctx.Providers.OpenIDConnect.WriteAuthorizeResponse(rw, requester, responder)
+	if uri != "" {
+		if err = ctx.Providers.OpenIDConnect.RevokePushedAuthorizeRequest(ctx, uri); err != nil {
+			ctx.Logger.Errorf("Authorization Request with id '%s' on client with id '%s' could not be processed: error occurred revoking the pushed authorization request: %+v", requester.GetID(), client.GetID(), err)
+
+			ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrPushedAuthorizeRequestCouldNotRevoke)
+
+			return
+		}
+	}
+
+	ctx.Providers.OpenIDConnect.WriteAuthorizeResponse(ctx, rw, requester, responder)
 }
@@ -31,13 +31,29 @@ func handleOIDCAuthorizationConsent(ctx *middlewares.AutheliaCtx, issuer *url.UR
 	var handler handlerAuthorizationConsent
 
 	switch {
+	case oidcClientRequiredLevel(ctx, client, &userSession) == authorization.Denied:
+		ctx.Logger.Errorf(logFmtErrConsentAuthorizationPolicyDenied, requester.GetID(), client.GetID(), client.Consent, userSession.Username)
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, fosite.ErrAccessDenied.WithHint("The user is not authorized to access this client."))
+
+		return nil, true
 	case userSession.IsAnonymous():
 		handler = handleOIDCAuthorizationConsentNotAuthenticated
-	case client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel):
+	case isOIDCAuthorizationReauthenticationRequired(ctx, client, userSession, requester):
+		if oidc.IsPromptNone(requester.GetRequestForm()) {
+			ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, fosite.ErrLoginRequired)
+
+			return nil, true
+		}
+
+		ctx.Logger.Debugf(logFmtDbgConsentReauthenticationRequired, requester.GetID(), client.GetID(), client.Consent, userSession.Username)
+
+		handler = handleOIDCAuthorizationConsentReauthenticate
+	case isOIDCClientAuthenticationLevelSufficient(ctx, client, &userSession):
 		if subject, err = ctx.Providers.OpenIDConnect.GetSubject(ctx, client.GetSectorIdentifier(), userSession.Username); err != nil {
 			ctx.Logger.Errorf(logFmtErrConsentCantGetSubject, requester.GetID(), client.GetID(), client.Consent, userSession.Username, client.GetSectorIdentifier(), err)
 
-			ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, oidc.ErrSubjectCouldNotLookup)
+			ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrSubjectCouldNotLookup)
 
 			return nil, true
 		}
@@ -52,7 +68,7 @@ func handleOIDCAuthorizationConsent(ctx *middlewares.AutheliaCtx, issuer *url.UR
 		default:
 			ctx.Logger.Errorf(logFmtErrConsentCantDetermineConsentMode, requester.GetID(), client.GetID())
 
-			ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, fosite.ErrServerError.WithHint("Could not determine the client consent mode."))
+			ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, fosite.ErrServerError.WithHint("Could not determine the client consent mode."))
 
 			return nil, true
 		}
@@ -60,7 +76,7 @@ func handleOIDCAuthorizationConsent(ctx *middlewares.AutheliaCtx, issuer *url.UR
 		if subject, err = ctx.Providers.OpenIDConnect.GetSubject(ctx, client.GetSectorIdentifier(), userSession.Username); err != nil {
 			ctx.Logger.Errorf(logFmtErrConsentCantGetSubject, requester.GetID(), client.GetID(), client.Consent, userSession.Username, client.GetSectorIdentifier(), err)
 
-			ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, oidc.ErrSubjectCouldNotLookup)
+			ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrSubjectCouldNotLookup)
 
 			return nil, true
 		}
@@ -71,9 +87,51 @@ func handleOIDCAuthorizationConsent(ctx *middlewares.AutheliaCtx, issuer *url.UR
 	return handler(ctx, issuer, client, userSession, subject, rw, r, requester)
 }
 
-func handleOIDCAuthorizationConsentNotAuthenticated(_ *middlewares.AutheliaCtx, issuer *url.URL, _ *oidc.Client,
+// isOIDCAuthorizationReauthenticationRequired returns true if the prompt or max_age parameters of the authorization
+// request require a sufficiently authenticated user to authenticate again. Requests which are returning from the login
+// portal or consent page only require it if the user has not authenticated again since the consent was requested.
+func isOIDCAuthorizationReauthenticationRequired(ctx *middlewares.AutheliaCtx, client *oidc.Client, userSession session.UserSession, requester fosite.AuthorizeRequester) bool {
+	if !isOIDCClientAuthenticationLevelSufficient(ctx, client, &userSession) {
+		return false
+	}
+
+	authTime, err := userSession.AuthenticatedTime(oidcClientRequiredLevel(ctx, client, &userSession))
+	if err != nil {
+		return false
+	}
+
+	rawConsentID := ctx.QueryArgs().PeekBytes(qryArgConsentID)
+
+	if len(rawConsentID) == 0 {
+		return oidc.IsReauthenticationRequired(requester.GetRequestForm(), authTime, ctx.Clock.Now())
+	}
+
+	var (
+		challengeID uuid.UUID
+		consent     *model.OAuth2ConsentSession
+	)
+
+	// The consent handlers are responsible for rejecting a consent id which is malformed or can't be loaded.
+	if challengeID, err = uuid.ParseBytes(rawConsentID); err != nil {
+		return false
+	}
+
+	if consent, err = ctx.Providers.StorageProvider.LoadOAuth2ConsentSessionByChallengeID(ctx, challengeID); err != nil {
+		return false
+	}
+
+	return oidc.IsReauthenticationRequiredSince(requester.GetRequestForm(), authTime, consent.RequestedAt)
+}
+
+func handleOIDCAuthorizationConsentNotAuthenticated(ctx *middlewares.AutheliaCtx, issuer *url.URL, _ *oidc.Client,
 	_ session.UserSession, _ uuid.UUID,
 	rw http.ResponseWriter, r *http.Request, requester fosite.AuthorizeRequester) (consent *model.OAuth2ConsentSession, handled bool) {
+	if oidc.IsPromptNone(requester.GetRequestForm()) {
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, fosite.ErrLoginRequired)
+
+		return nil, true
+	}
+
 	redirectionURL := handleOIDCAuthorizationConsentGetRedirectionURL(issuer, nil, requester)
 
 	http.Redirect(rw, r, redirectionURL.String(), http.StatusFound)
@@ -84,46 +142,96 @@ func handleOIDCAuthorizationConsentNotAuthenticated(_ *middlewares.AutheliaCtx,
 func handleOIDCAuthorizationConsentGenerate(ctx *middlewares.AutheliaCtx, issuer *url.URL, client *oidc.Client,
 	userSession session.UserSession, subject uuid.UUID,
 	rw http.ResponseWriter, r *http.Request, requester fosite.AuthorizeRequester) (consent *model.OAuth2ConsentSession, handled bool) {
-	var (
-		err error
-	)
-
 	ctx.Logger.Debugf(logFmtDbgConsentGenerate, requester.GetID(), client.GetID(), client.Consent)
 
 	if len(ctx.QueryArgs().PeekBytes(qryArgConsentID)) != 0 {
 		ctx.Logger.Errorf(logFmtErrConsentGenerateError, requester.GetID(), client.GetID(), client.Consent, "generating", errors.New("consent id value was present when it should be absent"))
 
-		ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, oidc.ErrConsentCouldNotGenerate)
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrConsentCouldNotGenerate)
+
+		return nil, true
+	}
+
+	if consent = handleOIDCAuthorizationConsentSave(ctx, client, subject, rw, requester); consent == nil {
+		return nil, true
+	}
+
+	handleOIDCAuthorizationConsentRedirect(ctx, issuer, consent, client, userSession, rw, r, requester)
 
+	return consent, true
+}
+
+// handleOIDCAuthorizationConsentReauthenticate generates a consent session and redirects the user to the login portal
+// to authenticate again without altering their existing session. The consent session has no subject so it's bound to
+// the user who authenticates in the login portal.
+func handleOIDCAuthorizationConsentReauthenticate(ctx *middlewares.AutheliaCtx, issuer *url.URL, client *oidc.Client,
+	_ session.UserSession, _ uuid.UUID,
+	rw http.ResponseWriter, r *http.Request, requester fosite.AuthorizeRequester) (consent *model.OAuth2ConsentSession, handled bool) {
+	ctx.Logger.Debugf(logFmtDbgConsentGenerate, requester.GetID(), client.GetID(), client.Consent)
+
+	if consent = handleOIDCAuthorizationConsentSave(ctx, client, uuid.UUID{}, rw, requester); consent == nil {
 		return nil, true
 	}
 
+	location := handleOIDCAuthorizationConsentGetRedirectionURL(issuer, consent, requester)
+
+	query := location.Query()
+	query.Set(queryArgPrompt, oidc.PromptLogin)
+
+	location.RawQuery = query.Encode()
+
+	ctx.Logger.Debugf(logFmtDbgConsentRedirect, requester.GetID(), client.GetID(), client.Consent, location)
+
+	http.Redirect(rw, r, location.String(), http.StatusFound)
+
+	return consent, true
+}
+
+// handleOIDCAuthorizationConsentSave creates and saves a new consent session for the authorization request. If the
+// consent session can't be created the error is written to the response and nil is returned.
+func handleOIDCAuthorizationConsentSave(ctx *middlewares.AutheliaCtx, client *oidc.Client, subject uuid.UUID,
+	rw http.ResponseWriter, requester fosite.AuthorizeRequester) (consent *model.OAuth2ConsentSession) {
+	var err error
+
 	if consent, err = model.NewOAuth2ConsentSession(subject, requester); err != nil {
 		ctx.Logger.Errorf(logFmtErrConsentGenerateError, requester.GetID(), client.GetID(), client.Consent, "generating", err)
 
-		ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, oidc.ErrConsentCouldNotGenerate)
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrConsentCouldNotGenerate)
 
-		return nil, true
+		return nil
 	}
 
+	consent.Form = oidc.NewAuthorizeRequestRedirectForm(requester.GetRequestForm()).Encode()
+
 	if err = ctx.Providers.StorageProvider.SaveOAuth2ConsentSession(ctx, *consent); err != nil {
 		ctx.Logger.Errorf(logFmtErrConsentGenerateError, requester.GetID(), client.GetID(), client.Consent, "saving", err)
 
-		ctx.Providers.OpenIDConnect.WriteAuthorizeError(rw, requester, oidc.ErrConsentCouldNotSave)
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrConsentCouldNotSave)
 
-		return nil, true
+		return nil
 	}
 
-	handleOIDCAuthorizationConsentRedirect(ctx, issuer, consent, client, userSession, rw, r, requester)
-
-	return consent, true
+	return consent
 }
 
 func handleOIDCAuthorizationConsentRedirect(ctx *middlewares.AutheliaCtx, issuer *url.URL, consent *model.OAuth2ConsentSession, client *oidc.Client,
 	userSession session.UserSession, rw http.ResponseWriter, r *http.Request, requester fosite.AuthorizeRequester) {
 	var location *url.URL
 
-	if client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel) {
+	level := oidcClientRequiredLevel(ctx, client, &userSession)
+	sufficient := isOIDCClientAuthenticationLevelSufficient(ctx, client, &userSession)
+
+	if oidc.IsPromptNone(requester.GetRequestForm()) {
+		if sufficient {
+			ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, fosite.ErrConsentRequired)
+		} else {
+			ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, fosite.ErrLoginRequired)
+		}
+
+		return
+	}
+
+	if sufficient {
 		location, _ = url.ParseRequestURI(issuer.String())
 		location.Path = path.Join(location.Path, oidc.EndpointPathConsent)
 
@@ -132,11 +240,11 @@ func handleOIDCAuthorizationConsentRedirect(ctx *middlewares.AutheliaCtx, issuer
 
 		location.RawQuery = query.Encode()
 
-		ctx.Logger.Debugf(logFmtDbgConsentAuthenticationSufficiency, requester.GetID(), client.GetID(), client.Consent, authentication.LevelToString(userSession.AuthenticationLevel), "sufficient", authorization.LevelToString(client.Policy))
+		ctx.Logger.Debugf(logFmtDbgConsentAuthenticationSufficiency, requester.GetID(), client.GetID(), client.Consent, authentication.LevelToString(userSession.AuthenticationLevel), "sufficient", authorization.LevelToString(level))
 	} else {
 		location = handleOIDCAuthorizationConsentGetRedirectionURL(issuer, consent, requester)
 
-		ctx.Logger.Debugf(logFmtDbgConsentAuthenticationSufficiency, requester.GetID(), client.GetID(), client.Consent, authentication.LevelToString(userSession.AuthenticationLevel), "insufficient", authorization.LevelToString(client.Policy))
+		ctx.Logger.Debugf(logFmtDbgConsentAuthenticationSufficiency, requester.GetID(), client.GetID(), client.Consent, authentication.LevelToString(userSession.AuthenticationLevel), "insufficient", authorization.LevelToString(level))
 	}
 
 	ctx.Logger.Debugf(logFmtDbgConsentRedirect, requester.GetID(), client.GetID(), client.Consent, location)
@@ -162,7 +270,7 @@ func handleOIDCAuthorizationConsentGetRedirectionURL(issuer *url.URL, consent *m
 	case requester != nil:
 		rd, _ := url.ParseRequestURI(iss)
 		rd.Path = path.Join(rd.Path, oidc.EndpointPathAuthorization)
-		rd.RawQuery = requester.GetRequestForm().Encode()
+		rd.RawQuery = oidc.NewAuthorizeRequestRedirectForm(requester.GetRequestForm()).Encode()
 
 		query.Set(queryArgRD, rd.String())
 	}
@@ -176,7 +284,7 @@ func verifyOIDCUserAuthorizedForConsent(ctx *middlewares.AutheliaCtx, client *oi
 	var sid uint32
 
 	if client == nil {
-		if client, err = ctx.Providers.OpenIDConnect.GetFullClient(consent.ClientID); err != nil {
+		if client, err = ctx.Providers.OpenIDConnect.GetFullClient(ctx, consent.ClientID); err != nil {
 			return fmt.Errorf("failed to retrieve client: %w", err)
 		}
 	}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+func TestIsOIDCAuthorizationReauthenticationRequired(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	challengeID := uuid.MustParse("3b2f3c5e-6a1d-4a3e-9a0e-6f3f4c2d1b0a")
+
+	testCases := []struct {
+		name      string
+		form      url.Values
+		level     authentication.Level
+		authTime  time.Time
+		consentID string
+		consent   *model.OAuth2ConsentSession
+		expected  bool
+	}{
+		{
+			"ShouldRequireWithPromptLogin",
+			url.Values{oidc.FormParameterPrompt: []string{oidc.PromptLogin}},
+			authentication.OneFactor, now.Add(-time.Minute), "", nil, true,
+		},
+		{
+			"ShouldRequireAfterMaxAge",
+			url.Values{oidc.FormParameterMaxAge: []string{"30"}},
+			authentication.OneFactor, now.Add(-time.Minute), "", nil, true,
+		},
+		{
+			"ShouldNotRequireWithinMaxAge",
+			url.Values{oidc.FormParameterMaxAge: []string{"3600"}},
+			authentication.OneFactor, now.Add(-time.Minute), "", nil, false,
+		},
+		{
+			"ShouldNotRequireWhenNotAuthenticated",
+			url.Values{oidc.FormParameterPrompt: []string{oidc.PromptLogin}},
+			authentication.NotAuthenticated, time.Unix(0, 0), "", nil, false,
+		},
+		{
+			"ShouldRequireWithPromptLoginWhenNotAuthenticatedSinceConsent",
+			url.Values{oidc.FormParameterPrompt: []string{oidc.PromptLogin}},
+			authentication.OneFactor, now.Add(-time.Minute), challengeID.String(),
+			&model.OAuth2ConsentSession{ChallengeID: challengeID, RequestedAt: now.Add(-time.Second * 30)}, true,
+		},
+		{
+			"ShouldNotRequireWithPromptLoginWhenAuthenticatedSinceConsent",
+			url.Values{oidc.FormParameterPrompt: []string{oidc.PromptLogin}},
+			authentication.OneFactor, now.Add(-time.Second * 10), challengeID.String(),
+			&model.OAuth2ConsentSession{ChallengeID: challengeID, RequestedAt: now.Add(-time.Second * 30)}, false,
+		},
+		{
+			"ShouldRequireWithMaxAgeWhenNotAuthenticatedSinceConsent",
+			url.Values{oidc.FormParameterMaxAge: []string{"0"}},
+			authentication.OneFactor, now.Add(-time.Minute), challengeID.String(),
+			&model.OAuth2ConsentSession{ChallengeID: challengeID, RequestedAt: now.Add(-time.Second * 30)}, true,
+		},
+		{
+			"ShouldNotRequireWithMaxAgeWhenAuthenticatedSinceConsent",
+			url.Values{oidc.FormParameterMaxAge: []string{"0"}},
+			authentication.OneFactor, now.Add(-time.Second * 10), challengeID.String(),
+			&model.OAuth2ConsentSession{ChallengeID: challengeID, RequestedAt: now.Add(-time.Second * 30)}, false,
+		},
+		{
+			"ShouldNotRequireWithInvalidConsentID",
+			url.Values{oidc.FormParameterPrompt: []string{oidc.PromptLogin}},
+			authentication.OneFactor, now.Add(-time.Minute), "not-a-uuid", nil, false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Clock.Set(now)
+			mock.Ctx.Clock = &mock.Clock
+
+			if tc.consentID != "" {
+				mock.Ctx.QueryArgs().Set(queryArgConsentID, tc.consentID)
+			}
+
+			if tc.consent != nil {
+				mock.StorageMock.EXPECT().LoadOAuth2ConsentSessionByChallengeID(mock.Ctx, challengeID).Return(tc.consent, nil)
+			}
+
+			client := &oidc.Client{ID: "app", Policy: authorization.OneFactor}
+
+			userSession := session.UserSession{
+				Username:                  testUsername,
+				AuthenticationLevel:       tc.level,
+				FirstFactorAuthnTimestamp: tc.authTime.Unix(),
+			}
+
+			requester := fosite.NewAuthorizeRequest()
+			requester.Form = tc.form
+
+			assert.Equal(t, tc.expected, isOIDCAuthorizationReauthenticationRequired(mock.Ctx, client, userSession, requester))
+		})
+	}
+}
+
+func TestHandleOIDCAuthorizationConsentShouldRedirectToReauthenticateWithoutResettingSession(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	now := time.Now()
+
+	mock.Clock.Set(now)
+	mock.Ctx.Clock = &mock.Clock
+
+	userSession := mock.Ctx.GetSession()
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.OneFactor
+	userSession.FirstFactorAuthnTimestamp = now.Add(-time.Minute).Unix()
+
+	require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	var saved model.OAuth2ConsentSession
+
+	mock.StorageMock.EXPECT().SaveOAuth2ConsentSession(mock.Ctx, gomock.Any()).DoAndReturn(func(_ any, consent model.OAuth2ConsentSession) error {
+		saved = consent
+
+		return nil
+	})
+
+	client := &oidc.Client{ID: "app", Policy: authorization.OneFactor}
+
+	requester := fosite.NewAuthorizeRequest()
+	requester.Client = client
+	requester.Form = url.Values{
+		oidc.FormParameterClientID: []string{"app"},
+		oidc.FormParameterPrompt:   []string{oidc.PromptLogin},
+	}
+
+	issuer, err := url.Parse("https://auth.example.com")
+
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+
+	consent, handled := handleOIDCAuthorizationConsent(mock.Ctx, issuer, client, userSession, rw, httptest.NewRequest(http.MethodGet, "/api/oidc/authorization", nil), requester)
+
+	assert.True(t, handled)
+	require.NotNil(t, consent)
+	assert.Equal(t, saved.ChallengeID, consent.ChallengeID)
+	assert.False(t, consent.Subject.Valid)
+
+	assert.Equal(t, http.StatusFound, rw.Code)
+
+	location, err := url.Parse(rw.Header().Get("Location"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "auth.example.com", location.Host)
+	assert.Equal(t, workflowOpenIDConnect, location.Query().Get(queryArgWorkflow))
+	assert.Equal(t, consent.ChallengeID.String(), location.Query().Get(queryArgWorkflowID))
+	assert.Equal(t, oidc.PromptLogin, location.Query().Get(queryArgPrompt))
+
+	actual := mock.Ctx.GetSession()
+
+	assert.Equal(t, testUsername, actual.Username)
+	assert.Equal(t, authentication.OneFactor, actual.AuthenticationLevel)
+}
@@ -31,16 +31,27 @@ func OpenIDConnectConsentGET(ctx *middlewares.AutheliaCtx) {
 	}
 
 	var (
-		consent *model.OAuth2ConsentSession
-		client  *oidc.Client
-		handled bool
+		consent      *model.OAuth2ConsentSession
+		client       *oidc.Client
+		verification *oidcDeviceVerification
+		handled      bool
 	)
 
-	if _, consent, client, handled = oidcConsentGetSessionsAndClient(ctx, consentID); handled {
+	if _, consent, client, verification, handled = oidcConsentGetSessionsAndClient(ctx, consentID); handled {
 		return
 	}
 
-	if err = ctx.SetJSONBody(client.GetConsentResponseBody(consent)); err != nil {
+	body := client.GetConsentResponseBody(consent)
+
+	if verification != nil {
+		body.PreConfiguration = false
+
+		if form, err := consent.GetForm(); err == nil {
+			body.UserCode = form.Get(oidc.FormParameterUserCode)
+		}
+	}
+
+	if err = ctx.SetJSONBody(body); err != nil {
 		ctx.Error(fmt.Errorf("unable to set JSON body: %v", err), "Operation failed")
 	}
 }
@@ -68,13 +79,14 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 	}
 
 	var (
-		userSession session.UserSession
-		consent     *model.OAuth2ConsentSession
-		client      *oidc.Client
-		handled     bool
+		userSession  session.UserSession
+		consent      *model.OAuth2ConsentSession
+		client       *oidc.Client
+		verification *oidcDeviceVerification
+		handled      bool
 	)
 
-	if userSession, consent, client, handled = oidcConsentGetSessionsAndClient(ctx, consentID); handled {
+	if userSession, consent, client, verification, handled = oidcConsentGetSessionsAndClient(ctx, consentID); handled {
 		return
 	}
 
@@ -89,7 +101,7 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 	if bodyJSON.Consent {
 		consent.Grant()
 
-		if bodyJSON.PreConfigure {
+		if bodyJSON.PreConfigure && verification == nil {
 			if client.Consent.Mode == oidc.ClientConsentModePreConfigured {
 				config := model.OAuth2ConsentPreConfig{
 					ClientID:  consent.ClientID,
@@ -125,6 +137,12 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
+	if verification != nil {
+		handleOIDCDeviceVerificationConsentResponse(ctx, userSession, consent, client, verification, bodyJSON.Consent)
+
+		return
+	}
+
 	var (
 		redirectURI *url.URL
 		query       url.Values
@@ -156,7 +174,7 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 	}
 }
 
-func oidcConsentGetSessionsAndClient(ctx *middlewares.AutheliaCtx, consentID uuid.UUID) (userSession session.UserSession, consent *model.OAuth2ConsentSession, client *oidc.Client, handled bool) {
+func oidcConsentGetSessionsAndClient(ctx *middlewares.AutheliaCtx, consentID uuid.UUID) (userSession session.UserSession, consent *model.OAuth2ConsentSession, client *oidc.Client, verification *oidcDeviceVerification, handled bool) {
 	var (
 		err error
 	)
@@ -167,14 +185,14 @@ func oidcConsentGetSessionsAndClient(ctx *middlewares.AutheliaCtx, consentID uui
 		ctx.Logger.Errorf("Unable to load consent session with challenge id '%s': %v", consentID, err)
 		ctx.ReplyForbidden()
 
-		return userSession, nil, nil, true
+		return userSession, nil, nil, nil, true
 	}
 
-	if client, err = ctx.Providers.OpenIDConnect.GetFullClient(consent.ClientID); err != nil {
+	if client, err = ctx.Providers.OpenIDConnect.GetFullClient(ctx, consent.ClientID); err != nil {
 		ctx.Logger.Errorf("Unable to find related client configuration with name '%s': %v", consent.ClientID, err)
 		ctx.ReplyForbidden()
 
-		return userSession, nil, nil, true
+		return userSession, nil, nil, nil, true
 	}
 
 	if err = verifyOIDCUserAuthorizedForConsent(ctx, client, userSession, consent, uuid.UUID{}); err != nil {
@@ -182,36 +200,42 @@ func oidcConsentGetSessionsAndClient(ctx *middlewares.AutheliaCtx, consentID uui
 
 		ctx.ReplyForbidden()
 
-		return userSession, nil, nil, true
+		return userSession, nil, nil, nil, true
 	}
 
-	switch client.Consent.Mode {
-	case oidc.ClientConsentModeImplicit:
+	// Device Authorization Requests always require the user to explicitly respond to the consent session regardless
+	// of the consent mode of the client.
+	if verification, err = getOIDCDeviceVerification(ctx, consent); err != nil {
+		ctx.Logger.Errorf("Unable to load the device authorization request for the consent session with challenge id '%s': %v", consent.ChallengeID, err)
+		ctx.ReplyForbidden()
+
+		return userSession, nil, nil, nil, true
+	}
+
+	switch {
+	case client.Consent.Mode == oidc.ClientConsentModeImplicit && verification == nil:
 		ctx.Logger.Errorf("Unable to perform OpenID Connect Consent for user '%s' and client id '%s': the client is using the implicit consent mode", userSession.Username, consent.ClientID)
 		ctx.ReplyForbidden()
 
-		return
-	default:
-		switch {
-		case consent.Responded():
-			ctx.Logger.Errorf("Unable to perform OpenID Connect Consent for user '%s' and client id '%s': the client is using the explicit consent mode and this consent session has already been responded to", userSession.Username, consent.ClientID)
-			ctx.ReplyForbidden()
-
-			return userSession, nil, nil, true
-		case !consent.CanGrant():
-			ctx.Logger.Errorf("Unable to perform OpenID Connect Consent for user '%s' and client id '%s': the specified consent session cannot be granted", userSession.Username, consent.ClientID)
-			ctx.ReplyForbidden()
-
-			return userSession, nil, nil, true
-		}
+		return userSession, nil, nil, nil, true
+	case consent.Responded():
+		ctx.Logger.Errorf("Unable to perform OpenID Connect Consent for user '%s' and client id '%s': the client is using the explicit consent mode and this consent session has already been responded to", userSession.Username, consent.ClientID)
+		ctx.ReplyForbidden()
+
+		return userSession, nil, nil, nil, true
+	case !consent.CanGrant():
+		ctx.Logger.Errorf("Unable to perform OpenID Connect Consent for user '%s' and client id '%s': the specified consent session cannot be granted", userSession.Username, consent.ClientID)
+		ctx.ReplyForbidden()
+
+		return userSession, nil, nil, nil, true
 	}
 
-	if !client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel) {
+	if !isOIDCClientAuthenticationLevelSufficient(ctx, client, &userSession) {
 		ctx.Logger.Errorf("Unable to perform OpenID Connect Consent for user '%s' and client id '%s': the user is not sufficiently authenticated", userSession.Username, consent.ClientID)
 		ctx.ReplyForbidden()
 
-		return userSession, nil, nil, true
+		return userSession, nil, nil, nil, true
 	}
 
-	return userSession, consent, client, false
+	return userSession, consent, client, verification, false
 }
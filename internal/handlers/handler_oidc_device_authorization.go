@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ory/fosite"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// OpenIDConnectDeviceAuthorizationPOST handles POST requests to the OAuth 2.0 Device Authorization endpoint.
+//
+// RFC8628: https://www.rfc-editor.org/rfc/rfc8628.html#section-3.1
+func OpenIDConnectDeviceAuthorizationPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	var (
+		requester fosite.Requester
+		responder *oidc.DeviceAuthorizeResponse
+		issuer    *url.URL
+		err       error
+	)
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("Device Authorization Request failed with error: error occurred determining issuer: %+v", err)
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(rw, nil, oidc.ErrIssuerCouldNotDerive)
+
+		return
+	}
+
+	if requester, err = ctx.Providers.OpenIDConnect.NewDeviceAuthorizeRequest(ctx, r); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("Device Authorization Request failed with error: %s", rfc.WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(rw, nil, err)
+
+		return
+	}
+
+	clientID := requester.GetClient().GetID()
+
+	ctx.Logger.Debugf("Device Authorization Request with id '%s' on client with id '%s' is being processed", requester.GetID(), clientID)
+
+	if responder, err = ctx.Providers.OpenIDConnect.NewDeviceAuthorizeResponse(ctx, requester, issuer); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("Device Authorization Response for Request with id '%s' on client with id '%s' could not be created: %s", requester.GetID(), clientID, rfc.WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(rw, nil, err)
+
+		return
+	}
+
+	ctx.Logger.Debugf("Device Authorization Request with id '%s' on client with id '%s' was successfully processed", requester.GetID(), clientID)
+
+	ctx.Providers.OpenIDConnect.WriteDeviceAuthorizeResponse(rw, responder)
+}
+
+// OpenIDConnectDeviceVerificationGET handles GET requests to the device verification endpoint which the device page of
+// the portal redirects the user to once they have entered the user_code. A consent session is generated and bound to
+// the Device Authorization Request, and the user is taken through the same authentication flow as the authorization
+// endpoint before being shown the consent page which displays the client and the user_code. This endpoint never
+// approves the Device Authorization Request regardless of the consent mode of the client, it's only approved when the
+// user explicitly accepts the consent request.
+//
+// RFC8628: https://www.rfc-editor.org/rfc/rfc8628.html#section-3.3
+func OpenIDConnectDeviceVerificationGET(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	var (
+		requester fosite.AuthorizeRequester
+		device    *model.OAuth2DeviceCodeSession
+		client    *oidc.Client
+		consent   *model.OAuth2ConsentSession
+		subject   uuid.UUID
+		issuer    *url.URL
+		err       error
+	)
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("Device Verification Request failed with error: error occurred determining issuer: %+v", err)
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, fosite.NewAuthorizeRequest(), oidc.ErrIssuerCouldNotDerive)
+
+		return
+	}
+
+	userCode := r.URL.Query().Get(oidc.FormParameterUserCode)
+
+	if requester, device, err = ctx.Providers.OpenIDConnect.NewDeviceVerificationRequest(ctx, userCode); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("Device Verification Request failed with error: %s", rfc.WithExposeDebug(true).GetDescription())
+
+		http.Redirect(rw, r, newOIDCDeviceVerificationResultURL(issuer, userCode, deviceResultInvalid).String(), http.StatusFound)
+
+		return
+	}
+
+	clientID := requester.GetClient().GetID()
+
+	ctx.Logger.Debugf("Device Verification Request with id '%s' on client with id '%s' is being processed", requester.GetID(), clientID)
+
+	if client, err = ctx.Providers.OpenIDConnect.GetFullClient(ctx, clientID); err != nil {
+		if errors.Is(err, fosite.ErrNotFound) {
+			ctx.Logger.Errorf("Device Verification Request with id '%s' on client with id '%s' could not be processed: client was not found", requester.GetID(), clientID)
+		} else {
+			ctx.Logger.Errorf("Device Verification Request with id '%s' on client with id '%s' could not be processed: failed to find client: %+v", requester.GetID(), clientID, err)
+		}
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, err)
+
+		return
+	}
+
+	userSession := ctx.GetSession()
+
+	switch {
+	case oidcClientRequiredLevel(ctx, client, &userSession) == authorization.Denied:
+		ctx.Logger.Errorf(logFmtErrConsentAuthorizationPolicyDenied, requester.GetID(), client.GetID(), client.Consent, userSession.Username)
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, fosite.ErrAccessDenied.WithHint("The user is not authorized to access this client."))
+
+		return
+	case userSession.IsAnonymous():
+		// The consent session has no subject so it's bound to the user who authenticates in the login portal.
+		break
+	default:
+		if subject, err = ctx.Providers.OpenIDConnect.GetSubject(ctx, client.GetSectorIdentifier(), userSession.Username); err != nil {
+			ctx.Logger.Errorf(logFmtErrConsentCantGetSubject, requester.GetID(), client.GetID(), client.Consent, userSession.Username, client.GetSectorIdentifier(), err)
+
+			ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrSubjectCouldNotLookup)
+
+			return
+		}
+	}
+
+	if consent = handleOIDCAuthorizationConsentSave(ctx, client, subject, rw, requester); consent == nil {
+		return
+	}
+
+	if err = ctx.Providers.OpenIDConnect.BindDeviceVerificationRequest(ctx, device, consent); err != nil {
+		ctx.Logger.Errorf("Device Verification Request with id '%s' on client with id '%s' could not be processed: error occurred saving the device code session: %+v", requester.GetID(), client.GetID(), err)
+
+		ctx.Providers.OpenIDConnect.WriteAuthorizeError(ctx, rw, requester, oidc.ErrDeviceCodeCouldNotSave)
+
+		return
+	}
+
+	handleOIDCAuthorizationConsentRedirect(ctx, issuer, consent, client, userSession, rw, r, requester)
+}
+
+// oidcDeviceVerification represents the Device Authorization Request a consent session was generated for.
+type oidcDeviceVerification struct {
+	requester fosite.AuthorizeRequester
+	session   *model.OAuth2DeviceCodeSession
+}
+
+// getOIDCDeviceVerification returns the Device Authorization Request bound to the consent session by the device
+// verification endpoint, or nil if the consent session was generated for an authorization request.
+func getOIDCDeviceVerification(ctx *middlewares.AutheliaCtx, consent *model.OAuth2ConsentSession) (verification *oidcDeviceVerification, err error) {
+	var (
+		requester fosite.AuthorizeRequester
+		device    *model.OAuth2DeviceCodeSession
+	)
+
+	if requester, device, err = ctx.Providers.OpenIDConnect.NewDeviceVerificationRequestByConsent(ctx, consent.ChallengeID); err != nil {
+		return nil, err
+	}
+
+	if device == nil {
+		return nil, nil
+	}
+
+	return &oidcDeviceVerification{requester: requester, session: device}, nil
+}
+
+// handleOIDCDeviceVerificationConsentResponse approves or denies the Device Authorization Request once the user has
+// explicitly responded to the consent session bound to it, and responds with the location of the device page which
+// displays the result.
+func handleOIDCDeviceVerificationConsentResponse(ctx *middlewares.AutheliaCtx, userSession session.UserSession,
+	consent *model.OAuth2ConsentSession, client *oidc.Client, verification *oidcDeviceVerification, approved bool) {
+	var (
+		issuer *url.URL
+		result string
+		err    error
+	)
+
+	requester := verification.requester
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("Device Verification Request with id '%s' on client with id '%s' could not be processed: error occurred determining issuer: %+v", requester.GetID(), client.GetID(), err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if approved {
+		err = handleOIDCDeviceVerificationApprove(ctx, issuer, userSession, consent, client, verification)
+		result = deviceResultApproved
+	} else {
+		err = ctx.Providers.OpenIDConnect.DenyDeviceVerificationRequest(ctx, verification.session)
+		result = deviceResultDenied
+	}
+
+	if err != nil {
+		ctx.Logger.Errorf("Device Verification Request with id '%s' on client with id '%s' could not be processed: %+v", requester.GetID(), client.GetID(), err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	ctx.Logger.Debugf("Device Verification Request with id '%s' on client with id '%s' was successfully %s by user '%s'", requester.GetID(), client.GetID(), result, userSession.Username)
+
+	if err = ctx.SetJSONBody(oidc.ConsentPostResponseBody{RedirectURI: newOIDCDeviceVerificationResultURL(issuer, "", result).String()}); err != nil {
+		ctx.Error(fmt.Errorf("unable to set JSON bodyJSON in response"), "Operation failed")
+	}
+}
+
+func handleOIDCDeviceVerificationApprove(ctx *middlewares.AutheliaCtx, issuer *url.URL, userSession session.UserSession,
+	consent *model.OAuth2ConsentSession, client *oidc.Client, verification *oidcDeviceVerification) (err error) {
+	var (
+		userinfoClaims map[string]any
+		authTime       time.Time
+		sid            string
+	)
+
+	requester := verification.requester
+
+	extraClaims := oidcGrantRequests(requester, consent, &userSession)
+
+	if userinfoClaims, err = oidcGrantCustomClaims(ctx, client, consent, &userSession, extraClaims); err != nil {
+		return fmt.Errorf("error occurred retrieving the user details for the custom claims: %w", err)
+	}
+
+	if authTime, err = userSession.AuthenticatedTime(oidcClientRequiredLevel(ctx, client, &userSession)); err != nil {
+		return fmt.Errorf("error occurred checking authentication time: %w", err)
+	}
+
+	if sid, err = userSession.GetOpenIDConnectSessionID(); err != nil {
+		return fmt.Errorf("error occurred generating the session id: %w", err)
+	}
+
+	userSession.AddOpenIDConnectClient(client.GetID(), consent.Subject.UUID.String())
+
+	if err = ctx.SaveSession(userSession); err != nil {
+		return fmt.Errorf("error occurred saving the user session: %w", err)
+	}
+
+	alg := client.GetIDTokenSignedResponseAlg()
+
+	oidcSession := oidc.NewSessionWithAuthorizeRequest(issuer, ctx.Providers.OpenIDConnect.KeyManager.GetKeyIDFromAlg(alg), alg, sid,
+		userSession.Username, userSession.AuthenticationMethodRefs.MarshalRFC8176(), extraClaims, authTime, consent, requester)
+
+	oidcSession.Claims.AuthenticationContextClassReference = ctx.Providers.OpenIDConnect.GetAuthenticationContextClassReference(
+		userSession.AuthenticationLevel, oidcSession.Claims.AuthenticationMethodsReferences, requester.GetRequestForm())
+
+	for claim, value := range userinfoClaims {
+		oidcSession.Extra[claim] = value
+	}
+
+	requester.SetSession(oidcSession)
+
+	if err = ctx.Providers.OpenIDConnect.ApproveDeviceVerificationRequest(ctx, verification.session, consent, requester); err != nil {
+		return fmt.Errorf("error occurred saving the device code session: %w", err)
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveOAuth2ConsentSessionGranted(ctx, consent.ID); err != nil {
+		return fmt.Errorf("error occurred saving consent session: %w", err)
+	}
+
+	return nil
+}
+
+// newOIDCDeviceVerificationResultURL returns the location of the device page of the portal which displays the result
+// of the Device Verification Request.
+func newOIDCDeviceVerificationResultURL(issuer *url.URL, userCode, result string) (location *url.URL) {
+	location, _ = url.ParseRequestURI(issuer.String())
+	location.Path = path.Join(location.Path, oidc.EndpointPathDevice)
+
+	query := location.Query()
+	query.Set(queryArgResult, result)
+
+	if userCode != "" && result == deviceResultInvalid {
+		query.Set(oidc.FormParameterUserCode, userCode)
+	}
+
+	location.RawQuery = query.Encode()
+
+	return location
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+func TestOpenIDConnectDeviceVerificationGETShouldNeverApprove(t *testing.T) {
+	testCases := []struct {
+		name    string
+		consent string
+	}{
+		{"ShouldNotApproveImplicitClient", "implicit"},
+		{"ShouldNotApprovePreConfiguredClient", "pre-configured"},
+		{"ShouldNotApproveExplicitClient", "explicit"},
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Request.Header.Set("X-Forwarded-Proto", "https")
+			mock.Ctx.Request.Header.Set("X-Forwarded-Host", "auth.example.com")
+
+			duration := time.Hour
+
+			mock.Ctx.Providers.OpenIDConnect, err = oidc.NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+				IssuerPrivateKey: key,
+				HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+				DeviceAuthorization: schema.OpenIDConnectDeviceAuthorizationConfiguration{
+					CodeLifespan:    time.Minute * 10,
+					PollingInterval: time.Second * 5,
+				},
+				Clients: []schema.OpenIDConnectClientConfiguration{
+					{
+						ID:                           "tv",
+						Public:                       true,
+						Policy:                       "one_factor",
+						ConsentMode:                  tc.consent,
+						ConsentPreConfiguredDuration: &duration,
+						Scopes:                       []string{oidc.ScopeOpenID},
+						GrantTypes:                   []string{oidc.GrantTypeDeviceCode},
+					},
+				},
+			}, mock.StorageMock)
+
+			require.NoError(t, err)
+
+			userSession := mock.Ctx.GetSession()
+			userSession.Username = testUsername
+			userSession.AuthenticationLevel = authentication.OneFactor
+			userSession.FirstFactorAuthnTimestamp = time.Now().Unix()
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			device := &model.OAuth2DeviceCodeSession{
+				RequestID:       "d2c4f7e0-3b1a-4c5d-8e9f-0a1b2c3d4e5f",
+				ClientID:        "tv",
+				Signature:       "device-signature",
+				Status:          model.OAuth2DeviceCodeStatusPending,
+				RequestedAt:     time.Now(),
+				ExpiresAt:       time.Now().Add(time.Minute),
+				RequestedScopes: []string{oidc.ScopeOpenID},
+				Form:            url.Values{oidc.FormParameterClientID: []string{"tv"}, oidc.FormParameterScope: []string{oidc.ScopeOpenID}}.Encode(),
+			}
+
+			var saved model.OAuth2ConsentSession
+
+			gomock.InOrder(
+				mock.StorageMock.EXPECT().LoadOAuth2DeviceCodeSessionByUserCode(mock.Ctx, model.NewOAuth2DeviceCodeSignature("BCDFGHJK")).Return(device, nil),
+				mock.StorageMock.EXPECT().LoadUserOpaqueIdentifierBySignature(mock.Ctx, "openid", "", testUsername).
+					Return(&model.UserOpaqueIdentifier{Service: "openid", Username: testUsername, Identifier: uuid.New()}, nil),
+				mock.StorageMock.EXPECT().SaveOAuth2ConsentSession(mock.Ctx, gomock.Any()).DoAndReturn(func(_ any, consent model.OAuth2ConsentSession) error {
+					saved = consent
+
+					return nil
+				}),
+				mock.StorageMock.EXPECT().UpdateOAuth2DeviceCodeSessionChallengeID(mock.Ctx, "device-signature", gomock.Any()).Return(nil),
+			)
+
+			rw := httptest.NewRecorder()
+
+			OpenIDConnectDeviceVerificationGET(mock.Ctx, rw, httptest.NewRequest(http.MethodGet, "/api/oidc/device-verification?user_code=BCDF-GHJK", nil))
+
+			assert.Equal(t, http.StatusFound, rw.Code)
+			assert.Equal(t, model.OAuth2DeviceCodeStatusPending, device.Status)
+			assert.Equal(t, uuid.NullUUID{UUID: saved.ChallengeID, Valid: true}, device.ChallengeID)
+
+			location, err := url.Parse(rw.Header().Get("Location"))
+
+			require.NoError(t, err)
+			assert.Equal(t, oidc.EndpointPathConsent, location.Path)
+			assert.Equal(t, saved.ChallengeID.String(), location.Query().Get(queryArgID))
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/ory/fosite/token/jwt"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// OpenIDConnectEndSession handles GET/POST requests to the OpenID Connect RP-Initiated Logout 1.0 End Session endpoint.
+//
+// When the user is logged in the session is only destroyed if the id_token_hint was issued for the session. Requests
+// made without an id_token_hint redirect the user to the portal to confirm they want to log out, which prevents third
+// parties from logging the user out.
+//
+// https://openid.net/specs/openid-connect-rpinitiated-1_0.html#RPLogout
+func OpenIDConnectEndSession(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.Request) {
+	var (
+		issuer *url.URL
+		claims jwt.MapClaims
+		client *oidc.Client
+		err    error
+	)
+
+	if err = req.ParseForm(); err != nil {
+		ctx.Logger.Errorf("End Session Request failed to parse the form: %+v", err)
+
+		ctx.Providers.OpenIDConnect.WriteError(rw, req, oidc.ErrEndSessionIDTokenHintInvalid)
+
+		return
+	}
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("End Session Request could not be processed: error occurred determining issuer: %+v", err)
+
+		ctx.Providers.OpenIDConnect.WriteError(rw, req, oidc.ErrIssuerCouldNotDerive)
+
+		return
+	}
+
+	var (
+		hint                  = req.Form.Get(oidc.FormParameterIDTokenHint)
+		clientID              = req.Form.Get(oidc.FormParameterClientID)
+		postLogoutRedirectURI = req.Form.Get(oidc.FormParameterPostLogoutRedirectURI)
+		state                 = req.Form.Get(oidc.FormParameterState)
+	)
+
+	if hint != "" {
+		if claims, err = ctx.Providers.OpenIDConnect.DecodeIDTokenHint(req.Context(), issuer.String(), hint); err != nil {
+			ctx.Logger.Errorf("End Session Request could not be processed: %+v", err)
+
+			ctx.Providers.OpenIDConnect.WriteError(rw, req, oidc.ErrEndSessionIDTokenHintInvalid)
+
+			return
+		}
+
+		hintClientID := oidc.IDTokenClaimsClientID(claims)
+
+		switch {
+		case clientID == "":
+			clientID = hintClientID
+		case clientID != hintClientID:
+			ctx.Logger.Errorf("End Session Request could not be processed: the client with id '%s' does not match the client with id '%s' which the id token hint was issued to", clientID, hintClientID)
+
+			ctx.Providers.OpenIDConnect.WriteError(rw, req, oidc.ErrEndSessionClientMismatch)
+
+			return
+		}
+	}
+
+	if postLogoutRedirectURI != "" {
+		if clientID == "" {
+			ctx.Logger.Errorf("End Session Request could not be processed: the post logout redirect uri '%s' was provided without a client", postLogoutRedirectURI)
+
+			ctx.Providers.OpenIDConnect.WriteError(rw, req, oidc.ErrEndSessionPostLogoutRedirectURIClient)
+
+			return
+		}
+
+		if client, err = ctx.Providers.OpenIDConnect.GetFullClient(ctx, clientID); err != nil {
+			ctx.Logger.Errorf("End Session Request on client with id '%s' could not be processed: failed to find client: %+v", clientID, err)
+
+			ctx.Providers.OpenIDConnect.WriteError(rw, req, oidc.ErrEndSessionClientUnknown)
+
+			return
+		}
+
+		if !client.IsPostLogoutRedirectURIAllowed(postLogoutRedirectURI) {
+			ctx.Logger.Errorf("End Session Request on client with id '%s' could not be processed: the post logout redirect uri '%s' is not registered for the client", clientID, postLogoutRedirectURI)
+
+			ctx.Providers.OpenIDConnect.WriteError(rw, req, oidc.ErrEndSessionPostLogoutRedirectURIInvalid)
+
+			return
+		}
+	}
+
+	userSession := ctx.GetSession()
+
+	if !userSession.IsAnonymous() {
+		if claims == nil {
+			oidcEndSessionConfirmation(ctx, rw, req, issuer, &userSession, clientID, postLogoutRedirectURI, state)
+
+			return
+		}
+
+		subject, _ := claims[oidc.ClaimSubject].(string)
+		sid, _ := claims[oidc.ClaimSessionID].(string)
+
+		if !userSession.IsOpenIDConnectEndSessionHintValid(clientID, subject, sid) {
+			ctx.Logger.Errorf("End Session Request on client with id '%s' could not be processed: the id token hint was not issued for the session of user '%s'", clientID, userSession.Username)
+
+			ctx.Providers.OpenIDConnect.WriteError(rw, req, oidc.ErrEndSessionIDTokenHintSessionMismatch)
+
+			return
+		}
+
+		if err = oidcEndSession(ctx, &userSession, clientID); err != nil {
+			ctx.Logger.Errorf("End Session Request on client with id '%s' could not be processed: error occurred destroying session for user '%s': %+v", clientID, userSession.Username, err)
+
+			ctx.Providers.OpenIDConnect.WriteError(rw, req, oidc.ErrEndSessionCouldNotDestroy)
+
+			return
+		}
+	}
+
+	http.Redirect(rw, req, oidcEndSessionRedirectURI(issuer, postLogoutRedirectURI, state), http.StatusFound)
+}
+
+// OpenIDConnectEndSessionConfirmationGET handles requests from the portal for the details of an End Session Request
+// which is awaiting the confirmation of the user.
+func OpenIDConnectEndSessionConfirmationGET(ctx *middlewares.AutheliaCtx) {
+	userSession := ctx.GetSession()
+
+	confirmation, ok := oidcEndSessionGetConfirmation(ctx, &userSession, string(ctx.RequestCtx.QueryArgs().PeekBytes(qryArgID)))
+	if !ok {
+		ctx.ReplyForbidden()
+
+		return
+	}
+
+	body := oidc.EndSessionConfirmationGetResponseBody{
+		ClientID: confirmation.ClientID,
+	}
+
+	if confirmation.ClientID != "" {
+		if client, err := ctx.Providers.OpenIDConnect.GetFullClient(ctx, confirmation.ClientID); err == nil {
+			body.ClientDescription = client.Description
+		}
+	}
+
+	if err := ctx.SetJSONBody(body); err != nil {
+		ctx.Error(fmt.Errorf("unable to set JSON body: %v", err), messageOperationFailed)
+	}
+}
+
+// OpenIDConnectEndSessionConfirmationPOST handles the response of the user to an End Session Request which is awaiting
+// their confirmation. The session is only destroyed if the user confirms they want to log out.
+func OpenIDConnectEndSessionConfirmationPOST(ctx *middlewares.AutheliaCtx) {
+	var (
+		bodyJSON oidc.EndSessionConfirmationPostRequestBody
+		issuer   *url.URL
+		err      error
+	)
+
+	if err = json.Unmarshal(ctx.Request.Body(), &bodyJSON); err != nil {
+		ctx.Logger.Errorf("Failed to parse JSON body in end session confirmation POST: %+v", err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	userSession := ctx.GetSession()
+
+	confirmation, ok := oidcEndSessionGetConfirmation(ctx, &userSession, bodyJSON.ID)
+	if !ok {
+		ctx.ReplyForbidden()
+
+		return
+	}
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("End Session Request on client with id '%s' could not be processed: error occurred determining issuer: %+v", confirmation.ClientID, err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	response := oidc.EndSessionConfirmationPostResponseBody{}
+
+	if bodyJSON.Confirm {
+		if err = oidcEndSession(ctx, &userSession, confirmation.ClientID); err != nil {
+			ctx.Logger.Errorf("End Session Request on client with id '%s' could not be processed: error occurred destroying session for user '%s': %+v", confirmation.ClientID, userSession.Username, err)
+			ctx.SetJSONError(messageOperationFailed)
+
+			return
+		}
+
+		response.RedirectURI = oidcEndSessionRedirectURI(issuer, confirmation.PostLogoutRedirectURI, confirmation.State)
+	} else {
+		ctx.Logger.Debugf("End Session Request on client with id '%s' was denied by user '%s'", confirmation.ClientID, userSession.Username)
+
+		userSession.OpenIDConnectEndSessionConfirmation = nil
+
+		if err = ctx.SaveSession(userSession); err != nil {
+			ctx.Logger.Errorf("End Session Request on client with id '%s' could not be processed: error occurred removing the confirmation from the session for user '%s': %+v", confirmation.ClientID, userSession.Username, err)
+			ctx.SetJSONError(messageOperationFailed)
+
+			return
+		}
+
+		response.RedirectURI = issuer.String()
+	}
+
+	if err = ctx.SetJSONBody(response); err != nil {
+		ctx.Error(fmt.Errorf("unable to set JSON body: %v", err), messageOperationFailed)
+	}
+}
+
+// oidcEndSessionConfirmation saves the End Session Request to the session and redirects the user to the portal which
+// asks them to confirm they want to log out.
+func oidcEndSessionConfirmation(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.Request, issuer *url.URL, userSession *session.UserSession, clientID, postLogoutRedirectURI, state string) {
+	userSession.OpenIDConnectEndSessionConfirmation = &oidc.EndSessionConfirmation{
+		ID:                    utils.RandomString(32, utils.CharSetAlphaNumeric, true),
+		ClientID:              clientID,
+		PostLogoutRedirectURI: postLogoutRedirectURI,
+		State:                 state,
+	}
+
+	if err := ctx.SaveSession(*userSession); err != nil {
+		ctx.Logger.Errorf("End Session Request on client with id '%s' could not be processed: error occurred saving the confirmation to the session for user '%s': %+v", clientID, userSession.Username, err)
+
+		ctx.Providers.OpenIDConnect.WriteError(rw, req, oidc.ErrEndSessionCouldNotDestroy)
+
+		return
+	}
+
+	ctx.Logger.Debugf("End Session Request on client with id '%s' requires confirmation from user '%s' as it did not include an id token hint", clientID, userSession.Username)
+
+	location := *issuer
+	location.Path = path.Join(location.Path, oidc.EndpointPathEndSessionConfirmation)
+	location.RawQuery = url.Values{queryArgID: []string{userSession.OpenIDConnectEndSessionConfirmation.ID}}.Encode()
+
+	http.Redirect(rw, req, location.String(), http.StatusFound)
+}
+
+// oidcEndSessionGetConfirmation returns the End Session Request awaiting confirmation if the user is logged in and the
+// id matches the End Session Request saved to their session.
+func oidcEndSessionGetConfirmation(ctx *middlewares.AutheliaCtx, userSession *session.UserSession, id string) (confirmation *oidc.EndSessionConfirmation, ok bool) {
+	confirmation = userSession.OpenIDConnectEndSessionConfirmation
+
+	switch {
+	case userSession.IsAnonymous():
+		ctx.Logger.Errorf("End Session Request confirmation could not be processed: the user is anonymous")
+	case confirmation == nil || id == "" || subtle.ConstantTimeCompare([]byte(id), []byte(confirmation.ID)) != 1:
+		ctx.Logger.Errorf("End Session Request confirmation could not be processed: the id does not match an End Session Request awaiting confirmation for user '%s'", userSession.Username)
+	default:
+		return confirmation, true
+	}
+
+	return nil, false
+}
+
+// oidcEndSession destroys the session of the user and sends the Back-Channel Logout requests to the clients the session
+// was authenticated to.
+func oidcEndSession(ctx *middlewares.AutheliaCtx, userSession *session.UserSession, clientID string) (err error) {
+	if err = ctx.Providers.SessionProvider.DestroySession(ctx.RequestCtx); err != nil {
+		return err
+	}
+
+	ctx.Logger.Debugf("End Session Request on client with id '%s' successfully destroyed the session for user '%s'", clientID, userSession.Username)
+
+	oidcBackChannelLogout(ctx, userSession)
+
+	return nil
+}
+
+// oidcEndSessionRedirectURI returns the URI the user is redirected to after the End Session Request has been processed,
+// which is the post logout redirect URI with the state when provided and the issuer otherwise.
+func oidcEndSessionRedirectURI(issuer *url.URL, postLogoutRedirectURI, state string) string {
+	if postLogoutRedirectURI == "" {
+		return issuer.String()
+	}
+
+	redirectURL, _ := url.Parse(postLogoutRedirectURI)
+
+	if state != "" {
+		query := redirectURL.Query()
+		query.Set(oidc.FormParameterState, state)
+		redirectURL.RawQuery = query.Encode()
+	}
+
+	return redirectURL.String()
+}
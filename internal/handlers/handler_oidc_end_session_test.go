@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+type OpenIDConnectEndSessionSuite struct {
+	suite.Suite
+
+	key  *rsa.PrivateKey
+	mock *mocks.MockAutheliaCtx
+}
+
+func (s *OpenIDConnectEndSessionSuite) SetupSuite() {
+	var err error
+
+	s.key, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+}
+
+func (s *OpenIDConnectEndSessionSuite) SetupTest() {
+	s.mock = mocks.NewMockAutheliaCtx(s.T())
+
+	s.mock.Ctx.Request.Header.Set("X-Forwarded-Proto", "https")
+	s.mock.Ctx.Request.Header.Set("X-Forwarded-Host", "auth.example.com")
+
+	secret, err := schema.NewPasswordDigest("$plaintext$app-secret", true)
+	s.Require().NoError(err)
+
+	s.mock.Ctx.Providers.OpenIDConnect, err = oidc.NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: s.key,
+		HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:                     "app",
+				Secret:                 secret,
+				Policy:                 "one_factor",
+				RedirectURIs:           []string{"https://app.example.com/callback"},
+				PostLogoutRedirectURIs: []string{"https://app.example.com/logged-out"},
+			},
+		},
+	}, s.mock.StorageMock)
+
+	s.Require().NoError(err)
+
+	userSession := s.mock.Ctx.GetSession()
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = 1
+	userSession.OpenIDConnectSessionID = "2b5f7e6c-4f0e-4c1f-9d43-6c2d3b1a0e9f"
+	userSession.AddOpenIDConnectClient("app", "5c3b2f8e-7d41-4a6b-9f0e-1a2b3c4d5e6f")
+
+	s.Require().NoError(s.mock.Ctx.SaveSession(userSession))
+}
+
+func (s *OpenIDConnectEndSessionSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *OpenIDConnectEndSessionSuite) hint(claims jwt.MapClaims) string {
+	claims[oidc.ClaimIssuer] = "https://auth.example.com"
+	claims[oidc.ClaimAudience] = []string{"app"}
+	claims[oidc.ClaimExpirationTime] = time.Now().Add(time.Hour).Unix()
+
+	token, _, err := s.mock.Ctx.Providers.OpenIDConnect.KeyManager.Strategy().Generate(context.Background(), claims, &jwt.Headers{
+		Extra: map[string]any{oidc.JWTHeaderKeyIdentifier: s.mock.Ctx.Providers.OpenIDConnect.KeyManager.GetActiveKeyID()},
+	})
+
+	s.Require().NoError(err)
+
+	return token
+}
+
+func (s *OpenIDConnectEndSessionSuite) do(method string, form url.Values) *httptest.ResponseRecorder {
+	var req *http.Request
+
+	if method == http.MethodPost {
+		req = httptest.NewRequest(method, oidc.EndpointPathEndSession, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		req = httptest.NewRequest(method, oidc.EndpointPathEndSession+"?"+form.Encode(), nil)
+	}
+
+	rw := httptest.NewRecorder()
+
+	OpenIDConnectEndSession(s.mock.Ctx, rw, req)
+
+	return rw
+}
+
+func (s *OpenIDConnectEndSessionSuite) TestShouldDestroySessionWithHintForSession() {
+	rw := s.do(http.MethodGet, url.Values{
+		oidc.FormParameterIDTokenHint:           []string{s.hint(jwt.MapClaims{oidc.ClaimSubject: "5c3b2f8e-7d41-4a6b-9f0e-1a2b3c4d5e6f", oidc.ClaimSessionID: "2b5f7e6c-4f0e-4c1f-9d43-6c2d3b1a0e9f"})},
+		oidc.FormParameterPostLogoutRedirectURI: []string{"https://app.example.com/logged-out"},
+		oidc.FormParameterState:                 []string{"abc123"},
+	})
+
+	s.Equal(http.StatusFound, rw.Code)
+	s.Equal("https://app.example.com/logged-out?state=abc123", rw.Header().Get("Location"))
+
+	userSession := s.mock.Ctx.GetSession()
+	s.True(userSession.IsAnonymous())
+}
+
+func (s *OpenIDConnectEndSessionSuite) TestShouldDestroySessionWithHintSubjectForSession() {
+	rw := s.do(http.MethodGet, url.Values{
+		oidc.FormParameterIDTokenHint: []string{s.hint(jwt.MapClaims{oidc.ClaimSubject: "5c3b2f8e-7d41-4a6b-9f0e-1a2b3c4d5e6f"})},
+	})
+
+	s.Equal(http.StatusFound, rw.Code)
+	s.Equal("https://auth.example.com", rw.Header().Get("Location"))
+
+	userSession := s.mock.Ctx.GetSession()
+	s.True(userSession.IsAnonymous())
+}
+
+func (s *OpenIDConnectEndSessionSuite) TestShouldNotDestroySessionWithHintForAnotherSession() {
+	testCases := []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{"ShouldRejectSessionIDMismatch", jwt.MapClaims{oidc.ClaimSubject: "5c3b2f8e-7d41-4a6b-9f0e-1a2b3c4d5e6f", oidc.ClaimSessionID: "a-different-session"}},
+		{"ShouldRejectSubjectMismatch", jwt.MapClaims{oidc.ClaimSubject: "9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b"}},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			rw := s.do(http.MethodGet, url.Values{
+				oidc.FormParameterIDTokenHint: []string{s.hint(tc.claims)},
+			})
+
+			s.Equal(http.StatusBadRequest, rw.Code)
+			s.Contains(rw.Body.String(), "The 'id_token_hint' parameter was not issued for the current session.")
+
+			userSession := s.mock.Ctx.GetSession()
+			s.Equal(testUsername, userSession.Username)
+		})
+	}
+}
+
+func (s *OpenIDConnectEndSessionSuite) confirmation() *oidc.EndSessionConfirmation {
+	rw := s.do(http.MethodGet, url.Values{
+		oidc.FormParameterClientID:              []string{"app"},
+		oidc.FormParameterPostLogoutRedirectURI: []string{"https://app.example.com/logged-out"},
+		oidc.FormParameterState:                 []string{"abc123"},
+	})
+
+	userSession := s.mock.Ctx.GetSession()
+	s.Equal(testUsername, userSession.Username)
+	s.Require().NotNil(userSession.OpenIDConnectEndSessionConfirmation)
+
+	confirmation := userSession.OpenIDConnectEndSessionConfirmation
+
+	s.Equal(http.StatusFound, rw.Code)
+	s.Equal("https://auth.example.com/end-session?id="+confirmation.ID, rw.Header().Get("Location"))
+
+	return confirmation
+}
+
+func (s *OpenIDConnectEndSessionSuite) TestShouldRedirectToPortalForConfirmationWithoutHint() {
+	confirmation := s.confirmation()
+
+	s.Equal("app", confirmation.ClientID)
+	s.Equal("https://app.example.com/logged-out", confirmation.PostLogoutRedirectURI)
+	s.Equal("abc123", confirmation.State)
+
+	rw := s.do(http.MethodPost, url.Values{
+		oidc.FormParameterClientID: []string{"app"},
+		"logout_confirmation":      []string{confirmation.ID},
+	})
+
+	s.Equal(http.StatusFound, rw.Code, "the end session endpoint must not accept the confirmation")
+
+	userSession := s.mock.Ctx.GetSession()
+	s.Equal(testUsername, userSession.Username)
+}
+
+func (s *OpenIDConnectEndSessionSuite) TestShouldGetConfirmation() {
+	confirmation := s.confirmation()
+
+	s.mock.Ctx.Request.SetRequestURI("/api/oidc/end-session/confirmation?id=" + confirmation.ID)
+
+	OpenIDConnectEndSessionConfirmationGET(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), oidc.EndSessionConfirmationGetResponseBody{ClientID: "app"})
+}
+
+func (s *OpenIDConnectEndSessionSuite) TestShouldNotGetConfirmationWithInvalidID() {
+	s.confirmation()
+
+	s.mock.Ctx.Request.SetRequestURI("/api/oidc/end-session/confirmation?id=not-the-confirmation")
+
+	OpenIDConnectEndSessionConfirmationGET(s.mock.Ctx)
+
+	s.Equal(fasthttp.StatusForbidden, s.mock.Ctx.Response.StatusCode())
+}
+
+func (s *OpenIDConnectEndSessionSuite) TestShouldDestroySessionWhenConfirmed() {
+	confirmation := s.confirmation()
+
+	s.mock.SetRequestBody(s.T(), oidc.EndSessionConfirmationPostRequestBody{ID: confirmation.ID, Confirm: true})
+
+	OpenIDConnectEndSessionConfirmationPOST(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), oidc.EndSessionConfirmationPostResponseBody{RedirectURI: "https://app.example.com/logged-out?state=abc123"})
+
+	userSession := s.mock.Ctx.GetSession()
+	s.True(userSession.IsAnonymous())
+}
+
+func (s *OpenIDConnectEndSessionSuite) TestShouldNotDestroySessionWhenDenied() {
+	confirmation := s.confirmation()
+
+	s.mock.SetRequestBody(s.T(), oidc.EndSessionConfirmationPostRequestBody{ID: confirmation.ID, Confirm: false})
+
+	OpenIDConnectEndSessionConfirmationPOST(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), oidc.EndSessionConfirmationPostResponseBody{RedirectURI: "https://auth.example.com"})
+
+	userSession := s.mock.Ctx.GetSession()
+	s.Equal(testUsername, userSession.Username)
+	s.Nil(userSession.OpenIDConnectEndSessionConfirmation)
+}
+
+func (s *OpenIDConnectEndSessionSuite) TestShouldNotDestroySessionWithInvalidConfirmationID() {
+	s.confirmation()
+
+	s.mock.SetRequestBody(s.T(), oidc.EndSessionConfirmationPostRequestBody{ID: "not-the-confirmation", Confirm: true})
+
+	OpenIDConnectEndSessionConfirmationPOST(s.mock.Ctx)
+
+	s.Equal(fasthttp.StatusForbidden, s.mock.Ctx.Response.StatusCode())
+
+	userSession := s.mock.Ctx.GetSession()
+	s.Equal(testUsername, userSession.Username)
+	s.NotNil(userSession.OpenIDConnectEndSessionConfirmation)
+}
+
+func (s *OpenIDConnectEndSessionSuite) TestShouldNotRequireConfirmationWhenAnonymous() {
+	s.Require().NoError(s.mock.Ctx.Providers.SessionProvider.DestroySession(s.mock.Ctx.RequestCtx))
+
+	rw := s.do(http.MethodGet, url.Values{})
+
+	s.Equal(http.StatusFound, rw.Code)
+	s.Equal("https://auth.example.com", rw.Header().Get("Location"))
+}
+
+func (s *OpenIDConnectEndSessionSuite) TestShouldRejectInvalidPostLogoutRedirectURI() {
+	testCases := []struct {
+		name     string
+		form     url.Values
+		expected string
+	}{
+		{
+			"ShouldRejectUnregisteredURI",
+			url.Values{
+				oidc.FormParameterIDTokenHint:           []string{s.hint(jwt.MapClaims{oidc.ClaimSubject: "5c3b2f8e-7d41-4a6b-9f0e-1a2b3c4d5e6f"})},
+				oidc.FormParameterPostLogoutRedirectURI: []string{"https://evil.example.com/logged-out"},
+			},
+			"The 'post_logout_redirect_uri' parameter does not match any of the registered post logout redirect URIs for the client.",
+		},
+		{
+			"ShouldRejectURIWithoutClient",
+			url.Values{
+				oidc.FormParameterPostLogoutRedirectURI: []string{"https://app.example.com/logged-out"},
+			},
+			"The 'post_logout_redirect_uri' parameter requires either the 'id_token_hint' or 'client_id' parameter.",
+		},
+		{
+			"ShouldRejectClientMismatch",
+			url.Values{
+				oidc.FormParameterIDTokenHint: []string{s.hint(jwt.MapClaims{oidc.ClaimSubject: "5c3b2f8e-7d41-4a6b-9f0e-1a2b3c4d5e6f"})},
+				oidc.FormParameterClientID:    []string{"other"},
+			},
+			"The 'client_id' parameter does not match the client the 'id_token_hint' was issued to.",
+		},
+		{
+			"ShouldRejectInvalidHint",
+			url.Values{
+				oidc.FormParameterIDTokenHint: []string{"abc.123"},
+			},
+			"The 'id_token_hint' parameter is not a valid ID Token issued by this provider.",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			rw := s.do(http.MethodGet, tc.form)
+
+			s.Equal(http.StatusBadRequest, rw.Code)
+			s.Contains(rw.Body.String(), tc.expected)
+
+			userSession := s.mock.Ctx.GetSession()
+			s.Equal(testUsername, userSession.Username)
+		})
+	}
+}
+
+func TestRunOpenIDConnectEndSessionSuite(t *testing.T) {
+	suite.Run(t, new(OpenIDConnectEndSessionSuite))
+}
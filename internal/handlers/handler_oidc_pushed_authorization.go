@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ory/fosite"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+// OpenIDConnectPushedAuthorizationRequest handles POST requests to the OAuth 2.0 Pushed Authorization Request endpoint.
+//
+// RFC9126: https://www.rfc-editor.org/rfc/rfc9126.html
+func OpenIDConnectPushedAuthorizationRequest(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	var (
+		requester fosite.AuthorizeRequester
+		responder *oidc.PushedAuthorizeResponse
+		err       error
+	)
+
+	if requester, err = ctx.Providers.OpenIDConnect.NewPushedAuthorizeRequest(ctx, r); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("Pushed Authorization Request failed with error: %s", rfc.WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(rw, nil, err)
+
+		return
+	}
+
+	clientID := requester.GetClient().GetID()
+
+	ctx.Logger.Debugf("Pushed Authorization Request with id '%s' on client with id '%s' is being processed", requester.GetID(), clientID)
+
+	if responder, err = ctx.Providers.OpenIDConnect.NewPushedAuthorizeResponse(ctx, requester); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("Pushed Authorization Response for Request with id '%s' on client with id '%s' could not be created: %s", requester.GetID(), clientID, rfc.WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(rw, nil, err)
+
+		return
+	}
+
+	ctx.Logger.Debugf("Pushed Authorization Request with id '%s' on client with id '%s' was successfully processed", requester.GetID(), clientID)
+
+	ctx.Providers.OpenIDConnect.WritePushedAuthorizeResponse(rw, responder)
+}
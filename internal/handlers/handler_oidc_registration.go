@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/ory/fosite"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+// OpenIDConnectRegistrationPOST handles POST requests to the OAuth 2.0 Dynamic Client Registration endpoint.
+//
+// RFC7591: https://www.rfc-editor.org/rfc/rfc7591.html#section-3
+func OpenIDConnectRegistrationPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	var (
+		response *oidc.ClientRegistrationResponse
+		issuer   *url.URL
+		err      error
+	)
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("Client Registration Request failed with error: error occurred determining issuer: %+v", err)
+
+		ctx.Providers.OpenIDConnect.WriteClientRegistrationError(rw, oidc.ErrIssuerCouldNotDerive)
+
+		return
+	}
+
+	if response, err = ctx.Providers.OpenIDConnect.RegisterClient(ctx, r, issuer); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("Client Registration Request failed with error: %s", rfc.WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteClientRegistrationError(rw, err)
+
+		return
+	}
+
+	ctx.Logger.Infof("Client Registration Request was successfully processed and registered the client with id '%s'", response.ClientID)
+
+	ctx.Providers.OpenIDConnect.WriteClientRegistrationResponse(rw, http.StatusCreated, response)
+}
+
+// OpenIDConnectRegistrationClientGET handles GET requests to the OAuth 2.0 Dynamic Client Registration Management
+// endpoint. Every successful request rotates the registration_access_token of the client, so the client must use the
+// registration_access_token from the response for subsequent requests.
+//
+// RFC7592: https://www.rfc-editor.org/rfc/rfc7592.html#section-2.1
+func OpenIDConnectRegistrationClientGET(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	var (
+		response *oidc.ClientRegistrationResponse
+		issuer   *url.URL
+		err      error
+	)
+
+	clientID, _ := ctx.UserValue(oidc.FormParameterClientID).(string)
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("Client Read Request for client with id '%s' failed with error: error occurred determining issuer: %+v", clientID, err)
+
+		ctx.Providers.OpenIDConnect.WriteClientRegistrationError(rw, oidc.ErrIssuerCouldNotDerive)
+
+		return
+	}
+
+	if response, err = ctx.Providers.OpenIDConnect.ReadClientRegistration(ctx, r, issuer, clientID); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("Client Read Request for client with id '%s' failed with error: %s", clientID, rfc.WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteClientRegistrationError(rw, err)
+
+		return
+	}
+
+	ctx.Providers.OpenIDConnect.WriteClientRegistrationResponse(rw, http.StatusOK, response)
+}
+
+// OpenIDConnectRegistrationClientPUT handles PUT requests to the OAuth 2.0 Dynamic Client Registration Management
+// endpoint.
+//
+// RFC7592: https://www.rfc-editor.org/rfc/rfc7592.html#section-2.2
+func OpenIDConnectRegistrationClientPUT(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	var (
+		response *oidc.ClientRegistrationResponse
+		issuer   *url.URL
+		err      error
+	)
+
+	clientID, _ := ctx.UserValue(oidc.FormParameterClientID).(string)
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("Client Update Request for client with id '%s' failed with error: error occurred determining issuer: %+v", clientID, err)
+
+		ctx.Providers.OpenIDConnect.WriteClientRegistrationError(rw, oidc.ErrIssuerCouldNotDerive)
+
+		return
+	}
+
+	if response, err = ctx.Providers.OpenIDConnect.UpdateClientRegistration(ctx, r, issuer, clientID); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("Client Update Request for client with id '%s' failed with error: %s", clientID, rfc.WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteClientRegistrationError(rw, err)
+
+		return
+	}
+
+	ctx.Logger.Infof("Client Update Request for client with id '%s' was successfully processed", clientID)
+
+	ctx.Providers.OpenIDConnect.WriteClientRegistrationResponse(rw, http.StatusOK, response)
+}
+
+// OpenIDConnectRegistrationClientDELETE handles DELETE requests to the OAuth 2.0 Dynamic Client Registration Management
+// endpoint.
+//
+// RFC7592: https://www.rfc-editor.org/rfc/rfc7592.html#section-2.3
+func OpenIDConnectRegistrationClientDELETE(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	clientID, _ := ctx.UserValue(oidc.FormParameterClientID).(string)
+
+	if err := ctx.Providers.OpenIDConnect.DeleteClientRegistration(ctx, r, clientID); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("Client Delete Request for client with id '%s' failed with error: %s", clientID, rfc.WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteClientRegistrationError(rw, err)
+
+		return
+	}
+
+	ctx.Logger.Infof("Client Delete Request for client with id '%s' was successfully processed", clientID)
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
 
 	"github.com/ory/fosite"
 
@@ -16,15 +18,42 @@ func OpenIDConnectTokenPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter
 	var (
 		requester fosite.AccessRequester
 		responder fosite.AccessResponder
+		issuer    *url.URL
+		proof     *oidc.DPoPProof
 		err       error
 	)
 
 	oidcSession := oidc.NewSession()
 
+	// The issuer is only used by grants which don't restore a previous session such as the client_credentials grant,
+	// where it's the issuer of the JWT access token.
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("Access Request failed with error: error occurred determining issuer: %+v", err)
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(rw, nil, oidc.ErrIssuerCouldNotDerive)
+
+		return
+	}
+
+	oidcSession.Claims.Issuer = issuer.String()
+
+	if proof, err = ctx.Providers.OpenIDConnect.ValidateDPoPProof(ctx, req, oidc.EndpointPathToken, ""); err != nil {
+		ctx.Logger.Errorf("Access Request failed with error: %s", fosite.ErrorToRFC6749Error(err).WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(rw, nil, err)
+
+		return
+	}
+
 	if requester, err = ctx.Providers.OpenIDConnect.NewAccessRequest(ctx, req, oidcSession); err != nil {
 		rfc := fosite.ErrorToRFC6749Error(err)
 
-		ctx.Logger.Errorf("Access Request failed with error: %s", rfc.WithExposeDebug(true).GetDescription())
+		// The Device Authorization Grant pending errors are expected while the client polls the token endpoint.
+		if errors.Is(err, oidc.ErrDeviceAuthorizationPending) || errors.Is(err, oidc.ErrDeviceSlowDown) {
+			ctx.Logger.Debugf("Access Request is pending with error: %s", rfc.WithExposeDebug(true).GetDescription())
+		} else {
+			ctx.Logger.Errorf("Access Request failed with error: %s", rfc.WithExposeDebug(true).GetDescription())
+		}
 
 		ctx.Providers.OpenIDConnect.WriteAccessError(rw, requester, err)
 
@@ -35,13 +64,20 @@ func OpenIDConnectTokenPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter
 
 	ctx.Logger.Debugf("Access Request with id '%s' on client with id '%s' is being processed", requester.GetID(), client.GetID())
 
-	// If this is a client_credentials grant, grant all scopes the client is allowed to perform.
-	if requester.GetGrantTypes().ExactOne("client_credentials") {
-		for _, scope := range requester.GetRequestedScopes() {
-			if fosite.HierarchicScopeStrategy(client.GetScopes(), scope) {
-				requester.GrantScope(scope)
-			}
-		}
+	if err = ctx.Providers.OpenIDConnect.BindDPoPAccessRequest(requester, proof); err != nil {
+		ctx.Logger.Errorf("Access Request with id '%s' on client with id '%s' failed with error: %s", requester.GetID(), client.GetID(), fosite.ErrorToRFC6749Error(err).WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(rw, requester, err)
+
+		return
+	}
+
+	if err = ctx.Providers.OpenIDConnect.BindMutualTLSAccessRequest(req, requester); err != nil {
+		ctx.Logger.Errorf("Access Request with id '%s' on client with id '%s' failed with error: %s", requester.GetID(), client.GetID(), fosite.ErrorToRFC6749Error(err).WithExposeDebug(true).GetDescription())
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(rw, requester, err)
+
+		return
 	}
 
 	ctx.Logger.Tracef("Access Request with id '%s' on client with id '%s' response is being generated for session with type '%T'", requester.GetID(), client.GetID(), requester.GetSession())
@@ -56,6 +92,10 @@ func OpenIDConnectTokenPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter
 		return
 	}
 
+	if proof != nil {
+		responder.SetTokenType(oidc.TokenTypeDPoP)
+	}
+
 	ctx.Logger.Debugf("Access Request with id '%s' on client with id '%s' has successfully been processed", requester.GetID(), client.GetID())
 
 	ctx.Logger.Tracef("Access Request with id '%s' on client with id '%s' produced the following claims: %+v", requester.GetID(), client.GetID(), responder.ToMap())
@@ -29,14 +29,16 @@ func OpenIDConnectUserinfo(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter,
 
 	oidcSession := oidc.NewSession()
 
+	accessToken, scheme := oidc.AccessTokenFromRequest(req)
+
 	if tokenType, requester, err = ctx.Providers.OpenIDConnect.IntrospectToken(
-		req.Context(), fosite.AccessTokenFromRequest(req), fosite.AccessToken, oidcSession); err != nil {
+		req.Context(), accessToken, fosite.AccessToken, oidcSession); err != nil {
 		rfc := fosite.ErrorToRFC6749Error(err)
 
 		ctx.Logger.Errorf("UserInfo Request failed with error: %+v", rfc)
 
 		if rfc.StatusCode() == http.StatusUnauthorized {
-			rw.Header().Set(fasthttp.HeaderWWWAuthenticate, fmt.Sprintf(`Bearer error="%s",error_description="%s"`, rfc.ErrorField, rfc.GetDescription()))
+			rw.Header().Set(fasthttp.HeaderWWWAuthenticate, fmt.Sprintf(`%s error="%s",error_description="%s"`, scheme, rfc.ErrorField, rfc.GetDescription()))
 		}
 
 		ctx.Providers.OpenIDConnect.WriteError(rw, req, err)
@@ -56,13 +58,45 @@ func OpenIDConnectUserinfo(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter,
 		return
 	}
 
-	if client, err = ctx.Providers.OpenIDConnect.GetFullClient(clientID); err != nil {
+	if err = ctx.Providers.OpenIDConnect.ValidateDPoPResourceRequest(ctx, req, oidc.EndpointPathUserinfo, scheme, accessToken, requester); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("UserInfo Request with id '%s' on client with id '%s' failed with error: %s", requester.GetID(), clientID, rfc.WithExposeDebug(true).GetDescription())
+
+		if rfc.StatusCode() != http.StatusInternalServerError {
+			rw.Header().Set(fasthttp.HeaderWWWAuthenticate, fmt.Sprintf(`%s error="%s",error_description="%s"`, oidc.TokenTypeDPoP, rfc.ErrorField, rfc.GetDescription()))
+			ctx.Providers.OpenIDConnect.WriteErrorCode(rw, req, http.StatusUnauthorized, err)
+		} else {
+			ctx.Providers.OpenIDConnect.WriteError(rw, req, err)
+		}
+
+		return
+	}
+
+	if err = ctx.Providers.OpenIDConnect.ValidateMutualTLSResourceRequest(req, requester); err != nil {
+		rfc := fosite.ErrorToRFC6749Error(err)
+
+		ctx.Logger.Errorf("UserInfo Request with id '%s' on client with id '%s' failed with error: %s", requester.GetID(), clientID, rfc.WithExposeDebug(true).GetDescription())
+
+		if rfc.StatusCode() != http.StatusInternalServerError {
+			rw.Header().Set(fasthttp.HeaderWWWAuthenticate, fmt.Sprintf(`%s error="%s",error_description="%s"`, scheme, rfc.ErrorField, rfc.GetDescription()))
+			ctx.Providers.OpenIDConnect.WriteErrorCode(rw, req, http.StatusUnauthorized, err)
+		} else {
+			ctx.Providers.OpenIDConnect.WriteError(rw, req, err)
+		}
+
+		return
+	}
+
+	if client, err = ctx.Providers.OpenIDConnect.GetFullClient(ctx, clientID); err != nil {
 		ctx.Providers.OpenIDConnect.WriteError(rw, req, errors.WithStack(fosite.ErrServerError.WithHint("Unable to assert type of client")))
 
 		return
 	}
 
-	claims := requester.GetSession().(*model.OpenIDSession).IDTokenClaims().ToMap()
+	oidcSession = requester.GetSession().(*model.OpenIDSession)
+
+	claims := oidcSession.IDTokenClaims().ToMap()
 	delete(claims, oidc.ClaimJWTID)
 	delete(claims, oidc.ClaimSessionID)
 	delete(claims, oidc.ClaimAccessTokenHash)
@@ -70,6 +104,11 @@ func OpenIDConnectUserinfo(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter,
 	delete(claims, oidc.ClaimExpirationTime)
 	delete(claims, oidc.ClaimNonce)
 
+	// The custom claims which are only released via the UserInfo endpoint.
+	for claim, value := range oidcSession.Extra {
+		claims[claim] = value
+	}
+
 	audience, ok := claims[oidc.ClaimAudience].([]string)
 
 	if !ok || len(audience) == 0 {
@@ -95,8 +134,18 @@ func OpenIDConnectUserinfo(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter,
 
 	ctx.Logger.Tracef("UserInfo Response with id '%s' on client with id '%s' is being sent with the following claims: %+v", requester.GetID(), clientID, claims)
 
-	switch client.UserinfoSigningAlgorithm {
-	case oidc.SigningAlgorithmRSAWithSHA256:
+	switch alg := client.UserinfoSigningAlgorithm; alg {
+	case oidc.SigningAlgorithmNone, "":
+		ctx.Providers.OpenIDConnect.Write(rw, req, claims)
+	default:
+		var jwk *oidc.JWK
+
+		if jwk = ctx.Providers.OpenIDConnect.KeyManager.GetByAlg(alg); jwk == nil {
+			ctx.Providers.OpenIDConnect.WriteError(rw, req, errors.WithStack(fosite.ErrServerError.WithHintf("Unsupported UserInfo signing algorithm '%s'.", alg)))
+
+			return
+		}
+
 		var jti uuid.UUID
 
 		if jti, err = uuid.NewRandom(); err != nil {
@@ -110,7 +159,7 @@ func OpenIDConnectUserinfo(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter,
 
 		headers := &jwt.Headers{
 			Extra: map[string]any{
-				oidc.JWTHeaderKeyIdentifier: ctx.Providers.OpenIDConnect.KeyManager.GetActiveKeyID(),
+				oidc.JWTHeaderKeyIdentifier: jwk.KeyID(),
 			},
 		}
 
@@ -122,9 +171,5 @@ func OpenIDConnectUserinfo(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter,
 
 		rw.Header().Set("Content-Type", "application/jwt")
 		_, _ = rw.Write([]byte(token))
-	case "none", "":
-		ctx.Providers.OpenIDConnect.Write(rw, req, claims)
-	default:
-		ctx.Providers.OpenIDConnect.WriteError(rw, req, errors.WithStack(fosite.ErrServerError.WithHintf("Unsupported UserInfo signing algorithm '%s'.", client.UserinfoSigningAlgorithm)))
 	}
 }
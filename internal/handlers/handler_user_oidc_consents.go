@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// UserOpenIDConnectConsentsGET lists the OpenID Connect consents granted and pre-configured by the user grouped by
+// client.
+func UserOpenIDConnectConsentsGET(ctx *middlewares.AutheliaCtx) {
+	userSession := ctx.GetSession()
+
+	var (
+		consents []model.OAuth2ConsentSession
+		configs  []model.OAuth2ConsentPreConfig
+		err      error
+	)
+
+	if consents, configs, err = loadUserOpenIDConnectConsents(ctx, userSession.Username); err != nil {
+		ctx.Error(err, messageOperationFailed)
+
+		return
+	}
+
+	clients := []oidc.UserConsentsClient{}
+	indexes := map[string]int{}
+
+	client := func(clientID string) *oidc.UserConsentsClient {
+		if i, ok := indexes[clientID]; ok {
+			return &clients[i]
+		}
+
+		indexes[clientID] = len(clients)
+
+		clients = append(clients, oidc.UserConsentsClient{
+			ClientID:          clientID,
+			ClientDescription: userOpenIDConnectConsentClientDescription(ctx, clientID),
+			Consents:          []oidc.UserConsent{},
+			PreConfigurations: []oidc.UserConsentPreConfiguration{},
+		})
+
+		return &clients[len(clients)-1]
+	}
+
+	for _, consent := range consents {
+		c := client(consent.ClientID)
+
+		item := oidc.UserConsent{
+			ID:       consent.ID,
+			Scopes:   consent.GrantedScopes,
+			Audience: consent.GrantedAudience,
+		}
+
+		if consent.RespondedAt.Valid {
+			grantedAt := consent.RespondedAt.Time
+
+			item.GrantedAt = &grantedAt
+		}
+
+		c.Consents = append(c.Consents, item)
+	}
+
+	for _, config := range configs {
+		c := client(config.ClientID)
+
+		item := oidc.UserConsentPreConfiguration{
+			ID:        config.ID,
+			Scopes:    config.Scopes,
+			Audience:  config.Audience,
+			CreatedAt: config.CreatedAt,
+		}
+
+		if config.ExpiresAt.Valid {
+			expiresAt := config.ExpiresAt.Time
+
+			item.ExpiresAt = &expiresAt
+		}
+
+		c.PreConfigurations = append(c.PreConfigurations, item)
+	}
+
+	if err = ctx.SetJSONBody(clients); err != nil {
+		ctx.Logger.Errorf("Unable to set user consents response in body: %s", err)
+	}
+}
+
+// UserOpenIDConnectConsentsDELETE revokes the OpenID Connect consents granted or pre-configured by the user for a
+// client, as well as the tokens issued as a result of those consents.
+func UserOpenIDConnectConsentsDELETE(ctx *middlewares.AutheliaCtx) {
+	var (
+		bodyJSON oidc.UserConsentsDeleteRequestBody
+		err      error
+	)
+
+	if err = ctx.ParseBody(&bodyJSON); err != nil {
+		ctx.Error(err, messageOperationFailed)
+
+		return
+	}
+
+	userSession := ctx.GetSession()
+
+	var (
+		consents []model.OAuth2ConsentSession
+		configs  []model.OAuth2ConsentPreConfig
+	)
+
+	if consents, configs, err = loadUserOpenIDConnectConsents(ctx, userSession.Username); err != nil {
+		ctx.Error(err, messageOperationFailed)
+
+		return
+	}
+
+	all := bodyJSON.ConsentID == nil && bodyJSON.PreConfigurationID == nil
+	revokedConfigs := map[int64]bool{}
+	revoked := 0
+
+	for _, config := range configs {
+		if config.ClientID != bodyJSON.ClientID || !(all || (bodyJSON.PreConfigurationID != nil && *bodyJSON.PreConfigurationID == config.ID)) {
+			continue
+		}
+
+		if err = ctx.Providers.StorageProvider.RevokeOAuth2ConsentPreConfiguration(ctx, config.ID); err != nil {
+			ctx.Error(err, messageOperationFailed)
+
+			return
+		}
+
+		revokedConfigs[config.ID] = true
+		revoked++
+	}
+
+	for _, consent := range consents {
+		if consent.ClientID != bodyJSON.ClientID {
+			continue
+		}
+
+		if !all && !(bodyJSON.ConsentID != nil && *bodyJSON.ConsentID == consent.ID) &&
+			!(consent.PreConfiguration.Valid && revokedConfigs[consent.PreConfiguration.Int64]) {
+			continue
+		}
+
+		if err = revokeUserOpenIDConnectConsent(ctx, consent); err != nil {
+			ctx.Error(err, messageOperationFailed)
+
+			return
+		}
+
+		revoked++
+	}
+
+	if revoked == 0 {
+		ctx.Error(fmt.Errorf("user '%s' does not have any matching consents for client with id '%s'", userSession.Username, bodyJSON.ClientID), messageOperationFailed)
+
+		return
+	}
+
+	ctx.Logger.Debugf("User '%s' revoked %d consents for client with id '%s'", userSession.Username, revoked, bodyJSON.ClientID)
+
+	ctx.ReplyOK()
+}
+
+func loadUserOpenIDConnectConsents(ctx *middlewares.AutheliaCtx, username string) (consents []model.OAuth2ConsentSession, configs []model.OAuth2ConsentPreConfig, err error) {
+	if consents, err = ctx.Providers.StorageProvider.LoadOAuth2ConsentSessionsByUsername(ctx, username); err != nil {
+		return nil, nil, fmt.Errorf("unable to load the consents of user '%s': %w", username, err)
+	}
+
+	if configs, err = ctx.Providers.StorageProvider.LoadOAuth2ConsentPreConfigurationsByUsername(ctx, username); err != nil {
+		return nil, nil, fmt.Errorf("unable to load the consent pre-configurations of user '%s': %w", username, err)
+	}
+
+	return consents, configs, nil
+}
+
+// revokeUserOpenIDConnectConsent revokes a consent and the authorization codes, access tokens, and refresh tokens which
+// were issued as a result of it.
+func revokeUserOpenIDConnectConsent(ctx *middlewares.AutheliaCtx, consent model.OAuth2ConsentSession) (err error) {
+	for _, sessionType := range []storage.OAuth2SessionType{storage.OAuth2SessionTypeAuthorizeCode, storage.OAuth2SessionTypeAccessToken, storage.OAuth2SessionTypeRefreshToken} {
+		var requestIDs []string
+
+		if requestIDs, err = ctx.Providers.StorageProvider.LoadOAuth2SessionRequestIDsByChallengeID(ctx, sessionType, consent.ChallengeID); err != nil {
+			return err
+		}
+
+		for _, requestID := range requestIDs {
+			if err = ctx.Providers.StorageProvider.RevokeOAuth2SessionByRequestID(ctx, sessionType, requestID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return ctx.Providers.StorageProvider.RevokeOAuth2ConsentSession(ctx, consent.ID)
+}
+
+func userOpenIDConnectConsentClientDescription(ctx *middlewares.AutheliaCtx, clientID string) string {
+	if ctx.Providers.OpenIDConnect == nil {
+		return clientID
+	}
+
+	client, err := ctx.Providers.OpenIDConnect.GetFullClient(ctx, clientID)
+	if err != nil || client.Description == "" {
+		return clientID
+	}
+
+	return client.Description
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+type UserOpenIDConnectConsentsSuite struct {
+	suite.Suite
+	mock *mocks.MockAutheliaCtx
+
+	challengeID uuid.UUID
+	grantedAt   time.Time
+}
+
+func (s *UserOpenIDConnectConsentsSuite) SetupTest() {
+	s.mock = mocks.NewMockAutheliaCtx(s.T())
+	s.challengeID = uuid.MustParse("f0d5c2d4-2e51-4c1b-a2c9-0f6a1e7e6a4b")
+	s.grantedAt = time.Unix(1670000000, 0).UTC()
+
+	userSession := s.mock.Ctx.GetSession()
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = 1
+	err := s.mock.Ctx.SaveSession(userSession)
+	require.NoError(s.T(), err)
+}
+
+func (s *UserOpenIDConnectConsentsSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *UserOpenIDConnectConsentsSuite) expectLoad(consents []model.OAuth2ConsentSession, configs []model.OAuth2ConsentPreConfig) {
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadOAuth2ConsentSessionsByUsername(s.mock.Ctx, gomock.Eq(testUsername)).
+			Return(consents, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadOAuth2ConsentPreConfigurationsByUsername(s.mock.Ctx, gomock.Eq(testUsername)).
+			Return(configs, nil),
+	)
+}
+
+func (s *UserOpenIDConnectConsentsSuite) consents() []model.OAuth2ConsentSession {
+	return []model.OAuth2ConsentSession{
+		{
+			ID:               1,
+			ChallengeID:      s.challengeID,
+			ClientID:         "app",
+			Authorized:       true,
+			Granted:          true,
+			RespondedAt:      sql.NullTime{Time: s.grantedAt, Valid: true},
+			GrantedScopes:    model.StringSlicePipeDelimited{"openid", "profile"},
+			GrantedAudience:  model.StringSlicePipeDelimited{"app"},
+			PreConfiguration: sql.NullInt64{Int64: 5, Valid: true},
+		},
+		{
+			ID:              2,
+			ChallengeID:     uuid.MustParse("e3b7a2f8-95c3-4a27-9e1b-2e0d0f4c8d11"),
+			ClientID:        "other",
+			Authorized:      true,
+			Granted:         true,
+			GrantedScopes:   model.StringSlicePipeDelimited{"openid"},
+			GrantedAudience: model.StringSlicePipeDelimited{"other"},
+		},
+	}
+}
+
+func (s *UserOpenIDConnectConsentsSuite) configs() []model.OAuth2ConsentPreConfig {
+	return []model.OAuth2ConsentPreConfig{
+		{
+			ID:        5,
+			ClientID:  "app",
+			CreatedAt: s.grantedAt,
+			Scopes:    model.StringSlicePipeDelimited{"openid", "profile"},
+			Audience:  model.StringSlicePipeDelimited{"app"},
+		},
+	}
+}
+
+func (s *UserOpenIDConnectConsentsSuite) TestShouldListConsentsGroupedByClient() {
+	s.expectLoad(s.consents(), s.configs())
+
+	UserOpenIDConnectConsentsGET(s.mock.Ctx)
+
+	grantedAt := s.grantedAt
+
+	s.mock.Assert200OK(s.T(), []oidc.UserConsentsClient{
+		{
+			ClientID:          "app",
+			ClientDescription: "app",
+			Consents: []oidc.UserConsent{
+				{ID: 1, Scopes: []string{"openid", "profile"}, Audience: []string{"app"}, GrantedAt: &grantedAt},
+			},
+			PreConfigurations: []oidc.UserConsentPreConfiguration{
+				{ID: 5, Scopes: []string{"openid", "profile"}, Audience: []string{"app"}, CreatedAt: s.grantedAt},
+			},
+		},
+		{
+			ClientID:          "other",
+			ClientDescription: "other",
+			Consents: []oidc.UserConsent{
+				{ID: 2, Scopes: []string{"openid"}, Audience: []string{"other"}},
+			},
+			PreConfigurations: []oidc.UserConsentPreConfiguration{},
+		},
+	})
+}
+
+func (s *UserOpenIDConnectConsentsSuite) TestShouldReturnErrorWhenListFails() {
+	s.mock.StorageMock.EXPECT().
+		LoadOAuth2ConsentSessionsByUsername(s.mock.Ctx, gomock.Eq(testUsername)).
+		Return(nil, fmt.Errorf("failure"))
+
+	UserOpenIDConnectConsentsGET(s.mock.Ctx)
+
+	s.mock.Assert200KO(s.T(), "Operation failed.")
+	assert.Equal(s.T(), "unable to load the consents of user 'john': failure", s.mock.Hook.LastEntry().Message)
+	assert.Equal(s.T(), logrus.ErrorLevel, s.mock.Hook.LastEntry().Level)
+}
+
+func (s *UserOpenIDConnectConsentsSuite) TestShouldRevokePreConfigurationAndLinkedConsents() {
+	s.mock.Ctx.Request.SetBody([]byte(`{"client_id":"app","pre_configuration_id":5}`))
+
+	s.expectLoad(s.consents(), s.configs())
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			RevokeOAuth2ConsentPreConfiguration(s.mock.Ctx, gomock.Eq(int64(5))).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			LoadOAuth2SessionRequestIDsByChallengeID(s.mock.Ctx, gomock.Eq(storage.OAuth2SessionTypeAuthorizeCode), gomock.Eq(s.challengeID)).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadOAuth2SessionRequestIDsByChallengeID(s.mock.Ctx, gomock.Eq(storage.OAuth2SessionTypeAccessToken), gomock.Eq(s.challengeID)).
+			Return([]string{"req-1"}, nil),
+		s.mock.StorageMock.EXPECT().
+			RevokeOAuth2SessionByRequestID(s.mock.Ctx, gomock.Eq(storage.OAuth2SessionTypeAccessToken), gomock.Eq("req-1")).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			LoadOAuth2SessionRequestIDsByChallengeID(s.mock.Ctx, gomock.Eq(storage.OAuth2SessionTypeRefreshToken), gomock.Eq(s.challengeID)).
+			Return([]string{"req-1"}, nil),
+		s.mock.StorageMock.EXPECT().
+			RevokeOAuth2SessionByRequestID(s.mock.Ctx, gomock.Eq(storage.OAuth2SessionTypeRefreshToken), gomock.Eq("req-1")).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			RevokeOAuth2ConsentSession(s.mock.Ctx, gomock.Eq(1)).
+			Return(nil),
+	)
+
+	UserOpenIDConnectConsentsDELETE(s.mock.Ctx)
+
+	assert.Equal(s.T(), 200, s.mock.Ctx.Response.StatusCode())
+	assert.Equal(s.T(), `{"status":"OK"}`, string(s.mock.Ctx.Response.Body()))
+}
+
+func (s *UserOpenIDConnectConsentsSuite) TestShouldNotRevokeConsentsOfOtherUsers() {
+	s.mock.Ctx.Request.SetBody([]byte(`{"client_id":"app","consent_id":42}`))
+
+	s.expectLoad(s.consents(), s.configs())
+
+	UserOpenIDConnectConsentsDELETE(s.mock.Ctx)
+
+	s.mock.Assert200KO(s.T(), "Operation failed.")
+	assert.Equal(s.T(), "user 'john' does not have any matching consents for client with id 'app'", s.mock.Hook.LastEntry().Message)
+}
+
+func (s *UserOpenIDConnectConsentsSuite) TestShouldReturnErrorWhenNoClientIDProvided() {
+	s.mock.Ctx.Request.SetBody([]byte(`{"consent_id":1}`))
+
+	UserOpenIDConnectConsentsDELETE(s.mock.Ctx)
+
+	s.mock.Assert200KO(s.T(), "Operation failed.")
+	assert.Equal(s.T(), "unable to validate body: client_id: non zero value required", s.mock.Hook.LastEntry().Message)
+}
+
+func TestUserOpenIDConnectConsentsSuite(t *testing.T) {
+	suite.Run(t, &UserOpenIDConnectConsentsSuite{})
+}
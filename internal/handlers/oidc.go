@@ -1,13 +1,46 @@
 package handlers
 
 import (
+	"net/url"
+	"strings"
+
 	"github.com/ory/fosite"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
+// oidcClientRequiredLevel returns the authorization.Level the user requires to authorize the client, taking into account
+// the user, their groups, and their remote IP when the client uses an authorization policy.
+func oidcClientRequiredLevel(ctx *middlewares.AutheliaCtx, client *oidc.Client, userSession *session.UserSession) (level authorization.Level) {
+	if ctx.Providers.Authorizer == nil {
+		return client.Policy
+	}
+
+	subject := authorization.Subject{
+		Username: userSession.Username,
+		Groups:   userSession.Groups,
+		IP:       ctx.RemoteIP(),
+	}
+
+	return ctx.Providers.Authorizer.GetRequiredLevelOpenIDConnect(client.AuthorizationPolicy, client.Policy, subject)
+}
+
+// isOIDCClientAuthenticationLevelSufficient returns true if the authentication level of the user is sufficient to
+// authorize the client.
+func isOIDCClientAuthenticationLevelSufficient(ctx *middlewares.AutheliaCtx, client *oidc.Client, userSession *session.UserSession) bool {
+	if userSession.AuthenticationLevel == authentication.NotAuthenticated {
+		return false
+	}
+
+	return authorization.IsAuthLevelSufficient(userSession.AuthenticationLevel, oidcClientRequiredLevel(ctx, client, userSession))
+}
+
 func oidcGrantRequests(ar fosite.AuthorizeRequester, consent *model.OAuth2ConsentSession, userSession *session.UserSession) (extraClaims map[string]any) {
 	extraClaims = map[string]any{}
 
@@ -43,3 +76,108 @@ func oidcGrantRequests(ar fosite.AuthorizeRequester, consent *model.OAuth2Consen
 
 	return extraClaims
 }
+
+// oidcGrantCustomClaims adds the custom claims from the claims policy of the client which are released by the granted
+// scopes to the extra claims of the ID Token, and returns the custom claims which are only released via the UserInfo
+// endpoint. The user details are only retrieved from the user provider when there are custom claims to release.
+func oidcGrantCustomClaims(ctx *middlewares.AutheliaCtx, client *oidc.Client, consent *model.OAuth2ConsentSession, userSession *session.UserSession, extraClaims map[string]any) (userinfo map[string]any, err error) {
+	claims := ctx.Providers.OpenIDConnect.GetCustomClaims(client, consent.GrantedScopes)
+
+	if len(claims) == 0 {
+		return nil, nil
+	}
+
+	var details *authentication.UserDetails
+
+	if details, err = ctx.Providers.UserProvider.GetDetails(userSession.Username); err != nil {
+		return nil, err
+	}
+
+	idToken, userinfo := oidc.ResolveCustomClaims(claims, details)
+
+	for claim, value := range idToken {
+		extraClaims[claim] = value
+	}
+
+	return userinfo, nil
+}
+
+// oidcGrantClaimsRequests adds the claims requested via the claims parameter which the client is permitted to receive
+// to the extra claims of the ID Token, and returns the requested claims which are only released via the UserInfo
+// endpoint. The client is permitted to receive the standard claims of the scopes it's allowed to request and the custom
+// claims of its claims policy.
+func oidcGrantClaimsRequests(ctx *middlewares.AutheliaCtx, client *oidc.Client, consent *model.OAuth2ConsentSession, userSession *session.UserSession, extraClaims map[string]any) (userinfo map[string]any, err error) {
+	var (
+		form     url.Values
+		requests *oidc.ClaimsRequests
+	)
+
+	if form, err = consent.GetForm(); err != nil {
+		return nil, err
+	}
+
+	if requests, err = oidc.NewClaimsRequests(form); err != nil || requests == nil {
+		return nil, err
+	}
+
+	if !requests.MatchesSubject(consent.Subject.UUID.String()) {
+		return nil, oidc.ErrClaimsRequestSubjectMismatch
+	}
+
+	available := oidcGrantRequests(nil, &model.OAuth2ConsentSession{GrantedScopes: client.Scopes}, userSession)
+
+	names, _ := requests.Names()
+
+	var claims []oidc.CustomClaim
+
+	for _, claim := range ctx.Providers.OpenIDConnect.GetClaimsPolicyClaims(client) {
+		if utils.IsStringInSlice(claim.Name, names) {
+			claims = append(claims, claim)
+		}
+	}
+
+	if len(claims) != 0 {
+		var details *authentication.UserDetails
+
+		if details, err = ctx.Providers.UserProvider.GetDetails(userSession.Username); err != nil {
+			return nil, err
+		}
+
+		idToken, custom := oidc.ResolveCustomClaims(claims, details)
+
+		for _, values := range []map[string]any{idToken, custom} {
+			for claim, value := range values {
+				available[claim] = value
+			}
+		}
+	}
+
+	idToken, userinfo, unsatisfied := requests.Resolve(available)
+
+	if len(unsatisfied) != 0 {
+		ctx.Logger.Debugf("Claims Request on client with id '%s' for user '%s' could not satisfy the essential claims: %s", client.GetID(), userSession.Username, strings.Join(unsatisfied, ", "))
+	}
+
+	for claim, value := range idToken {
+		extraClaims[claim] = value
+	}
+
+	return userinfo, nil
+}
+
+// oidcBackChannelLogout sends an OpenID Connect Back-Channel Logout notification to every client the provided
+// session has been authenticated to.
+func oidcBackChannelLogout(ctx *middlewares.AutheliaCtx, userSession *session.UserSession) {
+	if ctx.Providers.OpenIDConnect == nil || len(userSession.OpenIDConnectClients) == 0 {
+		return
+	}
+
+	issuer, err := ctx.IssuerURL()
+	if err != nil {
+		ctx.Logger.Errorf("Back-Channel Logout for user '%s' could not be processed: error occurred determining issuer: %+v", userSession.Username, err)
+
+		return
+	}
+
+	ctx.Providers.OpenIDConnect.BackChannelLogout(ctx, issuer.String(), userSession.OpenIDConnectSessionID, userSession.OpenIDConnectClients)
+}
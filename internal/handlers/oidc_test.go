@@ -1,11 +1,17 @@
 package handlers
 
 import (
+	"net/url"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
@@ -106,6 +112,160 @@ func TestShouldGrantAppropriateClaimsForScopeOpenIDAndProfile(t *testing.T) {
 	assert.Equal(t, extraClaims[oidc.ClaimFullName], "Fred Smith")
 }
 
+func TestShouldGrantAppropriateCustomClaims(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	mock.Ctx.Providers.OpenIDConnect = &oidc.OpenIDConnectProvider{
+		Store: oidc.NewOpenIDConnectStore(&schema.OpenIDConnectConfiguration{
+			ClaimsPolicies: []schema.OpenIDConnectClaimsPolicy{
+				{
+					Name:    "tenant",
+					IDToken: []string{"tenant_id"},
+					CustomClaims: []schema.OpenIDConnectCustomClaim{
+						{Name: "tenant_id", Attribute: "tenant"},
+						{Name: "phone_number", Attribute: "phone"},
+					},
+				},
+			},
+			Scopes: []schema.OpenIDConnectScope{
+				{Name: "tenant", Claims: []string{"tenant_id", "phone_number"}},
+			},
+		}, nil),
+	}
+
+	client := &oidc.Client{ID: "example", ClaimsPolicy: "tenant"}
+
+	consent := &model.OAuth2ConsentSession{
+		GrantedScopes: []string{oidc.ScopeOpenID, oidc.ScopeProfile},
+	}
+
+	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJohn)
+
+	userinfo, err := oidcGrantCustomClaims(mock.Ctx, client, consent, &oidcUserSessionJohn, extraClaims)
+
+	require.NoError(t, err)
+	assert.Nil(t, userinfo)
+	assert.Len(t, extraClaims, 2)
+
+	consent.GrantedScopes = append(consent.GrantedScopes, "tenant")
+
+	mock.UserProviderMock.EXPECT().
+		GetDetails("john").
+		Return(&authentication.UserDetails{
+			Username: "john",
+			Extra: map[string]any{
+				"tenant": "example",
+				"phone":  "+1 555 0100",
+			},
+		}, nil)
+
+	userinfo, err = oidcGrantCustomClaims(mock.Ctx, client, consent, &oidcUserSessionJohn, extraClaims)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"phone_number": "+1 555 0100"}, userinfo)
+	assert.Len(t, extraClaims, 3)
+	assert.Equal(t, "example", extraClaims["tenant_id"])
+}
+
+func TestShouldGrantAppropriateClaimsRequests(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	mock.Ctx.Providers.OpenIDConnect = &oidc.OpenIDConnectProvider{
+		Store: oidc.NewOpenIDConnectStore(&schema.OpenIDConnectConfiguration{
+			ClaimsPolicies: []schema.OpenIDConnectClaimsPolicy{
+				{
+					Name: "tenant",
+					CustomClaims: []schema.OpenIDConnectCustomClaim{
+						{Name: "tenant_id", Attribute: "tenant"},
+					},
+				},
+			},
+		}, nil),
+	}
+
+	subject := uuid.MustParse("c2f8ab6e-ab5f-4dd8-95cc-ac0a8d4fe2c2")
+
+	client := &oidc.Client{ID: "example", ClaimsPolicy: "tenant", Scopes: []string{oidc.ScopeOpenID, oidc.ScopeEmail}}
+
+	consent := &model.OAuth2ConsentSession{
+		Subject:       uuid.NullUUID{UUID: subject, Valid: true},
+		GrantedScopes: []string{oidc.ScopeOpenID},
+		Form: url.Values{oidc.FormParameterClaims: []string{
+			`{"id_token":{"email":{"essential":true},"tenant_id":null,"name":null},"userinfo":{"groups":null,"tenant_id":{"value":"other"}}}`,
+		}}.Encode(),
+	}
+
+	mock.UserProviderMock.EXPECT().
+		GetDetails("john").
+		Return(&authentication.UserDetails{
+			Username: "john",
+			Extra: map[string]any{
+				"tenant": "example",
+			},
+		}, nil)
+
+	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJohn)
+
+	userinfo, err := oidcGrantClaimsRequests(mock.Ctx, client, consent, &oidcUserSessionJohn, extraClaims)
+
+	require.NoError(t, err)
+	assert.Len(t, userinfo, 0)
+	assert.Equal(t, map[string]any{"email": "j.smith@authelia.com", "tenant_id": "example"}, extraClaims)
+
+	consent.Form = url.Values{oidc.FormParameterClaims: []string{`{"id_token":{"sub":{"value":"another"}}}`}}.Encode()
+
+	userinfo, err = oidcGrantClaimsRequests(mock.Ctx, client, consent, &oidcUserSessionJohn, extraClaims)
+
+	assert.Nil(t, userinfo)
+	assert.EqualError(t, err, "access_denied")
+}
+
+func TestShouldDetermineClientRequiredLevelFromAuthorizationPolicy(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	config := &schema.Configuration{
+		IdentityProviders: schema.IdentityProvidersConfiguration{
+			OIDC: &schema.OpenIDConnectConfiguration{
+				AuthorizationPolicies: []schema.OpenIDConnectAuthorizationPolicy{
+					{
+						Name:          "admins",
+						DefaultPolicy: "deny",
+						Rules: []schema.OpenIDConnectAuthorizationPolicyRule{
+							{Policy: "two_factor", Subjects: [][]string{{"group:admins"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(config)
+	mock.Ctx.Request.Header.Set("X-Forwarded-For", "10.0.0.1")
+
+	client := oidc.NewClient(schema.OpenIDConnectClientConfiguration{ID: "test", Policy: "admins"})
+
+	admin := session.UserSession{Username: "john", Groups: []string{"admins"}, AuthenticationLevel: authentication.OneFactor}
+	user := session.UserSession{Username: "harry", Groups: []string{"users"}, AuthenticationLevel: authentication.TwoFactor}
+
+	assert.Equal(t, authorization.TwoFactor, oidcClientRequiredLevel(mock.Ctx, client, &admin))
+	assert.False(t, isOIDCClientAuthenticationLevelSufficient(mock.Ctx, client, &admin))
+
+	admin.AuthenticationLevel = authentication.TwoFactor
+
+	assert.True(t, isOIDCClientAuthenticationLevelSufficient(mock.Ctx, client, &admin))
+
+	assert.Equal(t, authorization.Denied, oidcClientRequiredLevel(mock.Ctx, client, &user))
+	assert.False(t, isOIDCClientAuthenticationLevelSufficient(mock.Ctx, client, &user))
+
+	client = oidc.NewClient(schema.OpenIDConnectClientConfiguration{ID: "test", Policy: "one_factor"})
+
+	assert.Equal(t, authorization.OneFactor, oidcClientRequiredLevel(mock.Ctx, client, &user))
+	assert.True(t, isOIDCClientAuthenticationLevelSufficient(mock.Ctx, client, &user))
+}
+
 var (
 	oidcUserSessionJohn = session.UserSession{
 		Username:    "john",
@@ -195,7 +195,7 @@ func handleOIDCWorkflowResponseWithID(ctx *middlewares.AutheliaCtx, id string) {
 		return
 	}
 
-	if client, err = ctx.Providers.OpenIDConnect.GetFullClient(consent.ClientID); err != nil {
+	if client, err = ctx.Providers.OpenIDConnect.GetFullClient(ctx, consent.ClientID); err != nil {
 		ctx.Error(fmt.Errorf("unable to get client for client with id '%s' with consent challenge id '%s': %w", id, consent.ChallengeID, err), messageAuthenticationFailed)
 
 		return
@@ -209,7 +209,7 @@ func handleOIDCWorkflowResponseWithID(ctx *middlewares.AutheliaCtx, id string) {
 		return
 	}
 
-	if !client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel) {
+	if oidcClientRequiredLevel(ctx, client, &userSession) != authorization.Denied && !isOIDCClientAuthenticationLevelSufficient(ctx, client, &userSession) {
 		ctx.Logger.Warnf("OpenID Connect client '%s' requires 2FA, cannot be redirected yet", client.ID)
 		ctx.ReplyOK()
 
@@ -227,16 +227,30 @@ func handleOIDCWorkflowResponseWithID(ctx *middlewares.AutheliaCtx, id string) {
 		return
 	}
 
-	if form, err = consent.GetForm(); err != nil {
-		ctx.Error(fmt.Errorf("unable to get authorization form values from consent session with challenge id '%s': %w", consent.ChallengeID, err), messageAuthenticationFailed)
+	var verification *oidcDeviceVerification
+
+	if verification, err = getOIDCDeviceVerification(ctx, consent); err != nil {
+		ctx.Error(fmt.Errorf("unable to get device authorization request for consent session with challenge id '%s': %w", consent.ChallengeID, err), messageAuthenticationFailed)
 
 		return
 	}
 
-	form.Set(queryArgConsentID, workflowID.String())
+	if verification != nil {
+		// Device Authorization Requests are only ever approved by the user explicitly accepting the consent request.
+		targetURL.Path = path.Join(targetURL.Path, oidc.EndpointPathConsent)
+		targetURL.RawQuery = url.Values{queryArgID: []string{consent.ChallengeID.String()}}.Encode()
+	} else {
+		if form, err = consent.GetForm(); err != nil {
+			ctx.Error(fmt.Errorf("unable to get authorization form values from consent session with challenge id '%s': %w", consent.ChallengeID, err), messageAuthenticationFailed)
 
-	targetURL.Path = path.Join(targetURL.Path, oidc.EndpointPathAuthorization)
-	targetURL.RawQuery = form.Encode()
+			return
+		}
+
+		form.Set(queryArgConsentID, workflowID.String())
+
+		targetURL.Path = path.Join(targetURL.Path, oidc.EndpointPathAuthorization)
+		targetURL.RawQuery = form.Encode()
+	}
 
 	if err = ctx.SetJSONBody(redirectResponse{Redirect: targetURL.String()}); err != nil {
 		ctx.Logger.Errorf("Unable to set default redirection URL in body: %s", err)
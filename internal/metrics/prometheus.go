@@ -25,6 +25,7 @@ type Prometheus struct {
 	reqVerifyCounter *prometheus.CounterVec
 	auth1FACounter   *prometheus.CounterVec
 	auth2FACounter   *prometheus.CounterVec
+	cleanupCounter   *prometheus.CounterVec
 }
 
 // RecordRequest takes the statusCode string, requestMethod string, and the elapsed time.Duration to record the request and request duration metrics.
@@ -53,6 +54,11 @@ func (r *Prometheus) RecordAuthenticationDuration(success bool, elapsed time.Dur
 	r.authDuration.WithLabelValues(strconv.FormatBool(success)).Observe(elapsed.Seconds())
 }
 
+// RecordStorageCleanup takes the table string and the number of rows purged from it to record the storage cleanup metrics.
+func (r *Prometheus) RecordStorageCleanup(table string, purged int64) {
+	r.cleanupCounter.WithLabelValues(table).Add(float64(purged))
+}
+
 func (r *Prometheus) register() {
 	r.authDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -109,4 +115,13 @@ func (r *Prometheus) register() {
 		},
 		[]string{"success", "banned", "type"},
 	)
+
+	r.cleanupCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "authelia",
+			Name:      "storage_cleanup_purged",
+			Help:      "The number of rows purged from the storage by the cleanup.",
+		},
+		[]string{"table"},
+	)
 }
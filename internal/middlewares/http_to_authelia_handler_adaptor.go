@@ -82,6 +82,7 @@ func NewHTTPToAutheliaHandlerAdaptor(h AutheliaHandlerFunc) RequestHandler {
 		r.ContentLength = int64(len(body))
 		r.Host = string(ctx.Host())
 		r.RemoteAddr = ctx.RemoteAddr().String()
+		r.TLS = ctx.TLSConnectionState()
 
 		hdr := make(http.Header)
 		ctx.Request.Header.VisitAll(func(k, v []byte) {
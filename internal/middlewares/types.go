@@ -40,6 +40,7 @@ type Providers struct {
 	NTP             *ntp.Provider
 	UserProvider    authentication.UserProvider
 	StorageProvider storage.Provider
+	StorageCleaner  *storage.Cleaner
 	Notifier        notification.Notifier
 	Templates       *templates.Provider
 	TOTP            totp.Provider
@@ -111,6 +111,20 @@ func (mr *MockStorageMockRecorder) ConsumeIdentityVerification(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeIdentityVerification", reflect.TypeOf((*MockStorage)(nil).ConsumeIdentityVerification), arg0, arg1, arg2)
 }
 
+// ConsumeOAuth2DeviceCodeSession mocks base method.
+func (m *MockStorage) ConsumeOAuth2DeviceCodeSession(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeOAuth2DeviceCodeSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeOAuth2DeviceCodeSession indicates an expected call of ConsumeOAuth2DeviceCodeSession.
+func (mr *MockStorageMockRecorder) ConsumeOAuth2DeviceCodeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOAuth2DeviceCodeSession", reflect.TypeOf((*MockStorage)(nil).ConsumeOAuth2DeviceCodeSession), arg0, arg1)
+}
+
 // DeactivateOAuth2Session mocks base method.
 func (m *MockStorage) DeactivateOAuth2Session(arg0 context.Context, arg1 storage.OAuth2SessionType, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -139,6 +153,34 @@ func (mr *MockStorageMockRecorder) DeactivateOAuth2SessionByRequestID(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateOAuth2SessionByRequestID", reflect.TypeOf((*MockStorage)(nil).DeactivateOAuth2SessionByRequestID), arg0, arg1, arg2)
 }
 
+// DeleteOAuth2Client mocks base method.
+func (m *MockStorage) DeleteOAuth2Client(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuth2Client", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOAuth2Client indicates an expected call of DeleteOAuth2Client.
+func (mr *MockStorageMockRecorder) DeleteOAuth2Client(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuth2Client", reflect.TypeOf((*MockStorage)(nil).DeleteOAuth2Client), arg0, arg1)
+}
+
+// DeleteOAuth2IssuerKey mocks base method.
+func (m *MockStorage) DeleteOAuth2IssuerKey(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuth2IssuerKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOAuth2IssuerKey indicates an expected call of DeleteOAuth2IssuerKey.
+func (mr *MockStorageMockRecorder) DeleteOAuth2IssuerKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuth2IssuerKey", reflect.TypeOf((*MockStorage)(nil).DeleteOAuth2IssuerKey), arg0, arg1)
+}
+
 // DeletePreferredDuoDevice mocks base method.
 func (m *MockStorage) DeletePreferredDuoDevice(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -225,6 +267,21 @@ func (mr *MockStorageMockRecorder) LoadAuthenticationLogs(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogs", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogs), arg0, arg1, arg2, arg3, arg4)
 }
 
+// LoadOAuth2BackChannelLogoutsPending mocks base method.
+func (m *MockStorage) LoadOAuth2BackChannelLogoutsPending(arg0 context.Context, arg1 int) ([]model.OAuth2BackChannelLogout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2BackChannelLogoutsPending", arg0, arg1)
+	ret0, _ := ret[0].([]model.OAuth2BackChannelLogout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2BackChannelLogoutsPending indicates an expected call of LoadOAuth2BackChannelLogoutsPending.
+func (mr *MockStorageMockRecorder) LoadOAuth2BackChannelLogoutsPending(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2BackChannelLogoutsPending", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2BackChannelLogoutsPending), arg0, arg1)
+}
+
 // LoadOAuth2BlacklistedJTI mocks base method.
 func (m *MockStorage) LoadOAuth2BlacklistedJTI(arg0 context.Context, arg1 string) (*model.OAuth2BlacklistedJTI, error) {
 	m.ctrl.T.Helper()
@@ -240,6 +297,36 @@ func (mr *MockStorageMockRecorder) LoadOAuth2BlacklistedJTI(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2BlacklistedJTI", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2BlacklistedJTI), arg0, arg1)
 }
 
+// LoadOAuth2Client mocks base method.
+func (m *MockStorage) LoadOAuth2Client(arg0 context.Context, arg1 string) (*model.OAuth2Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2Client", arg0, arg1)
+	ret0, _ := ret[0].(*model.OAuth2Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2Client indicates an expected call of LoadOAuth2Client.
+func (mr *MockStorageMockRecorder) LoadOAuth2Client(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2Client", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2Client), arg0, arg1)
+}
+
+// LoadOAuth2Clients mocks base method.
+func (m *MockStorage) LoadOAuth2Clients(arg0 context.Context, arg1, arg2 int) ([]model.OAuth2Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2Clients", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.OAuth2Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2Clients indicates an expected call of LoadOAuth2Clients.
+func (mr *MockStorageMockRecorder) LoadOAuth2Clients(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2Clients", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2Clients), arg0, arg1, arg2)
+}
+
 // LoadOAuth2ConsentPreConfigurations mocks base method.
 func (m *MockStorage) LoadOAuth2ConsentPreConfigurations(arg0 context.Context, arg1 string, arg2 uuid.UUID) (*storage.ConsentPreConfigRows, error) {
 	m.ctrl.T.Helper()
@@ -255,6 +342,21 @@ func (mr *MockStorageMockRecorder) LoadOAuth2ConsentPreConfigurations(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2ConsentPreConfigurations", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2ConsentPreConfigurations), arg0, arg1, arg2)
 }
 
+// LoadOAuth2ConsentPreConfigurationsByUsername mocks base method.
+func (m *MockStorage) LoadOAuth2ConsentPreConfigurationsByUsername(arg0 context.Context, arg1 string) ([]model.OAuth2ConsentPreConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2ConsentPreConfigurationsByUsername", arg0, arg1)
+	ret0, _ := ret[0].([]model.OAuth2ConsentPreConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2ConsentPreConfigurationsByUsername indicates an expected call of LoadOAuth2ConsentPreConfigurationsByUsername.
+func (mr *MockStorageMockRecorder) LoadOAuth2ConsentPreConfigurationsByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2ConsentPreConfigurationsByUsername", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2ConsentPreConfigurationsByUsername), arg0, arg1)
+}
+
 // LoadOAuth2ConsentSessionByChallengeID mocks base method.
 func (m *MockStorage) LoadOAuth2ConsentSessionByChallengeID(arg0 context.Context, arg1 uuid.UUID) (*model.OAuth2ConsentSession, error) {
 	m.ctrl.T.Helper()
@@ -270,6 +372,111 @@ func (mr *MockStorageMockRecorder) LoadOAuth2ConsentSessionByChallengeID(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2ConsentSessionByChallengeID", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2ConsentSessionByChallengeID), arg0, arg1)
 }
 
+// LoadOAuth2ConsentSessionsByUsername mocks base method.
+func (m *MockStorage) LoadOAuth2ConsentSessionsByUsername(arg0 context.Context, arg1 string) ([]model.OAuth2ConsentSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2ConsentSessionsByUsername", arg0, arg1)
+	ret0, _ := ret[0].([]model.OAuth2ConsentSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2ConsentSessionsByUsername indicates an expected call of LoadOAuth2ConsentSessionsByUsername.
+func (mr *MockStorageMockRecorder) LoadOAuth2ConsentSessionsByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2ConsentSessionsByUsername", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2ConsentSessionsByUsername), arg0, arg1)
+}
+
+// LoadOAuth2DeviceCodeSession mocks base method.
+func (m *MockStorage) LoadOAuth2DeviceCodeSession(arg0 context.Context, arg1 string) (*model.OAuth2DeviceCodeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2DeviceCodeSession", arg0, arg1)
+	ret0, _ := ret[0].(*model.OAuth2DeviceCodeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2DeviceCodeSession indicates an expected call of LoadOAuth2DeviceCodeSession.
+func (mr *MockStorageMockRecorder) LoadOAuth2DeviceCodeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2DeviceCodeSession", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2DeviceCodeSession), arg0, arg1)
+}
+
+// LoadOAuth2DeviceCodeSessionByChallengeID mocks base method.
+func (m *MockStorage) LoadOAuth2DeviceCodeSessionByChallengeID(arg0 context.Context, arg1 uuid.UUID) (*model.OAuth2DeviceCodeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2DeviceCodeSessionByChallengeID", arg0, arg1)
+	ret0, _ := ret[0].(*model.OAuth2DeviceCodeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2DeviceCodeSessionByChallengeID indicates an expected call of LoadOAuth2DeviceCodeSessionByChallengeID.
+func (mr *MockStorageMockRecorder) LoadOAuth2DeviceCodeSessionByChallengeID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2DeviceCodeSessionByChallengeID", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2DeviceCodeSessionByChallengeID), arg0, arg1)
+}
+
+// LoadOAuth2DeviceCodeSessionByUserCode mocks base method.
+func (m *MockStorage) LoadOAuth2DeviceCodeSessionByUserCode(arg0 context.Context, arg1 string) (*model.OAuth2DeviceCodeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2DeviceCodeSessionByUserCode", arg0, arg1)
+	ret0, _ := ret[0].(*model.OAuth2DeviceCodeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2DeviceCodeSessionByUserCode indicates an expected call of LoadOAuth2DeviceCodeSessionByUserCode.
+func (mr *MockStorageMockRecorder) LoadOAuth2DeviceCodeSessionByUserCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2DeviceCodeSessionByUserCode", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2DeviceCodeSessionByUserCode), arg0, arg1)
+}
+
+// LoadOAuth2IssuerKeys mocks base method.
+func (m *MockStorage) LoadOAuth2IssuerKeys(arg0 context.Context) ([]model.OAuth2IssuerKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2IssuerKeys", arg0)
+	ret0, _ := ret[0].([]model.OAuth2IssuerKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2IssuerKeys indicates an expected call of LoadOAuth2IssuerKeys.
+func (mr *MockStorageMockRecorder) LoadOAuth2IssuerKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2IssuerKeys", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2IssuerKeys), arg0)
+}
+
+// LoadOAuth2PARContext mocks base method.
+func (m *MockStorage) LoadOAuth2PARContext(arg0 context.Context, arg1 string) (*model.OAuth2PARContext, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2PARContext", arg0, arg1)
+	ret0, _ := ret[0].(*model.OAuth2PARContext)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2PARContext indicates an expected call of LoadOAuth2PARContext.
+func (mr *MockStorageMockRecorder) LoadOAuth2PARContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2PARContext", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2PARContext), arg0, arg1)
+}
+
+// LoadOAuth2RefreshTokenSessionRotatedAt mocks base method.
+func (m *MockStorage) LoadOAuth2RefreshTokenSessionRotatedAt(arg0 context.Context, arg1 string, arg2 time.Time) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2RefreshTokenSessionRotatedAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2RefreshTokenSessionRotatedAt indicates an expected call of LoadOAuth2RefreshTokenSessionRotatedAt.
+func (mr *MockStorageMockRecorder) LoadOAuth2RefreshTokenSessionRotatedAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2RefreshTokenSessionRotatedAt", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2RefreshTokenSessionRotatedAt), arg0, arg1, arg2)
+}
+
 // LoadOAuth2Session mocks base method.
 func (m *MockStorage) LoadOAuth2Session(arg0 context.Context, arg1 storage.OAuth2SessionType, arg2 string) (*model.OAuth2Session, error) {
 	m.ctrl.T.Helper()
@@ -285,6 +492,21 @@ func (mr *MockStorageMockRecorder) LoadOAuth2Session(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2Session", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2Session), arg0, arg1, arg2)
 }
 
+// LoadOAuth2SessionRequestIDsByChallengeID mocks base method.
+func (m *MockStorage) LoadOAuth2SessionRequestIDsByChallengeID(arg0 context.Context, arg1 storage.OAuth2SessionType, arg2 uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2SessionRequestIDsByChallengeID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2SessionRequestIDsByChallengeID indicates an expected call of LoadOAuth2SessionRequestIDsByChallengeID.
+func (mr *MockStorageMockRecorder) LoadOAuth2SessionRequestIDsByChallengeID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2SessionRequestIDsByChallengeID", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2SessionRequestIDsByChallengeID), arg0, arg1, arg2)
+}
+
 // LoadPreferred2FAMethod mocks base method.
 func (m *MockStorage) LoadPreferred2FAMethod(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -435,6 +657,168 @@ func (mr *MockStorageMockRecorder) LoadWebauthnDevicesByUsername(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadWebauthnDevicesByUsername", reflect.TypeOf((*MockStorage)(nil).LoadWebauthnDevicesByUsername), arg0, arg1)
 }
 
+// PurgeAuthenticationLogs mocks base method.
+func (m *MockStorage) PurgeAuthenticationLogs(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAuthenticationLogs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeAuthenticationLogs indicates an expected call of PurgeAuthenticationLogs.
+func (mr *MockStorageMockRecorder) PurgeAuthenticationLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAuthenticationLogs", reflect.TypeOf((*MockStorage)(nil).PurgeAuthenticationLogs), arg0, arg1)
+}
+
+// PurgeIdentityVerifications mocks base method.
+func (m *MockStorage) PurgeIdentityVerifications(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeIdentityVerifications", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeIdentityVerifications indicates an expected call of PurgeIdentityVerifications.
+func (mr *MockStorageMockRecorder) PurgeIdentityVerifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeIdentityVerifications", reflect.TypeOf((*MockStorage)(nil).PurgeIdentityVerifications), arg0, arg1)
+}
+
+// PurgeOAuth2BackChannelLogouts mocks base method.
+func (m *MockStorage) PurgeOAuth2BackChannelLogouts(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOAuth2BackChannelLogouts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeOAuth2BackChannelLogouts indicates an expected call of PurgeOAuth2BackChannelLogouts.
+func (mr *MockStorageMockRecorder) PurgeOAuth2BackChannelLogouts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOAuth2BackChannelLogouts", reflect.TypeOf((*MockStorage)(nil).PurgeOAuth2BackChannelLogouts), arg0, arg1)
+}
+
+// PurgeOAuth2BlacklistedJTIs mocks base method.
+func (m *MockStorage) PurgeOAuth2BlacklistedJTIs(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOAuth2BlacklistedJTIs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeOAuth2BlacklistedJTIs indicates an expected call of PurgeOAuth2BlacklistedJTIs.
+func (mr *MockStorageMockRecorder) PurgeOAuth2BlacklistedJTIs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOAuth2BlacklistedJTIs", reflect.TypeOf((*MockStorage)(nil).PurgeOAuth2BlacklistedJTIs), arg0, arg1)
+}
+
+// PurgeOAuth2ConsentSessions mocks base method.
+func (m *MockStorage) PurgeOAuth2ConsentSessions(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOAuth2ConsentSessions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeOAuth2ConsentSessions indicates an expected call of PurgeOAuth2ConsentSessions.
+func (mr *MockStorageMockRecorder) PurgeOAuth2ConsentSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOAuth2ConsentSessions", reflect.TypeOf((*MockStorage)(nil).PurgeOAuth2ConsentSessions), arg0, arg1)
+}
+
+// PurgeOAuth2DeviceCodeSessions mocks base method.
+func (m *MockStorage) PurgeOAuth2DeviceCodeSessions(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOAuth2DeviceCodeSessions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeOAuth2DeviceCodeSessions indicates an expected call of PurgeOAuth2DeviceCodeSessions.
+func (mr *MockStorageMockRecorder) PurgeOAuth2DeviceCodeSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOAuth2DeviceCodeSessions", reflect.TypeOf((*MockStorage)(nil).PurgeOAuth2DeviceCodeSessions), arg0, arg1)
+}
+
+// PurgeOAuth2PARContexts mocks base method.
+func (m *MockStorage) PurgeOAuth2PARContexts(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOAuth2PARContexts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeOAuth2PARContexts indicates an expected call of PurgeOAuth2PARContexts.
+func (mr *MockStorageMockRecorder) PurgeOAuth2PARContexts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOAuth2PARContexts", reflect.TypeOf((*MockStorage)(nil).PurgeOAuth2PARContexts), arg0, arg1)
+}
+
+// PurgeOAuth2Sessions mocks base method.
+func (m *MockStorage) PurgeOAuth2Sessions(arg0 context.Context, arg1 storage.OAuth2SessionType, arg2 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOAuth2Sessions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeOAuth2Sessions indicates an expected call of PurgeOAuth2Sessions.
+func (mr *MockStorageMockRecorder) PurgeOAuth2Sessions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOAuth2Sessions", reflect.TypeOf((*MockStorage)(nil).PurgeOAuth2Sessions), arg0, arg1, arg2)
+}
+
+// RevokeOAuth2ConsentPreConfiguration mocks base method.
+func (m *MockStorage) RevokeOAuth2ConsentPreConfiguration(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOAuth2ConsentPreConfiguration", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOAuth2ConsentPreConfiguration indicates an expected call of RevokeOAuth2ConsentPreConfiguration.
+func (mr *MockStorageMockRecorder) RevokeOAuth2ConsentPreConfiguration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOAuth2ConsentPreConfiguration", reflect.TypeOf((*MockStorage)(nil).RevokeOAuth2ConsentPreConfiguration), arg0, arg1)
+}
+
+// RevokeOAuth2ConsentSession mocks base method.
+func (m *MockStorage) RevokeOAuth2ConsentSession(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOAuth2ConsentSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOAuth2ConsentSession indicates an expected call of RevokeOAuth2ConsentSession.
+func (mr *MockStorageMockRecorder) RevokeOAuth2ConsentSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOAuth2ConsentSession", reflect.TypeOf((*MockStorage)(nil).RevokeOAuth2ConsentSession), arg0, arg1)
+}
+
+// RevokeOAuth2PARContext mocks base method.
+func (m *MockStorage) RevokeOAuth2PARContext(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOAuth2PARContext", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOAuth2PARContext indicates an expected call of RevokeOAuth2PARContext.
+func (mr *MockStorageMockRecorder) RevokeOAuth2PARContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOAuth2PARContext", reflect.TypeOf((*MockStorage)(nil).RevokeOAuth2PARContext), arg0, arg1)
+}
+
 // RevokeOAuth2Session mocks base method.
 func (m *MockStorage) RevokeOAuth2Session(arg0 context.Context, arg1 storage.OAuth2SessionType, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -491,6 +875,20 @@ func (mr *MockStorageMockRecorder) SaveIdentityVerification(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdentityVerification", reflect.TypeOf((*MockStorage)(nil).SaveIdentityVerification), arg0, arg1)
 }
 
+// SaveOAuth2BackChannelLogout mocks base method.
+func (m *MockStorage) SaveOAuth2BackChannelLogout(arg0 context.Context, arg1 model.OAuth2BackChannelLogout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOAuth2BackChannelLogout", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOAuth2BackChannelLogout indicates an expected call of SaveOAuth2BackChannelLogout.
+func (mr *MockStorageMockRecorder) SaveOAuth2BackChannelLogout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2BackChannelLogout", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2BackChannelLogout), arg0, arg1)
+}
+
 // SaveOAuth2BlacklistedJTI mocks base method.
 func (m *MockStorage) SaveOAuth2BlacklistedJTI(arg0 context.Context, arg1 model.OAuth2BlacklistedJTI) error {
 	m.ctrl.T.Helper()
@@ -505,6 +903,20 @@ func (mr *MockStorageMockRecorder) SaveOAuth2BlacklistedJTI(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2BlacklistedJTI", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2BlacklistedJTI), arg0, arg1)
 }
 
+// SaveOAuth2Client mocks base method.
+func (m *MockStorage) SaveOAuth2Client(arg0 context.Context, arg1 model.OAuth2Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOAuth2Client", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOAuth2Client indicates an expected call of SaveOAuth2Client.
+func (mr *MockStorageMockRecorder) SaveOAuth2Client(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2Client", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2Client), arg0, arg1)
+}
+
 // SaveOAuth2ConsentPreConfiguration mocks base method.
 func (m *MockStorage) SaveOAuth2ConsentPreConfiguration(arg0 context.Context, arg1 model.OAuth2ConsentPreConfig) (int64, error) {
 	m.ctrl.T.Helper()
@@ -576,6 +988,48 @@ func (mr *MockStorageMockRecorder) SaveOAuth2ConsentSessionSubject(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2ConsentSessionSubject", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2ConsentSessionSubject), arg0, arg1)
 }
 
+// SaveOAuth2DeviceCodeSession mocks base method.
+func (m *MockStorage) SaveOAuth2DeviceCodeSession(arg0 context.Context, arg1 model.OAuth2DeviceCodeSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOAuth2DeviceCodeSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOAuth2DeviceCodeSession indicates an expected call of SaveOAuth2DeviceCodeSession.
+func (mr *MockStorageMockRecorder) SaveOAuth2DeviceCodeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2DeviceCodeSession", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2DeviceCodeSession), arg0, arg1)
+}
+
+// SaveOAuth2IssuerKey mocks base method.
+func (m *MockStorage) SaveOAuth2IssuerKey(arg0 context.Context, arg1 model.OAuth2IssuerKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOAuth2IssuerKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOAuth2IssuerKey indicates an expected call of SaveOAuth2IssuerKey.
+func (mr *MockStorageMockRecorder) SaveOAuth2IssuerKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2IssuerKey", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2IssuerKey), arg0, arg1)
+}
+
+// SaveOAuth2PARContext mocks base method.
+func (m *MockStorage) SaveOAuth2PARContext(arg0 context.Context, arg1 model.OAuth2PARContext) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOAuth2PARContext", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOAuth2PARContext indicates an expected call of SaveOAuth2PARContext.
+func (mr *MockStorageMockRecorder) SaveOAuth2PARContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOAuth2PARContext", reflect.TypeOf((*MockStorage)(nil).SaveOAuth2PARContext), arg0, arg1)
+}
+
 // SaveOAuth2Session mocks base method.
 func (m *MockStorage) SaveOAuth2Session(arg0 context.Context, arg1 storage.OAuth2SessionType, arg2 model.OAuth2Session) error {
 	m.ctrl.T.Helper()
@@ -806,6 +1260,105 @@ func (mr *MockStorageMockRecorder) StartupCheck() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartupCheck", reflect.TypeOf((*MockStorage)(nil).StartupCheck))
 }
 
+// UpdateOAuth2BackChannelLogoutAttempt mocks base method.
+func (m *MockStorage) UpdateOAuth2BackChannelLogoutAttempt(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2BackChannelLogoutAttempt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOAuth2BackChannelLogoutAttempt indicates an expected call of UpdateOAuth2BackChannelLogoutAttempt.
+func (mr *MockStorageMockRecorder) UpdateOAuth2BackChannelLogoutAttempt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2BackChannelLogoutAttempt", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2BackChannelLogoutAttempt), arg0, arg1, arg2)
+}
+
+// UpdateOAuth2BackChannelLogoutLease mocks base method.
+func (m *MockStorage) UpdateOAuth2BackChannelLogoutLease(arg0 context.Context, arg1 string, arg2 int, arg3 time.Time, arg4 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2BackChannelLogoutLease", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOAuth2BackChannelLogoutLease indicates an expected call of UpdateOAuth2BackChannelLogoutLease.
+func (mr *MockStorageMockRecorder) UpdateOAuth2BackChannelLogoutLease(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2BackChannelLogoutLease", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2BackChannelLogoutLease), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpdateOAuth2Client mocks base method.
+func (m *MockStorage) UpdateOAuth2Client(arg0 context.Context, arg1 model.OAuth2Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2Client", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOAuth2Client indicates an expected call of UpdateOAuth2Client.
+func (mr *MockStorageMockRecorder) UpdateOAuth2Client(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2Client", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2Client), arg0, arg1)
+}
+
+// UpdateOAuth2DeviceCodeSession mocks base method.
+func (m *MockStorage) UpdateOAuth2DeviceCodeSession(arg0 context.Context, arg1 model.OAuth2DeviceCodeSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2DeviceCodeSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOAuth2DeviceCodeSession indicates an expected call of UpdateOAuth2DeviceCodeSession.
+func (mr *MockStorageMockRecorder) UpdateOAuth2DeviceCodeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2DeviceCodeSession", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2DeviceCodeSession), arg0, arg1)
+}
+
+// UpdateOAuth2DeviceCodeSessionChallengeID mocks base method.
+func (m *MockStorage) UpdateOAuth2DeviceCodeSessionChallengeID(arg0 context.Context, arg1 string, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2DeviceCodeSessionChallengeID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOAuth2DeviceCodeSessionChallengeID indicates an expected call of UpdateOAuth2DeviceCodeSessionChallengeID.
+func (mr *MockStorageMockRecorder) UpdateOAuth2DeviceCodeSessionChallengeID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2DeviceCodeSessionChallengeID", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2DeviceCodeSessionChallengeID), arg0, arg1, arg2)
+}
+
+// UpdateOAuth2DeviceCodeSessionCheckedAt mocks base method.
+func (m *MockStorage) UpdateOAuth2DeviceCodeSessionCheckedAt(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2DeviceCodeSessionCheckedAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOAuth2DeviceCodeSessionCheckedAt indicates an expected call of UpdateOAuth2DeviceCodeSessionCheckedAt.
+func (mr *MockStorageMockRecorder) UpdateOAuth2DeviceCodeSessionCheckedAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2DeviceCodeSessionCheckedAt", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2DeviceCodeSessionCheckedAt), arg0, arg1, arg2)
+}
+
+// UpdateOAuth2DeviceCodeSessionStatus mocks base method.
+func (m *MockStorage) UpdateOAuth2DeviceCodeSessionStatus(arg0 context.Context, arg1 string, arg2 model.OAuth2DeviceCodeStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2DeviceCodeSessionStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOAuth2DeviceCodeSessionStatus indicates an expected call of UpdateOAuth2DeviceCodeSessionStatus.
+func (mr *MockStorageMockRecorder) UpdateOAuth2DeviceCodeSessionStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2DeviceCodeSessionStatus", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2DeviceCodeSessionStatus), arg0, arg1, arg2)
+}
+
 // UpdateTOTPConfigurationSignIn mocks base method.
 func (m *MockStorage) UpdateTOTPConfigurationSignIn(arg0 context.Context, arg1 int, arg2 sql.NullTime) error {
 	m.ctrl.T.Helper()
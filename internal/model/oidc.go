@@ -130,6 +130,7 @@ type OAuth2ConsentSession struct {
 
 	Authorized bool `db:"authorized"`
 	Granted    bool `db:"granted"`
+	Revoked    bool `db:"revoked"`
 
 	RequestedAt time.Time    `db:"requested_at"`
 	RespondedAt sql.NullTime `db:"responded_at"`
@@ -207,6 +208,220 @@ type OAuth2BlacklistedJTI struct {
 	ExpiresAt time.Time `db:"expires_at"`
 }
 
+// NewOAuth2BackChannelLogout creates a new OAuth2BackChannelLogout which is leased until the provided time.
+func NewOAuth2BackChannelLogout(jti, issuer, clientID, subject, sessionID, uri, token string, lease time.Time) (logout OAuth2BackChannelLogout) {
+	return OAuth2BackChannelLogout{
+		JTI:            jti,
+		Issuer:         issuer,
+		ClientID:       clientID,
+		Subject:        subject,
+		SessionID:      sessionID,
+		URI:            uri,
+		LogoutToken:    token,
+		CreatedAt:      time.Now(),
+		LeaseExpiresAt: sql.NullTime{Time: lease, Valid: true},
+	}
+}
+
+// OAuth2BackChannelLogout represents an OpenID Connect 1.0 Back-Channel Logout notification sent to a client.
+type OAuth2BackChannelLogout struct {
+	ID          int          `db:"id"`
+	JTI         string       `db:"jti"`
+	Issuer      string       `db:"issuer"`
+	ClientID    string       `db:"client_id"`
+	Subject     string       `db:"subject"`
+	SessionID   string       `db:"session_id"`
+	URI         string       `db:"uri"`
+	LogoutToken string       `db:"logout_token"`
+	CreatedAt   time.Time    `db:"created_at"`
+	AttemptedAt sql.NullTime `db:"attempted_at"`
+	Attempts    int          `db:"attempts"`
+	Delivered   bool         `db:"delivered"`
+
+	// LeaseExpiresAt is the time until which the instance which claimed the OAuth2BackChannelLogout is delivering it.
+	LeaseExpiresAt sql.NullTime `db:"lease_expires_at"`
+}
+
+// OAuth2IssuerKey represents a generated OpenID Connect issuer key managed by the key rotation subsystem. The PrivateKey
+// is the PKCS #8 DER encoded private key which is stored encrypted.
+type OAuth2IssuerKey struct {
+	ID         int       `db:"id"`
+	KeyID      string    `db:"kid"`
+	Algorithm  string    `db:"algorithm"`
+	PrivateKey []byte    `db:"private_key"`
+	CreatedAt  time.Time `db:"created_at"`
+	NotBefore  time.Time `db:"not_before"`
+	NotAfter   time.Time `db:"not_after"`
+}
+
+// NewOAuth2PARContext creates a new OAuth2PARContext given the random portion of a request_uri, a client id, the
+// pushed form values, and the expiration time. The random portion is hashed to form the signature.
+func NewOAuth2PARContext(id, clientID string, form url.Values, exp time.Time) (ctx OAuth2PARContext) {
+	return OAuth2PARContext{
+		Signature:   NewOAuth2PARContextSignature(id),
+		ClientID:    clientID,
+		RequestedAt: time.Now(),
+		ExpiresAt:   exp,
+		Form:        form.Encode(),
+	}
+}
+
+// NewOAuth2PARContextSignature returns the signature used to store a OAuth2PARContext given the random portion of a
+// request_uri.
+func NewOAuth2PARContextSignature(id string) (signature string) {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(id)))
+}
+
+// OAuth2PARContext represents a RFC9126 OAuth 2.0 Pushed Authorization Request.
+type OAuth2PARContext struct {
+	ID          int       `db:"id"`
+	Signature   string    `db:"signature"`
+	ClientID    string    `db:"client_id"`
+	RequestedAt time.Time `db:"requested_at"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	Revoked     bool      `db:"revoked"`
+	Form        string    `db:"form_data"`
+}
+
+// GetForm returns the form of the pushed authorization request.
+func (c *OAuth2PARContext) GetForm() (form url.Values, err error) {
+	return url.ParseQuery(c.Form)
+}
+
+// NewOAuth2ClientRegistrationAccessTokenSignature returns the signature used to store the registration access token of
+// a OAuth2Client.
+func NewOAuth2ClientRegistrationAccessTokenSignature(token string) (signature string) {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+}
+
+// OAuth2Client represents a OAuth 2.0 Client persisted in the database either via the RFC7591 OAuth 2.0 Dynamic Client
+// Registration endpoint or the CLI. The Metadata is the JSON encoded client metadata and the Secret is the encoded digest
+// of the client secret. Clients managed via the CLI have an empty RegistrationAccessTokenSignature and can only be managed
+// via the CLI, and the Policy is empty for clients which use the policy configured for dynamically registered clients.
+type OAuth2Client struct {
+	ID                               int            `db:"id"`
+	ClientID                         string         `db:"client_id"`
+	Secret                           sql.NullString `db:"client_secret"`
+	RegistrationAccessTokenSignature string         `db:"registration_access_token_signature"`
+	Policy                           string         `db:"authorization_policy"`
+	CreatedAt                        time.Time      `db:"created_at"`
+	UpdatedAt                        time.Time      `db:"updated_at"`
+	Metadata                         string         `db:"metadata"`
+}
+
+// NewOAuth2DeviceCodeSession creates a new pending OAuth2DeviceCodeSession given the device code and the normalized
+// user code issued to the client, the fosite.Requester from the Device Authorization Request, and the expiration time.
+// The codes are hashed to form the signatures.
+func NewOAuth2DeviceCodeSession(deviceCode, userCode string, r fosite.Requester, exp time.Time) (session *OAuth2DeviceCodeSession, err error) {
+	var sessionData []byte
+
+	if sessionData, err = json.Marshal(r.GetSession()); err != nil {
+		return nil, err
+	}
+
+	return &OAuth2DeviceCodeSession{
+		RequestID:         r.GetID(),
+		ClientID:          r.GetClient().GetID(),
+		Signature:         NewOAuth2DeviceCodeSignature(deviceCode),
+		UserCodeSignature: NewOAuth2DeviceCodeSignature(userCode),
+		Status:            OAuth2DeviceCodeStatusPending,
+		RequestedAt:       r.GetRequestedAt(),
+		ExpiresAt:         exp,
+		RequestedScopes:   StringSlicePipeDelimited(r.GetRequestedScopes()),
+		GrantedScopes:     StringSlicePipeDelimited{},
+		RequestedAudience: StringSlicePipeDelimited(r.GetRequestedAudience()),
+		GrantedAudience:   StringSlicePipeDelimited{},
+		Form:              r.GetRequestForm().Encode(),
+		Session:           sessionData,
+	}, nil
+}
+
+// NewOAuth2DeviceCodeSignature returns the signature used to store a OAuth2DeviceCodeSession given a device code or a
+// normalized user code.
+func NewOAuth2DeviceCodeSignature(code string) (signature string) {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(code)))
+}
+
+// OAuth2DeviceCodeStatus represents the status of a OAuth2DeviceCodeSession.
+type OAuth2DeviceCodeStatus int
+
+// String returns the string representation of the OAuth2DeviceCodeStatus.
+func (s OAuth2DeviceCodeStatus) String() string {
+	switch s {
+	case OAuth2DeviceCodeStatusPending:
+		return "pending"
+	case OAuth2DeviceCodeStatusApproved:
+		return "approved"
+	case OAuth2DeviceCodeStatusDenied:
+		return "denied"
+	case OAuth2DeviceCodeStatusUsed:
+		return "used"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// OAuth2DeviceCodeStatusPending is the status of a device code the user has not yet responded to.
+	OAuth2DeviceCodeStatusPending OAuth2DeviceCodeStatus = iota
+
+	// OAuth2DeviceCodeStatusApproved is the status of a device code the user has approved.
+	OAuth2DeviceCodeStatusApproved
+
+	// OAuth2DeviceCodeStatusDenied is the status of a device code the user has denied.
+	OAuth2DeviceCodeStatusDenied
+
+	// OAuth2DeviceCodeStatusUsed is the status of a device code which has been exchanged for tokens.
+	OAuth2DeviceCodeStatusUsed
+)
+
+// OAuth2DeviceCodeSession represents a RFC8628 OAuth 2.0 Device Authorization Grant session.
+type OAuth2DeviceCodeSession struct {
+	ID                int                      `db:"id"`
+	ChallengeID       uuid.NullUUID            `db:"challenge_id"`
+	RequestID         string                   `db:"request_id"`
+	ClientID          string                   `db:"client_id"`
+	Signature         string                   `db:"signature"`
+	UserCodeSignature string                   `db:"user_code_signature"`
+	Status            OAuth2DeviceCodeStatus   `db:"status"`
+	Subject           uuid.NullUUID            `db:"subject"`
+	RequestedAt       time.Time                `db:"requested_at"`
+	CheckedAt         sql.NullTime             `db:"checked_at"`
+	ExpiresAt         time.Time                `db:"expires_at"`
+	RequestedScopes   StringSlicePipeDelimited `db:"requested_scopes"`
+	GrantedScopes     StringSlicePipeDelimited `db:"granted_scopes"`
+	RequestedAudience StringSlicePipeDelimited `db:"requested_audience"`
+	GrantedAudience   StringSlicePipeDelimited `db:"granted_audience"`
+	Form              string                   `db:"form_data"`
+	Session           []byte                   `db:"session_data"`
+}
+
+// Approve marks the OAuth2DeviceCodeSession as approved by the user given the consent session and the fosite.Requester
+// which contains the granted scopes, audience, and the session used to issue the tokens.
+func (s *OAuth2DeviceCodeSession) Approve(consent *OAuth2ConsentSession, r fosite.Requester) (err error) {
+	if s.Session, err = json.Marshal(r.GetSession()); err != nil {
+		return err
+	}
+
+	s.ChallengeID = uuid.NullUUID{UUID: consent.ChallengeID, Valid: true}
+	s.Subject = consent.Subject
+	s.Status = OAuth2DeviceCodeStatusApproved
+	s.GrantedScopes = StringSlicePipeDelimited(r.GetGrantedScopes())
+	s.GrantedAudience = StringSlicePipeDelimited(r.GetGrantedAudience())
+
+	return nil
+}
+
+// IsExpired returns true if the device code has expired.
+func (s *OAuth2DeviceCodeSession) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// GetForm returns the form of the device authorization request.
+func (s *OAuth2DeviceCodeSession) GetForm() (form url.Values, err error) {
+	return url.ParseQuery(s.Form)
+}
+
 // OAuth2Session represents a OAuth2.0 session.
 type OAuth2Session struct {
 	ID                int                      `db:"id"`
@@ -272,6 +487,60 @@ type OpenIDSession struct {
 	ClientID    string
 
 	Extra map[string]any `json:"extra"`
+
+	// DPoPJWKThumbprint is the RFC9449 JWK Thumbprint of the key the tokens issued for this session are bound to.
+	DPoPJWKThumbprint string `json:"dpop_jkt,omitempty"`
+
+	// MutualTLSCertificateThumbprint is the RFC8705 thumbprint of the client certificate the tokens issued for this
+	// session are bound to.
+	MutualTLSCertificateThumbprint string `json:"mtls_x5t_s256,omitempty"`
+
+	// ServiceAccount is true when the tokens issued for this session were issued to the service account of a client by
+	// the Client Credentials grant.
+	ServiceAccount bool `json:"service_account,omitempty"`
+}
+
+// GetConfirmation returns the confirmation claim which describes the RFC9449 DPoP key and RFC8705 client certificate the
+// tokens issued for this session are bound to, or nil if they're not bound.
+func (s *OpenIDSession) GetConfirmation() (cnf map[string]any) {
+	if s == nil || (s.DPoPJWKThumbprint == "" && s.MutualTLSCertificateThumbprint == "") {
+		return nil
+	}
+
+	cnf = map[string]any{}
+
+	if s.DPoPJWKThumbprint != "" {
+		cnf["jkt"] = s.DPoPJWKThumbprint
+	}
+
+	if s.MutualTLSCertificateThumbprint != "" {
+		cnf["x5t#S256"] = s.MutualTLSCertificateThumbprint
+	}
+
+	return cnf
+}
+
+// GetExtraClaims implements fosite.ExtraClaimsSession which exposes the claims in the Introspection Response. Only the
+// confirmation claim is exposed when the tokens are bound to a DPoP key or client certificate, and the service account
+// claim when the tokens were issued by the Client Credentials grant.
+func (s *OpenIDSession) GetExtraClaims() (claims map[string]any) {
+	cnf := s.GetConfirmation()
+
+	if cnf == nil && (s == nil || !s.ServiceAccount) {
+		return nil
+	}
+
+	claims = map[string]any{}
+
+	if cnf != nil {
+		claims["cnf"] = cnf
+	}
+
+	if s.ServiceAccount {
+		claims["service_account"] = true
+	}
+
+	return claims
 }
 
 // Clone copies the OpenIDSession to a new fosite.Session.
@@ -0,0 +1,126 @@
+package oidc
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// AuthenticationContextClassReference represents an acr value and the requirements a user must satisfy for it to be
+// issued.
+type AuthenticationContextClassReference struct {
+	Value  string
+	Policy authorization.Level
+	AMR    []string
+}
+
+// NewAuthenticationContextClassReferences creates the AuthenticationContextClassReference values from the
+// configuration.
+func NewAuthenticationContextClassReferences(config []schema.OpenIDConnectACRValue) (acrs []AuthenticationContextClassReference) {
+	for _, value := range config {
+		acrs = append(acrs, AuthenticationContextClassReference{
+			Value:  value.Value,
+			Policy: authorization.StringToLevel(value.Policy),
+			AMR:    value.AMR,
+		})
+	}
+
+	return acrs
+}
+
+// IsSatisfiedBy returns true if the authentication level is sufficient for the policy and all the required RFC8176
+// Authentication Method Reference values are present.
+func (a AuthenticationContextClassReference) IsSatisfiedBy(level authentication.Level, amr []string) bool {
+	if !authorization.IsAuthLevelSufficient(level, a.Policy) {
+		return false
+	}
+
+	for _, value := range a.AMR {
+		if !utils.IsStringInSlice(value, amr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetAuthenticationContextClassReference returns the acr value for a user who authenticated with the given level and
+// RFC8176 Authentication Method Reference values. The values requested via the acr_values parameter are preferred in
+// the order they were requested, otherwise the first configured value the user satisfies is used. An empty string is
+// returned if the user does not satisfy any of the values.
+func (p *OpenIDConnectProvider) GetAuthenticationContextClassReference(level authentication.Level, amr []string, form url.Values) (acr string) {
+	for _, requested := range strings.Fields(form.Get(FormParameterACRValues)) {
+		for _, value := range p.acrValues {
+			if value.Value == requested && value.IsSatisfiedBy(level, amr) {
+				return value.Value
+			}
+		}
+	}
+
+	for _, value := range p.acrValues {
+		if value.IsSatisfiedBy(level, amr) {
+			return value.Value
+		}
+	}
+
+	return ""
+}
+
+// RequestPrompts returns the space delimited values of the prompt parameter of an authorization request form.
+func RequestPrompts(form url.Values) (prompts []string) {
+	return strings.Fields(form.Get(FormParameterPrompt))
+}
+
+// IsPromptNone returns true if the authorization request form has the prompt parameter with the none value.
+func IsPromptNone(form url.Values) bool {
+	return utils.IsStringInSlice(PromptNone, RequestPrompts(form))
+}
+
+// IsReauthenticationRequired returns true if the prompt or max_age parameters of the authorization request form require
+// the user to authenticate again given the time they last authenticated at.
+func IsReauthenticationRequired(form url.Values, authTime, now time.Time) bool {
+	if utils.IsStringInSlice(PromptLogin, RequestPrompts(form)) {
+		return true
+	}
+
+	maxAge, ok := requestMaxAge(form)
+
+	return ok && authTime.Add(maxAge).Before(now)
+}
+
+// IsReauthenticationRequiredSince returns true if the prompt or max_age parameters of the authorization request form
+// made at the requested time still require the user to authenticate again given the time they last authenticated at.
+// Unlike IsReauthenticationRequired the prompt parameter with the login value is satisfied by the user authenticating
+// after the request was made. The requested time is truncated to the second as the time the user authenticated at is
+// only stored with second precision.
+func IsReauthenticationRequiredSince(form url.Values, authTime, requestedAt time.Time) bool {
+	requestedAt = requestedAt.Truncate(time.Second)
+
+	if utils.IsStringInSlice(PromptLogin, RequestPrompts(form)) && authTime.Before(requestedAt) {
+		return true
+	}
+
+	maxAge, ok := requestMaxAge(form)
+
+	return ok && authTime.Add(maxAge).Before(requestedAt)
+}
+
+func requestMaxAge(form url.Values) (maxAge time.Duration, ok bool) {
+	raw := form.Get(FormParameterMaxAge)
+	if raw == "" {
+		return 0, false
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || value < 0 {
+		return 0, false
+	}
+
+	return time.Duration(value) * time.Second, true
+}
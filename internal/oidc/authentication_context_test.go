@@ -0,0 +1,113 @@
+package oidc
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestIsReauthenticationRequired(t *testing.T) {
+	now := time.Unix(1670000000, 0)
+
+	testCases := []struct {
+		name     string
+		form     url.Values
+		authTime time.Time
+		expected bool
+	}{
+		{"ShouldNotRequireWithoutParameters", url.Values{}, now.Add(-time.Hour), false},
+		{"ShouldRequireWithPromptLogin", url.Values{FormParameterPrompt: []string{"login"}}, now, true},
+		{"ShouldRequireWithPromptLoginAndConsent", url.Values{FormParameterPrompt: []string{"consent login"}}, now, true},
+		{"ShouldNotRequireWithPromptConsent", url.Values{FormParameterPrompt: []string{"consent"}}, now.Add(-time.Hour), false},
+		{"ShouldNotRequireWithinMaxAge", url.Values{FormParameterMaxAge: []string{"3600"}}, now.Add(-time.Minute), false},
+		{"ShouldRequireAfterMaxAge", url.Values{FormParameterMaxAge: []string{"60"}}, now.Add(-time.Hour), true},
+		{"ShouldRequireWithMaxAgeZero", url.Values{FormParameterMaxAge: []string{"0"}}, now.Add(-time.Second), true},
+		{"ShouldIgnoreInvalidMaxAge", url.Values{FormParameterMaxAge: []string{"abc"}}, now.Add(-time.Hour), false},
+		{"ShouldIgnoreNegativeMaxAge", url.Values{FormParameterMaxAge: []string{"-1"}}, now.Add(-time.Hour), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsReauthenticationRequired(tc.form, tc.authTime, now))
+		})
+	}
+}
+
+func TestIsReauthenticationRequiredSince(t *testing.T) {
+	requestedAt := time.Unix(1670000000, 500000000)
+
+	testCases := []struct {
+		name     string
+		form     url.Values
+		authTime time.Time
+		expected bool
+	}{
+		{"ShouldNotRequireWithoutParameters", url.Values{}, requestedAt.Add(-time.Hour), false},
+		{"ShouldRequireWithPromptLoginBeforeRequest", url.Values{FormParameterPrompt: []string{"login"}}, requestedAt.Add(-time.Minute), true},
+		{"ShouldNotRequireWithPromptLoginAfterRequest", url.Values{FormParameterPrompt: []string{"login"}}, requestedAt.Add(time.Minute), false},
+		{"ShouldNotRequireWithPromptLoginSameSecond", url.Values{FormParameterPrompt: []string{"login"}}, time.Unix(requestedAt.Unix(), 0), false},
+		{"ShouldNotRequireWithinMaxAge", url.Values{FormParameterMaxAge: []string{"3600"}}, requestedAt.Add(-time.Minute), false},
+		{"ShouldRequireAfterMaxAge", url.Values{FormParameterMaxAge: []string{"60"}}, requestedAt.Add(-time.Hour), true},
+		{"ShouldRequireWithMaxAgeZeroBeforeRequest", url.Values{FormParameterMaxAge: []string{"0"}}, requestedAt.Add(-time.Minute), true},
+		{"ShouldNotRequireWithMaxAgeZeroAfterRequest", url.Values{FormParameterMaxAge: []string{"0"}}, requestedAt.Add(time.Minute), false},
+		{"ShouldIgnoreInvalidMaxAge", url.Values{FormParameterMaxAge: []string{"abc"}}, requestedAt.Add(-time.Hour), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsReauthenticationRequiredSince(tc.form, tc.authTime, requestedAt))
+		})
+	}
+}
+
+func TestIsPromptNone(t *testing.T) {
+	assert.True(t, IsPromptNone(url.Values{FormParameterPrompt: []string{"none"}}))
+	assert.False(t, IsPromptNone(url.Values{FormParameterPrompt: []string{"login consent"}}))
+	assert.False(t, IsPromptNone(url.Values{}))
+}
+
+func TestOpenIDConnectProvider_GetAuthenticationContextClassReference(t *testing.T) {
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+		ACRValues: []schema.OpenIDConnectACRValue{
+			{Value: "phr", Policy: "two_factor", AMR: []string{AMRHardwareSecuredKey}},
+			{Value: "mfa", Policy: "two_factor"},
+			{Value: "pwd", Policy: "one_factor", AMR: []string{AMRPasswordBasedAuthentication}},
+		},
+	}, nil)
+
+	require.NoError(t, err)
+
+	disco := provider.GetOpenIDConnectWellKnownConfiguration("https://example.com")
+
+	assert.Equal(t, []string{"phr", "mfa", "pwd"}, disco.ACRValuesSupported)
+	assert.Contains(t, disco.ClaimsSupported, ClaimAuthenticationContextClassReference)
+
+	testCases := []struct {
+		name     string
+		level    authentication.Level
+		amr      []string
+		form     url.Values
+		expected string
+	}{
+		{"ShouldReturnFirstSatisfiedValue", authentication.TwoFactor, []string{AMRPasswordBasedAuthentication, AMRHardwareSecuredKey}, url.Values{}, "phr"},
+		{"ShouldSkipValuesMissingAMR", authentication.TwoFactor, []string{AMRPasswordBasedAuthentication, AMROneTimePassword}, url.Values{}, "mfa"},
+		{"ShouldSkipValuesWithInsufficientLevel", authentication.OneFactor, []string{AMRPasswordBasedAuthentication}, url.Values{}, "pwd"},
+		{"ShouldPreferRequestedValues", authentication.TwoFactor, []string{AMRPasswordBasedAuthentication, AMRHardwareSecuredKey}, url.Values{FormParameterACRValues: []string{"unknown pwd mfa"}}, "pwd"},
+		{"ShouldIgnoreUnsatisfiedRequestedValues", authentication.OneFactor, []string{AMRPasswordBasedAuthentication}, url.Values{FormParameterACRValues: []string{"phr"}}, "pwd"},
+		{"ShouldReturnEmptyWhenNothingSatisfied", authentication.NotAuthenticated, nil, url.Values{}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, provider.GetAuthenticationContextClassReference(tc.level, tc.amr, tc.form))
+		})
+	}
+}
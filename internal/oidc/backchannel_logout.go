@@ -0,0 +1,236 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ory/fosite/token/jwt"
+
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// NewLogoutToken generates a signed OpenID Connect Back-Channel Logout 1.0 Logout Token for a client using the key for
+// the id_token_signed_response_alg of the client, or the active key of the KeyManager if the client is unknown.
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+func (p *OpenIDConnectProvider) NewLogoutToken(ctx context.Context, issuer, clientID, subject, sid string) (jti, token string, err error) {
+	jti = uuid.New().String()
+
+	if token, err = p.newLogoutToken(ctx, jti, issuer, clientID, subject, sid); err != nil {
+		return "", "", err
+	}
+
+	return jti, token, nil
+}
+
+func (p *OpenIDConnectProvider) newLogoutToken(ctx context.Context, jti, issuer, clientID, subject, sid string) (token string, err error) {
+	var strategy jwt.JWTStrategy
+
+	if p.KeyManager == nil {
+		return "", errors.New("could not generate the logout token as the key manager is not configured")
+	}
+
+	if strategy = p.KeyManager.Strategy(); strategy == nil {
+		return "", errors.New("could not generate the logout token as the key manager has no active key")
+	}
+
+	now := time.Now()
+
+	claims := jwt.MapClaims{
+		ClaimIssuer:         issuer,
+		ClaimAudience:       []string{clientID},
+		ClaimIssuedAt:       now.Unix(),
+		ClaimExpirationTime: now.Add(backChannelLogoutTokenLifespan).Unix(),
+		ClaimJWTID:          jti,
+		ClaimEvents: map[string]any{
+			EventBackChannelLogout: map[string]any{},
+		},
+	}
+
+	if subject != "" {
+		claims[ClaimSubject] = subject
+	}
+
+	if sid != "" {
+		claims[ClaimSessionID] = sid
+	}
+
+	kid := p.KeyManager.GetActiveKeyID()
+
+	if client, err := p.GetFullClient(ctx, clientID); err == nil {
+		kid = p.KeyManager.GetKeyIDFromAlg(client.GetIDTokenSignedResponseAlg())
+	}
+
+	headers := jwtHeaders{
+		JWTHeaderKeyIdentifier: kid,
+		JWTHeaderType:          JWTHeaderTypeLogoutToken,
+	}
+
+	if token, _, err = strategy.Generate(ctx, claims, headers); err != nil {
+		return "", fmt.Errorf("could not generate the logout token: %w", err)
+	}
+
+	return token, nil
+}
+
+// BackChannelLogout notifies every client in the provided list which has a registered back-channel logout uri that the
+// session with the provided sid has ended. Each Logout Token is recorded in storage and is delivered in the background,
+// with every delivery attempt also being recorded.
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#BCRequest
+func (p *OpenIDConnectProvider) BackChannelLogout(ctx context.Context, issuer, sid string, clients []SessionClient) {
+	logger := logging.Logger()
+
+	for _, sc := range clients {
+		client, err := p.GetFullClient(ctx, sc.ClientID)
+		if err != nil {
+			logger.Errorf("Back-Channel Logout for client with id '%s' could not be processed: failed to find client: %+v", sc.ClientID, err)
+
+			continue
+		}
+
+		if client.GetBackChannelLogoutURI() == "" {
+			continue
+		}
+
+		jti, token, err := p.NewLogoutToken(ctx, issuer, client.GetID(), sc.Subject, sid)
+		if err != nil {
+			logger.Errorf("Back-Channel Logout for client with id '%s' could not be processed: %+v", client.GetID(), err)
+
+			continue
+		}
+
+		// The Back-Channel Logout is leased to this instance so it isn't also delivered by another instance resuming it.
+		logout := model.NewOAuth2BackChannelLogout(jti, issuer, client.GetID(), sc.Subject, sid, client.GetBackChannelLogoutURI(), token, time.Now().Add(backChannelLogoutLease))
+
+		if err = p.provider.SaveOAuth2BackChannelLogout(ctx, logout); err != nil {
+			logger.Errorf("Back-Channel Logout for client with id '%s' could not be processed: %+v", client.GetID(), err)
+
+			continue
+		}
+
+		go p.deliverBackChannelLogout(logout)
+	}
+}
+
+// ResumeBackChannelLogouts delivers the Logout Tokens recorded in storage which have neither been delivered nor
+// exhausted their delivery attempts, such as those which were pending when Authelia was stopped. Each one is claimed
+// with a lease before it's delivered so when multiple instances resume the Back-Channel Logouts only one of them
+// delivers each one. The Logout Tokens are reissued with the same jti from the recorded issuer, client, subject, and
+// sid as they may have expired or have been signed by a key which has since been rotated.
+func (p *OpenIDConnectProvider) ResumeBackChannelLogouts(ctx context.Context) {
+	logger := logging.Logger()
+
+	logouts, err := p.provider.LoadOAuth2BackChannelLogoutsPending(ctx, backChannelLogoutMaxAttempts)
+	if err != nil {
+		logger.WithError(err).Error("Error occurred loading the pending Back-Channel Logouts")
+
+		return
+	}
+
+	var claimed bool
+
+	for i := range logouts {
+		logout := logouts[i]
+
+		now := time.Now()
+
+		if claimed, err = p.provider.UpdateOAuth2BackChannelLogoutLease(ctx, logout.JTI, backChannelLogoutMaxAttempts, now, now.Add(backChannelLogoutLease)); err != nil {
+			logger.Errorf("Back-Channel Logout with jti '%s' for client with id '%s' could not be resumed: %+v", logout.JTI, logout.ClientID, err)
+
+			continue
+		}
+
+		if !claimed {
+			logger.Debugf("Back-Channel Logout with jti '%s' for client with id '%s' is not being resumed as it's being delivered by another instance", logout.JTI, logout.ClientID)
+
+			continue
+		}
+
+		if logout.LogoutToken, err = p.newLogoutToken(ctx, logout.JTI, logout.Issuer, logout.ClientID, logout.Subject, logout.SessionID); err != nil {
+			logger.Errorf("Back-Channel Logout with jti '%s' for client with id '%s' could not be resumed: %+v", logout.JTI, logout.ClientID, err)
+
+			continue
+		}
+
+		logger.Debugf("Back-Channel Logout with jti '%s' for client with id '%s' is being resumed after %d attempts", logout.JTI, logout.ClientID, logout.Attempts)
+
+		go p.deliverBackChannelLogout(logout)
+	}
+}
+
+func (p *OpenIDConnectProvider) deliverBackChannelLogout(logout model.OAuth2BackChannelLogout) {
+	var (
+		ctx    = context.Background()
+		logger = logging.Logger()
+		err    error
+	)
+
+	// The client is looked up so the back-channel logout URI of registered clients, or clients which have since been
+	// deleted, is only requested using the restricted client.
+	client, _ := p.Store.GetFullClient(ctx, logout.ClientID)
+
+	httpClient := p.getHTTPClient(client)
+
+	// The attempts made before Authelia was restarted count towards the maximum number of attempts.
+	for attempt := logout.Attempts + 1; attempt <= backChannelLogoutMaxAttempts; attempt++ {
+		err = sendLogoutToken(ctx, httpClient, logout.URI, logout.LogoutToken)
+
+		if errStorage := p.provider.UpdateOAuth2BackChannelLogoutAttempt(ctx, logout.JTI, err == nil); errStorage != nil {
+			logger.Errorf("Back-Channel Logout with jti '%s' for client with id '%s' could not record the delivery attempt: %+v", logout.JTI, logout.ClientID, errStorage)
+		}
+
+		if err == nil {
+			logger.Debugf("Back-Channel Logout with jti '%s' for client with id '%s' was successfully delivered on attempt %d", logout.JTI, logout.ClientID, attempt)
+
+			return
+		}
+
+		logger.Warnf("Back-Channel Logout with jti '%s' for client with id '%s' failed on attempt %d of %d: %+v", logout.JTI, logout.ClientID, attempt, backChannelLogoutMaxAttempts, err)
+
+		if attempt < backChannelLogoutMaxAttempts {
+			time.Sleep(backChannelLogoutRetryInterval * time.Duration(attempt))
+		}
+	}
+
+	logger.Errorf("Back-Channel Logout with jti '%s' for client with id '%s' could not be delivered after %d attempts: %+v", logout.JTI, logout.ClientID, backChannelLogoutMaxAttempts, err)
+}
+
+func sendLogoutToken(ctx context.Context, client *http.Client, uri, token string) (err error) {
+	form := url.Values{}
+
+	form.Set(FormParameterLogoutToken, token)
+
+	var req *http.Request
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, uri, strings.NewReader(form.Encode())); err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var resp *http.Response
+
+	if resp, err = client.Do(req); err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return fmt.Errorf("the back-channel logout uri '%s' responded with status code %d", uri, resp.StatusCode)
+	}
+}
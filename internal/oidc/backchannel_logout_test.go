@@ -0,0 +1,248 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestOpenIDConnectProvider_NewLogoutToken(t *testing.T) {
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:     "a-client",
+				Secret: MustDecodeSecret("$plaintext$a-client-secret"),
+				Policy: "one_factor",
+				RedirectURIs: []string{
+					"https://google.com",
+				},
+				BackChannelLogoutURI: "https://google.com/backchannel-logout",
+			},
+		},
+	}, nil)
+
+	require.NoError(t, err)
+
+	jti, token, err := provider.NewLogoutToken(context.Background(), "https://example.com", "a-client", "a-subject", "a-session")
+
+	require.NoError(t, err)
+	require.NotEmpty(t, jti)
+
+	decoded, err := provider.KeyManager.Strategy().Decode(context.Background(), token)
+
+	require.NoError(t, err)
+
+	assert.Equal(t, JWTHeaderTypeLogoutToken, decoded.Header[JWTHeaderType])
+	assert.Equal(t, provider.KeyManager.GetActiveKeyID(), decoded.Header[JWTHeaderKeyIdentifier])
+
+	assert.Equal(t, "https://example.com", decoded.Claims[ClaimIssuer])
+	assert.Equal(t, []any{"a-client"}, decoded.Claims[ClaimAudience])
+	assert.Equal(t, "a-subject", decoded.Claims[ClaimSubject])
+	assert.Equal(t, "a-session", decoded.Claims[ClaimSessionID])
+	assert.Equal(t, jti, decoded.Claims[ClaimJWTID])
+	assert.Equal(t, map[string]any{EventBackChannelLogout: map[string]any{}}, decoded.Claims[ClaimEvents])
+	assert.NotContains(t, decoded.Claims, ClaimNonce)
+
+	_, token, err = provider.NewLogoutToken(context.Background(), "https://example.com", "a-client", "", "a-session")
+
+	require.NoError(t, err)
+
+	decoded, err = provider.KeyManager.Strategy().Decode(context.Background(), token)
+
+	require.NoError(t, err)
+
+	assert.NotContains(t, decoded.Claims, ClaimSubject)
+	assert.Equal(t, "a-session", decoded.Claims[ClaimSessionID])
+
+	provider.KeyManager = nil
+
+	_, _, err = provider.NewLogoutToken(context.Background(), "https://example.com", "a-client", "a-subject", "a-session")
+
+	assert.EqualError(t, err, "could not generate the logout token as the key manager is not configured")
+}
+
+func TestOpenIDConnectProvider_sendLogoutToken(t *testing.T) {
+	var (
+		contentType string
+		logoutToken string
+		status      = http.StatusOK
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		contentType = req.Header.Get("Content-Type")
+
+		_ = req.ParseForm()
+
+		logoutToken = req.PostForm.Get(FormParameterLogoutToken)
+
+		rw.WriteHeader(status)
+	}))
+
+	defer server.Close()
+
+	require.NoError(t, sendLogoutToken(context.Background(), server.Client(), server.URL, "abc.123.xyz"))
+
+	assert.Equal(t, "application/x-www-form-urlencoded", contentType)
+	assert.Equal(t, "abc.123.xyz", logoutToken)
+
+	status = http.StatusNoContent
+
+	assert.NoError(t, sendLogoutToken(context.Background(), server.Client(), server.URL, "abc.123.xyz"))
+
+	status = http.StatusBadRequest
+
+	assert.EqualError(t, sendLogoutToken(context.Background(), server.Client(), server.URL, "abc.123.xyz"), "the back-channel logout uri '"+server.URL+"' responded with status code 400")
+}
+
+func TestOpenIDConnectProvider_ResumeBackChannelLogouts(t *testing.T) {
+	received := make(chan string, 2)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_ = req.ParseForm()
+
+		received <- req.PostForm.Get(FormParameterLogoutToken)
+
+		rw.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	store := &testBackChannelLogoutStore{attempts: map[string]bool{}}
+
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:                   "a-client",
+				Secret:               MustDecodeSecret("$plaintext$a-client-secret"),
+				Policy:               "one_factor",
+				RedirectURIs:         []string{"https://google.com"},
+				BackChannelLogoutURI: server.URL,
+			},
+		},
+	}, store)
+
+	require.NoError(t, err)
+
+	provider.httpClient = server.Client()
+
+	expired, _, err := provider.KeyManager.Strategy().Generate(context.Background(), jwt.MapClaims{
+		ClaimIssuer:         "https://example.com",
+		ClaimAudience:       []string{"a-client"},
+		ClaimExpirationTime: time.Now().Add(-time.Minute).Unix(),
+		ClaimJWTID:          "expired-jti",
+	}, &jwt.Headers{Extra: map[string]any{JWTHeaderKeyIdentifier: provider.KeyManager.GetActiveKeyID()}})
+
+	require.NoError(t, err)
+
+	store.pending = []model.OAuth2BackChannelLogout{
+		{JTI: "expired-jti", Issuer: "https://example.com", ClientID: "a-client", Subject: "a-subject", SessionID: "a-session", URI: server.URL, LogoutToken: expired, Attempts: 2},
+		{JTI: "rotated-jti", Issuer: "https://example.com", ClientID: "a-client", Subject: "b-subject", SessionID: "b-session", URI: server.URL, LogoutToken: "eyJhbGciOiJSUzI1NiIsImtpZCI6InJvdGF0ZWQifQ.e30.c2ln", Attempts: 1},
+		{JTI: "leased-jti", Issuer: "https://example.com", ClientID: "a-client", Subject: "c-subject", SessionID: "c-session", URI: server.URL, LogoutToken: expired, Attempts: 1},
+	}
+
+	store.leased = map[string]bool{"leased-jti": true}
+
+	provider.ResumeBackChannelLogouts(context.Background())
+
+	tokens := map[string]*jwt.Token{}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case token := <-received:
+			decoded, err := provider.KeyManager.Strategy().Decode(context.Background(), token)
+
+			require.NoError(t, err)
+
+			tokens[decoded.Claims[ClaimJWTID].(string)] = decoded
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for the back-channel logouts to be delivered")
+		}
+	}
+
+	require.Contains(t, tokens, "expired-jti")
+	require.Contains(t, tokens, "rotated-jti")
+
+	assert.Equal(t, "https://example.com", tokens["expired-jti"].Claims[ClaimIssuer])
+	assert.Equal(t, "a-subject", tokens["expired-jti"].Claims[ClaimSubject])
+	assert.Equal(t, "a-session", tokens["expired-jti"].Claims[ClaimSessionID])
+
+	assert.Equal(t, "https://example.com", tokens["rotated-jti"].Claims[ClaimIssuer])
+	assert.Equal(t, "b-subject", tokens["rotated-jti"].Claims[ClaimSubject])
+	assert.Equal(t, "b-session", tokens["rotated-jti"].Claims[ClaimSessionID])
+
+	assert.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		return store.attempts["expired-jti"] && store.attempts["rotated-jti"]
+	}, time.Second*5, time.Millisecond*10)
+
+	select {
+	case token := <-received:
+		t.Fatalf("the back-channel logout leased by another instance was delivered: %s", token)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	assert.Equal(t, backChannelLogoutMaxAttempts, store.loadedAttempts)
+	assert.NotContains(t, store.attempts, "leased-jti")
+}
+
+type testBackChannelLogoutStore struct {
+	storage.Provider
+
+	mu             sync.Mutex
+	pending        []model.OAuth2BackChannelLogout
+	loadedAttempts int
+	attempts       map[string]bool
+	leased         map[string]bool
+}
+
+func (s *testBackChannelLogoutStore) LoadOAuth2BackChannelLogoutsPending(_ context.Context, attempts int) (logouts []model.OAuth2BackChannelLogout, err error) {
+	s.loadedAttempts = attempts
+
+	return s.pending, nil
+}
+
+func (s *testBackChannelLogoutStore) UpdateOAuth2BackChannelLogoutLease(_ context.Context, jti string, _ int, _, _ time.Time) (claimed bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.leased[jti] {
+		return false, nil
+	}
+
+	if s.leased == nil {
+		s.leased = map[string]bool{}
+	}
+
+	s.leased[jti] = true
+
+	return true, nil
+}
+
+func (s *testBackChannelLogoutStore) UpdateOAuth2BackChannelLogoutAttempt(_ context.Context, jti string, delivered bool) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts[jti] = delivered
+
+	return nil
+}
@@ -0,0 +1,298 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewClaimsPolicy converts a schema.OpenIDConnectClaimsPolicy into a *ClaimsPolicy.
+func NewClaimsPolicy(config schema.OpenIDConnectClaimsPolicy) (policy *ClaimsPolicy) {
+	policy = &ClaimsPolicy{
+		IDToken:      config.IDToken,
+		CustomClaims: map[string]string{},
+	}
+
+	for _, claim := range config.CustomClaims {
+		policy.CustomClaims[claim.Name] = claim.Attribute
+	}
+
+	return policy
+}
+
+// NewCustomScopes converts the []schema.OpenIDConnectScope into a map of scope names to the custom claims each scope
+// releases.
+func NewCustomScopes(config []schema.OpenIDConnectScope) (scopes map[string][]string) {
+	scopes = map[string][]string{}
+
+	for _, scope := range config {
+		scopes[scope.Name] = append(scopes[scope.Name], scope.Claims...)
+	}
+
+	return scopes
+}
+
+// GetCustomClaims returns the custom claims from the claims policy of the client which are released by the granted
+// scopes. Custom claims which are not released by any of the granted scopes are not returned.
+func (s *Store) GetCustomClaims(client *Client, scopes []string) (claims []CustomClaim) {
+	if client == nil || client.ClaimsPolicy == "" {
+		return nil
+	}
+
+	policy, ok := s.claimsPolicies[client.ClaimsPolicy]
+	if !ok {
+		return nil
+	}
+
+	var names []string
+
+	for _, scope := range scopes {
+		for _, name := range s.scopes[scope] {
+			if utils.IsStringInSlice(name, names) {
+				continue
+			}
+
+			attribute, ok := policy.CustomClaims[name]
+			if !ok {
+				continue
+			}
+
+			names = append(names, name)
+
+			claims = append(claims, CustomClaim{
+				Name:      name,
+				Attribute: attribute,
+				IDToken:   utils.IsStringInSlice(name, policy.IDToken),
+			})
+		}
+	}
+
+	return claims
+}
+
+// GetClaimsPolicyClaims returns all of the custom claims from the claims policy of the client regardless of the scopes
+// which release them. This is used to determine the custom claims which can be requested via the claims parameter.
+func (s *Store) GetClaimsPolicyClaims(client *Client) (claims []CustomClaim) {
+	if client == nil || client.ClaimsPolicy == "" {
+		return nil
+	}
+
+	policy, ok := s.claimsPolicies[client.ClaimsPolicy]
+	if !ok {
+		return nil
+	}
+
+	for name, attribute := range policy.CustomClaims {
+		claims = append(claims, CustomClaim{
+			Name:      name,
+			Attribute: attribute,
+			IDToken:   utils.IsStringInSlice(name, policy.IDToken),
+		})
+	}
+
+	sort.Slice(claims, func(i, j int) bool {
+		return claims[i].Name < claims[j].Name
+	})
+
+	return claims
+}
+
+// ResolveCustomClaims resolves the values of the provided custom claims from the authentication.UserDetails. The
+// claims which are included in the ID Token are returned separately to those which are only included in the UserInfo
+// response. Claims which map to an attribute the user does not have are omitted.
+func ResolveCustomClaims(claims []CustomClaim, details *authentication.UserDetails) (idToken, userinfo map[string]any) {
+	idToken, userinfo = map[string]any{}, map[string]any{}
+
+	if details == nil {
+		return idToken, userinfo
+	}
+
+	for _, claim := range claims {
+		value, ok := resolveUserAttribute(claim.Attribute, details)
+		if !ok {
+			continue
+		}
+
+		if claim.IDToken {
+			idToken[claim.Name] = value
+		} else {
+			userinfo[claim.Name] = value
+		}
+	}
+
+	return idToken, userinfo
+}
+
+func resolveUserAttribute(attribute string, details *authentication.UserDetails) (value any, ok bool) {
+	switch attribute {
+	case UserAttributeUsername:
+		return details.Username, details.Username != ""
+	case UserAttributeDisplayName:
+		return details.DisplayName, details.DisplayName != ""
+	case UserAttributeEmail:
+		if len(details.Emails) == 0 || details.Emails[0] == "" {
+			return nil, false
+		}
+
+		return details.Emails[0], true
+	case UserAttributeEmails:
+		return details.Emails, len(details.Emails) != 0
+	case UserAttributeGroups:
+		return details.Groups, true
+	default:
+		value, ok = details.Extra[attribute]
+
+		return value, ok
+	}
+}
+
+// appendCustomScopesAndClaims adds the custom scopes and claims from the configuration to the discovery document.
+func appendCustomScopesAndClaims(discovery *OpenIDConnectWellKnownConfiguration, config *schema.OpenIDConnectConfiguration) {
+	for _, scope := range config.Scopes {
+		if !utils.IsStringInSlice(scope.Name, discovery.ScopesSupported) {
+			discovery.ScopesSupported = append(discovery.ScopesSupported, scope.Name)
+		}
+	}
+
+	for _, policy := range config.ClaimsPolicies {
+		for _, claim := range policy.CustomClaims {
+			if !utils.IsStringInSlice(claim.Name, discovery.ClaimsSupported) {
+				discovery.ClaimsSupported = append(discovery.ClaimsSupported, claim.Name)
+			}
+		}
+	}
+}
+
+// NewClaimsRequests parses the claims parameter from the form. Returns nil without an error if the claims parameter is
+// absent.
+func NewClaimsRequests(form url.Values) (requests *ClaimsRequests, err error) {
+	var raw string
+
+	if raw = form.Get(FormParameterClaims); raw == "" {
+		return nil, nil
+	}
+
+	requests = &ClaimsRequests{}
+
+	if err = json.Unmarshal([]byte(raw), requests); err != nil {
+		return nil, ErrClaimsRequestInvalid.WithWrap(err).WithDebug(err.Error())
+	}
+
+	return requests, nil
+}
+
+// Names returns the sorted names of all of the claims requested for either the ID Token or the UserInfo response, and
+// the names of the claims which were requested as essential claims.
+func (r *ClaimsRequests) Names() (claims, essential []string) {
+	if r == nil {
+		return nil, nil
+	}
+
+	for _, requests := range []map[string]*ClaimRequest{r.IDToken, r.UserInfo} {
+		for name, request := range requests {
+			if !utils.IsStringInSlice(name, claims) {
+				claims = append(claims, name)
+			}
+
+			if request != nil && request.Essential && !utils.IsStringInSlice(name, essential) {
+				essential = append(essential, name)
+			}
+		}
+	}
+
+	sort.Strings(claims)
+	sort.Strings(essential)
+
+	return claims, essential
+}
+
+// MatchesSubject returns false if the sub claim was requested with a value which does not match the provided subject.
+func (r *ClaimsRequests) MatchesSubject(subject string) bool {
+	if r == nil {
+		return true
+	}
+
+	for _, requests := range []map[string]*ClaimRequest{r.IDToken, r.UserInfo} {
+		if request, ok := requests[ClaimSubject]; ok && !request.Matches(subject) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Resolve returns the requested claims for the ID Token and the UserInfo response which have a value in the available
+// claims that satisfies the request. The names of essential claims which could not be satisfied are also returned.
+func (r *ClaimsRequests) Resolve(available map[string]any) (idToken, userinfo map[string]any, unsatisfied []string) {
+	idToken, userinfo = map[string]any{}, map[string]any{}
+
+	if r == nil {
+		return idToken, userinfo, nil
+	}
+
+	for _, target := range []struct {
+		requests map[string]*ClaimRequest
+		claims   map[string]any
+	}{{r.IDToken, idToken}, {r.UserInfo, userinfo}} {
+		for name, request := range target.requests {
+			if value, ok := available[name]; ok && request.Matches(value) {
+				target.claims[name] = value
+			} else if request != nil && request.Essential && !utils.IsStringInSlice(name, unsatisfied) {
+				unsatisfied = append(unsatisfied, name)
+			}
+		}
+	}
+
+	sort.Strings(unsatisfied)
+
+	return idToken, userinfo, unsatisfied
+}
+
+// Matches returns true if the value satisfies the value or values of the claim request. A request without a value or
+// values is satisfied by any value. A multi-valued claim satisfies the request if any of its values match.
+func (r *ClaimRequest) Matches(value any) bool {
+	switch {
+	case r == nil:
+		return true
+	case r.Value != nil:
+		return claimValueMatches(r.Value, value)
+	case len(r.Values) != 0:
+		for _, expected := range r.Values {
+			if claimValueMatches(expected, value) {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return true
+	}
+}
+
+func claimValueMatches(expected, value any) bool {
+	switch v := value.(type) {
+	case []string:
+		for _, item := range v {
+			if fmt.Sprint(expected) == item {
+				return true
+			}
+		}
+
+		return false
+	case []any:
+		for _, item := range v {
+			if fmt.Sprint(expected) == fmt.Sprint(item) {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return fmt.Sprint(expected) == fmt.Sprint(value)
+	}
+}
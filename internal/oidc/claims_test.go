@@ -0,0 +1,244 @@
+package oidc
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestStore_GetCustomClaims(t *testing.T) {
+	config := &schema.OpenIDConnectConfiguration{
+		ClaimsPolicies: []schema.OpenIDConnectClaimsPolicy{
+			{
+				Name:    "tenant",
+				IDToken: []string{"tenant_id"},
+				CustomClaims: []schema.OpenIDConnectCustomClaim{
+					{Name: "tenant_id", Attribute: "tenant"},
+					{Name: "phone_number", Attribute: "phone"},
+				},
+			},
+		},
+		Scopes: []schema.OpenIDConnectScope{
+			{Name: "tenant", Claims: []string{"tenant_id", "phone_number"}},
+			{Name: ScopeProfile, Claims: []string{"phone_number"}},
+		},
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:           "with-policy",
+				ClaimsPolicy: "tenant",
+			},
+			{
+				ID: "without-policy",
+			},
+		},
+	}
+
+	s := NewOpenIDConnectStore(config, nil)
+
+	testCases := []struct {
+		name     string
+		client   string
+		scopes   []string
+		expected []CustomClaim
+	}{
+		{
+			"ShouldReturnClaimsForCustomScope",
+			"with-policy",
+			[]string{ScopeOpenID, "tenant"},
+			[]CustomClaim{{Name: "tenant_id", Attribute: "tenant", IDToken: true}, {Name: "phone_number", Attribute: "phone"}},
+		},
+		{
+			"ShouldReturnClaimsForStandardScope",
+			"with-policy",
+			[]string{ScopeOpenID, ScopeProfile},
+			[]CustomClaim{{Name: "phone_number", Attribute: "phone"}},
+		},
+		{
+			"ShouldNotReturnDuplicateClaims",
+			"with-policy",
+			[]string{ScopeProfile, "tenant"},
+			[]CustomClaim{{Name: "phone_number", Attribute: "phone"}, {Name: "tenant_id", Attribute: "tenant", IDToken: true}},
+		},
+		{
+			"ShouldNotReturnClaimsWithoutScope",
+			"with-policy",
+			[]string{ScopeOpenID},
+			nil,
+		},
+		{
+			"ShouldNotReturnClaimsWithoutPolicy",
+			"without-policy",
+			[]string{ScopeOpenID, "tenant"},
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, s.GetCustomClaims(s.clients[tc.client], tc.scopes))
+		})
+	}
+}
+
+func TestResolveCustomClaims(t *testing.T) {
+	claims := []CustomClaim{
+		{Name: "tenant_id", Attribute: "tenant", IDToken: true},
+		{Name: "phone_number", Attribute: "phone"},
+		{Name: "employee_number", Attribute: "employee"},
+		{Name: "login", Attribute: UserAttributeUsername, IDToken: true},
+		{Name: "mail", Attribute: UserAttributeEmail},
+	}
+
+	details := &authentication.UserDetails{
+		Username: "john",
+		Emails:   []string{"john@example.com", "john.doe@example.com"},
+		Extra: map[string]any{
+			"tenant": "abc",
+			"phone":  []string{"+1 555 0100"},
+		},
+	}
+
+	idToken, userinfo := ResolveCustomClaims(claims, details)
+
+	assert.Equal(t, map[string]any{"tenant_id": "abc", "login": "john"}, idToken)
+	assert.Equal(t, map[string]any{"phone_number": []string{"+1 555 0100"}, "mail": "john@example.com"}, userinfo)
+
+	idToken, userinfo = ResolveCustomClaims(claims, nil)
+
+	assert.Len(t, idToken, 0)
+	assert.Len(t, userinfo, 0)
+}
+
+func TestNewOpenIDConnectProvider_ShouldIncludeCustomScopesAndClaimsInDiscovery(t *testing.T) {
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerCertificateChain: schema.X509CertificateChain{},
+		IssuerPrivateKey:       mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:             "asbdhaaskmdlkamdklasmdlkams",
+		ClaimsPolicies: []schema.OpenIDConnectClaimsPolicy{
+			{
+				Name:         "tenant",
+				CustomClaims: []schema.OpenIDConnectCustomClaim{{Name: "tenant_id", Attribute: "tenant"}},
+			},
+		},
+		Scopes: []schema.OpenIDConnectScope{
+			{Name: "tenant", Claims: []string{"tenant_id"}},
+			{Name: ScopeProfile, Claims: []string{"tenant_id"}},
+		},
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:           "a-client",
+				Secret:       MustDecodeSecret("$plaintext$a-client-secret"),
+				RedirectURIs: []string{"https://google.com"},
+				ClaimsPolicy: "tenant",
+			},
+		},
+	}, nil)
+
+	require.NoError(t, err)
+
+	disco := provider.GetOpenIDConnectWellKnownConfiguration("https://example.com")
+
+	assert.Equal(t, []string{ScopeOfflineAccess, ScopeOpenID, ScopeProfile, ScopeGroups, ScopeEmail, "tenant"}, disco.ScopesSupported)
+	assert.Contains(t, disco.ClaimsSupported, "tenant_id")
+}
+
+func TestNewClaimsRequests(t *testing.T) {
+	testCases := []struct {
+		name     string
+		form     url.Values
+		expected *ClaimsRequests
+		err      string
+	}{
+		{
+			"ShouldReturnNilWhenAbsent",
+			url.Values{},
+			nil,
+			"",
+		},
+		{
+			"ShouldParseClaimsRequests",
+			url.Values{FormParameterClaims: []string{`{"id_token":{"tenant_id":{"essential":true},"email":null},"userinfo":{"groups":{"values":["admin","dev"]},"sub":{"value":"abc"}}}`}},
+			&ClaimsRequests{
+				IDToken: map[string]*ClaimRequest{
+					"tenant_id": {Essential: true},
+					"email":     nil,
+				},
+				UserInfo: map[string]*ClaimRequest{
+					"groups": {Values: []any{"admin", "dev"}},
+					"sub":    {Value: "abc"},
+				},
+			},
+			"",
+		},
+		{
+			"ShouldErrorOnInvalidJSON",
+			url.Values{FormParameterClaims: []string{`{"id_token":`}},
+			nil,
+			"invalid_request",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := NewClaimsRequests(tc.form)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, actual)
+			}
+		})
+	}
+}
+
+func TestClaimsRequests(t *testing.T) {
+	requests := &ClaimsRequests{
+		IDToken: map[string]*ClaimRequest{
+			"tenant_id":       {Essential: true},
+			"email":           nil,
+			"employee_number": {Essential: true},
+		},
+		UserInfo: map[string]*ClaimRequest{
+			"groups":      {Values: []any{"admin", "ops"}},
+			"name":        {Value: "Fred"},
+			ClaimSubject:  {Value: "abc"},
+			"tenant_id":   nil,
+			"phone_count": {Value: float64(2)},
+		},
+	}
+
+	claims, essential := requests.Names()
+
+	assert.Equal(t, []string{"email", "employee_number", "groups", "name", "phone_count", "sub", "tenant_id"}, claims)
+	assert.Equal(t, []string{"employee_number", "tenant_id"}, essential)
+
+	assert.True(t, requests.MatchesSubject("abc"))
+	assert.False(t, requests.MatchesSubject("xyz"))
+
+	idToken, userinfo, unsatisfied := requests.Resolve(map[string]any{
+		"tenant_id":   "example",
+		"email":       "john@example.com",
+		"groups":      []string{"admin", "dev"},
+		"name":        "John",
+		"phone_count": 2,
+	})
+
+	assert.Equal(t, map[string]any{"tenant_id": "example", "email": "john@example.com"}, idToken)
+	assert.Equal(t, map[string]any{"groups": []string{"admin", "dev"}, "tenant_id": "example", "phone_count": 2}, userinfo)
+	assert.Equal(t, []string{"employee_number"}, unsatisfied)
+
+	var empty *ClaimsRequests
+
+	claims, essential = empty.Names()
+
+	assert.Nil(t, claims)
+	assert.Nil(t, essential)
+	assert.True(t, empty.MatchesSubject("abc"))
+}
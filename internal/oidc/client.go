@@ -1,12 +1,17 @@
 package oidc
 
 import (
+	"time"
+
+	"github.com/go-crypt/crypt"
 	"github.com/ory/fosite"
+	jose "gopkg.in/square/go-jose.v2"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // NewClient creates a new Client.
@@ -18,16 +23,58 @@ func NewClient(config schema.OpenIDConnectClientConfiguration) (client *Client)
 		SectorIdentifier: config.SectorIdentifier.String(),
 		Public:           config.Public,
 
-		Audience:      config.Audience,
-		Scopes:        config.Scopes,
-		RedirectURIs:  config.RedirectURIs,
-		GrantTypes:    config.GrantTypes,
-		ResponseTypes: config.ResponseTypes,
-		ResponseModes: []fosite.ResponseModeType{fosite.ResponseModeDefault},
+		Audience:               config.Audience,
+		Scopes:                 config.Scopes,
+		RedirectURIs:           config.RedirectURIs,
+		PostLogoutRedirectURIs: config.PostLogoutRedirectURIs,
+		BackChannelLogoutURI:   config.BackChannelLogoutURI,
+		GrantTypes:             config.GrantTypes,
+		ResponseTypes:          config.ResponseTypes,
+		ResponseModes:          []fosite.ResponseModeType{fosite.ResponseModeDefault},
+
+		RequirePushedAuthorizationRequests: config.RequirePushedAuthorizationRequests,
+
+		EnforcePKCE: config.EnforcePKCE,
+
+		Lifespans: config.Lifespans,
+
+		ClientCredentials: ClientClientCredentials{
+			Subject:  config.ClientCredentials.Subject,
+			Audience: config.ClientCredentials.Audience,
+			Scopes:   config.ClientCredentials.Scopes,
+		},
+
+		TokenExchange: ClientTokenExchange{
+			Audience: config.TokenExchange.Audience,
+			Scopes:   config.TokenExchange.Scopes,
+		},
+
+		IDTokenSignedResponseAlg:     config.IDTokenSignedResponseAlg,
+		AccessTokenSignedResponseAlg: config.AccessTokenSignedResponseAlg,
+		UserinfoSigningAlgorithm:     config.UserinfoSigningAlgorithm,
 
-		UserinfoSigningAlgorithm: config.UserinfoSigningAlgorithm,
+		AuthorizationSignedResponseAlg: config.AuthorizationSignedResponseAlg,
 
-		Policy: authorization.StringToLevel(config.Policy),
+		TokenEndpointAuthMethod:     config.TokenEndpointAuthMethod,
+		TokenEndpointAuthSigningAlg: config.TokenEndpointAuthSigningAlg,
+		JSONWebKeysURI:              config.JSONWebKeysURI,
+
+		RequestURIs:                config.RequestURIs,
+		RequestObjectSigningAlg:    config.RequestObjectSigningAlg,
+		RequireSignedRequestObject: config.RequireSignedRequestObject,
+
+		DPoPBoundAccessTokens: config.DPoPBoundAccessTokens,
+
+		TLSClientAuthSubjectDN:                config.TLSClientAuthSubjectDN,
+		TLSClientAuthSANDNS:                   config.TLSClientAuthSANDNS,
+		TLSClientAuthSANURI:                   config.TLSClientAuthSANURI,
+		TLSClientAuthSANIP:                    config.TLSClientAuthSANIP,
+		TLSClientAuthSANEmail:                 config.TLSClientAuthSANEmail,
+		TLSClientCertificateBoundAccessTokens: config.TLSClientCertificateBoundAccessTokens,
+
+		Policy:              authorization.StringToLevel(config.Policy),
+		AuthorizationPolicy: config.Policy,
+		ClaimsPolicy:        config.ClaimsPolicy,
 
 		Consent: NewClientConsent(config.ConsentMode, config.ConsentPreConfiguredDuration),
 	}
@@ -36,6 +83,19 @@ func NewClient(config schema.OpenIDConnectClientConfiguration) (client *Client)
 		client.ResponseModes = append(client.ResponseModes, fosite.ResponseModeType(mode))
 	}
 
+	if len(config.JSONWebKeys) != 0 {
+		client.JSONWebKeys = &jose.JSONWebKeySet{}
+
+		for _, jwk := range config.JSONWebKeys {
+			client.JSONWebKeys.Keys = append(client.JSONWebKeys.Keys, jose.JSONWebKey{
+				Key:       jwk.Key,
+				KeyID:     jwk.KeyID,
+				Algorithm: jwk.Algorithm,
+				Use:       jwk.Use,
+			})
+		}
+	}
+
 	return client
 }
 
@@ -69,6 +129,12 @@ func (c *Client) GetConsentResponseBody(consent *model.OAuth2ConsentSession) Con
 	if consent != nil {
 		body.Scopes = consent.RequestedScopes
 		body.Audience = consent.RequestedAudience
+
+		if form, err := consent.GetForm(); err == nil {
+			if requests, err := NewClaimsRequests(form); err == nil {
+				body.Claims, body.EssentialClaims = requests.Names()
+			}
+		}
 	}
 
 	return body
@@ -83,11 +149,234 @@ func (c *Client) GetHashedSecret() []byte {
 	return []byte(c.Secret.Encode())
 }
 
+// GetSecretPlainText returns the plain text value of the Secret. This is only possible when the Secret is a plaintext
+// digest and is required for the client_secret_jwt client authentication method.
+func (c *Client) GetSecretPlainText() (secret []byte, err error) {
+	var digest *schema.PasswordDigest
+
+	switch d := c.Secret.(type) {
+	case *schema.PasswordDigest:
+		digest = d
+	case *crypt.PlainTextDigest:
+		digest = &schema.PasswordDigest{Digest: d}
+	}
+
+	if !digest.IsPlainText() {
+		return nil, errClientSecretNotPlainText
+	}
+
+	return digest.PlainText()
+}
+
 // GetRedirectURIs returns the RedirectURIs.
 func (c *Client) GetRedirectURIs() []string {
 	return c.RedirectURIs
 }
 
+// GetPostLogoutRedirectURIs returns the PostLogoutRedirectURIs.
+func (c *Client) GetPostLogoutRedirectURIs() []string {
+	return c.PostLogoutRedirectURIs
+}
+
+// IsPostLogoutRedirectURIAllowed returns true if the provided uri exactly matches one of the PostLogoutRedirectURIs.
+func (c *Client) IsPostLogoutRedirectURIAllowed(uri string) bool {
+	return utils.IsStringInSlice(uri, c.PostLogoutRedirectURIs)
+}
+
+// GetBackChannelLogoutURI returns the BackChannelLogoutURI.
+func (c *Client) GetBackChannelLogoutURI() string {
+	return c.BackChannelLogoutURI
+}
+
+// GetRequirePushedAuthorizationRequests returns true if the client must use a Pushed Authorization Request to
+// initiate an Authorization Request.
+func (c *Client) GetRequirePushedAuthorizationRequests() bool {
+	return c.RequirePushedAuthorizationRequests
+}
+
+// GetEnforcePKCE returns the PKCE enforcement of the client and true, or false if the client does not override the
+// global PKCE enforcement.
+func (c *Client) GetEnforcePKCE() (force, forcePublic, ok bool) {
+	switch c.EnforcePKCE {
+	case "always":
+		return true, true, true
+	case "public_clients_only":
+		return false, true, true
+	case "never":
+		return false, false, true
+	default:
+		return false, false, false
+	}
+}
+
+// GetEffectiveLifespan returns the lifespan of a token type issued to the client via a grant type. The lifespan
+// configured for the grant type takes precedence over the lifespan configured for the client, and the fallback is
+// returned if neither are configured.
+func (c *Client) GetEffectiveLifespan(gt string, tt fosite.TokenType, fallback time.Duration) time.Duration {
+	var grant schema.OpenIDConnectLifespans
+
+	switch gt {
+	case GrantTypeAuthorizationCode:
+		grant = c.Lifespans.Grants.AuthorizationCode
+	case GrantTypeImplicit:
+		grant = c.Lifespans.Grants.Implicit
+	case GrantTypeClientCredentials:
+		grant = c.Lifespans.Grants.ClientCredentials
+	case GrantTypeRefreshToken:
+		grant = c.Lifespans.Grants.RefreshToken
+	case GrantTypeDeviceCode:
+		grant = c.Lifespans.Grants.DeviceCode
+	case GrantTypeTokenExchange:
+		grant = c.Lifespans.Grants.TokenExchange
+	}
+
+	if lifespan := getLifespanByTokenType(grant, tt); lifespan > 0 {
+		return lifespan
+	}
+
+	if lifespan := getLifespanByTokenType(c.Lifespans.OpenIDConnectLifespans, tt); lifespan > 0 {
+		return lifespan
+	}
+
+	return fallback
+}
+
+// GetClientCredentialsSubject returns the subject of the tokens issued to the client by the Client Credentials grant,
+// defaulting to the client id prefixed with SubjectPrefixClientCredentials when a service account subject is not
+// configured.
+func (c *Client) GetClientCredentialsSubject() string {
+	if c.ClientCredentials.Subject == "" {
+		return SubjectPrefixClientCredentials + c.ID
+	}
+
+	return c.ClientCredentials.Subject
+}
+
+// IsClientCredentialsAudienceAllowed returns true if the client is permitted to obtain a token for the audience using
+// the Client Credentials grant. All audiences permitted for the client are allowed when no policy is configured.
+func (c *Client) IsClientCredentialsAudienceAllowed(audience string) bool {
+	if len(c.ClientCredentials.Audience) == 0 {
+		return utils.IsStringInSlice(audience, c.Audience)
+	}
+
+	return utils.IsStringInSlice(audience, c.ClientCredentials.Audience)
+}
+
+// IsClientCredentialsScopeAllowed returns true if the client is permitted to obtain a token for the scope using the
+// Client Credentials grant. All scopes permitted for the client are allowed when no policy is configured.
+func (c *Client) IsClientCredentialsScopeAllowed(scope string) bool {
+	if len(c.ClientCredentials.Scopes) == 0 {
+		return fosite.HierarchicScopeStrategy(c.Scopes, scope)
+	}
+
+	return utils.IsStringInSlice(scope, c.ClientCredentials.Scopes)
+}
+
+// IsTokenExchangeAudienceAllowed returns true if the client is permitted to exchange a token for the audience.
+func (c *Client) IsTokenExchangeAudienceAllowed(audience string) bool {
+	return utils.IsStringInSlice(audience, c.TokenExchange.Audience)
+}
+
+// IsTokenExchangeScopeAllowed returns true if the client is permitted to exchange a token for the scope.
+func (c *Client) IsTokenExchangeScopeAllowed(scope string) bool {
+	return utils.IsStringInSlice(scope, c.TokenExchange.Scopes)
+}
+
+// GetIDTokenSignedResponseAlg returns the IDTokenSignedResponseAlg, defaulting to RS256 when it's not configured.
+func (c *Client) GetIDTokenSignedResponseAlg() string {
+	if c.IDTokenSignedResponseAlg == "" {
+		return SigningAlgorithmRSAWithSHA256
+	}
+
+	return c.IDTokenSignedResponseAlg
+}
+
+// GetAuthorizationSignedResponseAlg returns the AuthorizationSignedResponseAlg used to sign JWT Secured Authorization
+// Responses, defaulting to RS256 when it's not configured.
+func (c *Client) GetAuthorizationSignedResponseAlg() string {
+	if c.AuthorizationSignedResponseAlg == "" {
+		return SigningAlgorithmRSAWithSHA256
+	}
+
+	return c.AuthorizationSignedResponseAlg
+}
+
+// GetAccessTokenSignedResponseAlg returns the AccessTokenSignedResponseAlg, defaulting to none when it's not
+// configured. The none value indicates the client is issued opaque access tokens instead of JWT access tokens.
+func (c *Client) GetAccessTokenSignedResponseAlg() string {
+	if c.AccessTokenSignedResponseAlg == "" {
+		return SigningAlgorithmNone
+	}
+
+	return c.AccessTokenSignedResponseAlg
+}
+
+// GetTokenEndpointAuthMethod returns the TokenEndpointAuthMethod. Public clients default to none, confidential
+// clients default to an empty value which permits both client_secret_basic and client_secret_post.
+func (c *Client) GetTokenEndpointAuthMethod() string {
+	if c.TokenEndpointAuthMethod == "" && c.Public {
+		return ClientAuthMethodNone
+	}
+
+	return c.TokenEndpointAuthMethod
+}
+
+// GetTokenEndpointAuthSigningAlg returns the TokenEndpointAuthSigningAlg, defaulting to RS256 for private_key_jwt and
+// HS256 for client_secret_jwt when it's not configured.
+func (c *Client) GetTokenEndpointAuthSigningAlg() string {
+	if c.TokenEndpointAuthSigningAlg != "" {
+		return c.TokenEndpointAuthSigningAlg
+	}
+
+	switch c.TokenEndpointAuthMethod {
+	case ClientAuthMethodPrivateKeyJWT:
+		return SigningAlgorithmRSAWithSHA256
+	case ClientAuthMethodClientSecretJWT:
+		return SigningAlgorithmHMACWithSHA256
+	default:
+		return ""
+	}
+}
+
+// GetJSONWebKeys returns the JSONWebKeys.
+func (c *Client) GetJSONWebKeys() *jose.JSONWebKeySet {
+	return c.JSONWebKeys
+}
+
+// GetJSONWebKeysURI returns the JSONWebKeysURI.
+func (c *Client) GetJSONWebKeysURI() string {
+	return c.JSONWebKeysURI
+}
+
+// GetRequestURIs returns the RequestURIs which are the only request_uri values the client is permitted to use to pass
+// a Request Object by reference.
+func (c *Client) GetRequestURIs() []string {
+	return c.RequestURIs
+}
+
+// GetRequestObjectSigningAlg returns the RequestObjectSigningAlg. An empty value permits any supported algorithm.
+func (c *Client) GetRequestObjectSigningAlg() string {
+	return c.RequestObjectSigningAlg
+}
+
+// GetRequireSignedRequestObject returns true if the client must use a signed Request Object for every Authorization
+// Request.
+func (c *Client) GetRequireSignedRequestObject() bool {
+	return c.RequireSignedRequestObject
+}
+
+// GetDPoPBoundAccessTokens returns true if the client must use DPoP proofs at the token endpoint so every access token
+// issued to it is bound to a DPoP key.
+func (c *Client) GetDPoPBoundAccessTokens() bool {
+	return c.DPoPBoundAccessTokens
+}
+
+// GetTLSClientCertificateBoundAccessTokens returns true if every access token issued to the client is bound to the
+// client certificate it used at the token endpoint.
+func (c *Client) GetTLSClientCertificateBoundAccessTokens() bool {
+	return c.TLSClientCertificateBoundAccessTokens
+}
+
 // GetGrantTypes returns the GrantTypes.
 func (c *Client) GetGrantTypes() fosite.Arguments {
 	if len(c.GrantTypes) == 0 {
@@ -127,3 +416,26 @@ func (c *Client) GetAudience() fosite.Arguments {
 func (c *Client) GetResponseModes() []fosite.ResponseModeType {
 	return c.ResponseModes
 }
+
+// getEffectiveLifespan returns the lifespan of a token type issued to a client via a grant type, or the fallback if the
+// client is not a *Client.
+func getEffectiveLifespan(client fosite.Client, gt string, tt fosite.TokenType, fallback time.Duration) time.Duration {
+	if c, ok := client.(*Client); ok {
+		return c.GetEffectiveLifespan(gt, tt, fallback)
+	}
+
+	return fallback
+}
+
+func getLifespanByTokenType(lifespans schema.OpenIDConnectLifespans, tt fosite.TokenType) time.Duration {
+	switch tt {
+	case fosite.AccessToken:
+		return lifespans.AccessToken
+	case fosite.RefreshToken:
+		return lifespans.RefreshToken
+	case fosite.IDToken:
+		return lifespans.IDToken
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,375 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/ory/x/errorsx"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+// NewClientAuthenticationStrategy creates a new ClientAuthenticationStrategy. The fetcher is used for the jwks_uri of
+// clients from the configuration, and the jwks_uri of registered clients is always fetched using a
+// RestrictedJWKSFetcherStrategy.
+func NewClientAuthenticationStrategy(store *Store, fetcher fosite.JWKSFetcherStrategy, hasher fosite.Hasher, mutualTLS *MutualTLSCertificateResolver) *ClientAuthenticationStrategy {
+	return &ClientAuthenticationStrategy{
+		store:             store,
+		fetcher:           fetcher,
+		registeredFetcher: NewRestrictedJWKSFetcherStrategy(NewRestrictedHTTPClient(jwksTimeout)),
+		hasher:            hasher,
+		mutualTLS:         mutualTLS,
+	}
+}
+
+// AuthenticateClient authenticates a client using the client_secret_basic, client_secret_post, client_secret_jwt,
+// private_key_jwt, tls_client_auth, self_signed_tls_client_auth, or none token endpoint authentication methods. This implements fosite.ClientAuthenticationStrategy.
+func (s *ClientAuthenticationStrategy) AuthenticateClient(ctx context.Context, r *http.Request, form url.Values) (client fosite.Client, err error) {
+	switch assertionType := form.Get(FormParameterClientAssertionType); assertionType {
+	case "":
+		return s.authenticateClientSecret(ctx, r, form)
+	case ClientAssertionTypeJWTBearer:
+		return s.authenticateClientAssertion(ctx, form)
+	default:
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Unknown client_assertion_type '%s'.", assertionType))
+	}
+}
+
+func (s *ClientAuthenticationStrategy) authenticateClientSecret(ctx context.Context, r *http.Request, form url.Values) (client fosite.Client, err error) {
+	var (
+		id, secret, method string
+		c                  *Client
+	)
+
+	if id, secret, method, err = clientCredentialsFromRequest(r, form); err != nil {
+		return nil, err
+	}
+
+	if c, err = s.store.GetFullClient(ctx, id); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	switch expected := c.GetTokenEndpointAuthMethod(); expected {
+	case "", method:
+		break
+	case ClientAuthMethodTLSClientAuth, ClientAuthMethodSelfSignedTLSClientAuth:
+		if method != ClientAuthMethodNone {
+			return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHintf("The OAuth 2.0 Client supports client authentication method '%s', but method '%s' was requested. You must configure the OAuth 2.0 client's 'token_endpoint_auth_method' value to accept '%s'.", expected, method, method))
+		}
+
+		if err = s.authenticateClientCertificate(r, c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	case ClientAuthMethodNone:
+		if secret == "" {
+			break
+		}
+
+		fallthrough
+	default:
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHintf("The OAuth 2.0 Client supports client authentication method '%s', but method '%s' was requested. You must configure the OAuth 2.0 client's 'token_endpoint_auth_method' value to accept '%s'.", expected, method, method))
+	}
+
+	if c.IsPublic() {
+		return c, nil
+	}
+
+	if err = s.hasher.Compare(ctx, c.GetHashedSecret(), []byte(secret)); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return c, nil
+}
+
+func (s *ClientAuthenticationStrategy) authenticateClientAssertion(ctx context.Context, form url.Values) (client fosite.Client, err error) {
+	assertion := form.Get(FormParameterClientAssertion)
+
+	if assertion == "" {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("The client_assertion request parameter must be set when using client_assertion_type of '%s'.", ClientAssertionTypeJWTBearer))
+	}
+
+	var (
+		c     *Client
+		token *jwt.Token
+	)
+
+	clientID := form.Get(FormParameterClientID)
+
+	token, err = jwt.ParseWithClaims(assertion, jwt.MapClaims{}, func(t *jwt.Token) (key any, err error) {
+		if clientID == "" {
+			var ok bool
+
+			if clientID, ok = t.Claims[ClaimSubject].(string); !ok || clientID == "" {
+				return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHint("The claim 'sub' from the client_assertion JSON Web Token is undefined."))
+			}
+		}
+
+		if c, err = s.store.GetFullClient(ctx, clientID); err != nil {
+			return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithWrap(err).WithDebug(err.Error()))
+		}
+
+		return s.getClientAssertionKey(c, t)
+	})
+
+	if err != nil {
+		var e *jwt.ValidationError
+
+		if errors.As(err, &e) {
+			if e.Inner != nil {
+				return nil, e.Inner
+			}
+
+			return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHint("Unable to verify the integrity of the 'client_assertion' value.").WithWrap(err).WithDebug(err.Error()))
+		}
+
+		return nil, err
+	}
+
+	claims := token.Claims
+
+	var (
+		jti     string
+		expires time.Time
+		ok      bool
+	)
+
+	switch {
+	case !claims.VerifyIssuer(clientID, true):
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHint("Claim 'iss' from 'client_assertion' must match the 'client_id' of the OAuth 2.0 Client."))
+	case claims[ClaimSubject] != clientID:
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHint("Claim 'sub' from 'client_assertion' must match the 'client_id' of the OAuth 2.0 Client."))
+	}
+
+	if jti, ok = claims[ClaimJWTID].(string); !ok || jti == "" {
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHint("Claim 'jti' from 'client_assertion' must be set but is not."))
+	}
+
+	if expires, ok = claimTime(claims, ClaimExpirationTime); !ok {
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHint("Claim 'exp' from 'client_assertion' must be set but is not."))
+	}
+
+	if err = s.verifyClientAssertionAudience(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	if err = s.store.ClientAssertionJWTValid(ctx, jti); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrJTIKnown.WithHint("Claim 'jti' from 'client_assertion' MUST only be used once.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if err = s.store.SetClientAssertionJWT(ctx, jti, expires); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return c, nil
+}
+
+func (s *ClientAuthenticationStrategy) verifyClientAssertionAudience(ctx context.Context, claims jwt.MapClaims) (err error) {
+	ictx, ok := ctx.(issuerContext)
+	if !ok {
+		return errorsx.WithStack(fosite.ErrMisconfiguration.WithHint("The authorization server's token endpoint URL could not be determined."))
+	}
+
+	var issuer *url.URL
+
+	if issuer, err = ictx.IssuerURL(); err != nil {
+		return errorsx.WithStack(ErrIssuerCouldNotDerive.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	token := fmt.Sprintf("%s%s", issuer, EndpointPathToken)
+
+	for _, audience := range []string{
+		token,
+		issuer.String(),
+		fmt.Sprintf("%s%s", issuer, EndpointPathIntrospection),
+		fmt.Sprintf("%s%s", issuer, EndpointPathRevocation),
+		fmt.Sprintf("%s%s", issuer, EndpointPathPushedAuthorizationRequest),
+		fmt.Sprintf("%s%s", issuer, EndpointPathDeviceAuthorization),
+	} {
+		if claims.VerifyAudience(audience, true) {
+			return nil
+		}
+	}
+
+	return errorsx.WithStack(fosite.ErrInvalidClient.WithHintf("Claim 'aud' from 'client_assertion' must match the authorization server's token endpoint '%s'.", token))
+}
+
+func (s *ClientAuthenticationStrategy) getClientAssertionKey(client *Client, t *jwt.Token) (key any, err error) {
+	method := client.GetTokenEndpointAuthMethod()
+
+	switch method {
+	case ClientAuthMethodPrivateKeyJWT, ClientAuthMethodClientSecretJWT:
+		break
+	case ClientAuthMethodNone:
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHint("This requested OAuth 2.0 client does not support client authentication, however 'client_assertion' was provided in the request."))
+	default:
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHintf("This requested OAuth 2.0 client only supports client authentication method '%s', however 'client_assertion' was provided in the request.", method))
+	}
+
+	alg, _ := t.Header[JWTHeaderAlgorithm].(string)
+
+	if expected := client.GetTokenEndpointAuthSigningAlg(); alg != expected {
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHintf("The 'client_assertion' uses signing algorithm '%s' but the requested OAuth 2.0 Client enforces signing algorithm '%s'.", alg, expected))
+	}
+
+	if method == ClientAuthMethodClientSecretJWT {
+		if !isSigningAlgorithmHMAC(alg) {
+			return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHintf("The 'client_assertion' request parameter uses unsupported signing algorithm '%s'.", alg))
+		}
+
+		var secret []byte
+
+		if secret, err = client.GetSecretPlainText(); err != nil {
+			return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHint("The OAuth 2.0 Client does not have a secret suitable for the 'client_secret_jwt' client authentication method.").WithWrap(err).WithDebug(err.Error()))
+		}
+
+		return &jose.JSONWebKey{Key: secret, Algorithm: alg}, nil
+	}
+
+	kid, _ := t.Header[JWTHeaderKeyIdentifier].(string)
+
+	return s.findClientPublicJWK(client, kid, alg)
+}
+
+func (s *ClientAuthenticationStrategy) findClientPublicJWK(client *Client, kid, alg string) (key *jose.JSONWebKey, err error) {
+	if keys := client.GetJSONWebKeys(); keys != nil {
+		return findPublicJWK(keys, kid, alg)
+	}
+
+	uri := client.GetJSONWebKeysURI()
+
+	if uri == "" {
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHint("The OAuth 2.0 Client has no JSON Web Keys set registered, but they are needed to complete the request."))
+	}
+
+	var keys *jose.JSONWebKeySet
+
+	if keys, err = s.resolveJSONWebKeys(client, uri, false); err != nil {
+		return nil, err
+	}
+
+	if key, err = findPublicJWK(keys, kid, alg); err == nil {
+		return key, nil
+	}
+
+	if keys, err = s.resolveJSONWebKeys(client, uri, true); err != nil {
+		return nil, err
+	}
+
+	return findPublicJWK(keys, kid, alg)
+}
+
+func (s *ClientAuthenticationStrategy) resolveJSONWebKeys(client *Client, uri string, force bool) (keys *jose.JSONWebKeySet, err error) {
+	file := strings.HasPrefix(uri, "file://")
+
+	switch {
+	case file && client.Registered:
+		// Registered clients are not provided by the administrator so they must never be able to read local files.
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithHint("The OAuth 2.0 Client has a 'jwks_uri' with the file scheme which is only permitted for clients in the configuration."))
+	case client.Registered:
+		return s.registeredFetcher.Resolve(uri, force)
+	case !file:
+		return s.fetcher.Resolve(uri, force)
+	}
+
+	var data []byte
+
+	if data, err = os.ReadFile(strings.TrimPrefix(uri, "file://")); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHintf("Unable to read the JSON Web Key Set from '%s'.", uri).WithWrap(err).WithDebug(err.Error()))
+	}
+
+	keys = &jose.JSONWebKeySet{}
+
+	if err = json.Unmarshal(data, keys); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHintf("Unable to decode the JSON Web Key Set from '%s'.", uri).WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return keys, nil
+}
+
+func findPublicJWK(set *jose.JSONWebKeySet, kid, alg string) (key *jose.JSONWebKey, err error) {
+	keys := set.Keys
+
+	if len(keys) == 0 {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The retrieved JSON Web Key Set does not contain any keys."))
+	}
+
+	if kid != "" {
+		keys = set.Key(kid)
+	}
+
+	for i := range keys {
+		if keys[i].Use != "" && keys[i].Use != KeyUseSignature {
+			continue
+		}
+
+		if keys[i].Algorithm != "" && keys[i].Algorithm != alg {
+			continue
+		}
+
+		if IsPublicJWKSigningAlgorithmValid(keys[i].Key, alg) {
+			return &keys[i], nil
+		}
+	}
+
+	return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("Unable to find a public key with use='sig' for kid '%s' and alg '%s' in the JSON Web Key Set.", kid, alg))
+}
+
+func isSigningAlgorithmHMAC(alg string) bool {
+	switch alg {
+	case SigningAlgorithmHMACWithSHA256, SigningAlgorithmHMACWithSHA384, SigningAlgorithmHMACWithSHA512:
+		return true
+	default:
+		return false
+	}
+}
+
+func claimTime(claims jwt.MapClaims, claim string) (t time.Time, ok bool) {
+	switch value := claims[claim].(type) {
+	case float64:
+		return time.Unix(int64(value), 0), true
+	case int64:
+		return time.Unix(value, 0), true
+	case json.Number:
+		v, err := value.Int64()
+
+		return time.Unix(v, 0), err == nil
+	default:
+		return time.Time{}, false
+	}
+}
+
+func clientCredentialsFromRequest(r *http.Request, form url.Values) (id, secret, method string, err error) {
+	var ok bool
+
+	if id, secret, ok = r.BasicAuth(); !ok {
+		if id = form.Get(FormParameterClientID); id == "" {
+			return "", "", "", errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("Client credentials missing or malformed in both HTTP Authorization header and HTTP POST body."))
+		}
+
+		if secret = form.Get(FormParameterClientSecret); secret == "" {
+			return id, "", ClientAuthMethodNone, nil
+		}
+
+		return id, secret, ClientAuthMethodClientSecretPost, nil
+	}
+
+	if id, err = url.QueryUnescape(id); err != nil {
+		return "", "", "", errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The client id in the HTTP authorization header could not be decoded from 'application/x-www-form-urlencoded'.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if secret, err = url.QueryUnescape(secret); err != nil {
+		return "", "", "", errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The client secret in the HTTP authorization header could not be decoded from 'application/x-www-form-urlencoded'.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return id, secret, ClientAuthMethodClientSecretBasic, nil
+}
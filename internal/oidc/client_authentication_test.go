@@ -0,0 +1,305 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestClientAuthenticationStrategy_ClientSecret(t *testing.T) {
+	strategy, _ := newTestClientAuthenticationStrategy(t, nil)
+
+	testCases := []struct {
+		name   string
+		basic  bool
+		id     string
+		secret string
+		hint   string
+		debug  string
+	}{
+		{"ShouldAuthenticateBasic", true, "basic", "client-secret", "", ""},
+		{"ShouldAuthenticatePost", false, "post", "client-secret", "", ""},
+		{"ShouldAuthenticateDefaultBasic", true, "default", "client-secret", "", ""},
+		{"ShouldAuthenticateDefaultPost", false, "default", "client-secret", "", ""},
+		{"ShouldAuthenticatePublic", false, "public", "", "", ""},
+		{"ShouldNotAuthenticateBasicWithPost", false, "basic", "client-secret", "The OAuth 2.0 Client supports client authentication method 'client_secret_basic', but method 'client_secret_post' was requested. You must configure the OAuth 2.0 client's 'token_endpoint_auth_method' value to accept 'client_secret_post'.", ""},
+		{"ShouldNotAuthenticatePostWithBasic", true, "post", "client-secret", "The OAuth 2.0 Client supports client authentication method 'client_secret_post', but method 'client_secret_basic' was requested. You must configure the OAuth 2.0 client's 'token_endpoint_auth_method' value to accept 'client_secret_basic'.", ""},
+		{"ShouldNotAuthenticateJWTWithSecret", false, "secret-jwt", "client-secret", "The OAuth 2.0 Client supports client authentication method 'client_secret_jwt', but method 'client_secret_post' was requested. You must configure the OAuth 2.0 client's 'token_endpoint_auth_method' value to accept 'client_secret_post'.", ""},
+		{"ShouldNotAuthenticateBadSecret", true, "basic", "bad-secret", "", "the passwords don't match"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &http.Request{Header: http.Header{}}
+			form := url.Values{}
+
+			if tc.basic {
+				r.SetBasicAuth(tc.id, tc.secret)
+			} else {
+				form.Set(FormParameterClientID, tc.id)
+				form.Set(FormParameterClientSecret, tc.secret)
+			}
+
+			client, err := strategy.AuthenticateClient(context.Background(), r, form)
+
+			if tc.hint == "" && tc.debug == "" {
+				assert.NoError(t, err)
+				require.NotNil(t, client)
+				assert.Equal(t, tc.id, client.GetID())
+			} else {
+				assert.Nil(t, client)
+				assert.ErrorIs(t, err, fosite.ErrInvalidClient)
+				assert.Equal(t, tc.hint, fosite.ErrorToRFC6749Error(err).HintField)
+				assert.Equal(t, tc.debug, fosite.ErrorToRFC6749Error(err).DebugField)
+			}
+		})
+	}
+}
+
+func TestClientAuthenticationStrategy_ClientAssertion(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jwks.json")
+
+	data, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "file", Algorithm: SigningAlgorithmECDSAWithSHA256, Use: KeyUseSignature}}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	strategy, store := newTestClientAuthenticationStrategy(t, &key.PublicKey)
+	strategy.store.clients["file"] = NewClient(schema.OpenIDConnectClientConfiguration{
+		ID:                          "file",
+		TokenEndpointAuthMethod:     ClientAuthMethodPrivateKeyJWT,
+		TokenEndpointAuthSigningAlg: SigningAlgorithmECDSAWithSHA256,
+		JSONWebKeysURI:              "file://" + path,
+	})
+
+	strategy.store.clients["registered-file"] = NewClient(schema.OpenIDConnectClientConfiguration{
+		ID:                          "registered-file",
+		TokenEndpointAuthMethod:     ClientAuthMethodPrivateKeyJWT,
+		TokenEndpointAuthSigningAlg: SigningAlgorithmECDSAWithSHA256,
+		JSONWebKeysURI:              "file://" + path,
+	})
+
+	strategy.store.clients["registered-file"].Registered = true
+
+	issuer := &url.URL{Scheme: "https", Host: "auth.example.com"}
+	ctx := &testIssuerContext{Context: context.Background(), issuer: issuer}
+	audience := fmt.Sprintf("%s%s", issuer, EndpointPathToken)
+	now := time.Now()
+
+	testCases := []struct {
+		name   string
+		id     string
+		alg    jose.SignatureAlgorithm
+		kid    string
+		key    any
+		claims jwt.Claims
+		err    error
+		hint   string
+	}{
+		{
+			"ShouldAuthenticatePrivateKeyJWT", "private-key-jwt", jose.ES256, "key", key,
+			jwt.Claims{Issuer: "private-key-jwt", Subject: "private-key-jwt", ID: "jti-1", Audience: jwt.Audience{audience}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			nil, "",
+		},
+		{
+			"ShouldAuthenticatePrivateKeyJWTIssuerAudience", "private-key-jwt", jose.ES256, "key", key,
+			jwt.Claims{Issuer: "private-key-jwt", Subject: "private-key-jwt", ID: "jti-2", Audience: jwt.Audience{issuer.String()}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			nil, "",
+		},
+		{
+			"ShouldAuthenticatePrivateKeyJWTFromFile", "file", jose.ES256, "file", key,
+			jwt.Claims{Issuer: "file", Subject: "file", ID: "jti-3", Audience: jwt.Audience{audience}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			nil, "",
+		},
+		{
+			"ShouldNotAuthenticatePrivateKeyJWTFromFileForRegisteredClient", "registered-file", jose.ES256, "file", key,
+			jwt.Claims{Issuer: "registered-file", Subject: "registered-file", ID: "jti-11", Audience: jwt.Audience{audience}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			fosite.ErrInvalidClient, "The OAuth 2.0 Client has a 'jwks_uri' with the file scheme which is only permitted for clients in the configuration.",
+		},
+		{
+			"ShouldAuthenticateClientSecretJWT", "secret-jwt", jose.HS256, "", []byte("client-secret"),
+			jwt.Claims{Issuer: "secret-jwt", Subject: "secret-jwt", ID: "jti-4", Audience: jwt.Audience{audience}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			nil, "",
+		},
+		{
+			"ShouldNotAuthenticateReplayedJTI", "private-key-jwt", jose.ES256, "key", key,
+			jwt.Claims{Issuer: "private-key-jwt", Subject: "private-key-jwt", ID: "jti-1", Audience: jwt.Audience{audience}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			fosite.ErrJTIKnown, "Claim 'jti' from 'client_assertion' MUST only be used once.",
+		},
+		{
+			"ShouldNotAuthenticateWrongAudience", "private-key-jwt", jose.ES256, "key", key,
+			jwt.Claims{Issuer: "private-key-jwt", Subject: "private-key-jwt", ID: "jti-5", Audience: jwt.Audience{"https://example.com"}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			fosite.ErrInvalidClient, "Claim 'aud' from 'client_assertion' must match the authorization server's token endpoint 'https://auth.example.com/api/oidc/token'.",
+		},
+		{
+			"ShouldNotAuthenticateWrongIssuer", "private-key-jwt", jose.ES256, "key", key,
+			jwt.Claims{Issuer: "other", Subject: "private-key-jwt", ID: "jti-6", Audience: jwt.Audience{audience}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			fosite.ErrInvalidClient, "Claim 'iss' from 'client_assertion' must match the 'client_id' of the OAuth 2.0 Client.",
+		},
+		{
+			"ShouldNotAuthenticateMissingJTI", "private-key-jwt", jose.ES256, "key", key,
+			jwt.Claims{Issuer: "private-key-jwt", Subject: "private-key-jwt", Audience: jwt.Audience{audience}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			fosite.ErrInvalidClient, "Claim 'jti' from 'client_assertion' must be set but is not.",
+		},
+		{
+			"ShouldNotAuthenticateMissingExpiry", "private-key-jwt", jose.ES256, "key", key,
+			jwt.Claims{Issuer: "private-key-jwt", Subject: "private-key-jwt", ID: "jti-7", Audience: jwt.Audience{audience}},
+			fosite.ErrInvalidClient, "Claim 'exp' from 'client_assertion' must be set but is not.",
+		},
+		{
+			"ShouldNotAuthenticateWrongKey", "private-key-jwt", jose.ES256, "key", other,
+			jwt.Claims{Issuer: "private-key-jwt", Subject: "private-key-jwt", ID: "jti-8", Audience: jwt.Audience{audience}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			fosite.ErrInvalidClient, "Unable to verify the integrity of the 'client_assertion' value.",
+		},
+		{
+			"ShouldNotAuthenticateWrongAlgorithm", "secret-jwt", jose.HS512, "", []byte("client-secret"),
+			jwt.Claims{Issuer: "secret-jwt", Subject: "secret-jwt", ID: "jti-9", Audience: jwt.Audience{audience}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			fosite.ErrInvalidClient, "The 'client_assertion' uses signing algorithm 'HS512' but the requested OAuth 2.0 Client enforces signing algorithm 'HS256'.",
+		},
+		{
+			"ShouldNotAuthenticateSecretClient", "basic", jose.HS256, "", []byte("client-secret"),
+			jwt.Claims{Issuer: "basic", Subject: "basic", ID: "jti-10", Audience: jwt.Audience{audience}, Expiry: jwt.NewNumericDate(now.Add(time.Minute))},
+			fosite.ErrInvalidClient, "This requested OAuth 2.0 client only supports client authentication method 'client_secret_basic', however 'client_assertion' was provided in the request.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := &jose.SignerOptions{}
+
+			if tc.kid != "" {
+				opts = opts.WithHeader(JWTHeaderKeyIdentifier, tc.kid)
+			}
+
+			signer, err := jose.NewSigner(jose.SigningKey{Algorithm: tc.alg, Key: tc.key}, opts)
+			require.NoError(t, err)
+
+			assertion, err := jwt.Signed(signer).Claims(tc.claims).CompactSerialize()
+			require.NoError(t, err)
+
+			form := url.Values{}
+			form.Set(FormParameterClientAssertionType, ClientAssertionTypeJWTBearer)
+			form.Set(FormParameterClientAssertion, assertion)
+
+			client, err := strategy.AuthenticateClient(ctx, &http.Request{Header: http.Header{}}, form)
+
+			if tc.err == nil {
+				assert.NoError(t, err)
+				require.NotNil(t, client)
+				assert.Equal(t, tc.id, client.GetID())
+
+				_, ok := store.jtis[fmt.Sprintf("%x", sha256.Sum256([]byte(tc.claims.ID)))]
+				assert.True(t, ok)
+			} else {
+				assert.Nil(t, client)
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, tc.hint, fosite.ErrorToRFC6749Error(err).HintField)
+			}
+		})
+	}
+}
+
+func TestClientAuthenticationStrategy_ShouldRejectUnknownAssertionType(t *testing.T) {
+	strategy, _ := newTestClientAuthenticationStrategy(t, nil)
+
+	form := url.Values{}
+	form.Set(FormParameterClientAssertionType, "urn:example:unknown")
+
+	client, err := strategy.AuthenticateClient(context.Background(), &http.Request{Header: http.Header{}}, form)
+
+	assert.Nil(t, client)
+	assert.ErrorIs(t, err, fosite.ErrInvalidRequest)
+	assert.Equal(t, "Unknown client_assertion_type 'urn:example:unknown'.", fosite.ErrorToRFC6749Error(err).HintField)
+
+	form.Set(FormParameterClientAssertionType, ClientAssertionTypeJWTBearer)
+
+	client, err = strategy.AuthenticateClient(context.Background(), &http.Request{Header: http.Header{}}, form)
+
+	assert.Nil(t, client)
+	assert.ErrorIs(t, err, fosite.ErrInvalidRequest)
+}
+
+func newTestClientAuthenticationStrategy(t *testing.T, key *ecdsa.PublicKey) (strategy *ClientAuthenticationStrategy, store *testJTIStore) {
+	t.Helper()
+
+	store = &testJTIStore{jtis: map[string]model.OAuth2BlacklistedJTI{}}
+
+	clients := []schema.OpenIDConnectClientConfiguration{
+		{ID: "basic", Secret: MustDecodeSecret("$plaintext$client-secret"), TokenEndpointAuthMethod: ClientAuthMethodClientSecretBasic},
+		{ID: "post", Secret: MustDecodeSecret("$plaintext$client-secret"), TokenEndpointAuthMethod: ClientAuthMethodClientSecretPost},
+		{ID: "default", Secret: MustDecodeSecret("$plaintext$client-secret")},
+		{ID: "public", Public: true},
+		{ID: "secret-jwt", Secret: MustDecodeSecret("$plaintext$client-secret"), TokenEndpointAuthMethod: ClientAuthMethodClientSecretJWT},
+	}
+
+	if key != nil {
+		clients = append(clients, schema.OpenIDConnectClientConfiguration{
+			ID:                          "private-key-jwt",
+			TokenEndpointAuthMethod:     ClientAuthMethodPrivateKeyJWT,
+			TokenEndpointAuthSigningAlg: SigningAlgorithmECDSAWithSHA256,
+			JSONWebKeys: []schema.OpenIDConnectClientJWK{
+				{KeyID: "key", Use: KeyUseSignature, Algorithm: SigningAlgorithmECDSAWithSHA256, Key: key},
+			},
+		})
+	}
+
+	return NewClientAuthenticationStrategy(NewOpenIDConnectStore(&schema.OpenIDConnectConfiguration{Clients: clients}, store), fosite.NewDefaultJWKSFetcherStrategy(), AdaptiveHasher{}, nil), store
+}
+
+type testIssuerContext struct {
+	context.Context
+
+	issuer *url.URL
+}
+
+func (ctx *testIssuerContext) IssuerURL() (issuerURL *url.URL, err error) {
+	return ctx.issuer, nil
+}
+
+type testJTIStore struct {
+	storage.Provider
+
+	jtis map[string]model.OAuth2BlacklistedJTI
+}
+
+func (s *testJTIStore) SaveOAuth2BlacklistedJTI(_ context.Context, blacklistedJTI model.OAuth2BlacklistedJTI) (err error) {
+	s.jtis[blacklistedJTI.Signature] = blacklistedJTI
+
+	return nil
+}
+
+func (s *testJTIStore) LoadOAuth2BlacklistedJTI(_ context.Context, signature string) (blacklistedJTI *model.OAuth2BlacklistedJTI, err error) {
+	jti, ok := s.jtis[signature]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+
+	return &jti, nil
+}
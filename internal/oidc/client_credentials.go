@@ -0,0 +1,103 @@
+package oidc
+
+import (
+	"context"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/x/errorsx"
+
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// clientCredentialsGrantFactory creates a ClientCredentialsGrantHandler.
+func clientCredentialsGrantFactory(config *compose.Config, storage any, strategy any) any {
+	return &ClientCredentialsGrantHandler{
+		ClientCredentialsGrantHandler: *compose.OAuth2ClientCredentialsGrantFactory(config, storage, strategy).(*oauth2.ClientCredentialsGrantHandler),
+	}
+}
+
+// ClientCredentialsGrantHandler is an oauth2.ClientCredentialsGrantHandler which enforces the Client Credentials policy
+// of the client, and issues the tokens to the service account subject of the client.
+type ClientCredentialsGrantHandler struct {
+	oauth2.ClientCredentialsGrantHandler
+}
+
+// HandleTokenEndpointRequest implements fosite.TokenEndpointHandler. It ensures the requested audience and scopes are
+// permitted by the policy of the client and grants them, falling back to the audience and scopes of the policy when
+// none are requested.
+//
+// RFC6749: https://www.rfc-editor.org/rfc/rfc6749.html#section-4.4.2
+func (h *ClientCredentialsGrantHandler) HandleTokenEndpointRequest(ctx context.Context, requester fosite.AccessRequester) (err error) {
+	if err = h.ClientCredentialsGrantHandler.HandleTokenEndpointRequest(ctx, requester); err != nil {
+		return err
+	}
+
+	client, ok := requester.GetClient().(*Client)
+	if !ok {
+		return nil
+	}
+
+	if err = h.handleAudience(client, requester); err != nil {
+		return err
+	}
+
+	if err = h.handleScopes(client, requester); err != nil {
+		return err
+	}
+
+	if session, ok := requester.GetSession().(*model.OpenIDSession); ok {
+		subject := client.GetClientCredentialsSubject()
+
+		session.Subject = subject
+		session.ClientID = client.GetID()
+		session.ServiceAccount = true
+
+		if session.Claims != nil {
+			session.Claims.Subject = subject
+		}
+	}
+
+	return nil
+}
+
+func (h *ClientCredentialsGrantHandler) handleAudience(client *Client, requester fosite.AccessRequester) (err error) {
+	audience := requester.GetRequestedAudience()
+
+	if len(audience) == 0 {
+		audience = client.ClientCredentials.Audience
+	}
+
+	for _, aud := range audience {
+		if !client.IsClientCredentialsAudienceAllowed(aud) {
+			return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("The OAuth 2.0 Client is not allowed to request a token for the audience '%s' using the Client Credentials grant.", aud))
+		}
+	}
+
+	for _, aud := range audience {
+		requester.GrantAudience(aud)
+	}
+
+	return nil
+}
+
+func (h *ClientCredentialsGrantHandler) handleScopes(client *Client, requester fosite.AccessRequester) (err error) {
+	scopes := requester.GetRequestedScopes()
+
+	if len(scopes) == 0 {
+		scopes = client.ClientCredentials.Scopes
+	}
+
+	for _, scope := range scopes {
+		if !client.IsClientCredentialsScopeAllowed(scope) {
+			return errorsx.WithStack(fosite.ErrInvalidScope.WithHintf("The OAuth 2.0 Client is not allowed to request a token for the scope '%s' using the Client Credentials grant.", scope))
+		}
+	}
+
+	for _, scope := range scopes {
+		requester.GrantScope(scope)
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+func TestOpenIDConnectProvider_ClientCredentialsGrant(t *testing.T) {
+	provider, _ := newTestClientCredentialsProvider(t)
+
+	ctx := context.Background()
+
+	requester, err := provider.NewAccessRequest(ctx, newTestClientCredentialsHTTPRequest("a-client", "a-client-secret", url.Values{
+		FormParameterScope: []string{"api.read"},
+		"audience":         []string{"https://api.example.com"},
+	}), NewSession())
+
+	require.NoError(t, err)
+	assert.Equal(t, fosite.Arguments{"api.read"}, requester.GetGrantedScopes())
+	assert.Equal(t, fosite.Arguments{"https://api.example.com"}, requester.GetGrantedAudience())
+
+	session, ok := requester.GetSession().(*model.OpenIDSession)
+
+	require.True(t, ok)
+	assert.Equal(t, "client:backup", session.GetSubject())
+	assert.Equal(t, "client:backup", session.Claims.Subject)
+	assert.Equal(t, "a-client", session.ClientID)
+	assert.True(t, session.ServiceAccount)
+
+	responder, err := provider.NewAccessResponse(ctx, requester)
+
+	require.NoError(t, err)
+
+	form := url.Values{"token": []string{responder.GetAccessToken()}}
+
+	r := httptest.NewRequest(http.MethodPost, "/api/oidc/introspection", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.SetBasicAuth("b-client", "b-client-secret")
+
+	introspection, err := provider.NewIntrospectionRequest(ctx, r, NewSession())
+
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+
+	provider.WriteIntrospectionResponse(rw, introspection)
+
+	var body map[string]any
+
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body))
+
+	assert.Equal(t, true, body["active"])
+	assert.Equal(t, "a-client", body["client_id"])
+	assert.Equal(t, "client:backup", body["sub"])
+	assert.Equal(t, "api.read", body["scope"])
+	assert.Equal(t, []any{"https://api.example.com"}, body["aud"])
+	assert.Equal(t, true, body["service_account"])
+}
+
+func TestOpenIDConnectProvider_ClientCredentialsGrantDefaults(t *testing.T) {
+	provider, _ := newTestClientCredentialsProvider(t)
+
+	requester, err := provider.NewAccessRequest(context.Background(), newTestClientCredentialsHTTPRequest("a-client", "a-client-secret", url.Values{}), NewSession())
+
+	require.NoError(t, err)
+	assert.Equal(t, fosite.Arguments{"api.read", "api.write"}, requester.GetGrantedScopes())
+	assert.Equal(t, fosite.Arguments{"https://api.example.com"}, requester.GetGrantedAudience())
+}
+
+func TestOpenIDConnectProvider_ClientCredentialsGrantWithoutPolicy(t *testing.T) {
+	provider, _ := newTestClientCredentialsProvider(t)
+
+	requester, err := provider.NewAccessRequest(context.Background(), newTestClientCredentialsHTTPRequest("b-client", "b-client-secret", url.Values{
+		FormParameterScope: []string{"api.read api.write"},
+		"audience":         []string{"https://api.example.com"},
+	}), NewSession())
+
+	require.NoError(t, err)
+	assert.Equal(t, fosite.Arguments{"api.read", "api.write"}, requester.GetGrantedScopes())
+	assert.Equal(t, fosite.Arguments{"https://api.example.com"}, requester.GetGrantedAudience())
+	assert.Equal(t, "client:b-client", requester.GetSession().GetSubject())
+}
+
+func TestOpenIDConnectProvider_ClientCredentialsGrantShouldEnforcePolicy(t *testing.T) {
+	provider, _ := newTestClientCredentialsProvider(t)
+
+	testCases := []struct {
+		name string
+		form url.Values
+		err  error
+		hint string
+	}{
+		{
+			name: "ShouldRejectScopeNotInPolicy",
+			form: url.Values{FormParameterScope: []string{"api.admin"}},
+			err:  fosite.ErrInvalidScope,
+			hint: "The OAuth 2.0 Client is not allowed to request a token for the scope 'api.admin' using the Client Credentials grant.",
+		},
+		{
+			name: "ShouldRejectScopeNotInClient",
+			form: url.Values{FormParameterScope: []string{ScopeOpenID}},
+			err:  fosite.ErrInvalidScope,
+			hint: "The OAuth 2.0 Client is not allowed to request scope 'openid'.",
+		},
+		{
+			name: "ShouldRejectAudienceNotInPolicy",
+			form: url.Values{"audience": []string{"https://admin.example.com"}},
+			err:  fosite.ErrInvalidRequest,
+			hint: "The OAuth 2.0 Client is not allowed to request a token for the audience 'https://admin.example.com' using the Client Credentials grant.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := provider.NewAccessRequest(context.Background(), newTestClientCredentialsHTTPRequest("a-client", "a-client-secret", tc.form), NewSession())
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.hint, fosite.ErrorToRFC6749Error(err).HintField)
+		})
+	}
+}
+
+func TestClient_IsClientCredentialsAllowed(t *testing.T) {
+	client := NewClient(schema.OpenIDConnectClientConfiguration{
+		ID:       "a-client",
+		Scopes:   []string{ScopeProfile, ScopeEmail},
+		Audience: []string{"https://api.example.com", "https://other.example.com"},
+		ClientCredentials: schema.OpenIDConnectClientClientCredentialsConfiguration{
+			Subject:  "client:backup",
+			Audience: []string{"https://api.example.com"},
+			Scopes:   []string{ScopeProfile},
+		},
+	})
+
+	assert.Equal(t, "client:backup", client.GetClientCredentialsSubject())
+	assert.True(t, client.IsClientCredentialsAudienceAllowed("https://api.example.com"))
+	assert.False(t, client.IsClientCredentialsAudienceAllowed("https://other.example.com"))
+	assert.True(t, client.IsClientCredentialsScopeAllowed(ScopeProfile))
+	assert.False(t, client.IsClientCredentialsScopeAllowed(ScopeEmail))
+
+	client = NewClient(schema.OpenIDConnectClientConfiguration{
+		ID:       "b-client",
+		Scopes:   []string{ScopeProfile},
+		Audience: []string{"https://api.example.com"},
+	})
+
+	assert.Equal(t, "client:b-client", client.GetClientCredentialsSubject())
+	assert.True(t, client.IsClientCredentialsAudienceAllowed("https://api.example.com"))
+	assert.False(t, client.IsClientCredentialsAudienceAllowed("https://other.example.com"))
+	assert.True(t, client.IsClientCredentialsScopeAllowed(ScopeProfile))
+	assert.False(t, client.IsClientCredentialsScopeAllowed(ScopeEmail))
+}
+
+func TestOpenIDSession_GetExtraClaimsServiceAccount(t *testing.T) {
+	session := NewSession()
+
+	session.ServiceAccount = true
+
+	assert.Equal(t, map[string]any{"service_account": true}, session.GetExtraClaims())
+
+	session.DPoPJWKThumbprint = "abc"
+
+	assert.Equal(t, map[string]any{"service_account": true, ClaimConfirmation: map[string]any{ClaimConfirmationJWKThumbprint: "abc"}}, session.GetExtraClaims())
+}
+
+func newTestClientCredentialsProvider(t *testing.T) (provider *OpenIDConnectProvider, store *testTokenExchangeStore) {
+	t.Helper()
+
+	store = &testTokenExchangeStore{sessions: map[string]model.OAuth2Session{}}
+
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey:    mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:          "asbdhaaskmdlkamdklasmdlkams",
+		AccessTokenLifespan: time.Hour,
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:         "a-client",
+				Secret:     MustDecodeSecret("$plaintext$a-client-secret"),
+				Policy:     "one_factor",
+				Scopes:     []string{ScopeOfflineAccess, "api.read", "api.write", "api.admin"},
+				Audience:   []string{"https://api.example.com", "https://admin.example.com"},
+				GrantTypes: []string{GrantTypeClientCredentials},
+				ClientCredentials: schema.OpenIDConnectClientClientCredentialsConfiguration{
+					Subject:  "client:backup",
+					Audience: []string{"https://api.example.com"},
+					Scopes:   []string{"api.read", "api.write"},
+				},
+			},
+			{
+				ID:         "b-client",
+				Secret:     MustDecodeSecret("$plaintext$b-client-secret"),
+				Policy:     "one_factor",
+				Scopes:     []string{"api.read", "api.write"},
+				Audience:   []string{"https://api.example.com"},
+				GrantTypes: []string{GrantTypeClientCredentials},
+			},
+		},
+	}, store)
+
+	require.NoError(t, err)
+
+	return provider, store
+}
+
+func newTestClientCredentialsHTTPRequest(id, secret string, form url.Values) (r *http.Request) {
+	form.Set("grant_type", GrantTypeClientCredentials)
+	form.Set(FormParameterClientID, id)
+	form.Set(FormParameterClientSecret, secret)
+
+	r = httptest.NewRequest(http.MethodPost, "/api/oidc/token", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return r
+}
@@ -0,0 +1,577 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-crypt/crypt"
+	"github.com/google/uuid"
+	"github.com/ory/fosite"
+	"github.com/ory/x/errorsx"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewRegisteredClient converts a model.OAuth2Client which was registered using the RFC7591 OAuth 2.0 Dynamic Client
+// Registration endpoint or the CLI into a Client given the authorization policy which applies to registered clients
+// which do not have their own authorization policy.
+func NewRegisteredClient(registered *model.OAuth2Client, policy authorization.Level) (client *Client, err error) {
+	metadata := ClientRegistrationMetadata{}
+
+	if registered.Policy != "" {
+		policy = authorization.StringToLevel(registered.Policy)
+	}
+
+	if err = json.Unmarshal([]byte(registered.Metadata), &metadata); err != nil {
+		return nil, fmt.Errorf("error decoding the metadata of the registered client with id '%s': %w", registered.ClientID, err)
+	}
+
+	client = &Client{
+		ID:          registered.ClientID,
+		Description: metadata.ClientName,
+		Public:      metadata.TokenEndpointAuthMethod == ClientAuthMethodNone,
+
+		Scopes:                 strings.Fields(metadata.Scope),
+		RedirectURIs:           metadata.RedirectURIs,
+		PostLogoutRedirectURIs: metadata.PostLogoutRedirectURIs,
+		BackChannelLogoutURI:   metadata.BackChannelLogoutURI,
+		GrantTypes:             metadata.GrantTypes,
+		ResponseTypes:          metadata.ResponseTypes,
+		ResponseModes:          []fosite.ResponseModeType{fosite.ResponseModeDefault},
+
+		IDTokenSignedResponseAlg:     metadata.IDTokenSignedResponseAlg,
+		AccessTokenSignedResponseAlg: metadata.AccessTokenSignedResponseAlg,
+		UserinfoSigningAlgorithm:     metadata.UserinfoSignedResponseAlg,
+
+		TokenEndpointAuthMethod:     metadata.TokenEndpointAuthMethod,
+		TokenEndpointAuthSigningAlg: metadata.TokenEndpointAuthSigningAlg,
+		JSONWebKeysURI:              metadata.JSONWebKeysURI,
+		JSONWebKeys:                 metadata.JSONWebKeys,
+
+		RequestURIs:                metadata.RequestURIs,
+		RequestObjectSigningAlg:    metadata.RequestObjectSigningAlg,
+		RequireSignedRequestObject: metadata.RequireSignedRequestObject,
+
+		DPoPBoundAccessTokens: metadata.DPoPBoundAccessTokens,
+
+		TLSClientAuthSubjectDN:                metadata.TLSClientAuthSubjectDN,
+		TLSClientAuthSANDNS:                   metadata.TLSClientAuthSANDNS,
+		TLSClientAuthSANURI:                   metadata.TLSClientAuthSANURI,
+		TLSClientAuthSANIP:                    metadata.TLSClientAuthSANIP,
+		TLSClientAuthSANEmail:                 metadata.TLSClientAuthSANEmail,
+		TLSClientCertificateBoundAccessTokens: metadata.TLSClientCertificateBoundAccessTokens,
+
+		Policy: policy,
+
+		Consent: NewClientConsent(ClientConsentModeExplicit.String(), nil),
+
+		Registered: true,
+	}
+
+	if client.Description == "" {
+		client.Description = client.ID
+	}
+
+	for _, mode := range schema.DefaultOpenIDConnectClientConfiguration.ResponseModes {
+		client.ResponseModes = append(client.ResponseModes, fosite.ResponseModeType(mode))
+	}
+
+	if registered.Secret.Valid {
+		if client.Secret, err = crypt.Decode(registered.Secret.String); err != nil {
+			return nil, fmt.Errorf("error decoding the secret of the registered client with id '%s': %w", registered.ClientID, err)
+		}
+	}
+
+	return client, nil
+}
+
+// RegisterClient handles a RFC7591 OAuth 2.0 Client Registration Request. The request must be authorized using the
+// configured initial access token as a bearer token. The client_id, client_secret, and registration_access_token are
+// generated by Authelia and only the digest of the client_secret and the signature of the registration_access_token are
+// stored.
+//
+// RFC7591: https://www.rfc-editor.org/rfc/rfc7591.html#section-3.1
+func (p *OpenIDConnectProvider) RegisterClient(ctx context.Context, r *http.Request, issuer *url.URL) (response *ClientRegistrationResponse, err error) {
+	if r.Method != http.MethodPost {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("HTTP method is '%s', expected 'POST'.", r.Method))
+	}
+
+	token := bearerTokenFromRequest(r)
+
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(p.registrationInitialAccessToken)) != 1 {
+		return nil, errorsx.WithStack(ErrClientRegistrationInvalidToken.WithHint("The initial access token is not valid."))
+	}
+
+	metadata := ClientRegistrationMetadata{}
+
+	if err = json.NewDecoder(r.Body).Decode(&metadata); err != nil {
+		return nil, errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHint("Unable to decode the client metadata, make sure to send a properly formatted JSON request body.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if err = validateClientRegistrationMetadata(&p.discovery, &metadata); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	registered := model.OAuth2Client{
+		ClientID:  uuid.New().String(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	response = &ClientRegistrationResponse{
+		ClientID:                   registered.ClientID,
+		ClientIDIssuedAt:           now.Unix(),
+		RegistrationAccessToken:    utils.RandomString(64, utils.CharSetAlphaNumeric, true),
+		RegistrationClientURI:      fmt.Sprintf("%s%s/%s", issuer, EndpointPathRegistration, registered.ClientID),
+		ClientRegistrationMetadata: metadata,
+	}
+
+	registered.RegistrationAccessTokenSignature = model.NewOAuth2ClientRegistrationAccessTokenSignature(response.RegistrationAccessToken)
+
+	if metadata.IsClientSecretRequired() {
+		if registered.Secret, response.ClientSecret, err = NewRegisteredClientSecret(); err != nil {
+			return nil, errorsx.WithStack(fosite.ErrServerError.WithHint("Could not generate the client secret.").WithWrap(err).WithDebug(err.Error()))
+		}
+
+		response.ClientSecretExpiresAt = new(int64)
+	}
+
+	if registered.Metadata, err = EncodeClientRegistrationMetadata(metadata); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if err = p.Store.provider.SaveOAuth2Client(ctx, registered); err != nil {
+		return nil, errorsx.WithStack(ErrClientRegistrationCouldNotSave.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return response, nil
+}
+
+// ReadClientRegistration handles a RFC7592 OAuth 2.0 Client Read Request. The request must be authorized using the
+// registration_access_token of the client as a bearer token. As only the signature of the registration_access_token is
+// stored and the response must include a registration_access_token, every read rotates it and the new token is returned
+// in the response. The previous registration_access_token is no longer valid after a successful read.
+//
+// RFC7592: https://www.rfc-editor.org/rfc/rfc7592.html#section-2.1
+func (p *OpenIDConnectProvider) ReadClientRegistration(ctx context.Context, r *http.Request, issuer *url.URL, clientID string) (response *ClientRegistrationResponse, err error) {
+	var (
+		registered *model.OAuth2Client
+		metadata   ClientRegistrationMetadata
+	)
+
+	if registered, err = p.authenticateClientRegistration(ctx, r, clientID); err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal([]byte(registered.Metadata), &metadata); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return p.updateClientRegistration(ctx, issuer, registered, metadata, "")
+}
+
+// UpdateClientRegistration handles a RFC7592 OAuth 2.0 Client Update Request. The request must be authorized using the
+// registration_access_token of the client as a bearer token, and the provided metadata replaces the existing metadata of
+// the client. A client_secret is only issued if the client was previously a public client.
+//
+// RFC7592: https://www.rfc-editor.org/rfc/rfc7592.html#section-2.2
+func (p *OpenIDConnectProvider) UpdateClientRegistration(ctx context.Context, r *http.Request, issuer *url.URL, clientID string) (response *ClientRegistrationResponse, err error) {
+	var (
+		registered *model.OAuth2Client
+		secret     string
+	)
+
+	if registered, err = p.authenticateClientRegistration(ctx, r, clientID); err != nil {
+		return nil, err
+	}
+
+	request := ClientRegistrationUpdateRequest{}
+
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHint("Unable to decode the client metadata, make sure to send a properly formatted JSON request body.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if request.ClientID != registered.ClientID {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The 'client_id' value does not match the client being updated."))
+	}
+
+	if request.ClientSecret != "" {
+		if !registered.Secret.Valid || (AdaptiveHasher{}).Compare(ctx, []byte(registered.Secret.String), []byte(request.ClientSecret)) != nil {
+			return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The 'client_secret' value does not match the secret of the client being updated."))
+		}
+	}
+
+	if err = validateClientRegistrationMetadata(&p.discovery, &request.ClientRegistrationMetadata); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case request.TokenEndpointAuthMethod == ClientAuthMethodNone:
+		registered.Secret = sql.NullString{}
+	case !registered.Secret.Valid:
+		if registered.Secret, secret, err = NewRegisteredClientSecret(); err != nil {
+			return nil, errorsx.WithStack(fosite.ErrServerError.WithHint("Could not generate the client secret.").WithWrap(err).WithDebug(err.Error()))
+		}
+	}
+
+	return p.updateClientRegistration(ctx, issuer, registered, request.ClientRegistrationMetadata, secret)
+}
+
+// DeleteClientRegistration handles a RFC7592 OAuth 2.0 Client Delete Request. The request must be authorized using the
+// registration_access_token of the client as a bearer token.
+//
+// RFC7592: https://www.rfc-editor.org/rfc/rfc7592.html#section-2.3
+func (p *OpenIDConnectProvider) DeleteClientRegistration(ctx context.Context, r *http.Request, clientID string) (err error) {
+	if _, err = p.authenticateClientRegistration(ctx, r, clientID); err != nil {
+		return err
+	}
+
+	if err = p.Store.provider.DeleteOAuth2Client(ctx, clientID); err != nil {
+		return errorsx.WithStack(ErrClientRegistrationCouldNotSave.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return nil
+}
+
+// WriteClientRegistrationResponse writes the ClientRegistrationResponse to the http.ResponseWriter with the provided
+// status code.
+func (p *OpenIDConnectProvider) WriteClientRegistrationResponse(rw http.ResponseWriter, status int, response *ClientRegistrationResponse) {
+	rw.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("Pragma", "no-cache")
+
+	rw.WriteHeader(status)
+
+	_ = json.NewEncoder(rw).Encode(response)
+}
+
+// WriteClientRegistrationError writes a RFC7591 OAuth 2.0 Client Registration Error Response to the
+// http.ResponseWriter.
+//
+// RFC7591: https://www.rfc-editor.org/rfc/rfc7591.html#section-3.2.2
+func (p *OpenIDConnectProvider) WriteClientRegistrationError(rw http.ResponseWriter, err error) {
+	rfc := fosite.ErrorToRFC6749Error(err)
+
+	rw.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("Pragma", "no-cache")
+
+	if rfc.CodeField == http.StatusUnauthorized {
+		rw.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="%s"`, rfc.ErrorField))
+	}
+
+	rw.WriteHeader(rfc.CodeField)
+
+	_ = json.NewEncoder(rw).Encode(rfc)
+}
+
+func (p *OpenIDConnectProvider) authenticateClientRegistration(ctx context.Context, r *http.Request, clientID string) (registered *model.OAuth2Client, err error) {
+	token := bearerTokenFromRequest(r)
+
+	if token == "" {
+		return nil, errorsx.WithStack(ErrClientRegistrationInvalidToken.WithHint("The registration access token is missing."))
+	}
+
+	if registered, err = p.Store.provider.LoadOAuth2Client(ctx, clientID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errorsx.WithStack(ErrClientRegistrationInvalidToken.WithHint("The registration access token is not valid."))
+		}
+
+		return nil, errorsx.WithStack(ErrClientRegistrationCouldNotLookup.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	signature := model.NewOAuth2ClientRegistrationAccessTokenSignature(token)
+
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(registered.RegistrationAccessTokenSignature)) != 1 {
+		return nil, errorsx.WithStack(ErrClientRegistrationInvalidToken.WithHint("The registration access token is not valid."))
+	}
+
+	return registered, nil
+}
+
+func (p *OpenIDConnectProvider) updateClientRegistration(ctx context.Context, issuer *url.URL, registered *model.OAuth2Client, metadata ClientRegistrationMetadata, secret string) (response *ClientRegistrationResponse, err error) {
+	response = &ClientRegistrationResponse{
+		ClientID:                   registered.ClientID,
+		ClientSecret:               secret,
+		ClientIDIssuedAt:           registered.CreatedAt.Unix(),
+		RegistrationAccessToken:    utils.RandomString(64, utils.CharSetAlphaNumeric, true),
+		RegistrationClientURI:      fmt.Sprintf("%s%s/%s", issuer, EndpointPathRegistration, registered.ClientID),
+		ClientRegistrationMetadata: metadata,
+	}
+
+	if secret != "" {
+		response.ClientSecretExpiresAt = new(int64)
+	}
+
+	registered.RegistrationAccessTokenSignature = model.NewOAuth2ClientRegistrationAccessTokenSignature(response.RegistrationAccessToken)
+	registered.UpdatedAt = time.Now().UTC()
+
+	if registered.Metadata, err = EncodeClientRegistrationMetadata(metadata); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if err = p.Store.provider.UpdateOAuth2Client(ctx, *registered); err != nil {
+		return nil, errorsx.WithStack(ErrClientRegistrationCouldNotSave.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return response, nil
+}
+
+// ValidateClientRegistrationMetadata validates the metadata of a client managed via the CLI and sets the defaults in
+// the same way as the metadata of a client registered via the RFC7591 OAuth 2.0 Dynamic Client Registration endpoint.
+func ValidateClientRegistrationMetadata(config *schema.OpenIDConnectConfiguration, metadata *ClientRegistrationMetadata) (err error) {
+	var algs []string
+
+	for _, key := range config.IssuerPrivateKeys {
+		if key.Algorithm != "" && !utils.IsStringInSlice(key.Algorithm, algs) {
+			algs = append(algs, key.Algorithm)
+		}
+	}
+
+	discovery := NewOpenIDConnectWellKnownConfiguration(config.EnablePKCEPlainChallenge, algs, nil)
+	appendCustomScopesAndClaims(&discovery, config)
+
+	return validateClientRegistrationMetadata(&discovery, metadata)
+}
+
+// validateClientRegistrationMetadata validates the client metadata and sets the defaults in the same way the clients
+// from the configuration are validated.
+//
+//nolint:gocyclo // Complexity is required in order to validate each of the metadata fields.
+func validateClientRegistrationMetadata(discovery *OpenIDConnectWellKnownConfiguration, metadata *ClientRegistrationMetadata) (err error) {
+	defaults := schema.DefaultOpenIDConnectClientConfiguration
+
+	if metadata.TokenEndpointAuthMethod == "" {
+		metadata.TokenEndpointAuthMethod = ClientAuthMethodClientSecretBasic
+	}
+
+	if len(metadata.GrantTypes) == 0 {
+		metadata.GrantTypes = defaults.GrantTypes
+	}
+
+	if len(metadata.ResponseTypes) == 0 {
+		metadata.ResponseTypes = defaults.ResponseTypes
+	}
+
+	if metadata.Scope == "" {
+		metadata.Scope = strings.Join(defaults.Scopes, " ")
+	}
+
+	if metadata.IDTokenSignedResponseAlg == "" {
+		metadata.IDTokenSignedResponseAlg = defaults.IDTokenSignedResponseAlg
+	}
+
+	if metadata.AccessTokenSignedResponseAlg == "" {
+		metadata.AccessTokenSignedResponseAlg = defaults.AccessTokenSignedResponseAlg
+	}
+
+	if metadata.UserinfoSignedResponseAlg == "" {
+		metadata.UserinfoSignedResponseAlg = defaults.UserinfoSigningAlgorithm
+	}
+
+	public := metadata.TokenEndpointAuthMethod == ClientAuthMethodNone
+
+	switch metadata.TokenEndpointAuthMethod {
+	case ClientAuthMethodClientSecretBasic, ClientAuthMethodClientSecretPost, ClientAuthMethodNone:
+		break
+	case ClientAuthMethodTLSClientAuth:
+		if metadata.countTLSClientAuthSubjects() != 1 {
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("Exactly one of the 'tls_client_auth_subject_dn', 'tls_client_auth_san_dns', 'tls_client_auth_san_uri', 'tls_client_auth_san_ip', or 'tls_client_auth_san_email' values is required when the 'token_endpoint_auth_method' is '%s'.", ClientAuthMethodTLSClientAuth))
+		}
+	case ClientAuthMethodPrivateKeyJWT, ClientAuthMethodSelfSignedTLSClientAuth:
+		if metadata.TokenEndpointAuthMethod == ClientAuthMethodPrivateKeyJWT && metadata.TokenEndpointAuthSigningAlg == "" {
+			metadata.TokenEndpointAuthSigningAlg = SigningAlgorithmRSAWithSHA256
+		}
+
+		switch {
+		case metadata.TokenEndpointAuthMethod == ClientAuthMethodPrivateKeyJWT && !utils.IsStringInSlice(metadata.TokenEndpointAuthSigningAlg, registrationTokenEndpointAuthSigningAlgs):
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'token_endpoint_auth_signing_alg' value '%s' is not supported.", metadata.TokenEndpointAuthSigningAlg))
+		case metadata.JSONWebKeysURI == "" && metadata.JSONWebKeys == nil:
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("Either the 'jwks_uri' or 'jwks' value is required when the 'token_endpoint_auth_method' is '%s'.", metadata.TokenEndpointAuthMethod))
+		case metadata.JSONWebKeysURI != "" && metadata.JSONWebKeys != nil:
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHint("The 'jwks_uri' and 'jwks' values must not both be present."))
+		}
+
+		if metadata.JSONWebKeysURI != "" {
+			if uri, err := url.Parse(metadata.JSONWebKeysURI); err == nil && uri.Scheme == "file" {
+				return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'jwks_uri' value '%s' must not have the file scheme as it's only permitted for clients in the configuration.", metadata.JSONWebKeysURI))
+			} else if err != nil || !uri.IsAbs() || uri.Scheme != schemeHTTPS {
+				return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'jwks_uri' value '%s' must be an absolute URI with the https scheme.", metadata.JSONWebKeysURI))
+			} else if isRestrictedHostURI(uri) {
+				return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'jwks_uri' value '%s' must not have a host which is localhost or a loopback, private, or link-local address.", metadata.JSONWebKeysURI))
+			}
+		}
+	default:
+		return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'token_endpoint_auth_method' value '%s' is not supported.", metadata.TokenEndpointAuthMethod))
+	}
+
+	for _, grantType := range metadata.GrantTypes {
+		switch {
+		case !utils.IsStringInSlice(grantType, registrationGrantTypes):
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'grant_types' value '%s' is not supported.", grantType))
+		case public && grantType == GrantTypeClientCredentials:
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'grant_types' value '%s' requires a confidential client.", grantType))
+		}
+	}
+
+	for _, responseType := range metadata.ResponseTypes {
+		if !utils.IsStringInSlice(responseType, discovery.ResponseTypesSupported) {
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'response_types' value '%s' is not supported.", responseType))
+		}
+	}
+
+	for _, scope := range strings.Fields(metadata.Scope) {
+		if !utils.IsStringInSlice(scope, discovery.ScopesSupported) {
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'scope' value '%s' is not supported.", scope))
+		}
+	}
+
+	algs := append([]string{SigningAlgorithmNone}, discovery.IDTokenSigningAlgValuesSupported...)
+
+	switch {
+	case !utils.IsStringInSlice(metadata.IDTokenSignedResponseAlg, discovery.IDTokenSigningAlgValuesSupported):
+		return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'id_token_signed_response_alg' value '%s' is not supported.", metadata.IDTokenSignedResponseAlg))
+	case !utils.IsStringInSlice(metadata.AccessTokenSignedResponseAlg, algs):
+		return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'access_token_signed_response_alg' value '%s' is not supported.", metadata.AccessTokenSignedResponseAlg))
+	case !utils.IsStringInSlice(metadata.UserinfoSignedResponseAlg, algs):
+		return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'userinfo_signed_response_alg' value '%s' is not supported.", metadata.UserinfoSignedResponseAlg))
+	}
+
+	if err = validateClientRegistrationRequestObject(discovery, metadata); err != nil {
+		return err
+	}
+
+	return validateClientRegistrationURIs(metadata, public)
+}
+
+func validateClientRegistrationRequestObject(discovery *OpenIDConnectWellKnownConfiguration, metadata *ClientRegistrationMetadata) (err error) {
+	switch {
+	case metadata.RequestObjectSigningAlg == "":
+		break
+	case !utils.IsStringInSlice(metadata.RequestObjectSigningAlg, discovery.RequestObjectSigningAlgValuesSupported):
+		return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'request_object_signing_alg' value '%s' is not supported.", metadata.RequestObjectSigningAlg))
+	case metadata.RequireSignedRequestObject && metadata.RequestObjectSigningAlg == SigningAlgorithmNone:
+		return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'request_object_signing_alg' value must not be '%s' when 'require_signed_request_object' is true.", SigningAlgorithmNone))
+	}
+
+	signed := metadata.RequireSignedRequestObject || (metadata.RequestObjectSigningAlg != "" && metadata.RequestObjectSigningAlg != SigningAlgorithmNone)
+
+	if signed && metadata.JSONWebKeysURI == "" && metadata.JSONWebKeys == nil {
+		return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHint("Either the 'jwks_uri' or 'jwks' value is required to verify signed Request Objects."))
+	}
+
+	for _, requestURI := range metadata.RequestURIs {
+		if uri, err := url.Parse(requestURI); err != nil || !uri.IsAbs() || uri.Scheme != schemeHTTPS {
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'request_uris' value '%s' must be an absolute URI with the https scheme.", requestURI))
+		} else if isRestrictedHostURI(uri) {
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'request_uris' value '%s' must not have a host which is localhost or a loopback, private, or link-local address.", requestURI))
+		}
+	}
+
+	return nil
+}
+
+func validateClientRegistrationURIs(metadata *ClientRegistrationMetadata, public bool) (err error) {
+	if len(metadata.RedirectURIs) == 0 && (utils.IsStringInSlice(GrantTypeAuthorizationCode, metadata.GrantTypes) || utils.IsStringInSlice(GrantTypeImplicit, metadata.GrantTypes)) {
+		return errorsx.WithStack(ErrClientRegistrationInvalidRedirectURI.WithHint("The 'redirect_uris' value is required for the requested 'grant_types'."))
+	}
+
+	for _, redirectURI := range metadata.RedirectURIs {
+		uri, err := url.Parse(redirectURI)
+
+		switch {
+		case err != nil || !uri.IsAbs():
+			return errorsx.WithStack(ErrClientRegistrationInvalidRedirectURI.WithHintf("The 'redirect_uris' value '%s' must be an absolute URI.", redirectURI))
+		case uri.Fragment != "":
+			return errorsx.WithStack(ErrClientRegistrationInvalidRedirectURI.WithHintf("The 'redirect_uris' value '%s' must not have a fragment.", redirectURI))
+		case uri.Scheme == schemeHTTP && !isLoopbackURI(uri):
+			return errorsx.WithStack(ErrClientRegistrationInvalidRedirectURI.WithHintf("The 'redirect_uris' value '%s' must have the https scheme unless the host is a loopback address.", redirectURI))
+		case uri.Scheme == schemeHTTPS || uri.Scheme == schemeHTTP:
+			break
+		case !public:
+			return errorsx.WithStack(ErrClientRegistrationInvalidRedirectURI.WithHintf("The 'redirect_uris' value '%s' must have the http or https scheme.", redirectURI))
+		case !regexpPrivateUseURIScheme.MatchString(uri.Scheme):
+			return errorsx.WithStack(ErrClientRegistrationInvalidRedirectURI.WithHintf("The 'redirect_uris' value '%s' must have the https scheme, the http scheme with a loopback address, or a private-use scheme in reverse domain name form such as 'com.example.app'.", redirectURI))
+		}
+	}
+
+	for _, value := range metadata.PostLogoutRedirectURIs {
+		if uri, err := url.Parse(value); err != nil || !uri.IsAbs() || uri.Fragment != "" || (uri.Scheme != schemeHTTPS && !(uri.Scheme == schemeHTTP && isLoopbackURI(uri))) {
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'post_logout_redirect_uris' value '%s' must be an absolute URI with the https scheme and without a fragment, or the http scheme if the host is a loopback address.", value))
+		}
+	}
+
+	// The back-channel logout URI is requested by Authelia itself rather than the user agent, so unlike the other URIs
+	// the loopback exception would allow a client to make Authelia send requests to services on its own network.
+	if metadata.BackChannelLogoutURI != "" {
+		if uri, err := url.Parse(metadata.BackChannelLogoutURI); err != nil || !uri.IsAbs() || uri.Fragment != "" || uri.Scheme != schemeHTTPS {
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'backchannel_logout_uri' value '%s' must be an absolute URI with the https scheme and without a fragment.", metadata.BackChannelLogoutURI))
+		} else if isRestrictedHostURI(uri) {
+			return errorsx.WithStack(ErrClientRegistrationInvalidClientMetadata.WithHintf("The 'backchannel_logout_uri' value '%s' must not have a host which is localhost or a loopback, private, or link-local address.", metadata.BackChannelLogoutURI))
+		}
+	}
+
+	return nil
+}
+
+// isLoopbackURI returns true if the host of the URI is localhost or a loopback IP address.
+func isLoopbackURI(uri *url.URL) bool {
+	host := uri.Hostname()
+
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// NewRegisteredClientSecret generates a new random client secret and returns it alongside the encoded digest which is
+// stored in the database.
+func NewRegisteredClientSecret() (digest sql.NullString, secret string, err error) {
+	var d crypt.Digest
+
+	secret = utils.RandomString(64, utils.CharSetAlphaNumeric, true)
+
+	if d, err = crypt.NewPBKDF2SHA512Hash().Hash(secret); err != nil {
+		return sql.NullString{}, "", err
+	}
+
+	return sql.NullString{String: d.Encode(), Valid: true}, secret, nil
+}
+
+// EncodeClientRegistrationMetadata encodes the ClientRegistrationMetadata in the format stored in the database.
+func EncodeClientRegistrationMetadata(metadata ClientRegistrationMetadata) (encoded string, err error) {
+	var data []byte
+
+	if data, err = json.Marshal(metadata); err != nil {
+		return "", fmt.Errorf("error encoding the client metadata: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func bearerTokenFromRequest(r *http.Request) (token string) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+
+	if !found || !strings.EqualFold(scheme, "bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
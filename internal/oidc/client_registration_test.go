@@ -0,0 +1,543 @@
+package oidc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestOpenIDConnectProvider_RegisterClient(t *testing.T) {
+	provider, store := newTestClientRegistrationProvider(t)
+
+	ctx := context.Background()
+
+	response, err := provider.RegisterClient(ctx, newTestClientRegistrationHTTPRequest(http.MethodPost, "", "an-initial-access-token", map[string]any{
+		"client_name":   "Example App",
+		"redirect_uris": []string{"https://app.example.com/callback"},
+		"scope":         "openid profile",
+	}), testClientRegistrationIssuer)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+
+	assert.NotEmpty(t, response.ClientID)
+	assert.NotEmpty(t, response.ClientSecret)
+	assert.NotEmpty(t, response.RegistrationAccessToken)
+	assert.Equal(t, "https://auth.example.com/api/oidc/registration/"+response.ClientID, response.RegistrationClientURI)
+	require.NotNil(t, response.ClientSecretExpiresAt)
+	assert.Equal(t, int64(0), *response.ClientSecretExpiresAt)
+	assert.Equal(t, ClientAuthMethodClientSecretBasic, response.TokenEndpointAuthMethod)
+	assert.Equal(t, []string{GrantTypeRefreshToken, GrantTypeAuthorizationCode}, response.GrantTypes)
+	assert.Equal(t, []string{"code"}, response.ResponseTypes)
+	assert.Equal(t, "openid profile", response.Scope)
+
+	require.Contains(t, store.clients, response.ClientID)
+	assert.Equal(t, model.NewOAuth2ClientRegistrationAccessTokenSignature(response.RegistrationAccessToken), store.clients[response.ClientID].RegistrationAccessTokenSignature)
+	assert.NotContains(t, store.clients[response.ClientID].Secret.String, response.ClientSecret)
+
+	client, err := provider.GetFullClient(ctx, response.ClientID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Example App", client.Description)
+	assert.Equal(t, authorization.OneFactor, client.Policy)
+	assert.Equal(t, []string{ScopeOpenID, ScopeProfile}, client.Scopes)
+	assert.Equal(t, []string{"https://app.example.com/callback"}, client.RedirectURIs)
+	assert.False(t, client.IsPublic())
+	assert.NoError(t, AdaptiveHasher{}.Compare(ctx, client.GetHashedSecret(), []byte(response.ClientSecret)))
+
+	client, err = provider.GetFullClient(ctx, "a-client")
+
+	require.NoError(t, err)
+	assert.Equal(t, authorization.TwoFactor, client.Policy)
+}
+
+func TestOpenIDConnectProvider_RegisterClient_Public(t *testing.T) {
+	provider, store := newTestClientRegistrationProvider(t)
+
+	response, err := provider.RegisterClient(context.Background(), newTestClientRegistrationHTTPRequest(http.MethodPost, "", "an-initial-access-token", map[string]any{
+		"redirect_uris":              []string{"https://app.example.com/callback"},
+		"token_endpoint_auth_method": "none",
+	}), testClientRegistrationIssuer)
+
+	require.NoError(t, err)
+	assert.Empty(t, response.ClientSecret)
+	assert.Nil(t, response.ClientSecretExpiresAt)
+	assert.False(t, store.clients[response.ClientID].Secret.Valid)
+
+	client, err := provider.GetFullClient(context.Background(), response.ClientID)
+
+	require.NoError(t, err)
+	assert.True(t, client.IsPublic())
+	assert.Equal(t, response.ClientID, client.Description)
+}
+
+func TestOpenIDConnectProvider_RegisterClient_LoopbackAndNativeRedirectURIs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		metadata map[string]any
+	}{
+		{
+			"ShouldAllowLoopbackIPv4",
+			map[string]any{"redirect_uris": []string{"http://127.0.0.1:8080/callback"}, "post_logout_redirect_uris": []string{"http://127.0.0.1:8080/logged-out"}},
+		},
+		{
+			"ShouldAllowLoopbackIPv6",
+			map[string]any{"redirect_uris": []string{"http://[::1]:8080/callback"}},
+		},
+		{
+			"ShouldAllowLocalhost",
+			map[string]any{"redirect_uris": []string{"http://localhost/callback"}},
+		},
+		{
+			"ShouldAllowCustomSchemePublic",
+			map[string]any{"redirect_uris": []string{"com.example.app:/callback"}, "token_endpoint_auth_method": "none"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, store := newTestClientRegistrationProvider(t)
+
+			response, err := provider.RegisterClient(context.Background(), newTestClientRegistrationHTTPRequest(http.MethodPost, "", "an-initial-access-token", tc.metadata), testClientRegistrationIssuer)
+
+			require.NoError(t, err)
+			assert.Contains(t, store.clients, response.ClientID)
+		})
+	}
+}
+
+func TestOpenIDConnectProvider_RegisterClient_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		token    string
+		metadata map[string]any
+		expected string
+		code     int
+	}{
+		{
+			"ShouldRejectMissingToken",
+			"",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}},
+			"The access token provided is expired, revoked, malformed, or invalid for other reasons. The initial access token is not valid.",
+			http.StatusUnauthorized,
+		},
+		{
+			"ShouldRejectInvalidToken",
+			"not-the-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}},
+			"The access token provided is expired, revoked, malformed, or invalid for other reasons. The initial access token is not valid.",
+			http.StatusUnauthorized,
+		},
+		{
+			"ShouldRejectMissingRedirectURIs",
+			"an-initial-access-token",
+			map[string]any{},
+			"The value of one or more redirection URIs is invalid. The 'redirect_uris' value is required for the requested 'grant_types'.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectRelativeRedirectURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"/callback"}},
+			"The value of one or more redirection URIs is invalid. The 'redirect_uris' value '/callback' must be an absolute URI.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectInsecureRedirectURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"http://app.example.com/callback"}},
+			"The value of one or more redirection URIs is invalid. The 'redirect_uris' value 'http://app.example.com/callback' must have the https scheme unless the host is a loopback address.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectInsecureRedirectURIPublic",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"http://app.example.com/callback"}, "token_endpoint_auth_method": "none"},
+			"The value of one or more redirection URIs is invalid. The 'redirect_uris' value 'http://app.example.com/callback' must have the https scheme unless the host is a loopback address.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectCustomSchemeRedirectURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"com.example.app:/callback"}},
+			"The value of one or more redirection URIs is invalid. The 'redirect_uris' value 'com.example.app:/callback' must have the http or https scheme.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectJavaScriptRedirectURIPublic",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"javascript:alert(1)"}, "token_endpoint_auth_method": "none"},
+			"The value of one or more redirection URIs is invalid. The 'redirect_uris' value 'javascript:alert(1)' must have the https scheme, the http scheme with a loopback address, or a private-use scheme in reverse domain name form such as 'com.example.app'.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectDataRedirectURIPublic",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"DATA:text/html,<script>alert(1)</script>"}, "token_endpoint_auth_method": "none"},
+			"The value of one or more redirection URIs is invalid. The 'redirect_uris' value 'DATA:text/html,<script>alert(1)</script>' must have the https scheme, the http scheme with a loopback address, or a private-use scheme in reverse domain name form such as 'com.example.app'.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectVBScriptRedirectURIPublic",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"vbscript:msgbox(1)"}, "token_endpoint_auth_method": "none"},
+			"The value of one or more redirection URIs is invalid. The 'redirect_uris' value 'vbscript:msgbox(1)' must have the https scheme, the http scheme with a loopback address, or a private-use scheme in reverse domain name form such as 'com.example.app'.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectNonReverseDomainRedirectURIPublic",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"ms-settings:privacy"}, "token_endpoint_auth_method": "none"},
+			"The value of one or more redirection URIs is invalid. The 'redirect_uris' value 'ms-settings:privacy' must have the https scheme, the http scheme with a loopback address, or a private-use scheme in reverse domain name form such as 'com.example.app'.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectInsecurePostLogoutRedirectURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "post_logout_redirect_uris": []string{"http://app.example.com/logged-out"}},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'post_logout_redirect_uris' value 'http://app.example.com/logged-out' must be an absolute URI with the https scheme and without a fragment, or the http scheme if the host is a loopback address.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectInsecureBackChannelLogoutURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "backchannel_logout_uri": "http://app.example.com/logout"},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'backchannel_logout_uri' value 'http://app.example.com/logout' must be an absolute URI with the https scheme and without a fragment.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectLoopbackBackChannelLogoutURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "backchannel_logout_uri": "http://127.0.0.1:9091/logout"},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'backchannel_logout_uri' value 'http://127.0.0.1:9091/logout' must be an absolute URI with the https scheme and without a fragment.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectPrivateBackChannelLogoutURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "backchannel_logout_uri": "https://10.0.0.1/logout"},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'backchannel_logout_uri' value 'https://10.0.0.1/logout' must not have a host which is localhost or a loopback, private, or link-local address.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectLocalhostBackChannelLogoutURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "backchannel_logout_uri": "https://localhost/logout"},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'backchannel_logout_uri' value 'https://localhost/logout' must not have a host which is localhost or a loopback, private, or link-local address.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectUnsupportedScope",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "scope": "openid admin"},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'scope' value 'admin' is not supported.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectClientSecretJWT",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "token_endpoint_auth_method": "client_secret_jwt"},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'token_endpoint_auth_method' value 'client_secret_jwt' is not supported.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectPrivateKeyJWTWithoutKeys",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "token_endpoint_auth_method": "private_key_jwt"},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. Either the 'jwks_uri' or 'jwks' value is required when the 'token_endpoint_auth_method' is 'private_key_jwt'.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectLinkLocalJSONWebKeysURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "token_endpoint_auth_method": "private_key_jwt", "jwks_uri": "https://169.254.169.254/jwks.json"},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'jwks_uri' value 'https://169.254.169.254/jwks.json' must not have a host which is localhost or a loopback, private, or link-local address.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectFileJSONWebKeysURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "token_endpoint_auth_method": "private_key_jwt", "jwks_uri": "file:///etc/passwd"},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'jwks_uri' value 'file:///etc/passwd' must not have the file scheme as it's only permitted for clients in the configuration.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectPublicClientCredentials",
+			"an-initial-access-token",
+			map[string]any{"grant_types": []string{"client_credentials"}, "token_endpoint_auth_method": "none"},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'grant_types' value 'client_credentials' requires a confidential client.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectTokenExchange",
+			"an-initial-access-token",
+			map[string]any{"grant_types": []string{GrantTypeTokenExchange}},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'grant_types' value 'urn:ietf:params:oauth:grant-type:token-exchange' is not supported.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectSignedRequestObjectWithoutKeys",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "require_signed_request_object": true},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. Either the 'jwks_uri' or 'jwks' value is required to verify signed Request Objects.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectInsecureRequestURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "request_uris": []string{"http://app.example.com/request.jwt"}},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'request_uris' value 'http://app.example.com/request.jwt' must be an absolute URI with the https scheme.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectLoopbackRequestURI",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "request_uris": []string{"https://[::1]:9091/request.jwt"}},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'request_uris' value 'https://[::1]:9091/request.jwt' must not have a host which is localhost or a loopback, private, or link-local address.",
+			http.StatusBadRequest,
+		},
+		{
+			"ShouldRejectUnknownIDTokenAlg",
+			"an-initial-access-token",
+			map[string]any{"redirect_uris": []string{"https://app.example.com/callback"}, "id_token_signed_response_alg": "ES512"},
+			"The value of one of the client metadata fields is invalid and the server has rejected this request. The 'id_token_signed_response_alg' value 'ES512' is not supported.",
+			http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, store := newTestClientRegistrationProvider(t)
+
+			response, err := provider.RegisterClient(context.Background(), newTestClientRegistrationHTTPRequest(http.MethodPost, "", tc.token, tc.metadata), testClientRegistrationIssuer)
+
+			assert.Nil(t, response)
+			require.Error(t, err)
+			assert.Equal(t, tc.expected, fosite.ErrorToRFC6749Error(err).GetDescription())
+			assert.Len(t, store.clients, 0)
+
+			rw := httptest.NewRecorder()
+
+			provider.WriteClientRegistrationError(rw, err)
+
+			assert.Equal(t, tc.code, rw.Code)
+
+			if tc.code == http.StatusUnauthorized {
+				assert.Equal(t, `Bearer error="invalid_token"`, rw.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectProvider_ClientRegistrationManagement(t *testing.T) {
+	provider, store := newTestClientRegistrationProvider(t)
+
+	ctx := context.Background()
+
+	registration, err := provider.RegisterClient(ctx, newTestClientRegistrationHTTPRequest(http.MethodPost, "", "an-initial-access-token", map[string]any{
+		"redirect_uris": []string{"https://app.example.com/callback"},
+	}), testClientRegistrationIssuer)
+
+	require.NoError(t, err)
+
+	path := "/api/oidc/registration/" + registration.ClientID
+
+	response, err := provider.ReadClientRegistration(ctx, newTestClientRegistrationHTTPRequest(http.MethodGet, path, "not-the-token", nil), testClientRegistrationIssuer, registration.ClientID)
+
+	assert.Nil(t, response)
+	assert.EqualError(t, err, "invalid_token")
+
+	response, err = provider.ReadClientRegistration(ctx, newTestClientRegistrationHTTPRequest(http.MethodGet, path, registration.RegistrationAccessToken, nil), testClientRegistrationIssuer, registration.ClientID)
+
+	require.NoError(t, err)
+	assert.Equal(t, registration.ClientID, response.ClientID)
+	assert.Empty(t, response.ClientSecret)
+	assert.Equal(t, registration.RedirectURIs, response.RedirectURIs)
+	assert.NotEqual(t, registration.RegistrationAccessToken, response.RegistrationAccessToken)
+
+	token := response.RegistrationAccessToken
+
+	_, err = provider.ReadClientRegistration(ctx, newTestClientRegistrationHTTPRequest(http.MethodGet, path, registration.RegistrationAccessToken, nil), testClientRegistrationIssuer, registration.ClientID)
+
+	assert.EqualError(t, err, "invalid_token")
+
+	_, err = provider.UpdateClientRegistration(ctx, newTestClientRegistrationHTTPRequest(http.MethodPut, path, token, map[string]any{
+		"client_id":     "another-client",
+		"redirect_uris": []string{"https://app.example.com/callback"},
+	}), testClientRegistrationIssuer, registration.ClientID)
+
+	assert.EqualError(t, err, "invalid_request")
+
+	response, err = provider.UpdateClientRegistration(ctx, newTestClientRegistrationHTTPRequest(http.MethodPut, path, token, map[string]any{
+		"client_id":     registration.ClientID,
+		"client_secret": registration.ClientSecret,
+		"client_name":   "Updated App",
+		"redirect_uris": []string{"https://app.example.com/callback", "https://app.example.com/other"},
+	}), testClientRegistrationIssuer, registration.ClientID)
+
+	require.NoError(t, err)
+	assert.Empty(t, response.ClientSecret)
+
+	client, err := provider.GetFullClient(ctx, registration.ClientID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Updated App", client.Description)
+	assert.Equal(t, []string{"https://app.example.com/callback", "https://app.example.com/other"}, client.RedirectURIs)
+	assert.NoError(t, AdaptiveHasher{}.Compare(ctx, client.GetHashedSecret(), []byte(registration.ClientSecret)))
+
+	rw := httptest.NewRecorder()
+
+	provider.WriteClientRegistrationResponse(rw, http.StatusOK, response)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "no-store", rw.Header().Get("Cache-Control"))
+
+	actual := &ClientRegistrationResponse{}
+
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), actual))
+	assert.Equal(t, response, actual)
+
+	assert.EqualError(t, provider.DeleteClientRegistration(ctx, newTestClientRegistrationHTTPRequest(http.MethodDelete, path, token, nil), registration.ClientID), "invalid_token")
+	assert.NoError(t, provider.DeleteClientRegistration(ctx, newTestClientRegistrationHTTPRequest(http.MethodDelete, path, response.RegistrationAccessToken, nil), registration.ClientID))
+	assert.NotContains(t, store.clients, registration.ClientID)
+
+	_, err = provider.GetFullClient(ctx, registration.ClientID)
+
+	assert.EqualError(t, err, "not_found")
+}
+
+func TestOpenIDConnectProvider_ClientRegistrationDiscovery(t *testing.T) {
+	provider, _ := newTestClientRegistrationProvider(t)
+
+	assert.Equal(t, "https://auth.example.com/api/oidc/registration", provider.GetOpenIDConnectWellKnownConfiguration("https://auth.example.com").RegistrationEndpoint)
+	assert.Equal(t, "https://auth.example.com/api/oidc/registration", provider.GetOAuth2WellKnownConfiguration("https://auth.example.com").RegistrationEndpoint)
+}
+
+func TestStore_GetFullClient_ShouldLoadRegisteredClientsWhenDisabled(t *testing.T) {
+	store := &testClientRegistrationStore{clients: map[string]model.OAuth2Client{
+		"registered": {ClientID: "registered", Metadata: "{}"},
+		"managed":    {ClientID: "managed", Policy: "one_factor", Metadata: "{}"},
+	}}
+
+	s := NewOpenIDConnectStore(&schema.OpenIDConnectConfiguration{
+		DynamicClientRegistration: schema.OpenIDConnectDynamicClientRegistrationConfiguration{
+			Policy: "two_factor",
+		},
+	}, store)
+
+	client, err := s.GetFullClient(context.Background(), "registered")
+
+	require.NoError(t, err)
+	assert.Equal(t, authorization.TwoFactor, client.Policy)
+
+	client, err = s.GetFullClient(context.Background(), "managed")
+
+	require.NoError(t, err)
+	assert.Equal(t, authorization.OneFactor, client.Policy)
+
+	client, err = s.GetFullClient(context.Background(), "missing")
+
+	assert.Nil(t, client)
+	assert.EqualError(t, err, "not_found")
+}
+
+var testClientRegistrationIssuer = &url.URL{Scheme: "https", Host: "auth.example.com"}
+
+func newTestClientRegistrationProvider(t *testing.T) (provider *OpenIDConnectProvider, store *testClientRegistrationStore) {
+	t.Helper()
+
+	store = &testClientRegistrationStore{clients: map[string]model.OAuth2Client{}}
+
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+		DynamicClientRegistration: schema.OpenIDConnectDynamicClientRegistrationConfiguration{
+			Enable:             true,
+			InitialAccessToken: "an-initial-access-token",
+			Policy:             "one_factor",
+		},
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:     "a-client",
+				Secret: MustDecodeSecret("$plaintext$a-client-secret"),
+				Policy: "two_factor",
+				Scopes: []string{ScopeOpenID},
+				RedirectURIs: []string{
+					"https://example.com/callback",
+				},
+			},
+		},
+	}, store)
+
+	require.NoError(t, err)
+
+	return provider, store
+}
+
+func newTestClientRegistrationHTTPRequest(method, path, token string, body map[string]any) (r *http.Request) {
+	if path == "" {
+		path = "/api/oidc/registration"
+	}
+
+	data, _ := json.Marshal(body)
+
+	r = httptest.NewRequest(method, path, strings.NewReader(string(data)))
+	r.Header.Set("Content-Type", "application/json")
+
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return r
+}
+
+type testClientRegistrationStore struct {
+	storage.Provider
+
+	clients map[string]model.OAuth2Client
+}
+
+func (s *testClientRegistrationStore) SaveOAuth2Client(_ context.Context, client model.OAuth2Client) (err error) {
+	s.clients[client.ClientID] = client
+
+	return nil
+}
+
+func (s *testClientRegistrationStore) UpdateOAuth2Client(_ context.Context, client model.OAuth2Client) (err error) {
+	if _, ok := s.clients[client.ClientID]; !ok {
+		return sql.ErrNoRows
+	}
+
+	s.clients[client.ClientID] = client
+
+	return nil
+}
+
+func (s *testClientRegistrationStore) LoadOAuth2Client(_ context.Context, clientID string) (client *model.OAuth2Client, err error) {
+	c, ok := s.clients[clientID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+
+	return &c, nil
+}
+
+func (s *testClientRegistrationStore) DeleteOAuth2Client(_ context.Context, clientID string) (err error) {
+	delete(s.clients, clientID)
+
+	return nil
+}
@@ -1,7 +1,9 @@
 package oidc
 
 import (
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/ory/fosite"
 	"github.com/stretchr/testify/assert"
@@ -93,6 +95,14 @@ func TestClient_GetConsentResponseBody(t *testing.T) {
 	assert.Equal(t, "My Client", consentRequestBody.ClientDescription)
 	assert.Equal(t, expectedScopes, consentRequestBody.Scopes)
 	assert.Equal(t, expectedAudiences, consentRequestBody.Audience)
+	assert.Nil(t, consentRequestBody.Claims)
+	assert.Nil(t, consentRequestBody.EssentialClaims)
+
+	consent.Form = url.Values{FormParameterClaims: []string{`{"id_token":{"tenant_id":{"essential":true}},"userinfo":{"phone_number":null}}`}}.Encode()
+
+	consentRequestBody = c.GetConsentResponseBody(consent)
+	assert.Equal(t, []string{"phone_number", "tenant_id"}, consentRequestBody.Claims)
+	assert.Equal(t, []string{"tenant_id"}, consentRequestBody.EssentialClaims)
 }
 
 func TestClient_GetAudience(t *testing.T) {
@@ -183,6 +193,108 @@ func TestClient_GetRedirectURIs(t *testing.T) {
 	assert.Equal(t, "https://example.com/oauth2/callback", redirectURIs[0])
 }
 
+func TestClient_GetPostLogoutRedirectURIs(t *testing.T) {
+	c := Client{}
+
+	redirectURIs := c.GetPostLogoutRedirectURIs()
+	require.Len(t, redirectURIs, 0)
+	assert.False(t, c.IsPostLogoutRedirectURIAllowed("https://example.com/logout"))
+
+	c.PostLogoutRedirectURIs = []string{"https://example.com/logout"}
+
+	redirectURIs = c.GetPostLogoutRedirectURIs()
+	require.Len(t, redirectURIs, 1)
+	assert.Equal(t, "https://example.com/logout", redirectURIs[0])
+	assert.True(t, c.IsPostLogoutRedirectURIAllowed("https://example.com/logout"))
+	assert.False(t, c.IsPostLogoutRedirectURIAllowed("https://example.com/Logout"))
+	assert.False(t, c.IsPostLogoutRedirectURIAllowed("https://example.com/logout?a=b"))
+}
+
+func TestClient_GetBackChannelLogoutURI(t *testing.T) {
+	c := Client{}
+
+	assert.Equal(t, "", c.GetBackChannelLogoutURI())
+
+	c.BackChannelLogoutURI = "https://example.com/backchannel-logout"
+
+	assert.Equal(t, "https://example.com/backchannel-logout", c.GetBackChannelLogoutURI())
+}
+
+func TestClient_GetIDTokenSignedResponseAlg(t *testing.T) {
+	c := Client{}
+
+	assert.Equal(t, SigningAlgorithmRSAWithSHA256, c.GetIDTokenSignedResponseAlg())
+
+	c.IDTokenSignedResponseAlg = SigningAlgorithmECDSAWithSHA256
+
+	assert.Equal(t, SigningAlgorithmECDSAWithSHA256, c.GetIDTokenSignedResponseAlg())
+}
+
+func TestClient_GetAccessTokenSignedResponseAlg(t *testing.T) {
+	c := Client{}
+
+	assert.Equal(t, SigningAlgorithmNone, c.GetAccessTokenSignedResponseAlg())
+
+	c.AccessTokenSignedResponseAlg = SigningAlgorithmEdDSA
+
+	assert.Equal(t, SigningAlgorithmEdDSA, c.GetAccessTokenSignedResponseAlg())
+}
+
+func TestClient_GetTokenEndpointAuthMethod(t *testing.T) {
+	c := Client{}
+
+	assert.Equal(t, "", c.GetTokenEndpointAuthMethod())
+
+	c.Public = true
+
+	assert.Equal(t, ClientAuthMethodNone, c.GetTokenEndpointAuthMethod())
+
+	c.Public = false
+	c.TokenEndpointAuthMethod = ClientAuthMethodPrivateKeyJWT
+
+	assert.Equal(t, ClientAuthMethodPrivateKeyJWT, c.GetTokenEndpointAuthMethod())
+}
+
+func TestClient_GetTokenEndpointAuthSigningAlg(t *testing.T) {
+	c := Client{}
+
+	assert.Equal(t, "", c.GetTokenEndpointAuthSigningAlg())
+
+	c.TokenEndpointAuthMethod = ClientAuthMethodPrivateKeyJWT
+
+	assert.Equal(t, SigningAlgorithmRSAWithSHA256, c.GetTokenEndpointAuthSigningAlg())
+
+	c.TokenEndpointAuthMethod = ClientAuthMethodClientSecretJWT
+
+	assert.Equal(t, SigningAlgorithmHMACWithSHA256, c.GetTokenEndpointAuthSigningAlg())
+
+	c.TokenEndpointAuthSigningAlg = SigningAlgorithmHMACWithSHA512
+
+	assert.Equal(t, SigningAlgorithmHMACWithSHA512, c.GetTokenEndpointAuthSigningAlg())
+}
+
+func TestClient_GetSecretPlainText(t *testing.T) {
+	c := Client{}
+
+	secret, err := c.GetSecretPlainText()
+
+	assert.Nil(t, secret)
+	assert.EqualError(t, err, "the client secret is not a plaintext secret")
+
+	c.Secret = MustDecodeSecret("$plaintext$a-client-secret")
+
+	secret, err = c.GetSecretPlainText()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("a-client-secret"), secret)
+
+	c.Secret = MustDecodeSecret("$pbkdf2-sha512$310000$c8p78n7pUMln0jzvd4aK4Q$JNRBzwAo0ek5qKn50cFzzvE9RXV88h1wJn5KGiHrD0YKtZaR/nCb2CJPOsKaPK0hjf.9yHxzQGZziziccp6Yng")
+
+	_, err = c.GetSecretPlainText()
+
+	assert.EqualError(t, err, "the client secret is not a plaintext secret")
+}
+
 func TestClient_GetResponseModes(t *testing.T) {
 	c := Client{}
 
@@ -226,6 +338,61 @@ func TestClient_IsPublic(t *testing.T) {
 	assert.True(t, c.IsPublic())
 }
 
+func TestClient_GetEffectiveLifespan(t *testing.T) {
+	c := &Client{
+		Lifespans: schema.OpenIDConnectClientLifespans{
+			OpenIDConnectLifespans: schema.OpenIDConnectLifespans{
+				AccessToken: time.Minute * 5,
+			},
+			Grants: schema.OpenIDConnectClientGrantLifespans{
+				AuthorizationCode: schema.OpenIDConnectLifespans{
+					AccessToken:  time.Hour * 8,
+					RefreshToken: time.Hour * 24,
+				},
+				ClientCredentials: schema.OpenIDConnectLifespans{
+					IDToken: time.Minute,
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, time.Hour*8, c.GetEffectiveLifespan(GrantTypeAuthorizationCode, fosite.AccessToken, time.Hour))
+	assert.Equal(t, time.Hour*24, c.GetEffectiveLifespan(GrantTypeAuthorizationCode, fosite.RefreshToken, time.Hour))
+	assert.Equal(t, time.Hour, c.GetEffectiveLifespan(GrantTypeAuthorizationCode, fosite.IDToken, time.Hour))
+	assert.Equal(t, time.Minute*5, c.GetEffectiveLifespan(GrantTypeRefreshToken, fosite.AccessToken, time.Hour))
+	assert.Equal(t, time.Minute*5, c.GetEffectiveLifespan(GrantTypeClientCredentials, fosite.AccessToken, time.Hour))
+	assert.Equal(t, time.Minute, c.GetEffectiveLifespan(GrantTypeClientCredentials, fosite.IDToken, time.Hour))
+	assert.Equal(t, time.Hour, c.GetEffectiveLifespan(GrantTypeDeviceCode, fosite.RefreshToken, time.Hour))
+	assert.Equal(t, time.Hour, c.GetEffectiveLifespan(GrantTypeImplicit, fosite.AuthorizeCode, time.Hour))
+
+	assert.Equal(t, time.Hour, getEffectiveLifespan(&fosite.DefaultClient{}, GrantTypeAuthorizationCode, fosite.AccessToken, time.Hour))
+	assert.Equal(t, time.Hour*8, getEffectiveLifespan(c, GrantTypeAuthorizationCode, fosite.AccessToken, time.Hour))
+}
+
+func TestClient_GetEnforcePKCE(t *testing.T) {
+	testCases := []struct {
+		have                         string
+		force, forcePublic, expected bool
+	}{
+		{"", false, false, false},
+		{"never", false, false, true},
+		{"public_clients_only", false, true, true},
+		{"always", true, true, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.have, func(t *testing.T) {
+			c := &Client{EnforcePKCE: tc.have}
+
+			force, forcePublic, ok := c.GetEnforcePKCE()
+
+			assert.Equal(t, tc.force, force)
+			assert.Equal(t, tc.forcePublic, forcePublic)
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+}
+
 func MustDecodeSecret(value string) *schema.PasswordDigest {
 	if secret, err := schema.NewPasswordDigest(value, true); err != nil {
 		panic(err)
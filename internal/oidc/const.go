@@ -1,5 +1,10 @@
 package oidc
 
+import (
+	"regexp"
+	"time"
+)
+
 // Scope strings.
 const (
 	ScopeOfflineAccess = "offline_access"
@@ -33,6 +38,34 @@ const (
 	ClaimAuthenticationContextClassReference = "acr"
 	ClaimAuthenticationMethodsReference      = "amr"
 	ClaimClientIdentifier                    = "client_id"
+	ClaimScope                               = "scope"
+	ClaimEvents                              = "events"
+	ClaimConfirmation                        = "cnf"
+)
+
+// RFC9449 OAuth 2.0 Demonstrating Proof of Possession (DPoP) claim strings.
+const (
+	// ClaimHTTPMethod is the DPoP proof claim for the HTTP method of the request the proof is attached to.
+	ClaimHTTPMethod = "htm"
+
+	// ClaimHTTPURI is the DPoP proof claim for the HTTP URI of the request the proof is attached to, without the query
+	// and fragment parts.
+	ClaimHTTPURI = "htu"
+
+	// ClaimAccessTokenHashDPoP is the DPoP proof claim for the base64url encoded SHA-256 hash of the access token the
+	// proof is presented with.
+	ClaimAccessTokenHashDPoP = "ath"
+
+	// ClaimConfirmationJWKThumbprint is the confirmation method member of the cnf claim which holds the base64url
+	// encoded SHA-256 JWK Thumbprint of the key a token is bound to.
+	ClaimConfirmationJWKThumbprint = "jkt"
+)
+
+// RFC8705 OAuth 2.0 Mutual-TLS Client Authentication and Certificate-Bound Access Tokens claim strings.
+const (
+	// ClaimConfirmationX509CertificateThumbprint is the confirmation method member of the cnf claim which holds the
+	// base64url encoded SHA-256 thumbprint of the X.509 certificate a token is bound to.
+	ClaimConfirmationX509CertificateThumbprint = "x5t#S256"
 )
 
 const (
@@ -41,11 +74,26 @@ const (
 	ClaimEmailAlts = "alt_emails"
 )
 
+// User Attribute strings. These are the names of the standard user attributes which can be mapped to custom claims,
+// any other attribute name is resolved from the extra attributes of the user.
+const (
+	UserAttributeUsername    = "username"
+	UserAttributeDisplayName = "display_name"
+	UserAttributeEmail       = "email"
+	UserAttributeEmails      = "emails"
+	UserAttributeGroups      = "groups"
+)
+
 // Response Mode strings.
 const (
 	ResponseModeQuery    = "query"
 	ResponseModeFormPost = "form_post"
 	ResponseModeFragment = "fragment"
+
+	ResponseModeJWT         = "jwt"
+	ResponseModeQueryJWT    = "query.jwt"
+	ResponseModeFormPostJWT = "form_post.jwt"
+	ResponseModeFragmentJWT = "fragment.jwt"
 )
 
 // Grant Type strings.
@@ -55,12 +103,60 @@ const (
 	GrantTypeAuthorizationCode = "authorization_code"
 	GrantTypePassword          = "password"
 	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+	GrantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+)
+
+// Token Type Identifier strings.
+//
+// RFC8693: https://www.rfc-editor.org/rfc/rfc8693.html#section-3
+const (
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
 )
 
 // Signing Algorithm strings.
 const (
-	SigningAlgorithmNone          = none
-	SigningAlgorithmRSAWithSHA256 = "RS256"
+	SigningAlgorithmNone             = none
+	SigningAlgorithmHMACWithSHA256   = "HS256"
+	SigningAlgorithmHMACWithSHA384   = "HS384"
+	SigningAlgorithmHMACWithSHA512   = "HS512"
+	SigningAlgorithmRSAWithSHA256    = "RS256"
+	SigningAlgorithmRSAWithSHA384    = "RS384"
+	SigningAlgorithmRSAWithSHA512    = "RS512"
+	SigningAlgorithmRSAPSSWithSHA256 = "PS256"
+	SigningAlgorithmRSAPSSWithSHA384 = "PS384"
+	SigningAlgorithmRSAPSSWithSHA512 = "PS512"
+	SigningAlgorithmECDSAWithSHA256  = "ES256"
+	SigningAlgorithmECDSAWithSHA384  = "ES384"
+	SigningAlgorithmECDSAWithSHA512  = "ES512"
+	SigningAlgorithmEdDSA            = "EdDSA"
+)
+
+// Client Authentication Method strings.
+const (
+	ClientAuthMethodClientSecretBasic = "client_secret_basic"
+	ClientAuthMethodClientSecretPost  = "client_secret_post"
+	ClientAuthMethodClientSecretJWT   = "client_secret_jwt"
+	ClientAuthMethodPrivateKeyJWT     = "private_key_jwt"
+	ClientAuthMethodNone              = none
+
+	// ClientAuthMethodTLSClientAuth is the RFC8705 PKI Mutual-TLS client authentication method.
+	ClientAuthMethodTLSClientAuth = "tls_client_auth"
+
+	// ClientAuthMethodSelfSignedTLSClientAuth is the RFC8705 Self-Signed Certificate Mutual-TLS client authentication
+	// method.
+	ClientAuthMethodSelfSignedTLSClientAuth = "self_signed_tls_client_auth"
+)
+
+// Client Assertion Type strings.
+const (
+	// ClientAssertionTypeJWTBearer is the client_assertion_type value used for RFC7523 JWT client authentication.
+	ClientAssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+)
+
+// Key Use strings.
+const (
+	KeyUseSignature = "sig"
 )
 
 // Subject Type strings.
@@ -82,17 +178,130 @@ const (
 	EndpointUserinfo      = "userinfo"
 	EndpointIntrospection = "introspection"
 	EndpointRevocation    = "revocation"
+	EndpointEndSession    = "end-session"
+
+	EndpointPushedAuthorizationRequest = "pushed-authorization-request"
+	EndpointDeviceAuthorization        = "device-authorization"
+	EndpointDeviceVerification         = "device-verification"
+	EndpointRegistration               = "registration"
+)
+
+// Form Parameter strings.
+const (
+	FormParameterClientID              = "client_id"
+	FormParameterState                 = "state"
+	FormParameterIDTokenHint           = "id_token_hint"
+	FormParameterPostLogoutRedirectURI = "post_logout_redirect_uri"
+	FormParameterLogoutToken           = "logout_token"
+	FormParameterClientSecret          = "client_secret"
+	FormParameterClientAssertionType   = "client_assertion_type"
+	FormParameterClientAssertion       = "client_assertion"
+	FormParameterRequest               = "request"
+	FormParameterRequestURI            = "request_uri"
+	FormParameterDeviceCode            = "device_code"
+	FormParameterUserCode              = "user_code"
+	FormParameterScope                 = "scope"
+	FormParameterSubjectToken          = "subject_token"
+	FormParameterSubjectTokenType      = "subject_token_type"
+	FormParameterActorToken            = "actor_token"
+	FormParameterActorTokenType        = "actor_token_type"
+	FormParameterRequestedTokenType    = "requested_token_type"
+	FormParameterClaims                = "claims"
+	FormParameterResponse              = "response"
+	FormParameterPrompt                = "prompt"
+	FormParameterMaxAge                = "max_age"
+	FormParameterACRValues             = "acr_values"
+)
+
+// Prompt strings.
+const (
+	PromptNone    = "none"
+	PromptLogin   = "login"
+	PromptConsent = "consent"
+)
+
+// Pushed Authorization Request strings.
+const (
+	// RequestURIPrefixPushedAuthorizationRequestURN is the prefix of the request_uri values issued by the RFC9126
+	// OAuth 2.0 Pushed Authorization Request endpoint.
+	RequestURIPrefixPushedAuthorizationRequestURN = "urn:ietf:params:oauth:request_uri:"
+)
+
+// Client Credentials Grant strings.
+const (
+	// SubjectPrefixClientCredentials is the prefix of the subject of the tokens issued by the Client Credentials grant.
+	// User subjects are opaque UUIDs which never have this prefix, so the service account subjects can't collide with
+	// them.
+	SubjectPrefixClientCredentials = "client:"
+)
+
+// Device Authorization Grant strings.
+const (
+	// DeviceUserCodeCharSet is the character set used to generate the RFC8628 OAuth 2.0 Device Authorization Grant
+	// user_code. It only contains upper case consonants which avoids generating words and confusion between characters
+	// which look alike.
+	DeviceUserCodeCharSet = "BCDFGHJKLMNPQRSTVWXZ"
+
+	// DeviceUserCodeLength is the number of characters in the user_code excluding the separator.
+	DeviceUserCodeLength = 8
+)
+
+// Event strings.
+const (
+	// EventBackChannelLogout is the event identifier used in the events claim of a Logout Token.
+	//
+	// OpenID Connect Back-Channel Logout 1.0: https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+	EventBackChannelLogout = "http://schemas.openid.net/event/backchannel-logout"
 )
 
 // JWT Headers.
 const (
 	// JWTHeaderKeyIdentifier is the JWT Header referencing the JWS Key Identifier used to sign a token.
 	JWTHeaderKeyIdentifier = "kid"
+
+	// JWTHeaderAlgorithm is the JWT Header referencing the JWS Algorithm used to sign a token.
+	JWTHeaderAlgorithm = "alg"
+
+	// JWTHeaderType is the JWT Header referencing the media type of the token.
+	JWTHeaderType = "typ"
+
+	// JWTHeaderJSONWebKey is the JWT Header referencing the public JSON Web Key used to sign a token.
+	JWTHeaderJSONWebKey = "jwk"
+)
+
+// JWT Header Type strings.
+const (
+	// JWTHeaderTypeLogoutToken is the JWT Header Type value used for OpenID Connect Back-Channel Logout 1.0 Logout
+	// Tokens.
+	JWTHeaderTypeLogoutToken = "logout+jwt"
+
+	// JWTHeaderTypeAccessToken is the JWT Header Type value used for RFC9068 JWT Profile for OAuth 2.0 Access Tokens.
+	JWTHeaderTypeAccessToken = "at+jwt"
+
+	// JWTHeaderTypeDPoP is the JWT Header Type value used for RFC9449 OAuth 2.0 Demonstrating Proof of Possession
+	// (DPoP) proofs.
+	JWTHeaderTypeDPoP = "dpop+jwt"
+)
+
+// RFC9449 OAuth 2.0 Demonstrating Proof of Possession (DPoP) strings.
+const (
+	// HeaderDPoP is the HTTP header which carries the DPoP proof.
+	HeaderDPoP = "DPoP"
+
+	// TokenTypeDPoP is the token_type of access tokens which are bound to a DPoP key, and the authorization scheme
+	// used to present them.
+	TokenTypeDPoP = "DPoP"
+
+	// TokenTypeBearer is the token_type of access tokens which are not bound to a key, and the authorization scheme
+	// used to present them.
+	TokenTypeBearer = "Bearer"
 )
 
 // Paths.
 const (
 	EndpointPathConsent                           = "/consent"
+	EndpointPathDevice                            = "/device"
+	EndpointPathEndSessionConfirmation            = "/end-session"
 	EndpointPathWellKnownOpenIDConfiguration      = "/.well-known/openid-configuration"
 	EndpointPathWellKnownOAuthAuthorizationServer = "/.well-known/oauth-authorization-server"
 	EndpointPathJWKs                              = "/jwks.json"
@@ -104,6 +313,12 @@ const (
 	EndpointPathUserinfo      = EndpointPathRoot + "/" + EndpointUserinfo
 	EndpointPathIntrospection = EndpointPathRoot + "/" + EndpointIntrospection
 	EndpointPathRevocation    = EndpointPathRoot + "/" + EndpointRevocation
+	EndpointPathEndSession    = EndpointPathRoot + "/" + EndpointEndSession
+
+	EndpointPathPushedAuthorizationRequest = EndpointPathRoot + "/" + EndpointPushedAuthorizationRequest
+	EndpointPathDeviceAuthorization        = EndpointPathRoot + "/" + EndpointDeviceAuthorization
+	EndpointPathDeviceVerification         = EndpointPathRoot + "/" + EndpointDeviceVerification
+	EndpointPathRegistration               = EndpointPathRoot + "/" + EndpointRegistration
 )
 
 // Authentication Method Reference Values https://datatracker.ietf.org/doc/html/rfc8176
@@ -206,3 +421,64 @@ const (
 	preconfigured = "pre-configured"
 	none          = "none"
 )
+
+const (
+	backChannelLogoutTimeout       = time.Second * 10
+	backChannelLogoutTokenLifespan = time.Minute * 2
+	backChannelLogoutRetryInterval = time.Second * 5
+	backChannelLogoutMaxAttempts   = 3
+
+	// backChannelLogoutLease is how long the instance which claimed a Back-Channel Logout has to deliver it before
+	// another instance may claim it. It's considerably longer than the worst case duration of every delivery attempt.
+	backChannelLogoutLease = time.Minute * 5
+)
+
+// jwtSecuredResponseLifespan is the lifespan of a JWT Secured Authorization Response.
+const jwtSecuredResponseLifespan = time.Minute * 5
+
+const (
+	// dpopProofLifespan is the duration after the iat claim a DPoP proof is accepted for, and the duration its jti is
+	// tracked for replay protection.
+	dpopProofLifespan = time.Minute * 5
+
+	// dpopProofLeeway is the tolerated clock skew for DPoP proofs issued in the future.
+	dpopProofLeeway = time.Second * 30
+)
+
+// requestObjectMaxSize is the maximum size in bytes of a Request Object fetched from a request_uri.
+const requestObjectMaxSize = 1 << 20
+
+const (
+	// jwksMaxSize is the maximum size in bytes of a JSON Web Key Set fetched from the jwks_uri of a registered client.
+	jwksMaxSize = 1 << 20
+
+	// jwksTimeout is the timeout for fetching a JSON Web Key Set from the jwks_uri of a registered client.
+	jwksTimeout = time.Second * 10
+)
+
+const (
+	keyRotationCheckInterval = time.Hour
+)
+
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+)
+
+var (
+	registrationGrantTypes = []string{GrantTypeAuthorizationCode, GrantTypeImplicit, GrantTypeRefreshToken,
+		GrantTypeClientCredentials, GrantTypeDeviceCode}
+	registrationTokenEndpointAuthSigningAlgs = []string{SigningAlgorithmRSAWithSHA256, SigningAlgorithmRSAWithSHA384,
+		SigningAlgorithmRSAWithSHA512, SigningAlgorithmRSAPSSWithSHA256, SigningAlgorithmRSAPSSWithSHA384,
+		SigningAlgorithmRSAPSSWithSHA512, SigningAlgorithmECDSAWithSHA256, SigningAlgorithmECDSAWithSHA384,
+		SigningAlgorithmECDSAWithSHA512, SigningAlgorithmEdDSA}
+	requestObjectSigningAlgs = append([]string{SigningAlgorithmNone}, registrationTokenEndpointAuthSigningAlgs...)
+	dpopSigningAlgs          = []string{SigningAlgorithmRSAWithSHA256, SigningAlgorithmRSAWithSHA384,
+		SigningAlgorithmRSAWithSHA512, SigningAlgorithmRSAPSSWithSHA256, SigningAlgorithmRSAPSSWithSHA384,
+		SigningAlgorithmRSAPSSWithSHA512, SigningAlgorithmECDSAWithSHA256, SigningAlgorithmECDSAWithSHA384,
+		SigningAlgorithmECDSAWithSHA512, SigningAlgorithmEdDSA}
+)
+
+// regexpPrivateUseURIScheme matches private-use URI schemes in the reverse domain name form described by RFC8252
+// Section 7.1, i.e. 'com.example.app'.
+var regexpPrivateUseURIScheme = regexp.MustCompile(`^[a-z][a-z0-9-]*(\.[a-z0-9][a-z0-9-]*)+$`)
@@ -0,0 +1,252 @@
+package oidc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/ory/fosite"
+	"github.com/ory/x/errorsx"
+
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewDeviceAuthorizeRequest handles a RFC8628 OAuth 2.0 Device Authorization Request. The client is authenticated
+// using the same methods as the token endpoint and must be permitted to use the device code grant type.
+//
+// RFC8628: https://www.rfc-editor.org/rfc/rfc8628.html#section-3.1
+func (p *OpenIDConnectProvider) NewDeviceAuthorizeRequest(ctx context.Context, r *http.Request) (requester fosite.Requester, err error) {
+	if r.Method != http.MethodPost {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("HTTP method is '%s', expected 'POST'.", r.Method))
+	}
+
+	if err = r.ParseMultipartForm(1 << 20); err != nil && err != http.ErrNotMultipart {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("Unable to parse HTTP body, make sure to send a properly formatted form request body.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	var client fosite.Client
+
+	if client, err = p.clientAuthenticationStrategy(ctx, r, r.PostForm); err != nil {
+		return nil, err
+	}
+
+	if !client.GetGrantTypes().Has(GrantTypeDeviceCode) {
+		return nil, errorsx.WithStack(fosite.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use the authorization grant '%s'.", GrantTypeDeviceCode))
+	}
+
+	form := url.Values{}
+
+	for key, values := range r.PostForm {
+		switch key {
+		case FormParameterClientSecret, FormParameterClientAssertionType, FormParameterClientAssertion:
+			continue
+		default:
+			form[key] = values
+		}
+	}
+
+	form.Set(FormParameterClientID, client.GetID())
+
+	scopes := fosite.RemoveEmpty(strings.Split(form.Get(FormParameterScope), " "))
+
+	for _, scope := range scopes {
+		if !fosite.HierarchicScopeStrategy(client.GetScopes(), scope) {
+			return nil, errorsx.WithStack(fosite.ErrInvalidScope.WithHintf("The OAuth 2.0 Client is not allowed to request scope '%s'.", scope))
+		}
+	}
+
+	audience := fosite.GetAudiences(form)
+
+	if err = fosite.DefaultAudienceMatchingStrategy(client.GetAudience(), audience); err != nil {
+		return nil, err
+	}
+
+	request := fosite.NewRequest()
+
+	request.Client = client
+	request.Form = form
+	request.Session = NewSession()
+
+	request.SetRequestedScopes(scopes)
+	request.SetRequestedAudience(audience)
+
+	return request, nil
+}
+
+// NewDeviceAuthorizeResponse persists a validated Device Authorization Request and generates the device_code and
+// user_code. The issuer is used to generate the verification URI which is the device page of the portal.
+func (p *OpenIDConnectProvider) NewDeviceAuthorizeResponse(ctx context.Context, requester fosite.Requester, issuer *url.URL) (responder *DeviceAuthorizeResponse, err error) {
+	deviceCode := utils.RandomString(64, utils.CharSetAlphaNumeric, true)
+	userCode := utils.RandomString(DeviceUserCodeLength, DeviceUserCodeCharSet, true)
+
+	var device *model.OAuth2DeviceCodeSession
+
+	if device, err = model.NewOAuth2DeviceCodeSession(deviceCode, userCode, requester, time.Now().Add(p.deviceCodeLifespan)); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHint("Could not generate the device code session.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if err = p.Store.provider.SaveOAuth2DeviceCodeSession(ctx, *device); err != nil {
+		return nil, errorsx.WithStack(ErrDeviceCodeCouldNotSave.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	verificationURI := fmt.Sprintf("%s%s", issuer, EndpointPathDevice)
+
+	userCode = FormatDeviceUserCode(userCode)
+
+	return &DeviceAuthorizeResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: fmt.Sprintf("%s?%s", verificationURI, url.Values{FormParameterUserCode: []string{userCode}}.Encode()),
+		ExpiresIn:               int(p.deviceCodeLifespan.Seconds()),
+		Interval:                int(p.deviceCodePollingInterval.Seconds()),
+	}, nil
+}
+
+// WriteDeviceAuthorizeResponse writes the DeviceAuthorizeResponse to the http.ResponseWriter.
+func (p *OpenIDConnectProvider) WriteDeviceAuthorizeResponse(rw http.ResponseWriter, responder *DeviceAuthorizeResponse) {
+	rw.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("Pragma", "no-cache")
+
+	rw.WriteHeader(http.StatusOK)
+
+	_ = json.NewEncoder(rw).Encode(responder)
+}
+
+// NewDeviceVerificationRequest looks up the pending Device Authorization Request given the user_code entered by the
+// user and returns a fosite.AuthorizeRequester representing it, which is used to perform the consent flow.
+func (p *OpenIDConnectProvider) NewDeviceVerificationRequest(ctx context.Context, userCode string) (requester fosite.AuthorizeRequester, device *model.OAuth2DeviceCodeSession, err error) {
+	code := NormalizeDeviceUserCode(userCode)
+
+	if code == "" {
+		return nil, nil, errorsx.WithStack(ErrDeviceUserCodeInvalid)
+	}
+
+	if device, err = p.Store.provider.LoadOAuth2DeviceCodeSessionByUserCode(ctx, model.NewOAuth2DeviceCodeSignature(code)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, errorsx.WithStack(ErrDeviceUserCodeInvalid)
+		}
+
+		return nil, nil, errorsx.WithStack(ErrDeviceCodeCouldNotLookup.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if requester, err = p.newDeviceVerificationRequest(ctx, device); err != nil {
+		return nil, nil, err
+	}
+
+	requester.GetRequestForm().Set(FormParameterUserCode, FormatDeviceUserCode(code))
+
+	return requester, device, nil
+}
+
+// NewDeviceVerificationRequestByConsent looks up the pending Device Authorization Request which was bound to the
+// consent session with the provided challenge id by BindDeviceVerificationRequest and returns a
+// fosite.AuthorizeRequester representing it. The returned model.OAuth2DeviceCodeSession is nil without an error if the
+// consent session was not generated for a Device Authorization Request.
+func (p *OpenIDConnectProvider) NewDeviceVerificationRequestByConsent(ctx context.Context, challengeID uuid.UUID) (requester fosite.AuthorizeRequester, device *model.OAuth2DeviceCodeSession, err error) {
+	if device, err = p.Store.provider.LoadOAuth2DeviceCodeSessionByChallengeID(ctx, challengeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, nil
+		}
+
+		return nil, nil, errorsx.WithStack(ErrDeviceCodeCouldNotLookup.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if requester, err = p.newDeviceVerificationRequest(ctx, device); err != nil {
+		return nil, nil, err
+	}
+
+	return requester, device, nil
+}
+
+func (p *OpenIDConnectProvider) newDeviceVerificationRequest(ctx context.Context, device *model.OAuth2DeviceCodeSession) (requester fosite.AuthorizeRequester, err error) {
+	if device.Status != model.OAuth2DeviceCodeStatusPending || device.IsExpired() {
+		return nil, errorsx.WithStack(ErrDeviceUserCodeInvalid)
+	}
+
+	var (
+		client fosite.Client
+		form   url.Values
+	)
+
+	if client, err = p.Store.GetClient(ctx, device.ClientID); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if form, err = device.GetForm(); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHint("Could not parse the device authorization request.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	request := fosite.NewAuthorizeRequest()
+
+	request.ID = device.RequestID
+	request.RequestedAt = device.RequestedAt
+	request.Client = client
+	request.Form = form
+
+	request.SetRequestedScopes(fosite.Arguments(device.RequestedScopes))
+	request.SetRequestedAudience(fosite.Arguments(device.RequestedAudience))
+
+	return request, nil
+}
+
+// BindDeviceVerificationRequest records the consent session the user must respond to in order to approve or deny the
+// Device Authorization Request. This is the only way a consent session is associated with a Device Authorization
+// Request, so the values of the authorization form are never relied upon to determine it.
+func (p *OpenIDConnectProvider) BindDeviceVerificationRequest(ctx context.Context, device *model.OAuth2DeviceCodeSession, consent *model.OAuth2ConsentSession) (err error) {
+	if err = p.Store.provider.UpdateOAuth2DeviceCodeSessionChallengeID(ctx, device.Signature, consent.ChallengeID); err != nil {
+		return err
+	}
+
+	device.ChallengeID = uuid.NullUUID{UUID: consent.ChallengeID, Valid: true}
+
+	return nil
+}
+
+// ApproveDeviceVerificationRequest records the approval of a Device Authorization Request by the user. The session of
+// the fosite.Requester is the session used to issue the tokens once the client polls the token endpoint.
+func (p *OpenIDConnectProvider) ApproveDeviceVerificationRequest(ctx context.Context, device *model.OAuth2DeviceCodeSession, consent *model.OAuth2ConsentSession, requester fosite.Requester) (err error) {
+	if err = device.Approve(consent, requester); err != nil {
+		return err
+	}
+
+	return p.Store.provider.UpdateOAuth2DeviceCodeSession(ctx, *device)
+}
+
+// DenyDeviceVerificationRequest records the denial of a Device Authorization Request by the user.
+func (p *OpenIDConnectProvider) DenyDeviceVerificationRequest(ctx context.Context, device *model.OAuth2DeviceCodeSession) (err error) {
+	device.Status = model.OAuth2DeviceCodeStatusDenied
+
+	return p.Store.provider.UpdateOAuth2DeviceCodeSessionStatus(ctx, device.Signature, device.Status)
+}
+
+// NormalizeDeviceUserCode normalizes a user_code entered by the user by removing the separator and any whitespace and
+// converting it to upper case.
+func NormalizeDeviceUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || unicode.IsSpace(r) {
+			return -1
+		}
+
+		return unicode.ToUpper(r)
+	}, code)
+}
+
+// FormatDeviceUserCode formats a normalized user_code for display by separating the two halves with a hyphen.
+func FormatDeviceUserCode(code string) string {
+	if len(code) < 2 {
+		return code
+	}
+
+	return code[:len(code)/2] + "-" + code[len(code)/2:]
+}
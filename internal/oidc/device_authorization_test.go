@@ -0,0 +1,687 @@
+package oidc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestOpenIDConnectProvider_DeviceAuthorizeRequest(t *testing.T) {
+	provider, store := newTestDeviceAuthorizeProvider(t)
+
+	ctx := context.Background()
+
+	requester, err := provider.NewDeviceAuthorizeRequest(ctx, newTestDeviceAuthorizeHTTPRequest(url.Values{
+		FormParameterClientID:     []string{"a-client"},
+		FormParameterClientSecret: []string{"a-client-secret"},
+		FormParameterScope:        []string{"openid offline_access"},
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, "a-client", requester.GetClient().GetID())
+	assert.Equal(t, "", requester.GetRequestForm().Get(FormParameterClientSecret))
+	assert.Equal(t, fosite.Arguments{ScopeOpenID, ScopeOfflineAccess}, requester.GetRequestedScopes())
+
+	issuer, err := url.Parse("https://auth.example.com")
+
+	require.NoError(t, err)
+
+	responder, err := provider.NewDeviceAuthorizeResponse(ctx, requester, issuer)
+
+	require.NoError(t, err)
+	assert.Len(t, store.sessions, 1)
+	assert.Equal(t, "https://auth.example.com/device", responder.VerificationURI)
+	assert.Equal(t, "https://auth.example.com/device?user_code="+responder.UserCode, responder.VerificationURIComplete)
+	assert.Equal(t, 600, responder.ExpiresIn)
+	assert.Equal(t, 5, responder.Interval)
+	assert.Regexp(t, "^[BCDFGHJKLMNPQRSTVWXZ]{4}-[BCDFGHJKLMNPQRSTVWXZ]{4}$", responder.UserCode)
+
+	rw := httptest.NewRecorder()
+
+	provider.WriteDeviceAuthorizeResponse(rw, responder)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "no-store", rw.Header().Get("Cache-Control"))
+
+	actual := &DeviceAuthorizeResponse{}
+
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), actual))
+	assert.Equal(t, responder, actual)
+
+	authorize, device, err := provider.NewDeviceVerificationRequest(ctx, strings.ToLower(responder.UserCode))
+
+	require.NoError(t, err)
+	assert.Equal(t, requester.GetID(), authorize.GetID())
+	assert.Equal(t, "a-client", authorize.GetClient().GetID())
+	assert.Equal(t, responder.UserCode, authorize.GetRequestForm().Get(FormParameterUserCode))
+	assert.Equal(t, model.OAuth2DeviceCodeStatusPending, device.Status)
+
+	require.NoError(t, provider.DenyDeviceVerificationRequest(ctx, device))
+
+	_, _, err = provider.NewDeviceVerificationRequest(ctx, responder.UserCode)
+
+	assert.ErrorIs(t, err, ErrDeviceUserCodeInvalid)
+}
+
+func TestOpenIDConnectProvider_NewDeviceAuthorizeRequestShouldFail(t *testing.T) {
+	provider, _ := newTestDeviceAuthorizeProvider(t)
+
+	testCases := []struct {
+		name string
+		r    *http.Request
+		err  *fosite.RFC6749Error
+		hint string
+	}{
+		{
+			name: "ShouldRejectGET",
+			r:    httptest.NewRequest(http.MethodGet, "/api/oidc/device-authorization", nil),
+			err:  fosite.ErrInvalidRequest,
+			hint: "HTTP method is 'GET', expected 'POST'.",
+		},
+		{
+			name: "ShouldRejectBadSecret",
+			r: newTestDeviceAuthorizeHTTPRequest(url.Values{
+				FormParameterClientID:     []string{"a-client"},
+				FormParameterClientSecret: []string{"bad-secret"},
+			}),
+			err: fosite.ErrInvalidClient,
+		},
+		{
+			name: "ShouldRejectClientWithoutGrantType",
+			r: newTestDeviceAuthorizeHTTPRequest(url.Values{
+				FormParameterClientID:     []string{"b-client"},
+				FormParameterClientSecret: []string{"b-client-secret"},
+			}),
+			err:  fosite.ErrUnauthorizedClient,
+			hint: "The OAuth 2.0 Client is not allowed to use the authorization grant 'urn:ietf:params:oauth:grant-type:device_code'.",
+		},
+		{
+			name: "ShouldRejectInvalidScope",
+			r: newTestDeviceAuthorizeHTTPRequest(url.Values{
+				FormParameterClientID:     []string{"a-client"},
+				FormParameterClientSecret: []string{"a-client-secret"},
+				FormParameterScope:        []string{"openid groups"},
+			}),
+			err:  fosite.ErrInvalidScope,
+			hint: "The OAuth 2.0 Client is not allowed to request scope 'groups'.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := provider.NewDeviceAuthorizeRequest(context.Background(), tc.r)
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.err)
+
+			if tc.hint != "" {
+				assert.Equal(t, tc.hint, fosite.ErrorToRFC6749Error(err).HintField)
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectProvider_NewDeviceVerificationRequestShouldFail(t *testing.T) {
+	provider, store := newTestDeviceAuthorizeProvider(t)
+
+	store.add(model.OAuth2DeviceCodeSession{
+		ClientID:          "a-client",
+		Signature:         model.NewOAuth2DeviceCodeSignature("expired"),
+		UserCodeSignature: model.NewOAuth2DeviceCodeSignature("BBBBCCCC"),
+		ExpiresAt:         time.Now().Add(-time.Minute),
+	})
+
+	store.add(model.OAuth2DeviceCodeSession{
+		ClientID:          "a-client",
+		Signature:         model.NewOAuth2DeviceCodeSignature("approved"),
+		UserCodeSignature: model.NewOAuth2DeviceCodeSignature("DDDDFFFF"),
+		Status:            model.OAuth2DeviceCodeStatusApproved,
+		ExpiresAt:         time.Now().Add(time.Minute),
+	})
+
+	testCases := []struct {
+		name string
+		code string
+	}{
+		{"ShouldRejectEmpty", " - "},
+		{"ShouldRejectUnknown", "ZZZZ-ZZZZ"},
+		{"ShouldRejectExpired", "BBBB-CCCC"},
+		{"ShouldRejectApproved", "DDDD-FFFF"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := provider.NewDeviceVerificationRequest(context.Background(), tc.code)
+
+			assert.ErrorIs(t, err, ErrDeviceUserCodeInvalid)
+		})
+	}
+}
+
+func TestOpenIDConnectProvider_DeviceCodeGrant(t *testing.T) {
+	provider, store := newTestDeviceAuthorizeProvider(t)
+
+	store.add(model.OAuth2DeviceCodeSession{
+		RequestID:         "pending-request",
+		ClientID:          "a-client",
+		Signature:         model.NewOAuth2DeviceCodeSignature("pending"),
+		UserCodeSignature: model.NewOAuth2DeviceCodeSignature("BBBBBBBB"),
+		ExpiresAt:         time.Now().Add(time.Minute),
+	})
+
+	store.add(model.OAuth2DeviceCodeSession{
+		ClientID:          "a-client",
+		Signature:         model.NewOAuth2DeviceCodeSignature("expired"),
+		UserCodeSignature: model.NewOAuth2DeviceCodeSignature("CCCCCCCC"),
+		ExpiresAt:         time.Now().Add(-time.Minute),
+	})
+
+	store.add(model.OAuth2DeviceCodeSession{
+		ClientID:          "a-client",
+		Signature:         model.NewOAuth2DeviceCodeSignature("denied"),
+		UserCodeSignature: model.NewOAuth2DeviceCodeSignature("DDDDDDDD"),
+		Status:            model.OAuth2DeviceCodeStatusDenied,
+		ExpiresAt:         time.Now().Add(time.Minute),
+	})
+
+	store.add(model.OAuth2DeviceCodeSession{
+		ClientID:          "a-client",
+		Signature:         model.NewOAuth2DeviceCodeSignature("used"),
+		UserCodeSignature: model.NewOAuth2DeviceCodeSignature("FFFFFFFF"),
+		Status:            model.OAuth2DeviceCodeStatusUsed,
+		ExpiresAt:         time.Now().Add(time.Minute),
+	})
+
+	store.add(model.OAuth2DeviceCodeSession{
+		ClientID:          "b-client",
+		Signature:         model.NewOAuth2DeviceCodeSignature("other"),
+		UserCodeSignature: model.NewOAuth2DeviceCodeSignature("GGGGGGGG"),
+		ExpiresAt:         time.Now().Add(time.Minute),
+	})
+
+	testCases := []struct {
+		name string
+		code string
+		err  error
+		hint string
+	}{
+		{"ShouldReturnPending", "pending", ErrDeviceAuthorizationPending, ""},
+		{"ShouldReturnSlowDown", "pending", ErrDeviceSlowDown, ""},
+		{"ShouldReturnExpired", "expired", ErrDeviceExpiredToken, ""},
+		{"ShouldReturnDenied", "denied", fosite.ErrAccessDenied, "The end user denied the device authorization request."},
+		{"ShouldRejectUsed", "used", fosite.ErrInvalidGrant, "The 'device_code' parameter has already been used."},
+		{"ShouldRejectOtherClient", "other", fosite.ErrInvalidGrant, "The 'device_code' parameter was not issued to this client."},
+		{"ShouldRejectUnknown", "unknown", fosite.ErrInvalidGrant, "The 'device_code' parameter does not reference a known device authorization request."},
+		{"ShouldRejectEmpty", "", fosite.ErrInvalidRequest, "The 'device_code' parameter is required."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := provider.NewAccessRequest(context.Background(), newTestDeviceCodeTokenHTTPRequest(tc.code), NewSession())
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.err)
+
+			if tc.hint != "" {
+				assert.Equal(t, tc.hint, fosite.ErrorToRFC6749Error(err).HintField)
+			}
+		})
+	}
+
+	assert.True(t, store.sessions[model.NewOAuth2DeviceCodeSignature("pending")].CheckedAt.Valid)
+}
+
+func TestOpenIDConnectProvider_DeviceCodeGrantApproved(t *testing.T) {
+	provider, store := newTestDeviceAuthorizeProvider(t)
+
+	ctx := context.Background()
+
+	requester, err := provider.NewDeviceAuthorizeRequest(ctx, newTestDeviceAuthorizeHTTPRequest(url.Values{
+		FormParameterClientID:     []string{"a-client"},
+		FormParameterClientSecret: []string{"a-client-secret"},
+		FormParameterScope:        []string{"openid offline_access"},
+	}))
+
+	require.NoError(t, err)
+
+	issuer, err := url.Parse("https://auth.example.com")
+
+	require.NoError(t, err)
+
+	responder, err := provider.NewDeviceAuthorizeResponse(ctx, requester, issuer)
+
+	require.NoError(t, err)
+
+	authorize, device, err := provider.NewDeviceVerificationRequest(ctx, responder.UserCode)
+
+	require.NoError(t, err)
+
+	consent := &model.OAuth2ConsentSession{
+		ChallengeID: uuid.New(),
+		ClientID:    "a-client",
+		Subject:     uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		RequestedAt: time.Now(),
+	}
+
+	authorize.GrantScope(ScopeOpenID)
+	authorize.GrantScope(ScopeOfflineAccess)
+
+	session := NewSessionWithAuthorizeRequest(issuer, "", "RS256", "", "john", []string{"pwd"}, nil, time.Now(), consent, authorize)
+
+	authorize.SetSession(session)
+
+	require.NoError(t, provider.ApproveDeviceVerificationRequest(ctx, device, consent, authorize))
+
+	access, err := provider.NewAccessRequest(ctx, newTestDeviceCodeTokenHTTPRequest(responder.DeviceCode), NewSession())
+
+	require.NoError(t, err)
+	assert.Equal(t, requester.GetID(), access.GetID())
+	assert.Equal(t, fosite.Arguments{ScopeOpenID, ScopeOfflineAccess}, access.GetGrantedScopes())
+
+	response, err := provider.NewAccessResponse(ctx, access)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.GetAccessToken())
+	assert.NotEmpty(t, response.GetExtra("refresh_token"))
+	assert.NotEmpty(t, response.GetExtra("id_token"))
+	assert.Equal(t, model.OAuth2DeviceCodeStatusUsed, store.sessions[device.Signature].Status)
+
+	_, err = provider.NewAccessRequest(ctx, newTestDeviceCodeTokenHTTPRequest(responder.DeviceCode), NewSession())
+
+	assert.ErrorIs(t, err, fosite.ErrInvalidGrant)
+}
+
+func TestOpenIDConnectProvider_DeviceCodeGrantApprovedConcurrently(t *testing.T) {
+	provider, store := newTestDeviceAuthorizeProvider(t)
+
+	ctx := context.Background()
+
+	requester, err := provider.NewDeviceAuthorizeRequest(ctx, newTestDeviceAuthorizeHTTPRequest(url.Values{
+		FormParameterClientID:     []string{"a-client"},
+		FormParameterClientSecret: []string{"a-client-secret"},
+		FormParameterScope:        []string{"openid"},
+	}))
+
+	require.NoError(t, err)
+
+	issuer, err := url.Parse("https://auth.example.com")
+
+	require.NoError(t, err)
+
+	responder, err := provider.NewDeviceAuthorizeResponse(ctx, requester, issuer)
+
+	require.NoError(t, err)
+
+	authorize, device, err := provider.NewDeviceVerificationRequest(ctx, responder.UserCode)
+
+	require.NoError(t, err)
+
+	consent := &model.OAuth2ConsentSession{
+		ChallengeID: uuid.New(),
+		ClientID:    "a-client",
+		Subject:     uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		RequestedAt: time.Now(),
+	}
+
+	authorize.GrantScope(ScopeOpenID)
+	authorize.SetSession(NewSessionWithAuthorizeRequest(issuer, "", "RS256", "", "john", []string{"pwd"}, nil, time.Now(), consent, authorize))
+
+	require.NoError(t, provider.ApproveDeviceVerificationRequest(ctx, device, consent, authorize))
+
+	const requests = 10
+
+	// All the access requests are created before any of them are responded to so that every one of them has observed
+	// the approved device_code, which is the situation where two clients race to exchange the same device_code.
+	accesses := make([]fosite.AccessRequester, requests)
+
+	for i := range accesses {
+		accesses[i], err = provider.NewAccessRequest(ctx, newTestDeviceCodeTokenHTTPRequest(responder.DeviceCode), NewSession())
+
+		require.NoError(t, err)
+	}
+
+	var (
+		wg     sync.WaitGroup
+		errs   = make([]error, requests)
+		issued = make([]fosite.AccessResponder, requests)
+	)
+
+	for i := range accesses {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			issued[i], errs[i] = provider.NewAccessResponse(ctx, accesses[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	succeeded := 0
+
+	for i, err := range errs {
+		if err == nil {
+			succeeded++
+
+			assert.NotEmpty(t, issued[i].GetAccessToken())
+
+			continue
+		}
+
+		assert.ErrorIs(t, err, fosite.ErrInvalidGrant)
+	}
+
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, model.OAuth2DeviceCodeStatusUsed, store.sessions[device.Signature].Status)
+}
+
+func TestNormalizeDeviceUserCode(t *testing.T) {
+	testCases := []struct {
+		name, have, expected string
+	}{
+		{"ShouldNormalizeFormatted", "BCDF-GHJK", "BCDFGHJK"},
+		{"ShouldNormalizeLowerCase", "bcdf-ghjk", "BCDFGHJK"},
+		{"ShouldNormalizeWhitespace", " bcdf ghjk ", "BCDFGHJK"},
+		{"ShouldNormalizeEmpty", "", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NormalizeDeviceUserCode(tc.have))
+		})
+	}
+}
+
+func TestFormatDeviceUserCode(t *testing.T) {
+	assert.Equal(t, "BCDF-GHJK", FormatDeviceUserCode("BCDFGHJK"))
+	assert.Equal(t, "B", FormatDeviceUserCode("B"))
+}
+
+func TestOpenIDConnectProvider_BindDeviceVerificationRequest(t *testing.T) {
+	provider, _ := newTestDeviceAuthorizeProvider(t)
+
+	ctx := context.Background()
+
+	requester, err := provider.NewDeviceAuthorizeRequest(ctx, newTestDeviceAuthorizeHTTPRequest(url.Values{
+		FormParameterClientID:     []string{"a-client"},
+		FormParameterClientSecret: []string{"a-client-secret"},
+		FormParameterScope:        []string{"openid"},
+	}))
+
+	require.NoError(t, err)
+
+	issuer, err := url.Parse("https://auth.example.com")
+
+	require.NoError(t, err)
+
+	responder, err := provider.NewDeviceAuthorizeResponse(ctx, requester, issuer)
+
+	require.NoError(t, err)
+
+	consent := &model.OAuth2ConsentSession{ChallengeID: uuid.New()}
+
+	_, device, err := provider.NewDeviceVerificationRequestByConsent(ctx, consent.ChallengeID)
+
+	require.NoError(t, err)
+	assert.Nil(t, device, "a consent session which was not bound must not be treated as a device verification")
+
+	authorize, device, err := provider.NewDeviceVerificationRequest(ctx, responder.UserCode)
+
+	require.NoError(t, err)
+	require.NoError(t, provider.BindDeviceVerificationRequest(ctx, device, consent))
+	assert.Equal(t, uuid.NullUUID{UUID: consent.ChallengeID, Valid: true}, device.ChallengeID)
+
+	bound, device, err := provider.NewDeviceVerificationRequestByConsent(ctx, consent.ChallengeID)
+
+	require.NoError(t, err)
+	require.NotNil(t, device)
+	assert.Equal(t, authorize.GetID(), bound.GetID())
+	assert.Equal(t, "", bound.GetRequestForm().Get(FormParameterUserCode))
+
+	require.NoError(t, provider.DenyDeviceVerificationRequest(ctx, device))
+
+	_, _, err = provider.NewDeviceVerificationRequestByConsent(ctx, consent.ChallengeID)
+
+	assert.ErrorIs(t, err, ErrDeviceUserCodeInvalid)
+	assert.ErrorIs(t, provider.BindDeviceVerificationRequest(ctx, device, &model.OAuth2ConsentSession{ChallengeID: uuid.New()}), storage.ErrNoPendingOAuth2DeviceCodeSession)
+}
+
+func TestNewAuthorizeRequestRedirectFormShouldNotTreatUserCodeAsDeviceVerification(t *testing.T) {
+	form := url.Values{
+		FormParameterClientID: []string{"a-client"},
+		FormParameterScope:    []string{"openid"},
+		FormParameterUserCode: []string{"BCDF-GHJK"},
+	}
+
+	assert.Equal(t, form, NewAuthorizeRequestRedirectForm(form))
+}
+
+func newTestDeviceAuthorizeProvider(t *testing.T) (provider *OpenIDConnectProvider, store *testDeviceStore) {
+	t.Helper()
+
+	store = &testDeviceStore{sessions: map[string]model.OAuth2DeviceCodeSession{}}
+
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey:     mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:           "asbdhaaskmdlkamdklasmdlkams",
+		AccessTokenLifespan:  time.Hour,
+		RefreshTokenLifespan: time.Hour * 2,
+		IDTokenLifespan:      time.Hour,
+		DeviceAuthorization: schema.OpenIDConnectDeviceAuthorizationConfiguration{
+			CodeLifespan:    time.Minute * 10,
+			PollingInterval: time.Second * 5,
+		},
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:         "a-client",
+				Secret:     MustDecodeSecret("$plaintext$a-client-secret"),
+				Policy:     "one_factor",
+				Scopes:     []string{ScopeOpenID, ScopeOfflineAccess},
+				GrantTypes: []string{GrantTypeDeviceCode, GrantTypeRefreshToken},
+			},
+			{
+				ID:         "b-client",
+				Secret:     MustDecodeSecret("$plaintext$b-client-secret"),
+				Policy:     "one_factor",
+				Scopes:     []string{ScopeOpenID},
+				GrantTypes: []string{GrantTypeAuthorizationCode},
+			},
+		},
+	}, store)
+
+	require.NoError(t, err)
+
+	return provider, store
+}
+
+func newTestDeviceAuthorizeHTTPRequest(form url.Values) (r *http.Request) {
+	r = httptest.NewRequest(http.MethodPost, "/api/oidc/device-authorization", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return r
+}
+
+func newTestDeviceCodeTokenHTTPRequest(code string) (r *http.Request) {
+	form := url.Values{
+		"grant_type":              []string{GrantTypeDeviceCode},
+		FormParameterClientID:     []string{"a-client"},
+		FormParameterClientSecret: []string{"a-client-secret"},
+	}
+
+	if code != "" {
+		form.Set(FormParameterDeviceCode, code)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/api/oidc/token", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return r
+}
+
+type testDeviceStore struct {
+	storage.Provider
+
+	mu       sync.Mutex
+	sessions map[string]model.OAuth2DeviceCodeSession
+}
+
+func (s *testDeviceStore) add(session model.OAuth2DeviceCodeSession) {
+	s.sessions[session.Signature] = session
+}
+
+func (s *testDeviceStore) BeginTX(ctx context.Context) (c context.Context, err error) {
+	return ctx, nil
+}
+
+func (s *testDeviceStore) Commit(_ context.Context) (err error) {
+	return nil
+}
+
+func (s *testDeviceStore) Rollback(_ context.Context) (err error) {
+	return nil
+}
+
+func (s *testDeviceStore) SaveOAuth2Session(_ context.Context, _ storage.OAuth2SessionType, _ model.OAuth2Session) (err error) {
+	return nil
+}
+
+func (s *testDeviceStore) SaveOAuth2DeviceCodeSession(_ context.Context, session model.OAuth2DeviceCodeSession) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[session.Signature] = session
+
+	return nil
+}
+
+func (s *testDeviceStore) UpdateOAuth2DeviceCodeSession(_ context.Context, session model.OAuth2DeviceCodeSession) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[session.Signature]; !ok {
+		return sql.ErrNoRows
+	}
+
+	s.sessions[session.Signature] = session
+
+	return nil
+}
+
+func (s *testDeviceStore) UpdateOAuth2DeviceCodeSessionStatus(_ context.Context, signature string, status model.OAuth2DeviceCodeStatus) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[signature]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	session.Status = status
+
+	s.sessions[signature] = session
+
+	return nil
+}
+
+func (s *testDeviceStore) ConsumeOAuth2DeviceCodeSession(_ context.Context, signature string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[signature]
+	if !ok || session.Status != model.OAuth2DeviceCodeStatusApproved {
+		return storage.ErrNoApprovedOAuth2DeviceCodeSession
+	}
+
+	session.Status = model.OAuth2DeviceCodeStatusUsed
+
+	s.sessions[signature] = session
+
+	return nil
+}
+
+func (s *testDeviceStore) UpdateOAuth2DeviceCodeSessionCheckedAt(_ context.Context, signature string, checkedAt time.Time) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[signature]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	session.CheckedAt = sql.NullTime{Time: checkedAt, Valid: true}
+
+	s.sessions[signature] = session
+
+	return nil
+}
+
+func (s *testDeviceStore) LoadOAuth2DeviceCodeSession(_ context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.sessions[signature]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+
+	return &c, nil
+}
+
+func (s *testDeviceStore) LoadOAuth2DeviceCodeSessionByUserCode(_ context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.sessions {
+		if c.UserCodeSignature == signature {
+			return &c, nil
+		}
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+func (s *testDeviceStore) UpdateOAuth2DeviceCodeSessionChallengeID(_ context.Context, signature string, challengeID uuid.UUID) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[signature]
+	if !ok || session.Status != model.OAuth2DeviceCodeStatusPending {
+		return storage.ErrNoPendingOAuth2DeviceCodeSession
+	}
+
+	session.ChallengeID = uuid.NullUUID{UUID: challengeID, Valid: true}
+
+	s.sessions[signature] = session
+
+	return nil
+}
+
+func (s *testDeviceStore) LoadOAuth2DeviceCodeSessionByChallengeID(_ context.Context, challengeID uuid.UUID) (session *model.OAuth2DeviceCodeSession, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.sessions {
+		if c.ChallengeID.Valid && c.ChallengeID.UUID == challengeID {
+			return &c, nil
+		}
+	}
+
+	return nil, sql.ErrNoRows
+}
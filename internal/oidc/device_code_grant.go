@@ -0,0 +1,221 @@
+package oidc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/handler/openid"
+	fstorage "github.com/ory/fosite/storage"
+	"github.com/ory/x/errorsx"
+
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// deviceCodeGrantFactory is a compose.Factory which creates the DeviceCodeGrantHandler.
+func (p *OpenIDConnectProvider) deviceCodeGrantFactory(config *compose.Config, store any, strategy any) any {
+	return &DeviceCodeGrantHandler{
+		IDTokenHandleHelper: &openid.IDTokenHandleHelper{
+			IDTokenStrategy: strategy.(openid.OpenIDConnectTokenStrategy),
+		},
+		store:                store.(*Store),
+		AccessTokenStrategy:  strategy.(oauth2.AccessTokenStrategy),
+		RefreshTokenStrategy: strategy.(oauth2.RefreshTokenStrategy),
+		AccessTokenLifespan:  config.GetAccessTokenLifespan(),
+		RefreshTokenLifespan: config.GetRefreshTokenLifespan(),
+		RefreshTokenScopes:   config.GetRefreshTokenScopes(),
+		PollingInterval:      p.deviceCodePollingInterval,
+	}
+}
+
+// HandleTokenEndpointRequest implements fosite.TokenEndpointHandler. It validates the device_code and restores the
+// session the user approved, or returns the authorization_pending, slow_down, expired_token, or access_denied errors.
+//
+// RFC8628: https://www.rfc-editor.org/rfc/rfc8628.html#section-3.4
+func (h *DeviceCodeGrantHandler) HandleTokenEndpointRequest(ctx context.Context, requester fosite.AccessRequester) (err error) {
+	if !h.CanHandleTokenEndpointRequest(requester) {
+		return errorsx.WithStack(fosite.ErrUnknownRequest)
+	}
+
+	if !requester.GetClient().GetGrantTypes().Has(GrantTypeDeviceCode) {
+		return errorsx.WithStack(fosite.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use the authorization grant '%s'.", GrantTypeDeviceCode))
+	}
+
+	code := requester.GetRequestForm().Get(FormParameterDeviceCode)
+
+	if code == "" {
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The 'device_code' parameter is required."))
+	}
+
+	var device *model.OAuth2DeviceCodeSession
+
+	if device, err = h.store.provider.LoadOAuth2DeviceCodeSession(ctx, model.NewOAuth2DeviceCodeSignature(code)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorsx.WithStack(fosite.ErrInvalidGrant.WithHint("The 'device_code' parameter does not reference a known device authorization request."))
+		}
+
+		return errorsx.WithStack(ErrDeviceCodeCouldNotLookup.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	switch {
+	case device.ClientID != requester.GetClient().GetID():
+		return errorsx.WithStack(fosite.ErrInvalidGrant.WithHint("The 'device_code' parameter was not issued to this client."))
+	case device.Status == model.OAuth2DeviceCodeStatusUsed:
+		return errorsx.WithStack(fosite.ErrInvalidGrant.WithHint("The 'device_code' parameter has already been used."))
+	case device.IsExpired():
+		return errorsx.WithStack(ErrDeviceExpiredToken)
+	case device.Status == model.OAuth2DeviceCodeStatusDenied:
+		return errorsx.WithStack(fosite.ErrAccessDenied.WithHint("The end user denied the device authorization request."))
+	case device.Status == model.OAuth2DeviceCodeStatusPending:
+		return h.handlePending(ctx, device)
+	}
+
+	if err = json.Unmarshal(device.Session, requester.GetSession()); err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	requester.SetID(device.RequestID)
+	requester.SetRequestedScopes(fosite.Arguments(device.RequestedScopes))
+	requester.SetRequestedAudience(fosite.Arguments(device.RequestedAudience))
+
+	for _, scope := range device.GrantedScopes {
+		requester.GrantScope(scope)
+	}
+
+	for _, audience := range device.GrantedAudience {
+		requester.GrantAudience(audience)
+	}
+
+	now := time.Now().UTC()
+
+	requester.GetSession().SetExpiresAt(fosite.AccessToken, now.Add(getEffectiveLifespan(requester.GetClient(), GrantTypeDeviceCode, fosite.AccessToken, h.AccessTokenLifespan)).Round(time.Second))
+
+	if h.RefreshTokenLifespan > -1 {
+		requester.GetSession().SetExpiresAt(fosite.RefreshToken, now.Add(getEffectiveLifespan(requester.GetClient(), GrantTypeDeviceCode, fosite.RefreshToken, h.RefreshTokenLifespan)).Round(time.Second))
+	}
+
+	return nil
+}
+
+func (h *DeviceCodeGrantHandler) handlePending(ctx context.Context, device *model.OAuth2DeviceCodeSession) (err error) {
+	now := time.Now()
+
+	if device.CheckedAt.Valid && now.Sub(device.CheckedAt.Time) < h.PollingInterval {
+		err = ErrDeviceSlowDown
+	} else {
+		err = ErrDeviceAuthorizationPending
+	}
+
+	if errUpdate := h.store.provider.UpdateOAuth2DeviceCodeSessionCheckedAt(ctx, device.Signature, now); errUpdate != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(errUpdate).WithDebug(errUpdate.Error()))
+	}
+
+	return errorsx.WithStack(err)
+}
+
+// PopulateTokenEndpointResponse implements fosite.TokenEndpointHandler. It marks the device_code as used and issues
+// the access token, the refresh token if the client is permitted to use the refresh token grant type and the
+// appropriate scope was granted, and the ID token if the openid scope was granted.
+func (h *DeviceCodeGrantHandler) PopulateTokenEndpointResponse(ctx context.Context, requester fosite.AccessRequester, responder fosite.AccessResponder) (err error) {
+	if !h.CanHandleTokenEndpointRequest(requester) {
+		return errorsx.WithStack(fosite.ErrUnknownRequest)
+	}
+
+	signature := model.NewOAuth2DeviceCodeSignature(requester.GetRequestForm().Get(FormParameterDeviceCode))
+
+	var access, accessSignature, refresh, refreshSignature string
+
+	if access, accessSignature, err = h.AccessTokenStrategy.GenerateAccessToken(ctx, requester); err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if h.canIssueRefreshToken(requester) {
+		if refresh, refreshSignature, err = h.RefreshTokenStrategy.GenerateRefreshToken(ctx, requester); err != nil {
+			return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+		}
+	}
+
+	if ctx, err = fstorage.MaybeBeginTx(ctx, h.store); err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	defer func() {
+		if err != nil {
+			if errRollback := fstorage.MaybeRollbackTx(ctx, h.store); errRollback != nil {
+				err = errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebugf("error: %s; rollback error: %s", err, errRollback))
+			}
+		}
+	}()
+
+	// The device_code is only consumed if it is still approved which ensures concurrent token requests using the same
+	// device_code can't both be issued tokens.
+	if err = h.store.provider.ConsumeOAuth2DeviceCodeSession(ctx, signature); err != nil {
+		if errors.Is(err, storage.ErrNoApprovedOAuth2DeviceCodeSession) {
+			return errorsx.WithStack(fosite.ErrInvalidGrant.WithHint("The 'device_code' parameter has already been used."))
+		}
+
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if err = h.store.CreateAccessTokenSession(ctx, accessSignature, requester.Sanitize([]string{})); err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if refreshSignature != "" {
+		if err = h.store.CreateRefreshTokenSession(ctx, refreshSignature, requester.Sanitize([]string{})); err != nil {
+			return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+		}
+	}
+
+	responder.SetAccessToken(access)
+	responder.SetTokenType("bearer")
+	responder.SetExpiresIn(time.Until(requester.GetSession().GetExpiresAt(fosite.AccessToken)))
+	responder.SetScopes(requester.GetGrantedScopes())
+
+	if refresh != "" {
+		responder.SetExtra("refresh_token", refresh)
+	}
+
+	if requester.GetGrantedScopes().Has(ScopeOpenID) {
+		session, ok := requester.GetSession().(openid.Session)
+		if !ok {
+			return errorsx.WithStack(fosite.ErrServerError.WithDebug("Failed to generate id token because session must be of type fosite/handler/openid.Session."))
+		}
+
+		session.IDTokenClaims().AccessTokenHash = h.GetAccessTokenHash(ctx, requester, responder)
+
+		if err = h.IssueExplicitIDToken(ctx, requester, responder); err != nil {
+			return err
+		}
+	}
+
+	if err = fstorage.MaybeCommitTx(ctx, h.store); err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return nil
+}
+
+// CanSkipClientAuth implements fosite.TokenEndpointHandler.
+func (h *DeviceCodeGrantHandler) CanSkipClientAuth(_ fosite.AccessRequester) bool {
+	return false
+}
+
+// CanHandleTokenEndpointRequest implements fosite.TokenEndpointHandler.
+func (h *DeviceCodeGrantHandler) CanHandleTokenEndpointRequest(requester fosite.AccessRequester) bool {
+	return requester.GetGrantTypes().ExactOne(GrantTypeDeviceCode)
+}
+
+func (h *DeviceCodeGrantHandler) canIssueRefreshToken(requester fosite.Requester) bool {
+	if len(h.RefreshTokenScopes) > 0 && !requester.GetGrantedScopes().HasOneOf(h.RefreshTokenScopes...) {
+		return false
+	}
+
+	return requester.GetClient().GetGrantTypes().Has(GrantTypeRefreshToken)
+}
@@ -1,7 +1,37 @@
 package oidc
 
-// NewOpenIDConnectWellKnownConfiguration generates a new OpenIDConnectWellKnownConfiguration.
-func NewOpenIDConnectWellKnownConfiguration(enablePKCEPlainChallenge bool, clients map[string]*Client) (config OpenIDConnectWellKnownConfiguration) {
+// NewOpenIDConnectWellKnownConfiguration generates a new OpenIDConnectWellKnownConfiguration. The algs are the signing
+// algorithms the issuer has keys for, which defaults to RS256 when empty.
+func NewOpenIDConnectWellKnownConfiguration(enablePKCEPlainChallenge bool, algs []string, clients map[string]*Client) (config OpenIDConnectWellKnownConfiguration) {
+	if len(algs) == 0 {
+		algs = []string{SigningAlgorithmRSAWithSHA256}
+	}
+
+	authMethods := []string{
+		ClientAuthMethodClientSecretBasic,
+		ClientAuthMethodClientSecretPost,
+		ClientAuthMethodClientSecretJWT,
+		ClientAuthMethodPrivateKeyJWT,
+		ClientAuthMethodTLSClientAuth,
+		ClientAuthMethodSelfSignedTLSClientAuth,
+	}
+
+	authSigningAlgs := []string{
+		SigningAlgorithmHMACWithSHA256,
+		SigningAlgorithmHMACWithSHA384,
+		SigningAlgorithmHMACWithSHA512,
+		SigningAlgorithmRSAWithSHA256,
+		SigningAlgorithmRSAWithSHA384,
+		SigningAlgorithmRSAWithSHA512,
+		SigningAlgorithmRSAPSSWithSHA256,
+		SigningAlgorithmRSAPSSWithSHA384,
+		SigningAlgorithmRSAPSSWithSHA512,
+		SigningAlgorithmECDSAWithSHA256,
+		SigningAlgorithmECDSAWithSHA384,
+		SigningAlgorithmECDSAWithSHA512,
+		SigningAlgorithmEdDSA,
+	}
+
 	config = OpenIDConnectWellKnownConfiguration{
 		CommonDiscoveryOptions: CommonDiscoveryOptions{
 			SubjectTypesSupported: []string{
@@ -21,6 +51,10 @@ func NewOpenIDConnectWellKnownConfiguration(enablePKCEPlainChallenge bool, clien
 				ResponseModeFormPost,
 				ResponseModeQuery,
 				ResponseModeFragment,
+				ResponseModeJWT,
+				ResponseModeFormPostJWT,
+				ResponseModeQueryJWT,
+				ResponseModeFragmentJWT,
 			},
 			ScopesSupported: []string{
 				ScopeOfflineAccess,
@@ -39,6 +73,7 @@ func NewOpenIDConnectWellKnownConfiguration(enablePKCEPlainChallenge bool, clien
 				ClaimIssuer,
 				ClaimJWTID,
 				ClaimRequestedAt,
+				ClaimSessionID,
 				ClaimSubject,
 				ClaimAuthenticationTime,
 				ClaimNonce,
@@ -49,24 +84,39 @@ func NewOpenIDConnectWellKnownConfiguration(enablePKCEPlainChallenge bool, clien
 				ClaimPreferredUsername,
 				ClaimFullName,
 			},
+			TokenEndpointAuthMethodsSupported:          append(authMethods, ClientAuthMethodNone),
+			TokenEndpointAuthSigningAlgValuesSupported: authSigningAlgs,
 		},
 		OAuth2DiscoveryOptions: OAuth2DiscoveryOptions{
 			CodeChallengeMethodsSupported: []string{
 				PKCEChallengeMethodSHA256,
 			},
+			IntrospectionEndpointAuthMethodsSupported:          authMethods,
+			IntrospectionEndpointAuthSigningAlgValuesSupported: authSigningAlgs,
+			RevocationEndpointAuthMethodsSupported:             append(authMethods, ClientAuthMethodNone),
+			RevocationEndpointAuthSigningAlgValuesSupported:    authSigningAlgs,
+		},
+		OAuth2JWTSecuredAuthorizationResponseModeDiscoveryOptions: OAuth2JWTSecuredAuthorizationResponseModeDiscoveryOptions{
+			AuthorizationSigningAlgValuesSupported: algs,
+		},
+		OAuth2MutualTLSClientAuthenticationDiscoveryOptions: OAuth2MutualTLSClientAuthenticationDiscoveryOptions{
+			TLSClientCertificateBoundAccessTokens: true,
+		},
+		OAuth2DPoPDiscoveryOptions: OAuth2DPoPDiscoveryOptions{
+			DPoPSigningAlgValuesSupported: dpopSigningAlgs,
 		},
 		OpenIDConnectDiscoveryOptions: OpenIDConnectDiscoveryOptions{
-			IDTokenSigningAlgValuesSupported: []string{
-				SigningAlgorithmRSAWithSHA256,
-			},
-			UserinfoSigningAlgValuesSupported: []string{
-				SigningAlgorithmNone,
-				SigningAlgorithmRSAWithSHA256,
-			},
-			RequestObjectSigningAlgValuesSupported: []string{
-				SigningAlgorithmNone,
-				SigningAlgorithmRSAWithSHA256,
-			},
+			IDTokenSigningAlgValuesSupported:       algs,
+			UserinfoSigningAlgValuesSupported:      append([]string{SigningAlgorithmNone}, algs...),
+			RequestObjectSigningAlgValuesSupported: requestObjectSigningAlgs,
+			RequestParameterSupported:              true,
+			RequestURIParameterSupported:           true,
+			RequireRequestURIRegistration:          true,
+			ClaimsParameterSupported:               true,
+		},
+		OpenIDConnectBackChannelLogoutDiscoveryOptions: OpenIDConnectBackChannelLogoutDiscoveryOptions{
+			BackChannelLogoutSupported:        true,
+			BackChannelLogoutSessionSupported: true,
 		},
 	}
 
@@ -0,0 +1,265 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/x/errorsx"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// DPoPProof represents a verified RFC9449 OAuth 2.0 Demonstrating Proof of Possession (DPoP) proof.
+//
+// RFC9449: https://www.rfc-editor.org/rfc/rfc9449.html#section-4.2
+type DPoPProof struct {
+	JWTID           string
+	HTTPMethod      string
+	HTTPURI         string
+	AccessTokenHash string
+	IssuedAt        time.Time
+
+	// JWKThumbprint is the base64url encoded RFC7638 SHA-256 JWK Thumbprint of the public key the proof was signed
+	// with, which is the value tokens are bound to.
+	JWKThumbprint string
+}
+
+type dpopProofClaims struct {
+	jwt.Claims
+
+	HTTPMethod      string `json:"htm"`
+	HTTPURI         string `json:"htu"`
+	AccessTokenHash string `json:"ath,omitempty"`
+}
+
+// ValidateDPoPProof validates the RFC9449 OAuth 2.0 Demonstrating Proof of Possession (DPoP) proof of a request to the
+// endpoint. If the access token is not empty the proof must include its hash. A nil proof and error is returned if the
+// request doesn't include a proof. The jti of the proof is tracked for replay protection.
+//
+// RFC9449: https://www.rfc-editor.org/rfc/rfc9449.html#section-4.3
+func (p *OpenIDConnectProvider) ValidateDPoPProof(ctx context.Context, r *http.Request, endpoint, accessToken string) (proof *DPoPProof, err error) {
+	values := r.Header.Values(HeaderDPoP)
+
+	switch len(values) {
+	case 0:
+		return nil, nil
+	case 1:
+		break
+	default:
+		return nil, errorsx.WithStack(ErrInvalidDPoPProof.WithHint("The request must not include more than one DPoP proof."))
+	}
+
+	var token *jwt.JSONWebToken
+
+	if token, err = jwt.ParseSigned(values[0]); err != nil {
+		return nil, errorsx.WithStack(ErrInvalidDPoPProof.WithHint("The DPoP proof is not a valid JWT.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if len(token.Headers) != 1 {
+		return nil, errorsx.WithStack(ErrInvalidDPoPProof.WithHint("The DPoP proof must have exactly one signature."))
+	}
+
+	header := token.Headers[0]
+
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != JWTHeaderTypeDPoP {
+		return nil, errorsx.WithStack(ErrInvalidDPoPProof.WithHintf("The DPoP proof must have the '%s' header with the value '%s'.", JWTHeaderType, JWTHeaderTypeDPoP))
+	}
+
+	if !utils.IsStringInSlice(header.Algorithm, dpopSigningAlgs) {
+		return nil, errorsx.WithStack(ErrInvalidDPoPProof.WithHintf("The DPoP proof uses unsupported signing algorithm '%s'.", header.Algorithm))
+	}
+
+	if header.JSONWebKey == nil || !header.JSONWebKey.Valid() || !header.JSONWebKey.IsPublic() {
+		return nil, errorsx.WithStack(ErrInvalidDPoPProof.WithHintf("The DPoP proof must have the '%s' header with a valid public key.", JWTHeaderJSONWebKey))
+	}
+
+	claims := dpopProofClaims{}
+
+	if err = token.Claims(header.JSONWebKey, &claims); err != nil {
+		return nil, errorsx.WithStack(ErrInvalidDPoPProof.WithHint("Unable to verify the integrity of the DPoP proof.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	var thumbprint []byte
+
+	if thumbprint, err = header.JSONWebKey.Thumbprint(crypto.SHA256); err != nil {
+		return nil, errorsx.WithStack(ErrInvalidDPoPProof.WithHint("Unable to compute the thumbprint of the DPoP proof key.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	proof = &DPoPProof{
+		JWTID:           claims.ID,
+		HTTPMethod:      claims.HTTPMethod,
+		HTTPURI:         claims.HTTPURI,
+		AccessTokenHash: claims.AccessTokenHash,
+		JWKThumbprint:   base64.RawURLEncoding.EncodeToString(thumbprint),
+	}
+
+	if claims.IssuedAt != nil {
+		proof.IssuedAt = claims.IssuedAt.Time()
+	}
+
+	if err = p.verifyDPoPProofClaims(ctx, r, endpoint, accessToken, proof); err != nil {
+		return nil, err
+	}
+
+	jti := fmt.Sprintf("dpop:%s:%s", proof.JWKThumbprint, proof.JWTID)
+
+	switch err = p.Store.ClientAssertionJWTValid(ctx, jti); {
+	case err == nil:
+		break
+	case errors.Is(err, fosite.ErrJTIKnown):
+		return nil, errorsx.WithStack(ErrInvalidDPoPProof.WithHint("The DPoP proof has already been used."))
+	default:
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHint("Could not check the DPoP proof for replay.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if err = p.Store.SetClientAssertionJWT(ctx, jti, proof.IssuedAt.Add(dpopProofLifespan)); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHint("Could not save the DPoP proof.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return proof, nil
+}
+
+func (p *OpenIDConnectProvider) verifyDPoPProofClaims(ctx context.Context, r *http.Request, endpoint, accessToken string, proof *DPoPProof) (err error) {
+	now := time.Now()
+
+	switch {
+	case proof.JWTID == "":
+		return errorsx.WithStack(ErrInvalidDPoPProof.WithHintf("The DPoP proof must include the '%s' claim.", ClaimJWTID))
+	case proof.IssuedAt.IsZero():
+		return errorsx.WithStack(ErrInvalidDPoPProof.WithHintf("The DPoP proof must include the '%s' claim.", ClaimIssuedAt))
+	case proof.IssuedAt.After(now.Add(dpopProofLeeway)):
+		return errorsx.WithStack(ErrInvalidDPoPProof.WithHint("The DPoP proof was issued in the future."))
+	case proof.IssuedAt.Add(dpopProofLifespan).Before(now):
+		return errorsx.WithStack(ErrInvalidDPoPProof.WithHint("The DPoP proof has expired."))
+	case proof.HTTPMethod != r.Method:
+		return errorsx.WithStack(ErrInvalidDPoPProof.WithHintf("The '%s' claim of the DPoP proof must match the HTTP method of the request.", ClaimHTTPMethod))
+	}
+
+	ictx, ok := ctx.(issuerContext)
+	if !ok {
+		return errorsx.WithStack(ErrIssuerCouldNotDerive)
+	}
+
+	var issuer, htu *url.URL
+
+	if issuer, err = ictx.IssuerURL(); err != nil {
+		return errorsx.WithStack(ErrIssuerCouldNotDerive.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if htu, err = url.Parse(proof.HTTPURI); err != nil || !strings.EqualFold(htu.Scheme, issuer.Scheme) ||
+		!strings.EqualFold(htu.Host, issuer.Host) || htu.Path != path.Join("/", issuer.Path, endpoint) {
+		return errorsx.WithStack(ErrInvalidDPoPProof.WithHintf("The '%s' claim of the DPoP proof must match the HTTP URI of the request.", ClaimHTTPURI))
+	}
+
+	if accessToken == "" {
+		return nil
+	}
+
+	sum := sha256.Sum256([]byte(accessToken))
+
+	if subtle.ConstantTimeCompare([]byte(proof.AccessTokenHash), []byte(base64.RawURLEncoding.EncodeToString(sum[:]))) != 1 {
+		return errorsx.WithStack(ErrInvalidDPoPProof.WithHintf("The '%s' claim of the DPoP proof must match the hash of the access token.", ClaimAccessTokenHashDPoP))
+	}
+
+	return nil
+}
+
+// BindDPoPAccessRequest binds the tokens issued for the Access Request to the key of the DPoP proof, or removes the
+// binding when there is no proof. Clients which require DPoP bound access tokens must include a proof, and the refresh
+// tokens of public clients remain bound to the key they were originally bound to.
+//
+// RFC9449: https://www.rfc-editor.org/rfc/rfc9449.html#section-5
+func (p *OpenIDConnectProvider) BindDPoPAccessRequest(requester fosite.AccessRequester, proof *DPoPProof) (err error) {
+	session, ok := requester.GetSession().(*model.OpenIDSession)
+	if !ok {
+		return errorsx.WithStack(fosite.ErrServerError.WithHint("The session does not support DPoP bound tokens."))
+	}
+
+	client := requester.GetClient()
+
+	if proof == nil {
+		if c, ok := client.(*Client); ok && c.GetDPoPBoundAccessTokens() {
+			return errorsx.WithStack(ErrInvalidDPoPProof.WithHint("The client is required to use DPoP bound access tokens but the request does not include a DPoP proof."))
+		}
+	}
+
+	if requester.GetGrantTypes().ExactOne(GrantTypeRefreshToken) && client.IsPublic() && session.DPoPJWKThumbprint != "" {
+		if proof == nil || proof.JWKThumbprint != session.DPoPJWKThumbprint {
+			return errorsx.WithStack(ErrInvalidDPoPProof.WithHint("The refresh token is bound to a DPoP key but the request does not include a DPoP proof signed by that key."))
+		}
+	}
+
+	if proof == nil {
+		session.DPoPJWKThumbprint = ""
+
+		return nil
+	}
+
+	session.DPoPJWKThumbprint = proof.JWKThumbprint
+
+	return nil
+}
+
+// ValidateDPoPResourceRequest validates the presentation of an access token to a protected resource. Access tokens bound
+// to a DPoP key must be presented with the DPoP authorization scheme and a DPoP proof signed by that key, and access
+// tokens which are not bound must not be presented with the DPoP authorization scheme.
+//
+// RFC9449: https://www.rfc-editor.org/rfc/rfc9449.html#section-7
+func (p *OpenIDConnectProvider) ValidateDPoPResourceRequest(ctx context.Context, r *http.Request, endpoint, scheme, accessToken string, requester fosite.Requester) (err error) {
+	var thumbprint string
+
+	if session, ok := requester.GetSession().(*model.OpenIDSession); ok {
+		thumbprint = session.DPoPJWKThumbprint
+	}
+
+	switch {
+	case thumbprint == "" && scheme == TokenTypeDPoP:
+		return errorsx.WithStack(ErrInvalidDPoPToken.WithHint("The access token is not bound to a DPoP key and must not be presented with the DPoP authorization scheme."))
+	case thumbprint == "":
+		return nil
+	case scheme != TokenTypeDPoP:
+		return errorsx.WithStack(ErrInvalidDPoPToken.WithHint("The access token is bound to a DPoP key and must be presented with the DPoP authorization scheme."))
+	}
+
+	var proof *DPoPProof
+
+	if proof, err = p.ValidateDPoPProof(ctx, r, endpoint, accessToken); err != nil {
+		return err
+	}
+
+	switch {
+	case proof == nil:
+		return errorsx.WithStack(ErrInvalidDPoPProof.WithHint("The access token is bound to a DPoP key but the request does not include a DPoP proof."))
+	case proof.JWKThumbprint != thumbprint:
+		return errorsx.WithStack(ErrInvalidDPoPProof.WithHint("The DPoP proof is not signed by the key the access token is bound to."))
+	}
+
+	return nil
+}
+
+// AccessTokenFromRequest returns the access token of a request to a protected resource and the authorization scheme
+// it was presented with. Access tokens presented with the DPoP authorization scheme are returned with the DPoP scheme,
+// otherwise the access token is extracted using fosite.AccessTokenFromRequest and returned with the Bearer scheme.
+func AccessTokenFromRequest(r *http.Request) (token, scheme string) {
+	parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+
+	if len(parts) == 2 && strings.EqualFold(parts[0], TokenTypeDPoP) {
+		return parts[1], TokenTypeDPoP
+	}
+
+	return fosite.AccessTokenFromRequest(r), TokenTypeBearer
+}
@@ -0,0 +1,372 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+func TestOpenIDConnectProvider_ValidateDPoPProof(t *testing.T) {
+	provider, _ := newTestDPoPProvider(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ctx := &testIssuerContext{Context: context.Background(), issuer: &url.URL{Scheme: "https", Host: "auth.example.com"}}
+
+	thumbprint, err := (&jose.JSONWebKey{Key: &key.PublicKey}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	hash := sha256.Sum256([]byte("an-access-token"))
+
+	htu := "https://auth.example.com" + EndpointPathToken
+
+	testCases := []struct {
+		name     string
+		typ      string
+		claims   map[string]any
+		method   string
+		token    string
+		expected string
+	}{
+		{
+			"ShouldValidateProof",
+			JWTHeaderTypeDPoP,
+			map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: htu},
+			http.MethodPost,
+			"",
+			"",
+		},
+		{
+			"ShouldValidateProofIgnoringQuery",
+			JWTHeaderTypeDPoP,
+			map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: htu + "?example=true"},
+			http.MethodPost,
+			"",
+			"",
+		},
+		{
+			"ShouldValidateProofWithAccessTokenHash",
+			JWTHeaderTypeDPoP,
+			map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: htu, ClaimAccessTokenHashDPoP: base64.RawURLEncoding.EncodeToString(hash[:])},
+			http.MethodPost,
+			"an-access-token",
+			"",
+		},
+		{
+			"ShouldFailInvalidType",
+			"JWT",
+			map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: htu},
+			http.MethodPost,
+			"",
+			"The DPoP proof is invalid. The DPoP proof must have the 'typ' header with the value 'dpop+jwt'.",
+		},
+		{
+			"ShouldFailInvalidMethod",
+			JWTHeaderTypeDPoP,
+			map[string]any{ClaimHTTPMethod: http.MethodGet, ClaimHTTPURI: htu},
+			http.MethodPost,
+			"",
+			"The DPoP proof is invalid. The 'htm' claim of the DPoP proof must match the HTTP method of the request.",
+		},
+		{
+			"ShouldFailInvalidURI",
+			JWTHeaderTypeDPoP,
+			map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: "https://auth.example.com" + EndpointPathUserinfo},
+			http.MethodPost,
+			"",
+			"The DPoP proof is invalid. The 'htu' claim of the DPoP proof must match the HTTP URI of the request.",
+		},
+		{
+			"ShouldFailExpired",
+			JWTHeaderTypeDPoP,
+			map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: htu, ClaimIssuedAt: time.Now().Add(-time.Hour).Unix()},
+			http.MethodPost,
+			"",
+			"The DPoP proof is invalid. The DPoP proof has expired.",
+		},
+		{
+			"ShouldFailFuture",
+			JWTHeaderTypeDPoP,
+			map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: htu, ClaimIssuedAt: time.Now().Add(time.Hour).Unix()},
+			http.MethodPost,
+			"",
+			"The DPoP proof is invalid. The DPoP proof was issued in the future.",
+		},
+		{
+			"ShouldFailMissingJTI",
+			JWTHeaderTypeDPoP,
+			map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: htu, ClaimJWTID: ""},
+			http.MethodPost,
+			"",
+			"The DPoP proof is invalid. The DPoP proof must include the 'jti' claim.",
+		},
+		{
+			"ShouldFailMissingAccessTokenHash",
+			JWTHeaderTypeDPoP,
+			map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: htu},
+			http.MethodPost,
+			"an-access-token",
+			"The DPoP proof is invalid. The 'ath' claim of the DPoP proof must match the hash of the access token.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, EndpointPathToken, nil)
+			r.Header.Set(HeaderDPoP, mustSignDPoPProof(t, key, tc.typ, tc.claims))
+
+			proof, err := provider.ValidateDPoPProof(ctx, r, EndpointPathToken, tc.token)
+
+			if tc.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, proof)
+
+				assert.Equal(t, base64.RawURLEncoding.EncodeToString(thumbprint), proof.JWKThumbprint)
+			} else {
+				assert.Nil(t, proof)
+				assert.Equal(t, tc.expected, fosite.ErrorToRFC6749Error(err).GetDescription())
+			}
+		})
+	}
+
+	t.Run("ShouldReturnNilWithoutProof", func(t *testing.T) {
+		proof, err := provider.ValidateDPoPProof(ctx, httptest.NewRequest(http.MethodPost, EndpointPathToken, nil), EndpointPathToken, "")
+
+		assert.NoError(t, err)
+		assert.Nil(t, proof)
+	})
+
+	t.Run("ShouldFailMultipleProofs", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, EndpointPathToken, nil)
+		r.Header.Add(HeaderDPoP, mustSignDPoPProof(t, key, JWTHeaderTypeDPoP, map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: htu}))
+		r.Header.Add(HeaderDPoP, mustSignDPoPProof(t, key, JWTHeaderTypeDPoP, map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: htu}))
+
+		_, err := provider.ValidateDPoPProof(ctx, r, EndpointPathToken, "")
+
+		assert.Equal(t, "The DPoP proof is invalid. The request must not include more than one DPoP proof.", fosite.ErrorToRFC6749Error(err).GetDescription())
+	})
+
+	t.Run("ShouldFailReplayedProof", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, EndpointPathToken, nil)
+		r.Header.Set(HeaderDPoP, mustSignDPoPProof(t, key, JWTHeaderTypeDPoP, map[string]any{ClaimHTTPMethod: http.MethodPost, ClaimHTTPURI: htu}))
+
+		_, err := provider.ValidateDPoPProof(ctx, r, EndpointPathToken, "")
+		require.NoError(t, err)
+
+		_, err = provider.ValidateDPoPProof(ctx, r, EndpointPathToken, "")
+		assert.Equal(t, "The DPoP proof is invalid. The DPoP proof has already been used.", fosite.ErrorToRFC6749Error(err).GetDescription())
+	})
+}
+
+func TestOpenIDConnectProvider_BindDPoPAccessRequest(t *testing.T) {
+	provider, _ := newTestDPoPProvider(t)
+
+	public, err := provider.GetFullClient(context.Background(), "dpop-public")
+	require.NoError(t, err)
+
+	bound, err := provider.GetFullClient(context.Background(), "dpop-bound")
+	require.NoError(t, err)
+
+	requester := func(client *Client, grant, thumbprint string) *fosite.AccessRequest {
+		session := NewSession()
+		session.DPoPJWKThumbprint = thumbprint
+
+		ar := fosite.NewAccessRequest(session)
+		ar.Client = client
+		ar.GrantTypes = fosite.Arguments{grant}
+
+		return ar
+	}
+
+	testCases := []struct {
+		name      string
+		requester *fosite.AccessRequest
+		proof     *DPoPProof
+		expected  string
+		err       string
+	}{
+		{"ShouldBindProof", requester(public, GrantTypeAuthorizationCode, ""), &DPoPProof{JWKThumbprint: "abc"}, "abc", ""},
+		{"ShouldNotBindWithoutProof", requester(public, GrantTypeAuthorizationCode, ""), nil, "", ""},
+		{"ShouldRequireProof", requester(bound, GrantTypeClientCredentials, ""), nil, "", "The DPoP proof is invalid. The client is required to use DPoP bound access tokens but the request does not include a DPoP proof."},
+		{"ShouldKeepPublicRefreshBinding", requester(public, GrantTypeRefreshToken, "abc"), &DPoPProof{JWKThumbprint: "abc"}, "abc", ""},
+		{"ShouldFailPublicRefreshWithoutProof", requester(public, GrantTypeRefreshToken, "abc"), nil, "abc", "The DPoP proof is invalid. The refresh token is bound to a DPoP key but the request does not include a DPoP proof signed by that key."},
+		{"ShouldFailPublicRefreshWithOtherKey", requester(public, GrantTypeRefreshToken, "abc"), &DPoPProof{JWKThumbprint: "xyz"}, "abc", "The DPoP proof is invalid. The refresh token is bound to a DPoP key but the request does not include a DPoP proof signed by that key."},
+		{"ShouldRebindConfidentialRefresh", requester(bound, GrantTypeRefreshToken, "abc"), &DPoPProof{JWKThumbprint: "xyz"}, "xyz", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := provider.BindDPoPAccessRequest(tc.requester, tc.proof)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tc.err, fosite.ErrorToRFC6749Error(err).GetDescription())
+			}
+
+			assert.Equal(t, tc.expected, tc.requester.GetSession().(*model.OpenIDSession).DPoPJWKThumbprint)
+		})
+	}
+}
+
+func TestOpenIDConnectProvider_ValidateDPoPResourceRequest(t *testing.T) {
+	provider, _ := newTestDPoPProvider(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	thumbprint, err := (&jose.JSONWebKey{Key: &key.PublicKey}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	ctx := &testIssuerContext{Context: context.Background(), issuer: &url.URL{Scheme: "https", Host: "auth.example.com"}}
+
+	hash := sha256.Sum256([]byte("an-access-token"))
+
+	requester := func(thumbprint string) *fosite.Request {
+		session := NewSession()
+		session.DPoPJWKThumbprint = thumbprint
+
+		return &fosite.Request{Session: session}
+	}
+
+	request := func(proof bool) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, EndpointPathUserinfo, nil)
+
+		if proof {
+			r.Header.Set(HeaderDPoP, mustSignDPoPProof(t, key, JWTHeaderTypeDPoP, map[string]any{
+				ClaimHTTPMethod:          http.MethodGet,
+				ClaimHTTPURI:             "https://auth.example.com" + EndpointPathUserinfo,
+				ClaimAccessTokenHashDPoP: base64.RawURLEncoding.EncodeToString(hash[:]),
+			}))
+		}
+
+		return r
+	}
+
+	testCases := []struct {
+		name       string
+		request    *http.Request
+		scheme     string
+		thumbprint string
+		expected   string
+	}{
+		{"ShouldAllowBearerUnbound", request(false), TokenTypeBearer, "", ""},
+		{"ShouldAllowDPoPBound", request(true), TokenTypeDPoP, base64.RawURLEncoding.EncodeToString(thumbprint), ""},
+		{"ShouldFailDPoPUnbound", request(true), TokenTypeDPoP, "", "The access token provided is expired, revoked, malformed, or invalid for other reasons. The access token is not bound to a DPoP key and must not be presented with the DPoP authorization scheme."},
+		{"ShouldFailBearerBound", request(false), TokenTypeBearer, "abc", "The access token provided is expired, revoked, malformed, or invalid for other reasons. The access token is bound to a DPoP key and must be presented with the DPoP authorization scheme."},
+		{"ShouldFailBoundWithoutProof", request(false), TokenTypeDPoP, "abc", "The DPoP proof is invalid. The access token is bound to a DPoP key but the request does not include a DPoP proof."},
+		{"ShouldFailBoundOtherKey", request(true), TokenTypeDPoP, "abc", "The DPoP proof is invalid. The DPoP proof is not signed by the key the access token is bound to."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := provider.ValidateDPoPResourceRequest(ctx, tc.request, EndpointPathUserinfo, tc.scheme, "an-access-token", requester(tc.thumbprint))
+
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tc.expected, fosite.ErrorToRFC6749Error(err).GetDescription())
+			}
+		})
+	}
+}
+
+func TestAccessTokenFromRequest(t *testing.T) {
+	testCases := []struct {
+		name           string
+		header         string
+		token, scheme  string
+		expectedScheme string
+	}{
+		{"ShouldExtractBearer", "Bearer abc", "abc", TokenTypeBearer, TokenTypeBearer},
+		{"ShouldExtractDPoP", "DPoP abc", "abc", TokenTypeDPoP, TokenTypeDPoP},
+		{"ShouldExtractDPoPCaseInsensitive", "dpop abc", "abc", TokenTypeDPoP, TokenTypeDPoP},
+		{"ShouldNotExtractOther", "Basic abc", "", TokenTypeBearer, TokenTypeBearer},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, EndpointPathUserinfo, nil)
+			r.Header.Set("Authorization", tc.header)
+
+			token, scheme := AccessTokenFromRequest(r)
+
+			assert.Equal(t, tc.token, token)
+			assert.Equal(t, tc.expectedScheme, scheme)
+		})
+	}
+}
+
+func TestOpenIDSession_GetExtraClaims(t *testing.T) {
+	session := NewSession()
+
+	assert.Nil(t, session.GetExtraClaims())
+
+	session.DPoPJWKThumbprint = "abc"
+
+	assert.Equal(t, map[string]any{ClaimConfirmation: map[string]any{ClaimConfirmationJWKThumbprint: "abc"}}, session.GetExtraClaims())
+}
+
+func newTestDPoPProvider(t *testing.T) (provider *OpenIDConnectProvider, store *testJTIStore) {
+	store = &testJTIStore{jtis: map[string]model.OAuth2BlacklistedJTI{}}
+
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:           "dpop-public",
+				Public:       true,
+				RedirectURIs: []string{"https://example.com/callback"},
+			},
+			{
+				ID:                    "dpop-bound",
+				Secret:                MustDecodeSecret("$plaintext$a-client-secret"),
+				DPoPBoundAccessTokens: true,
+			},
+		},
+	}, store)
+
+	require.NoError(t, err)
+
+	return provider, store
+}
+
+func mustSignDPoPProof(t *testing.T, key *ecdsa.PrivateKey, typ string, claims map[string]any) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, (&jose.SignerOptions{EmbedJWK: true}).WithType(jose.ContentType(typ)))
+	require.NoError(t, err)
+
+	values := map[string]any{
+		ClaimJWTID:    uuid.New().String(),
+		ClaimIssuedAt: time.Now().Unix(),
+	}
+
+	for claim, value := range claims {
+		values[claim] = value
+	}
+
+	token, err := jwt.Signed(signer).Claims(values).CompactSerialize()
+	require.NoError(t, err)
+
+	return token
+}
@@ -0,0 +1,77 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ory/fosite/token/jwt"
+)
+
+// DecodeIDTokenHint decodes an id_token_hint which was previously issued by this provider and returns the claims. The
+// signature and issuer of the token are validated, however as per OpenID Connect RP-Initiated Logout 1.0 an ID Token
+// which has expired is still considered a valid hint.
+//
+// https://openid.net/specs/openid-connect-rpinitiated-1_0.html#RPLogout
+func (p *OpenIDConnectProvider) DecodeIDTokenHint(ctx context.Context, issuer, hint string) (claims jwt.MapClaims, err error) {
+	var strategy jwt.JWTStrategy
+
+	if p.KeyManager == nil {
+		return nil, errors.New("could not decode the id token hint as the key manager is not configured")
+	}
+
+	if strategy = p.KeyManager.Strategy(); strategy == nil {
+		return nil, errors.New("could not decode the id token hint as the key manager has no active key")
+	}
+
+	var token *jwt.Token
+
+	if token, err = strategy.Decode(ctx, hint); err != nil && !isValidationErrorExpiryOnly(err) {
+		return nil, fmt.Errorf("could not decode the id token hint: %w", err)
+	}
+
+	if token == nil {
+		return nil, errors.New("could not decode the id token hint: token is empty")
+	}
+
+	claims = token.Claims
+
+	if !claims.VerifyIssuer(issuer, true) {
+		return nil, fmt.Errorf("could not decode the id token hint: the issuer '%v' does not match the expected issuer '%s'", claims[ClaimIssuer], issuer)
+	}
+
+	return claims, nil
+}
+
+// IDTokenClaimsClientID returns the client id the ID Token with the provided claims was issued to. This is the value
+// of the azp claim, or if absent the single audience.
+func IDTokenClaimsClientID(claims jwt.MapClaims) (clientID string) {
+	if azp, ok := claims[ClaimAuthorizedParty].(string); ok && azp != "" {
+		return azp
+	}
+
+	switch aud := claims[ClaimAudience].(type) {
+	case string:
+		return aud
+	case []any:
+		if len(aud) == 1 {
+			clientID, _ = aud[0].(string)
+		}
+	case []string:
+		if len(aud) == 1 {
+			return aud[0]
+		}
+	}
+
+	return clientID
+}
+
+func isValidationErrorExpiryOnly(err error) bool {
+	var ve *jwt.ValidationError
+
+	if !errors.As(err, &ve) {
+		return false
+	}
+
+	return ve.Errors == jwt.ValidationErrorExpired
+}
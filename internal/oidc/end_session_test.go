@@ -0,0 +1,121 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestOpenIDConnectProvider_DecodeIDTokenHint(t *testing.T) {
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:     "a-client",
+				Secret: MustDecodeSecret("$plaintext$a-client-secret"),
+				Policy: "one_factor",
+				RedirectURIs: []string{
+					"https://google.com",
+				},
+			},
+		},
+	}, nil)
+
+	require.NoError(t, err)
+
+	generate := func(claims jwt.MapClaims) string {
+		token, _, err := provider.KeyManager.Strategy().Generate(context.Background(), claims, &jwt.Headers{
+			Extra: map[string]any{JWTHeaderKeyIdentifier: provider.KeyManager.GetActiveKeyID()},
+		})
+
+		require.NoError(t, err)
+
+		return token
+	}
+
+	testCases := []struct {
+		name     string
+		hint     string
+		issuer   string
+		clientID string
+		err      string
+	}{
+		{
+			name: "ShouldDecodeValidToken",
+			hint: generate(jwt.MapClaims{
+				ClaimIssuer:         "https://example.com",
+				ClaimAudience:       []string{"a-client"},
+				ClaimExpirationTime: time.Now().Add(time.Hour).Unix(),
+			}),
+			issuer:   "https://example.com",
+			clientID: "a-client",
+		},
+		{
+			name: "ShouldDecodeExpiredToken",
+			hint: generate(jwt.MapClaims{
+				ClaimIssuer:          "https://example.com",
+				ClaimAudience:        []string{"a-client", "another"},
+				ClaimAuthorizedParty: "a-client",
+				ClaimExpirationTime:  time.Now().Add(-time.Hour).Unix(),
+			}),
+			issuer:   "https://example.com",
+			clientID: "a-client",
+		},
+		{
+			name: "ShouldNotDecodeTokenFromAnotherIssuer",
+			hint: generate(jwt.MapClaims{
+				ClaimIssuer:         "https://evil.example.com",
+				ClaimAudience:       []string{"a-client"},
+				ClaimExpirationTime: time.Now().Add(time.Hour).Unix(),
+			}),
+			issuer: "https://example.com",
+			err:    "could not decode the id token hint: the issuer 'https://evil.example.com' does not match the expected issuer 'https://example.com'",
+		},
+		{
+			name: "ShouldNotDecodeTokenNotYetValid",
+			hint: generate(jwt.MapClaims{
+				ClaimIssuer:    "https://example.com",
+				ClaimAudience:  []string{"a-client"},
+				ClaimNotBefore: time.Now().Add(time.Hour).Unix(),
+			}),
+			issuer: "https://example.com",
+			err:    "could not decode the id token hint: Token is not valid yet",
+		},
+		{
+			name:   "ShouldNotDecodeMalformedToken",
+			hint:   "abc.123",
+			issuer: "https://example.com",
+			err:    "could not decode the id token hint: square/go-jose: compact JWS format must have three parts",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := provider.DecodeIDTokenHint(context.Background(), tc.issuer, tc.hint)
+
+			if tc.err == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tc.clientID, IDTokenClaimsClientID(claims))
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, claims)
+			}
+		})
+	}
+}
+
+func TestIDTokenClaimsClientID(t *testing.T) {
+	assert.Equal(t, "", IDTokenClaimsClientID(jwt.MapClaims{}))
+	assert.Equal(t, "abc", IDTokenClaimsClientID(jwt.MapClaims{ClaimAudience: "abc"}))
+	assert.Equal(t, "abc", IDTokenClaimsClientID(jwt.MapClaims{ClaimAudience: []string{"abc"}}))
+	assert.Equal(t, "abc", IDTokenClaimsClientID(jwt.MapClaims{ClaimAudience: []any{"abc"}}))
+	assert.Equal(t, "", IDTokenClaimsClientID(jwt.MapClaims{ClaimAudience: []any{"abc", "123"}}))
+	assert.Equal(t, "123", IDTokenClaimsClientID(jwt.MapClaims{ClaimAudience: []any{"abc", "123"}, ClaimAuthorizedParty: "123"}))
+}
@@ -2,11 +2,17 @@ package oidc
 
 import (
 	"errors"
+	"net/http"
 
 	"github.com/ory/fosite"
 )
 
-var errPasswordsDoNotMatch = errors.New("the passwords don't match")
+var (
+	errPasswordsDoNotMatch = errors.New("the passwords don't match")
+	errNoActiveKey         = errors.New("the key manager does not have an active key")
+
+	errClientSecretNotPlainText = errors.New("the client secret is not a plaintext secret")
+)
 
 var (
 	ErrIssuerCouldNotDerive        = fosite.ErrServerError.WithHint("Could not safely derive the issuer.")
@@ -16,4 +22,134 @@ var (
 	ErrConsentCouldNotSave         = fosite.ErrServerError.WithHint("Could not save the consent session.")
 	ErrConsentCouldNotLookup       = fosite.ErrServerError.WithHint("Failed to lookup the consent session.")
 	ErrConsentMalformedChallengeID = fosite.ErrServerError.WithHint("Malformed consent session challenge ID.")
+	ErrSessionCouldNotSave         = fosite.ErrServerError.WithHint("Could not save the user session.")
+
+	ErrPushedAuthorizeRequestRequired       = fosite.ErrInvalidRequest.WithHint("The client is required to use a Pushed Authorization Request.")
+	ErrPushedAuthorizeRequestCouldNotRevoke = fosite.ErrServerError.WithHint("Could not revoke the Pushed Authorization Request.")
+
+	ErrRequestObjectRequired = fosite.ErrInvalidRequest.WithHint("The client is required to use a signed Request Object.")
+	ErrRequestObjectUnsigned = fosite.ErrInvalidRequestObject.WithHint("The client is required to use a signed Request Object but the Request Object is not signed.")
+
+	ErrDeviceCodeCouldNotSave   = fosite.ErrServerError.WithHint("Could not save the device code session.")
+	ErrDeviceCodeCouldNotLookup = fosite.ErrServerError.WithHint("Could not lookup the device code session.")
+	ErrDeviceUserCodeInvalid    = fosite.ErrInvalidRequest.WithHint("The 'user_code' parameter does not reference a pending device authorization request.")
+
+	ErrClientRegistrationCouldNotSave   = fosite.ErrServerError.WithHint("Could not save the registered client.")
+	ErrClientRegistrationCouldNotLookup = fosite.ErrServerError.WithHint("Could not lookup the registered client.")
+
+	ErrClaimsRequestInvalid         = fosite.ErrInvalidRequest.WithHint("The 'claims' parameter is not a valid JSON object.")
+	ErrClaimsRequestSubjectMismatch = fosite.ErrAccessDenied.WithHint("The 'sub' claim requested via the 'claims' parameter does not match the authenticated user.")
+
+	ErrTokenExchangeSubjectTokenInvalid = fosite.ErrInvalidGrant.WithHint("The 'subject_token' parameter is not a valid access token.")
+
+	ErrEndSessionIDTokenHintInvalid           = fosite.ErrInvalidRequest.WithHint("The 'id_token_hint' parameter is not a valid ID Token issued by this provider.")
+	ErrEndSessionIDTokenHintSessionMismatch   = fosite.ErrInvalidRequest.WithHint("The 'id_token_hint' parameter was not issued for the current session.")
+	ErrEndSessionClientMismatch               = fosite.ErrInvalidRequest.WithHint("The 'client_id' parameter does not match the client the 'id_token_hint' was issued to.")
+	ErrEndSessionClientUnknown                = fosite.ErrInvalidClient.WithHint("The client could not be found.")
+	ErrEndSessionPostLogoutRedirectURIClient  = fosite.ErrInvalidRequest.WithHint("The 'post_logout_redirect_uri' parameter requires either the 'id_token_hint' or 'client_id' parameter.")
+	ErrEndSessionPostLogoutRedirectURIInvalid = fosite.ErrInvalidRequest.WithHint("The 'post_logout_redirect_uri' parameter does not match any of the registered post logout redirect URIs for the client.")
+	ErrEndSessionCouldNotDestroy              = fosite.ErrServerError.WithHint("Could not destroy the session.")
+)
+
+// RFC8628 OAuth 2.0 Device Authorization Grant token endpoint errors. These are not implemented by fosite.
+//
+// RFC8628: https://www.rfc-editor.org/rfc/rfc8628.html#section-3.5
+var (
+	// ErrDeviceAuthorizationPending is returned when the user has not yet completed the user interaction steps.
+	ErrDeviceAuthorizationPending = &fosite.RFC6749Error{
+		ErrorField:       "authorization_pending",
+		DescriptionField: "The authorization request is still pending as the end user hasn't yet completed the user-interaction steps.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrDeviceSlowDown is returned when the client is polling more frequently than the interval it was issued.
+	ErrDeviceSlowDown = &fosite.RFC6749Error{
+		ErrorField:       "slow_down",
+		DescriptionField: "The authorization request is still pending and polling should continue, but the interval must be increased by 5 seconds for this and all subsequent requests.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrDeviceExpiredToken is returned when the device_code has expired.
+	ErrDeviceExpiredToken = &fosite.RFC6749Error{
+		ErrorField:       "expired_token",
+		DescriptionField: "The 'device_code' has expired, and the device authorization session has concluded.",
+		CodeField:        http.StatusBadRequest,
+	}
+)
+
+// RFC8693 OAuth 2.0 Token Exchange token endpoint errors. These are not implemented by fosite.
+//
+// RFC8693: https://www.rfc-editor.org/rfc/rfc8693.html#section-2.2.2
+var (
+	// ErrTokenExchangeInvalidTarget is returned when the client is not permitted to exchange a token for the requested
+	// audience.
+	ErrTokenExchangeInvalidTarget = &fosite.RFC6749Error{
+		ErrorField:       "invalid_target",
+		DescriptionField: "The authorization server is unable or unwilling to issue a token for the indicated audience.",
+		CodeField:        http.StatusBadRequest,
+	}
+)
+
+// RFC9449 OAuth 2.0 Demonstrating Proof of Possession (DPoP) errors. These are not implemented by fosite.
+//
+// RFC9449: https://www.rfc-editor.org/rfc/rfc9449.html#section-12.2
+var (
+	// ErrInvalidDPoPProof is returned when the DPoP proof is missing when required, or is invalid.
+	ErrInvalidDPoPProof = &fosite.RFC6749Error{
+		ErrorField:       "invalid_dpop_proof",
+		DescriptionField: "The DPoP proof is invalid.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrInvalidDPoPToken is returned by protected resources when the access token is presented with an authorization
+	// scheme which doesn't match its DPoP binding.
+	ErrInvalidDPoPToken = &fosite.RFC6749Error{
+		ErrorField:       "invalid_token",
+		DescriptionField: "The access token provided is expired, revoked, malformed, or invalid for other reasons.",
+		CodeField:        http.StatusUnauthorized,
+	}
+)
+
+// RFC8705 OAuth 2.0 Mutual-TLS Client Authentication and Certificate-Bound Access Tokens errors. These are not
+// implemented by fosite.
+//
+// RFC8705: https://www.rfc-editor.org/rfc/rfc8705.html#section-3
+var (
+	// ErrInvalidCertificateBoundToken is returned by protected resources when the access token is bound to a client
+	// certificate which was not presented with the request.
+	ErrInvalidCertificateBoundToken = &fosite.RFC6749Error{
+		ErrorField:       "invalid_token",
+		DescriptionField: "The access token provided is expired, revoked, malformed, or invalid for other reasons.",
+		CodeField:        http.StatusUnauthorized,
+	}
+)
+
+// RFC7591 OAuth 2.0 Dynamic Client Registration and RFC6750 Bearer Token errors. These are not implemented by fosite.
+//
+// RFC7591: https://www.rfc-editor.org/rfc/rfc7591.html#section-3.2.2
+//
+// RFC6750: https://www.rfc-editor.org/rfc/rfc6750.html#section-3.1
+var (
+	// ErrClientRegistrationInvalidRedirectURI is returned when the value of one or more redirection URIs is invalid.
+	ErrClientRegistrationInvalidRedirectURI = &fosite.RFC6749Error{
+		ErrorField:       "invalid_redirect_uri",
+		DescriptionField: "The value of one or more redirection URIs is invalid.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrClientRegistrationInvalidClientMetadata is returned when the value of one of the client metadata fields is
+	// invalid.
+	ErrClientRegistrationInvalidClientMetadata = &fosite.RFC6749Error{
+		ErrorField:       "invalid_client_metadata",
+		DescriptionField: "The value of one of the client metadata fields is invalid and the server has rejected this request.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrClientRegistrationInvalidToken is returned when the initial access token or registration access token is
+	// missing or invalid.
+	ErrClientRegistrationInvalidToken = &fosite.RFC6749Error{
+		ErrorField:       "invalid_token",
+		DescriptionField: "The access token provided is expired, revoked, malformed, or invalid for other reasons.",
+		CodeField:        http.StatusUnauthorized,
+	}
 )
@@ -0,0 +1,145 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/x/errorsx"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+// NewRestrictedHTTPClient returns a *http.Client which refuses to connect to any address which isn't a public address,
+// such as loopback, private, and link-local addresses. It's used for requests to URIs provided by registered clients as
+// Authelia performs these requests itself, so without the restriction any client could make Authelia send requests to
+// services which are only reachable from its own network. The address is checked when connecting rather than when
+// resolving the host so the restriction can't be bypassed by a host which resolves to a different address later.
+func NewRestrictedHTTPClient(timeout time.Duration) (client *http.Client) {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: restrictedDialControl,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	// Proxies are not used as the address of the proxy rather than the address of the host would be checked.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return fmt.Errorf("stopped after %d redirects", len(via))
+			}
+
+			if req.URL.Scheme != schemeHTTPS {
+				return fmt.Errorf("redirect to '%s' was refused as it doesn't have the https scheme", req.URL.Redacted())
+			}
+
+			return nil
+		},
+	}
+}
+
+func restrictedDialControl(_, address string, _ syscall.RawConn) (err error) {
+	var host string
+
+	if host, _, err = net.SplitHostPort(address); err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("connection to '%s' was refused as it's not a public address", host)
+	}
+
+	return nil
+}
+
+// getHTTPClient returns the *http.Client used to request the URIs of a client. Registered clients and unknown clients
+// use the restricted client as the URIs were not provided by the administrator.
+func (p *OpenIDConnectProvider) getHTTPClient(client *Client) *http.Client {
+	if client == nil || client.Registered {
+		return p.restrictedHTTPClient
+	}
+
+	return p.httpClient
+}
+
+// isPublicIP returns true if the net.IP is not a loopback, private, link-local, multicast, or unspecified address.
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// isRestrictedHostURI returns true if the host of the URI is localhost or an IP address which isn't a public address.
+// Hosts which are domain names are checked by the NewRestrictedHTTPClient when connecting.
+func isRestrictedHostURI(uri *url.URL) bool {
+	host := strings.ToLower(uri.Hostname())
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && !isPublicIP(ip)
+}
+
+// NewRestrictedJWKSFetcherStrategy returns a fosite.JWKSFetcherStrategy which caches the JSON Web Key Sets it fetches
+// using the provided *http.Client, which is expected to be a NewRestrictedHTTPClient.
+func NewRestrictedJWKSFetcherStrategy(client *http.Client) fosite.JWKSFetcherStrategy {
+	return &RestrictedJWKSFetcherStrategy{
+		client: client,
+		keys:   map[string]jose.JSONWebKeySet{},
+	}
+}
+
+// RestrictedJWKSFetcherStrategy is a fosite.JWKSFetcherStrategy used for the jwks_uri of registered clients.
+type RestrictedJWKSFetcherStrategy struct {
+	sync.Mutex
+
+	client *http.Client
+	keys   map[string]jose.JSONWebKeySet
+}
+
+// Resolve returns the JSON Web Key Set from the location, fetching it if it isn't cached or forceRefresh is true.
+func (s *RestrictedJWKSFetcherStrategy) Resolve(location string, forceRefresh bool) (keys *jose.JSONWebKeySet, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if set, ok := s.keys[location]; ok && !forceRefresh {
+		return &set, nil
+	}
+
+	var resp *http.Response
+
+	if resp, err = s.client.Get(location); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHintf("Unable to fetch JSON Web Keys from location '%s'. Check for typos or other network issues.", location).WithWrap(err).WithDebug(err.Error()))
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHintf("Expected successful status code in range of 200 - 399 from location '%s' but received code %d.", location, resp.StatusCode))
+	}
+
+	set := jose.JSONWebKeySet{}
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, jwksMaxSize)).Decode(&set); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHintf("Unable to decode JSON Web Keys from location '%s'. Please check for typos and if the URL returns valid JSON.", location).WithWrap(err).WithDebug(err.Error()))
+	}
+
+	s.keys[location] = set
+
+	return &set, nil
+}
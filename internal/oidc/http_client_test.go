@@ -0,0 +1,118 @@
+package oidc
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ip       string
+		expected bool
+	}{
+		{"ShouldAllowPublicIPv4", "93.184.216.34", true},
+		{"ShouldAllowPublicIPv6", "2606:2800:220:1:248:1893:25c8:1946", true},
+		{"ShouldRefuseLoopbackIPv4", "127.0.0.1", false},
+		{"ShouldRefuseLoopbackIPv6", "::1", false},
+		{"ShouldRefusePrivateIPv4", "10.0.0.1", false},
+		{"ShouldRefusePrivateIPv4ClassB", "172.16.0.1", false},
+		{"ShouldRefusePrivateIPv4ClassC", "192.168.1.1", false},
+		{"ShouldRefusePrivateIPv6", "fd00::1", false},
+		{"ShouldRefuseLinkLocalIPv4", "169.254.169.254", false},
+		{"ShouldRefuseLinkLocalIPv6", "fe80::1", false},
+		{"ShouldRefuseUnspecifiedIPv4", "0.0.0.0", false},
+		{"ShouldRefuseUnspecifiedIPv6", "::", false},
+		{"ShouldRefuseMulticast", "224.0.0.1", false},
+		{"ShouldRefuseIPv4MappedLoopback", "::ffff:127.0.0.1", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isPublicIP(net.ParseIP(tc.ip)))
+		})
+	}
+}
+
+func TestIsRestrictedHostURI(t *testing.T) {
+	testCases := []struct {
+		name     string
+		uri      string
+		expected bool
+	}{
+		{"ShouldAllowDomain", "https://app.example.com/jwks.json", false},
+		{"ShouldAllowPublicIP", "https://93.184.216.34/jwks.json", false},
+		{"ShouldRestrictLocalhost", "https://LOCALHOST:9091/jwks.json", true},
+		{"ShouldRestrictLocalhostSubdomain", "https://app.localhost/jwks.json", true},
+		{"ShouldRestrictLoopback", "https://127.0.0.1/jwks.json", true},
+		{"ShouldRestrictLoopbackIPv6", "https://[::1]/jwks.json", true},
+		{"ShouldRestrictPrivate", "https://192.168.1.1/jwks.json", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uri, err := url.Parse(tc.uri)
+
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, isRestrictedHostURI(uri))
+		})
+	}
+}
+
+func TestNewRestrictedHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	_, err := NewRestrictedHTTPClient(time.Second).Get(server.URL)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection to '127.0.0.1' was refused as it's not a public address")
+
+	resp, err := (&http.Client{Timeout: time.Second}).Get(server.URL)
+
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRestrictedJWKSFetcherStrategy_ShouldRefuseInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"keys":[]}`))
+	}))
+
+	defer server.Close()
+
+	keys, err := NewRestrictedJWKSFetcherStrategy(NewRestrictedHTTPClient(time.Second)).Resolve(server.URL, false)
+
+	assert.Nil(t, keys)
+	require.Error(t, err)
+
+	keys, err = NewRestrictedJWKSFetcherStrategy(server.Client()).Resolve(server.URL, false)
+
+	require.NoError(t, err)
+	assert.Len(t, keys.Keys, 0)
+}
+
+func TestOpenIDConnectProvider_getHTTPClient(t *testing.T) {
+	provider := &OpenIDConnectProvider{
+		httpClient:           &http.Client{},
+		restrictedHTTPClient: NewRestrictedHTTPClient(time.Second),
+	}
+
+	assert.Equal(t, provider.httpClient, provider.getHTTPClient(&Client{ID: "configured"}))
+	assert.Equal(t, provider.restrictedHTTPClient, provider.getHTTPClient(&Client{ID: "registered", Registered: true}))
+	assert.Equal(t, provider.restrictedHTTPClient, provider.getHTTPClient(nil))
+}
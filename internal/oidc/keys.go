@@ -3,6 +3,9 @@ package oidc
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"errors"
 	"fmt"
@@ -12,15 +15,29 @@ import (
 	"gopkg.in/square/go-jose.v2"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
-// NewKeyManagerWithConfiguration when provided a schema.OpenIDConnectConfiguration creates a new KeyManager and adds an
-// initial key to the manager.
+// NewKeyManagerWithConfiguration when provided a schema.OpenIDConnectConfiguration creates a new KeyManager and adds the
+// configured keys to the manager. When key rotation is enabled the manager may be returned without an active key as the
+// rotated keys are loaded from the storage provider at a later stage.
 func NewKeyManagerWithConfiguration(config *schema.OpenIDConnectConfiguration) (manager *KeyManager, err error) {
 	manager = NewKeyManager()
 
-	if _, err = manager.AddActiveJWK(config.IssuerCertificateChain, config.IssuerPrivateKey); err != nil {
-		return nil, err
+	if config.IssuerPrivateKey != nil || (len(config.IssuerPrivateKeys) == 0 && !config.KeyRotation.Enable) {
+		if _, err = manager.AddActiveJWK(config.IssuerCertificateChain, config.IssuerPrivateKey); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range config.IssuerPrivateKeys {
+		if _, err = manager.AddJWK(key.KeyID, key.Algorithm, key.CertificateChain, key.Key); err != nil {
+			return nil, err
+		}
+	}
+
+	if manager.jwk == nil && !config.KeyRotation.Enable {
+		return nil, fmt.Errorf("could not configure the key manager: at least one key with the algorithm '%s' is required", SigningAlgorithmRSAWithSHA256)
 	}
 
 	return manager, nil
@@ -29,26 +46,36 @@ func NewKeyManagerWithConfiguration(config *schema.OpenIDConnectConfiguration) (
 // NewKeyManager creates a new empty KeyManager.
 func NewKeyManager() (manager *KeyManager) {
 	return &KeyManager{
+		kids: map[string]*JWK{},
+		algs: map[string]*JWK{},
 		jwks: &jose.JSONWebKeySet{},
 	}
 }
 
-// Strategy returns the fosite jwt.JWTStrategy.
+// Strategy returns the fosite jwt.JWTStrategy. The strategy signs tokens with the key referenced by the kid or alg
+// header, falling back to the active key, and verifies tokens with the key referenced by their kid header. If the
+// manager does not currently have an active key this returns nil.
 func (m *KeyManager) Strategy() (strategy jwt.JWTStrategy) {
-	if m.jwk == nil {
+	if m.getActive() == nil {
 		return nil
 	}
 
-	return m.jwk.Strategy()
+	return NewKeyManagerStrategy(m)
 }
 
-// GetKeySet returns the joseJSONWebKeySet containing the rsa.PublicKey types.
+// GetKeySet returns the jose.JSONWebKeySet containing the public keys of all keys.
 func (m *KeyManager) GetKeySet() (jwks *jose.JSONWebKeySet) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	return m.jwks
 }
 
 // GetActiveJWK obtains the currently active jose.JSONWebKey.
 func (m *KeyManager) GetActiveJWK() (jwk *jose.JSONWebKey, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	if m.jwks == nil || m.jwk == nil {
 		return nil, errors.New("could not obtain the active JWK from an improperly configured key manager")
 	}
@@ -68,105 +95,496 @@ func (m *KeyManager) GetActiveJWK() (jwk *jose.JSONWebKey, err error) {
 
 // GetActiveKeyID returns the key id of the currently active key.
 func (m *KeyManager) GetActiveKeyID() (keyID string) {
-	if m.jwk == nil {
-		return ""
+	if jwk := m.getActive(); jwk != nil {
+		return jwk.id
 	}
 
-	return m.jwk.id
+	return ""
 }
 
-// GetActivePrivateKey returns the rsa.PrivateKey of the currently active key.
-func (m *KeyManager) GetActivePrivateKey() (key *rsa.PrivateKey, err error) {
-	if m.jwk == nil {
+// GetActivePrivateKey returns the private key of the currently active key.
+func (m *KeyManager) GetActivePrivateKey() (key schema.CryptographicPrivateKey, err error) {
+	jwk := m.getActive()
+
+	if jwk == nil {
 		return nil, errors.New("failed to retrieve active private key")
 	}
 
-	return m.jwk.key, nil
+	return jwk.key, nil
+}
+
+// GetByKeyID returns the JWK with the provided key id or nil if none exists.
+func (m *KeyManager) GetByKeyID(kid string) (jwk *JWK) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.kids[kid]
+}
+
+// GetByAlg returns the default JWK for the provided signing algorithm or nil if none exists.
+func (m *KeyManager) GetByAlg(alg string) (jwk *JWK) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.algs[alg]
+}
+
+// GetKeyIDFromAlg returns the key id of the default JWK for the provided signing algorithm, or the key id of the
+// active key if the signing algorithm is empty or has no key.
+func (m *KeyManager) GetKeyIDFromAlg(alg string) (kid string) {
+	if jwk := m.GetByAlg(alg); jwk != nil {
+		return jwk.id
+	}
+
+	return m.GetActiveKeyID()
+}
+
+// GetAlgorithms returns the signing algorithms this KeyManager has keys for in the order they were added.
+func (m *KeyManager) GetAlgorithms() (algs []string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, jwk := range m.jwks.Keys {
+		if !utils.IsStringInSlice(jwk.Algorithm, algs) {
+			algs = append(algs, jwk.Algorithm)
+		}
+	}
+
+	return algs
 }
 
-// AddActiveJWK is used to add a cert and key pair.
+// AddActiveJWK is used to add a RS256 cert and key pair and make it the active key.
 func (m *KeyManager) AddActiveJWK(chain schema.X509CertificateChain, key *rsa.PrivateKey) (jwk *JWK, err error) {
-	// TODO: Add a mutex when implementing key rotation to be utilized here and in methods which retrieve the JWK or JWKS.
-	if m.jwk, err = NewJWK(chain, key); err != nil {
+	if key == nil {
+		return nil, fmt.Errorf("JWK is not properly initialized: missing key")
+	}
+
+	if jwk, err = m.AddJWK("", SigningAlgorithmRSAWithSHA256, chain, key); err != nil {
 		return nil, err
 	}
 
-	m.jwks.Keys = append(m.jwks.Keys, *m.jwk.JSONWebKey())
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.active = jwk
+
+	m.rebuild()
 
-	return m.jwk, nil
+	return jwk, nil
 }
 
-// JWTStrategy is a decorator struct for the fosite jwt.JWTStrategy.
-type JWTStrategy struct {
-	jwt.JWTStrategy
+// AddJWK is used to add a cert and key pair. The first key added for a signing algorithm is the default for that
+// algorithm, and the first RS256 key is the active key unless one is explicitly made active via AddActiveJWK or a
+// rotated RS256 key is current.
+func (m *KeyManager) AddJWK(kid, alg string, chain schema.X509CertificateChain, key schema.CryptographicPrivateKey) (jwk *JWK, err error) {
+	if jwk, err = NewJWK(kid, alg, chain, key); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.kids[jwk.id]; ok {
+		return nil, fmt.Errorf("could not add the JWK: a key with the key id '%s' already exists", jwk.id)
+	}
+
+	m.keys = append(m.keys, jwk)
+
+	m.rebuild()
+
+	return jwk, nil
+}
+
+// SetRotatedJWKs replaces the keys managed by key rotation. The current key becomes the default for its signing
+// algorithm, and all of the keys are published in the JWKS so tokens signed by them can be verified. Rotated keys
+// with a key id which is already used by a configured key are ignored.
+func (m *KeyManager) SetRotatedJWKs(current *JWK, keys []*JWK) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.current, m.rotated = current, keys
+
+	m.rebuild()
+}
+
+// rebuild recalculates the key lookups and the JWKS from the configured and rotated keys. The caller must hold the
+// write lock.
+func (m *KeyManager) rebuild() {
+	kids := map[string]*JWK{}
+	algs := map[string]*JWK{}
+	jwks := &jose.JSONWebKeySet{}
+
+	for _, jwk := range m.keys {
+		kids[jwk.id] = jwk
 
-	id string
+		if _, ok := algs[jwk.alg]; !ok {
+			algs[jwk.alg] = jwk
+		}
+
+		jwks.Keys = append(jwks.Keys, *jwk.JSONWebKey())
+	}
+
+	if m.active != nil {
+		algs[m.active.alg] = m.active
+	}
+
+	for _, jwk := range m.rotated {
+		if _, ok := kids[jwk.id]; ok {
+			continue
+		}
+
+		kids[jwk.id] = jwk
+
+		jwks.Keys = append(jwks.Keys, *jwk.JSONWebKey())
+	}
+
+	if m.current != nil && kids[m.current.id] == m.current {
+		algs[m.current.alg] = m.current
+	}
+
+	m.kids, m.algs, m.jwks, m.jwk = kids, algs, jwks, algs[SigningAlgorithmRSAWithSHA256]
+}
+
+func (m *KeyManager) getActive() (jwk *JWK) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.jwk
+}
+
+// NewKeyManagerStrategy returns a new KeyManagerStrategy for the provided KeyManager. Unlike KeyManager.Strategy this
+// always returns a strategy, which returns errors when the KeyManager does not have an active key.
+func NewKeyManagerStrategy(manager *KeyManager) (strategy *KeyManagerStrategy) {
+	return &KeyManagerStrategy{manager: manager}
+}
+
+// KeyManagerStrategy is a jwt.JWTStrategy which selects the JWK from a KeyManager to sign and verify tokens with.
+type KeyManagerStrategy struct {
+	manager *KeyManager
+}
+
+// GetPublicKeyID returns the key id of the active key.
+func (s *KeyManagerStrategy) GetPublicKeyID(_ context.Context) (string, error) {
+	if jwk := s.manager.getActive(); jwk != nil {
+		return jwk.id, nil
+	}
+
+	return "", errNoActiveKey
+}
+
+// Generate generates a new token signed with the key referenced by the kid header. If the header is absent or
+// references an unknown key the default key for the alg header is used, followed by the active key.
+func (s *KeyManagerStrategy) Generate(ctx context.Context, claims jwt.MapClaims, header jwt.Mapper) (tokenString string, sig string, err error) {
+	if header == nil || claims == nil {
+		return "", "", errors.New("either claims or header is nil")
+	}
+
+	jwk := s.jwkFromHeader(header)
+
+	if jwk == nil {
+		return "", "", errNoActiveKey
+	}
+
+	return jwk.Strategy().Generate(ctx, claims, header)
+}
+
+// Validate validates a token and returns its signature.
+func (s *KeyManagerStrategy) Validate(ctx context.Context, token string) (sig string, err error) {
+	if _, err = s.Decode(ctx, token); err != nil {
+		return "", err
+	}
+
+	return s.GetSignature(ctx, token)
+}
+
+// Decode decodes a token and verifies it with the key referenced by its kid header, or the active key if it has no
+// kid header.
+func (s *KeyManagerStrategy) Decode(_ context.Context, token string) (t *jwt.Token, err error) {
+	return jwt.ParseWithClaims(token, jwt.MapClaims{}, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header[JWTHeaderKeyIdentifier].(string)
+
+		if kid == "" {
+			jwk := s.manager.getActive()
+
+			if jwk == nil {
+				return nil, errNoActiveKey
+			}
+
+			return jwk.keyFunc(t)
+		}
+
+		jwk := s.manager.GetByKeyID(kid)
+
+		if jwk == nil {
+			return nil, fmt.Errorf("the token was signed by the key with the key id '%s' which is unknown", kid)
+		}
+
+		return jwk.keyFunc(t)
+	})
+}
+
+// GetSignature returns the signature of a token.
+func (s *KeyManagerStrategy) GetSignature(_ context.Context, token string) (sig string, err error) {
+	return getTokenSignature(token)
+}
+
+// Hash hashes the input using the hash function of the active key, or SHA-256 if there is no active key.
+func (s *KeyManagerStrategy) Hash(ctx context.Context, in []byte) (sum []byte, err error) {
+	if jwk := s.manager.getActive(); jwk != nil {
+		return jwk.Strategy().Hash(ctx, in)
+	}
+
+	h := crypto.SHA256.New()
+
+	if _, err = h.Write(in); err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
+
+// GetSigningMethodLength returns the hash length of the active key, or the SHA-256 length if there is no active key.
+func (s *KeyManagerStrategy) GetSigningMethodLength() (size int) {
+	if jwk := s.manager.getActive(); jwk != nil {
+		return jwk.Strategy().GetSigningMethodLength()
+	}
+
+	return crypto.SHA256.Size()
+}
+
+func (s *KeyManagerStrategy) jwkFromHeader(header jwt.Mapper) (jwk *JWK) {
+	if kid, ok := header.Get(JWTHeaderKeyIdentifier).(string); ok && kid != "" {
+		if jwk = s.manager.GetByKeyID(kid); jwk != nil {
+			return jwk
+		}
+	}
+
+	if alg, ok := header.Get(JWTHeaderAlgorithm).(string); ok && alg != "" {
+		if jwk = s.manager.GetByAlg(alg); jwk != nil {
+			return jwk
+		}
+	}
+
+	return s.manager.getActive()
+}
+
+// JWTStrategy is a jwt.JWTStrategy which signs and verifies tokens with a single JWK.
+type JWTStrategy struct {
+	jwk *JWK
 }
 
 // KeyID returns the key id.
 func (s *JWTStrategy) KeyID() (id string) {
-	return s.id
+	return s.jwk.id
 }
 
-// GetPublicKeyID is a decorator func for the underlying fosite RS256JWTStrategy.
+// GetPublicKeyID returns the key id.
 func (s *JWTStrategy) GetPublicKeyID(_ context.Context) (string, error) {
-	return s.id, nil
+	return s.jwk.id, nil
 }
 
-// NewJWK creates a new JWK.
-func NewJWK(chain schema.X509CertificateChain, key *rsa.PrivateKey) (j *JWK, err error) {
+// Generate generates a new token signed with the JWK. The kid header is always set to the key id of the JWK.
+func (s *JWTStrategy) Generate(_ context.Context, claims jwt.MapClaims, header jwt.Mapper) (tokenString string, sig string, err error) {
+	if header == nil || claims == nil {
+		return "", "", errors.New("either claims or header is nil")
+	}
+
+	token := jwt.NewWithClaims(jose.SignatureAlgorithm(s.jwk.alg), claims)
+
+	for key, value := range header.ToMap() {
+		if key == JWTHeaderAlgorithm {
+			continue
+		}
+
+		token.Header[key] = value
+	}
+
+	token.Header[JWTHeaderKeyIdentifier] = s.jwk.id
+
+	if tokenString, err = token.SignedString(s.jwk.key); err != nil {
+		return "", "", err
+	}
+
+	if sig, err = getTokenSignature(tokenString); err != nil {
+		return "", "", err
+	}
+
+	return tokenString, sig, nil
+}
+
+// Validate validates a token and returns its signature.
+func (s *JWTStrategy) Validate(ctx context.Context, token string) (sig string, err error) {
+	if _, err = s.Decode(ctx, token); err != nil {
+		return "", err
+	}
+
+	return s.GetSignature(ctx, token)
+}
+
+// Decode decodes a token and verifies it with the JWK.
+func (s *JWTStrategy) Decode(_ context.Context, token string) (t *jwt.Token, err error) {
+	return jwt.ParseWithClaims(token, jwt.MapClaims{}, s.jwk.keyFunc)
+}
+
+// GetSignature returns the signature of a token.
+func (s *JWTStrategy) GetSignature(_ context.Context, token string) (sig string, err error) {
+	return getTokenSignature(token)
+}
+
+// Hash hashes the input using the hash function of the JWK signing algorithm.
+func (s *JWTStrategy) Hash(_ context.Context, in []byte) (sum []byte, err error) {
+	h := s.jwk.hash().New()
+
+	if _, err = h.Write(in); err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
+
+// GetSigningMethodLength returns the hash length of the JWK signing algorithm.
+func (s *JWTStrategy) GetSigningMethodLength() (size int) {
+	return s.jwk.hash().Size()
+}
+
+// NewJWK creates a new JWK. If the key id is empty it's derived from the SHA1 thumbprint of the public key, and if the
+// signing algorithm is empty it's derived from the key type.
+func NewJWK(kid, alg string, chain schema.X509CertificateChain, key schema.CryptographicPrivateKey) (j *JWK, err error) {
 	if key == nil {
 		return nil, fmt.Errorf("JWK is not properly initialized: missing key")
 	}
 
-	j = &JWK{
+	if alg == "" {
+		alg = DefaultJWKSigningAlgorithm(key)
+	}
+
+	if !IsJWKSigningAlgorithmValid(key, alg) {
+		return nil, fmt.Errorf("JWK is not properly initialized: the key of type %T can't be used with the algorithm '%s'", key, alg)
+	}
+
+	if kid == "" {
+		if kid, err = NewJWKKeyID(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return &JWK{
+		id:    kid,
+		alg:   alg,
 		key:   key,
 		chain: chain,
-	}
+	}, nil
+}
 
+// NewJWKKeyID returns the default key id for a key which is the first 6 characters of the hex encoded SHA1
+// thumbprint of the public key.
+func NewJWKKeyID(key schema.CryptographicPrivateKey) (kid string, err error) {
 	jwk := &jose.JSONWebKey{
-		Algorithm: SigningAlgorithmRSAWithSHA256,
-		Use:       "sig",
-		Key:       &key.PublicKey,
+		Key: key.Public(),
 	}
 
 	var thumbprint []byte
 
 	if thumbprint, err = jwk.Thumbprint(crypto.SHA1); err != nil {
-		return nil, fmt.Errorf("failed to calculate SHA1 thumbprint for certificate: %w", err)
+		return "", fmt.Errorf("failed to calculate SHA1 thumbprint for certificate: %w", err)
 	}
 
-	j.id = strings.ToLower(fmt.Sprintf("%x", thumbprint))
+	kid = strings.ToLower(fmt.Sprintf("%x", thumbprint))
 
-	if len(j.id) >= 7 {
-		j.id = j.id[:6]
+	if len(kid) >= 7 {
+		kid = kid[:6]
 	}
 
-	if len(j.id) >= 7 {
-		j.id = j.id[:6]
+	return kid, nil
+}
+
+// DefaultJWKSigningAlgorithm returns the default signing algorithm for a key, or an empty string if the key type is
+// not supported.
+func DefaultJWKSigningAlgorithm(key schema.CryptographicPrivateKey) (alg string) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return SigningAlgorithmRSAWithSHA256
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return SigningAlgorithmECDSAWithSHA256
+		case elliptic.P384():
+			return SigningAlgorithmECDSAWithSHA384
+		case elliptic.P521():
+			return SigningAlgorithmECDSAWithSHA512
+		}
+	case ed25519.PrivateKey:
+		return SigningAlgorithmEdDSA
 	}
 
-	return j, nil
+	return ""
+}
+
+// IsJWKSigningAlgorithmValid returns true if the signing algorithm can be used with the key.
+func IsJWKSigningAlgorithmValid(key schema.CryptographicPrivateKey, alg string) (valid bool) {
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		return alg == SigningAlgorithmRSAWithSHA256 || alg == SigningAlgorithmRSAWithSHA384 || alg == SigningAlgorithmRSAWithSHA512
+	default:
+		return alg != "" && alg == DefaultJWKSigningAlgorithm(key)
+	}
+}
+
+// IsPublicJWKSigningAlgorithmValid returns true if the signing algorithm can be used to verify signatures with the
+// public key.
+func IsPublicJWKSigningAlgorithmValid(key crypto.PublicKey, alg string) (valid bool) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		switch alg {
+		case SigningAlgorithmRSAWithSHA256, SigningAlgorithmRSAWithSHA384, SigningAlgorithmRSAWithSHA512,
+			SigningAlgorithmRSAPSSWithSHA256, SigningAlgorithmRSAPSSWithSHA384, SigningAlgorithmRSAPSSWithSHA512:
+			return true
+		}
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return alg == SigningAlgorithmECDSAWithSHA256
+		case elliptic.P384():
+			return alg == SigningAlgorithmECDSAWithSHA384
+		case elliptic.P521():
+			return alg == SigningAlgorithmECDSAWithSHA512
+		}
+	case ed25519.PublicKey:
+		return alg == SigningAlgorithmEdDSA
+	}
+
+	return false
 }
 
 // JWK is a utility wrapper for JSON Web Key's.
 type JWK struct {
 	id    string
-	key   *rsa.PrivateKey
+	alg   string
+	key   schema.CryptographicPrivateKey
 	chain schema.X509CertificateChain
 }
 
+// KeyID returns the key id.
+func (j *JWK) KeyID() (kid string) {
+	return j.id
+}
+
+// Algorithm returns the signing algorithm.
+func (j *JWK) Algorithm() (alg string) {
+	return j.alg
+}
+
 // Strategy returns the relevant jwt.JWTStrategy for this JWT.
 func (j *JWK) Strategy() (strategy jwt.JWTStrategy) {
-	return &JWTStrategy{id: j.id, JWTStrategy: &jwt.RS256JWTStrategy{PrivateKey: j.key}}
+	return &JWTStrategy{jwk: j}
 }
 
 // JSONWebKey returns the relevant *jose.JSONWebKey for this JWT.
 func (j *JWK) JSONWebKey() (jwk *jose.JSONWebKey) {
 	jwk = &jose.JSONWebKey{
-		Key:          &j.key.PublicKey,
+		Key:          j.key.Public(),
 		KeyID:        j.id,
-		Algorithm:    "RS256",
+		Algorithm:    j.alg,
 		Use:          "sig",
 		Certificates: j.chain.Certificates(),
 	}
@@ -177,3 +595,53 @@ func (j *JWK) JSONWebKey() (jwk *jose.JSONWebKey) {
 
 	return jwk
 }
+
+func (j *JWK) keyFunc(t *jwt.Token) (key any, err error) {
+	if string(t.Method) != j.alg {
+		return nil, fmt.Errorf("the token was signed with the algorithm '%s' but the key with the key id '%s' uses the algorithm '%s'", t.Method, j.id, j.alg)
+	}
+
+	// The public key is wrapped in a *jose.JSONWebKey as the ed25519.PublicKey type is not a pointer and the go-jose
+	// verifier does not accept a pointer to it.
+	return &jose.JSONWebKey{Key: j.key.Public()}, nil
+}
+
+func (j *JWK) hash() crypto.Hash {
+	switch j.alg {
+	case SigningAlgorithmRSAWithSHA384, SigningAlgorithmECDSAWithSHA384:
+		return crypto.SHA384
+	case SigningAlgorithmRSAWithSHA512, SigningAlgorithmECDSAWithSHA512:
+		return crypto.SHA512
+	default:
+		return crypto.SHA256
+	}
+}
+
+func getTokenSignature(token string) (sig string, err error) {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return "", errors.New("header, body and signature must all be set")
+	}
+
+	return parts[2], nil
+}
+
+// jwtHeaders is a jwt.Mapper which unlike jwt.Headers does not filter the typ header, allowing tokens to be generated
+// with an explicit type.
+type jwtHeaders map[string]any
+
+// ToMap returns the headers as a map.
+func (h jwtHeaders) ToMap() map[string]any {
+	return h
+}
+
+// Add adds a header.
+func (h jwtHeaders) Add(key string, value any) {
+	h[key] = value
+}
+
+// Get returns a header.
+func (h jwtHeaders) Get(key string) any {
+	return h[key]
+}
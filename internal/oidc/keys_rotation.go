@@ -0,0 +1,285 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewKeyRotator creates a new KeyRotator which manages the rotated keys of the provided KeyManager. Keys are retained
+// in the JWKS after they have expired for the longest lifespan of the tokens signed by them.
+func NewKeyRotator(config *schema.OpenIDConnectConfiguration, manager *KeyManager, store storage.Provider) (rotator *KeyRotator) {
+	return &KeyRotator{
+		config:    config.KeyRotation,
+		retention: getKeyRotationRetention(config),
+		manager:   manager,
+		store:     store,
+		clock:     utils.RealClock{},
+	}
+}
+
+// KeyRotator generates, promotes, and retires the rotated issuer keys. The keys are stored encrypted by the storage
+// provider so every instance shares the same keys.
+type KeyRotator struct {
+	config    schema.OpenIDConnectKeyRotationConfiguration
+	retention time.Duration
+
+	manager *KeyManager
+	store   storage.Provider
+	clock   utils.Clock
+}
+
+// Run performs the key rotation periodically until the context is done.
+func (r *KeyRotator) Run(ctx context.Context) {
+	logger := logging.Logger()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.clock.After(keyRotationCheckInterval):
+			if err := r.Rotate(ctx); err != nil {
+				logger.WithError(err).Error("Error occurred rotating the OpenID Connect issuer keys")
+			}
+		}
+	}
+}
+
+// Rotate loads the rotated keys from the storage provider and updates the KeyManager. Keys which have been retired for
+// longer than the retention period are deleted, the current key is generated if it doesn't exist or has expired, and
+// the next key is generated once the current key is within the pre-publish period of its expiration.
+func (r *KeyRotator) Rotate(ctx context.Context) (err error) {
+	now := r.clock.Now().UTC()
+
+	var keys []rotatedKey
+
+	if keys, err = r.load(ctx, now); err != nil {
+		return err
+	}
+
+	current := r.current(keys, now)
+
+	switch {
+	case current == nil:
+		err = r.generate(ctx, now, now.Add(r.config.Interval))
+	case now.After(current.NotAfter.Add(-r.config.PrePublish)) && r.next(keys, current) == nil:
+		err = r.generate(ctx, current.NotAfter, current.NotAfter.Add(r.config.Interval))
+	default:
+		r.publish(keys, current, now)
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Reload the keys after generating one so the keys generated by other instances are taken into account.
+	if keys, err = r.load(ctx, now); err != nil {
+		return err
+	}
+
+	if current = r.current(keys, now); current == nil {
+		return fmt.Errorf("error rotating the issuer keys: no current key with the algorithm '%s' exists after generating one", r.config.Algorithm)
+	}
+
+	r.publish(keys, current, now)
+
+	return nil
+}
+
+func (r *KeyRotator) load(ctx context.Context, now time.Time) (keys []rotatedKey, err error) {
+	var stored []model.OAuth2IssuerKey
+
+	if stored, err = r.store.LoadOAuth2IssuerKeys(ctx); err != nil {
+		return nil, fmt.Errorf("error loading the issuer keys: %w", err)
+	}
+
+	keys = make([]rotatedKey, 0, len(stored))
+
+	for _, key := range stored {
+		if !now.Before(key.NotAfter.Add(r.retention)) {
+			if err = r.store.DeleteOAuth2IssuerKey(ctx, key.KeyID); err != nil {
+				return nil, fmt.Errorf("error deleting the retired issuer key with key id '%s': %w", key.KeyID, err)
+			}
+
+			continue
+		}
+
+		var jwk *JWK
+
+		if jwk, err = newRotatedJWK(key); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, rotatedKey{OAuth2IssuerKey: key, jwk: jwk})
+	}
+
+	return keys, nil
+}
+
+func (r *KeyRotator) current(keys []rotatedKey, now time.Time) (current *rotatedKey) {
+	for i := range keys {
+		if keys[i].Algorithm != r.config.Algorithm || keys[i].NotBefore.After(now) || !now.Before(keys[i].NotAfter) {
+			continue
+		}
+
+		if current == nil || keys[i].NotBefore.After(current.NotBefore) {
+			current = &keys[i]
+		}
+	}
+
+	return current
+}
+
+func (r *KeyRotator) next(keys []rotatedKey, current *rotatedKey) (next *rotatedKey) {
+	for i := range keys {
+		if keys[i].Algorithm == r.config.Algorithm && !keys[i].NotBefore.Before(current.NotAfter) {
+			return &keys[i]
+		}
+	}
+
+	return nil
+}
+
+func (r *KeyRotator) publish(keys []rotatedKey, current *rotatedKey, now time.Time) {
+	published := make([]*JWK, 0, len(keys))
+
+	for _, key := range keys {
+		if key.NotBefore.Add(-r.config.PrePublish).After(now) {
+			continue
+		}
+
+		published = append(published, key.jwk)
+	}
+
+	r.manager.SetRotatedJWKs(current.jwk, published)
+}
+
+func (r *KeyRotator) generate(ctx context.Context, notBefore, notAfter time.Time) (err error) {
+	var key schema.CryptographicPrivateKey
+
+	if key, err = newRotatedPrivateKey(r.config.Algorithm, r.config.KeySize); err != nil {
+		return fmt.Errorf("error generating the issuer key: %w", err)
+	}
+
+	var jwk *JWK
+
+	if jwk, err = NewJWK("", r.config.Algorithm, schema.X509CertificateChain{}, key); err != nil {
+		return fmt.Errorf("error generating the issuer key: %w", err)
+	}
+
+	var der []byte
+
+	if der, err = x509.MarshalPKCS8PrivateKey(key); err != nil {
+		return fmt.Errorf("error generating the issuer key: %w", err)
+	}
+
+	stored := model.OAuth2IssuerKey{
+		KeyID:      jwk.id,
+		Algorithm:  jwk.alg,
+		PrivateKey: der,
+		CreatedAt:  r.clock.Now().UTC(),
+		NotBefore:  notBefore,
+		NotAfter:   notAfter,
+	}
+
+	if err = r.store.SaveOAuth2IssuerKey(ctx, stored); err != nil {
+		// Another instance may have generated the key for the same period at the same time in which case the keys are
+		// reloaded and the key generated by the other instance is used instead.
+		logging.Logger().WithError(err).Debug("Error occurred saving the generated issuer key, the keys will be reloaded")
+	}
+
+	return nil
+}
+
+type rotatedKey struct {
+	model.OAuth2IssuerKey
+
+	jwk *JWK
+}
+
+func newRotatedJWK(key model.OAuth2IssuerKey) (jwk *JWK, err error) {
+	var (
+		raw any
+		ok  bool
+		pk  schema.CryptographicPrivateKey
+	)
+
+	if raw, err = x509.ParsePKCS8PrivateKey(key.PrivateKey); err != nil {
+		return nil, fmt.Errorf("error parsing the issuer key with key id '%s': %w", key.KeyID, err)
+	}
+
+	if pk, ok = raw.(schema.CryptographicPrivateKey); !ok {
+		return nil, fmt.Errorf("error parsing the issuer key with key id '%s': the key of type %T is not supported", key.KeyID, raw)
+	}
+
+	if jwk, err = NewJWK(key.KeyID, key.Algorithm, schema.X509CertificateChain{}, pk); err != nil {
+		return nil, fmt.Errorf("error parsing the issuer key with key id '%s': %w", key.KeyID, err)
+	}
+
+	return jwk, nil
+}
+
+func newRotatedPrivateKey(alg string, size int) (key schema.CryptographicPrivateKey, err error) {
+	switch alg {
+	case SigningAlgorithmRSAWithSHA256, SigningAlgorithmRSAWithSHA384, SigningAlgorithmRSAWithSHA512:
+		return rsa.GenerateKey(rand.Reader, size)
+	case SigningAlgorithmECDSAWithSHA256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case SigningAlgorithmECDSAWithSHA384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case SigningAlgorithmECDSAWithSHA512:
+		return ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	case SigningAlgorithmEdDSA:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+
+		return key, err
+	default:
+		return nil, fmt.Errorf("the algorithm '%s' is not supported", alg)
+	}
+}
+
+// getKeyRotationRetention returns the longest lifespan of the tokens signed by the issuer keys, which includes the
+// lifespans of every client and grant type as they may exceed the global lifespans.
+func getKeyRotationRetention(config *schema.OpenIDConnectConfiguration) (retention time.Duration) {
+	lifespans := []schema.OpenIDConnectLifespans{
+		{AccessToken: config.AccessTokenLifespan, IDToken: config.IDTokenLifespan},
+	}
+
+	for _, client := range config.Clients {
+		lifespans = append(lifespans,
+			client.Lifespans.OpenIDConnectLifespans,
+			client.Lifespans.Grants.AuthorizationCode,
+			client.Lifespans.Grants.Implicit,
+			client.Lifespans.Grants.ClientCredentials,
+			client.Lifespans.Grants.RefreshToken,
+			client.Lifespans.Grants.DeviceCode,
+			client.Lifespans.Grants.TokenExchange,
+		)
+	}
+
+	for _, lifespan := range lifespans {
+		if lifespan.AccessToken > retention {
+			retention = lifespan.AccessToken
+		}
+
+		if lifespan.IDToken > retention {
+			retention = lifespan.IDToken
+		}
+	}
+
+	return retention
+}
@@ -0,0 +1,207 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite/token/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestKeyRotator_Rotate(t *testing.T) {
+	config := &schema.OpenIDConnectConfiguration{
+		IDTokenLifespan:     time.Hour,
+		AccessTokenLifespan: time.Minute * 30,
+		KeyRotation: schema.OpenIDConnectKeyRotationConfiguration{
+			Enable:     true,
+			Algorithm:  SigningAlgorithmECDSAWithSHA256,
+			Interval:   time.Hour * 24,
+			PrePublish: time.Hour * 2,
+		},
+	}
+
+	manager, err := NewKeyManagerWithConfiguration(config)
+	require.NoError(t, err)
+	assert.Nil(t, manager.Strategy())
+
+	store := &testIssuerKeyStore{}
+	clock := &testClock{now: time.Unix(1700000000, 0)}
+
+	rotator := NewKeyRotator(config, manager, store)
+	rotator.clock = clock
+
+	ctx := context.Background()
+
+	require.NoError(t, rotator.Rotate(ctx))
+	require.Len(t, store.keys, 1)
+
+	first := store.keys[0].KeyID
+
+	assert.Equal(t, first, manager.GetKeyIDFromAlg(SigningAlgorithmECDSAWithSHA256))
+	assert.Len(t, manager.GetKeySet().Keys, 1)
+
+	token, _, err := NewKeyManagerStrategy(manager).Generate(ctx, jwt.MapClaims{}, jwtHeaders{JWTHeaderAlgorithm: SigningAlgorithmECDSAWithSHA256})
+	require.NoError(t, err)
+
+	// Within the pre-publish period the next key is published but is not used to sign tokens.
+	clock.now = clock.now.Add(time.Hour * 23)
+
+	require.NoError(t, rotator.Rotate(ctx))
+	require.Len(t, store.keys, 2)
+
+	second := store.keys[1].KeyID
+
+	assert.Equal(t, store.keys[0].NotAfter, store.keys[1].NotBefore)
+	assert.Equal(t, first, manager.GetKeyIDFromAlg(SigningAlgorithmECDSAWithSHA256))
+	assert.Len(t, manager.GetKeySet().Keys, 2)
+	assert.Len(t, manager.GetKeySet().Key(second), 1)
+
+	// After the current key expires the next key is promoted and the retired key is still published.
+	clock.now = clock.now.Add(time.Hour + time.Minute*30)
+
+	require.NoError(t, rotator.Rotate(ctx))
+	require.Len(t, store.keys, 2)
+
+	assert.Equal(t, second, manager.GetKeyIDFromAlg(SigningAlgorithmECDSAWithSHA256))
+	assert.Len(t, manager.GetKeySet().Keys, 2)
+
+	_, err = NewKeyManagerStrategy(manager).Decode(ctx, token)
+	assert.NoError(t, err)
+
+	// After the retention period the retired key is deleted.
+	clock.now = clock.now.Add(time.Hour)
+
+	require.NoError(t, rotator.Rotate(ctx))
+	require.Len(t, store.keys, 1)
+
+	assert.Equal(t, second, store.keys[0].KeyID)
+	assert.Len(t, manager.GetKeySet().Keys, 1)
+
+	_, err = NewKeyManagerStrategy(manager).Decode(ctx, token)
+	assert.EqualError(t, err, "the token was signed by the key with the key id '"+first+"' which is unknown")
+}
+
+func TestNewKeyRotatorShouldRetainKeysForTheLongestClientLifespan(t *testing.T) {
+	testCases := []struct {
+		name     string
+		clients  []schema.OpenIDConnectClientConfiguration
+		expected time.Duration
+	}{
+		{
+			"ShouldUseGlobalLifespans",
+			nil,
+			time.Hour,
+		},
+		{
+			"ShouldUseClientLifespans",
+			[]schema.OpenIDConnectClientConfiguration{
+				{ID: "a", Lifespans: schema.OpenIDConnectClientLifespans{OpenIDConnectLifespans: schema.OpenIDConnectLifespans{AccessToken: time.Hour * 2}}},
+				{ID: "b", Lifespans: schema.OpenIDConnectClientLifespans{OpenIDConnectLifespans: schema.OpenIDConnectLifespans{IDToken: time.Hour * 3}}},
+			},
+			time.Hour * 3,
+		},
+		{
+			"ShouldUseGrantLifespans",
+			[]schema.OpenIDConnectClientConfiguration{
+				{ID: "a", Lifespans: schema.OpenIDConnectClientLifespans{Grants: schema.OpenIDConnectClientGrantLifespans{DeviceCode: schema.OpenIDConnectLifespans{AccessToken: time.Hour * 4}}}},
+			},
+			time.Hour * 4,
+		},
+		{
+			"ShouldIgnoreRefreshTokenLifespans",
+			[]schema.OpenIDConnectClientConfiguration{
+				{ID: "a", Lifespans: schema.OpenIDConnectClientLifespans{OpenIDConnectLifespans: schema.OpenIDConnectLifespans{RefreshToken: time.Hour * 24}}},
+			},
+			time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &schema.OpenIDConnectConfiguration{
+				IDTokenLifespan:     time.Hour,
+				AccessTokenLifespan: time.Minute * 30,
+				Clients:             tc.clients,
+			}
+
+			rotator := NewKeyRotator(config, nil, nil)
+
+			assert.Equal(t, tc.expected, rotator.retention)
+		})
+	}
+}
+
+func TestKeyRotator_RotateShouldReturnStorageErrors(t *testing.T) {
+	config := &schema.OpenIDConnectConfiguration{
+		KeyRotation: schema.OpenIDConnectKeyRotationConfiguration{
+			Enable:     true,
+			Algorithm:  SigningAlgorithmEdDSA,
+			Interval:   time.Hour * 24,
+			PrePublish: time.Hour * 2,
+		},
+	}
+
+	manager, err := NewKeyManagerWithConfiguration(config)
+	require.NoError(t, err)
+
+	rotator := NewKeyRotator(config, manager, &testIssuerKeyStore{err: errors.New("bad conn")})
+
+	assert.EqualError(t, rotator.Rotate(context.Background()), "error loading the issuer keys: bad conn")
+}
+
+type testIssuerKeyStore struct {
+	storage.Provider
+
+	keys []model.OAuth2IssuerKey
+	err  error
+}
+
+func (s *testIssuerKeyStore) SaveOAuth2IssuerKey(_ context.Context, key model.OAuth2IssuerKey) (err error) {
+	s.keys = append(s.keys, key)
+
+	sort.Slice(s.keys, func(i, j int) bool {
+		return s.keys[i].NotBefore.Before(s.keys[j].NotBefore)
+	})
+
+	return nil
+}
+
+func (s *testIssuerKeyStore) LoadOAuth2IssuerKeys(_ context.Context) (keys []model.OAuth2IssuerKey, err error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return append([]model.OAuth2IssuerKey{}, s.keys...), nil
+}
+
+func (s *testIssuerKeyStore) DeleteOAuth2IssuerKey(_ context.Context, kid string) (err error) {
+	for i, key := range s.keys {
+		if key.KeyID == kid {
+			s.keys = append(s.keys[:i], s.keys[i+1:]...)
+
+			break
+		}
+	}
+
+	return nil
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -1,11 +1,17 @@
 package oidc
 
 import (
+	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
 	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/ory/fosite/token/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -46,3 +52,78 @@ func TestKeyManager_AddActiveJWK(t *testing.T) {
 	assert.NotNil(t, keySet)
 	assert.Equal(t, kid, manager.GetActiveKeyID())
 }
+
+func TestKeyManager_AddJWK(t *testing.T) {
+	keyECDSA, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	_, keyEd25519, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	manager, err := NewKeyManagerWithConfiguration(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKeys: []schema.JWK{
+			{Key: keyECDSA},
+			{KeyID: "rsa", Algorithm: SigningAlgorithmRSAWithSHA512, Key: mustParseRSAPrivateKey(exampleIssuerPrivateKey)},
+			{KeyID: "ed", Key: keyEd25519},
+		},
+	})
+
+	assert.EqualError(t, err, "could not configure the key manager: at least one key with the algorithm 'RS256' is required")
+	assert.Nil(t, manager)
+
+	manager, err = NewKeyManagerWithConfiguration(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKeys: []schema.JWK{
+			{Key: keyECDSA},
+			{KeyID: "rsa", Key: mustParseRSAPrivateKey(exampleIssuerPrivateKey)},
+			{KeyID: "ed", Key: keyEd25519},
+		},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, manager)
+
+	assert.Equal(t, "rsa", manager.GetActiveKeyID())
+	assert.Equal(t, []string{SigningAlgorithmECDSAWithSHA384, SigningAlgorithmRSAWithSHA256, SigningAlgorithmEdDSA}, manager.GetAlgorithms())
+	assert.Len(t, manager.GetKeySet().Keys, 3)
+	assert.Equal(t, "ed", manager.GetKeyIDFromAlg(SigningAlgorithmEdDSA))
+	assert.Equal(t, "rsa", manager.GetKeyIDFromAlg(SigningAlgorithmECDSAWithSHA512))
+	assert.Nil(t, manager.GetByAlg(SigningAlgorithmECDSAWithSHA256))
+
+	_, err = manager.AddJWK("ed", SigningAlgorithmEdDSA, schema.X509CertificateChain{}, keyEd25519)
+	assert.EqualError(t, err, "could not add the JWK: a key with the key id 'ed' already exists")
+
+	_, err = manager.AddJWK("bad", SigningAlgorithmECDSAWithSHA256, schema.X509CertificateChain{}, keyECDSA)
+	assert.EqualError(t, err, "JWK is not properly initialized: the key of type *ecdsa.PrivateKey can't be used with the algorithm 'ES256'")
+
+	strategy := manager.Strategy()
+
+	for _, alg := range manager.GetAlgorithms() {
+		t.Run(alg, func(t *testing.T) {
+			kid := manager.GetKeyIDFromAlg(alg)
+
+			token, sig, err := strategy.Generate(context.Background(), jwt.MapClaims{ClaimSubject: "john"}, &jwt.Headers{
+				Extra: map[string]any{JWTHeaderKeyIdentifier: kid},
+			})
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, sig)
+
+			decoded, err := strategy.Decode(context.Background(), token)
+			require.NoError(t, err)
+
+			assert.Equal(t, alg, string(decoded.Method))
+			assert.Equal(t, kid, decoded.Header[JWTHeaderKeyIdentifier])
+			assert.Equal(t, "john", decoded.Claims[ClaimSubject])
+
+			actual, err := strategy.Validate(context.Background(), token)
+			assert.NoError(t, err)
+			assert.Equal(t, sig, actual)
+		})
+	}
+
+	token, _, err := manager.GetByKeyID("ed").Strategy().Generate(context.Background(), jwt.MapClaims{}, &jwt.Headers{})
+	require.NoError(t, err)
+
+	_, err = manager.GetByKeyID("rsa").Strategy().Decode(context.Background(), token)
+	assert.EqualError(t, err, "the token was signed with the algorithm 'EdDSA' but the key with the key id 'rsa' uses the algorithm 'RS256'")
+}
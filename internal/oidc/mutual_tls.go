@@ -0,0 +1,363 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ory/fosite"
+	"github.com/ory/x/errorsx"
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewMutualTLSCertificateResolver creates a new MutualTLSCertificateResolver.
+func NewMutualTLSCertificateResolver(config schema.OpenIDConnectMutualTLSConfiguration) (resolver *MutualTLSCertificateResolver) {
+	resolver = &MutualTLSCertificateResolver{
+		header: config.ForwardedCertificateHeader,
+	}
+
+	if certs := config.CertificateAuthorities.Certificates(); len(certs) != 0 {
+		resolver.roots = x509.NewCertPool()
+
+		for _, cert := range certs {
+			resolver.roots.AddCert(cert)
+		}
+	}
+
+	for _, proxy := range config.TrustedProxies {
+		if network, err := parseNetwork(proxy); err == nil {
+			resolver.proxies = append(resolver.proxies, network)
+		}
+	}
+
+	return resolver
+}
+
+// MutualTLSCertificateResolver resolves the client certificate of a request either from the TLS connection or from the
+// forwarded certificate header set by a trusted proxy which terminates TLS.
+type MutualTLSCertificateResolver struct {
+	roots   *x509.CertPool
+	header  string
+	proxies []*net.IPNet
+}
+
+// Resolve returns the client certificate of the request, and true if the certificate chain was verified either by the
+// TLS connection or against the configured certificate authorities. A nil certificate is returned if the request
+// doesn't have a client certificate, or the forwarded certificate header was not set by a trusted proxy.
+func (r *MutualTLSCertificateResolver) Resolve(req *http.Request) (cert *x509.Certificate, verified bool, err error) {
+	if req.TLS != nil && len(req.TLS.PeerCertificates) != 0 {
+		cert = req.TLS.PeerCertificates[0]
+
+		return cert, len(req.TLS.VerifiedChains) != 0 || r.verify(cert, req.TLS.PeerCertificates[1:]), nil
+	}
+
+	if r == nil || r.header == "" {
+		return nil, false, nil
+	}
+
+	value := req.Header.Get(r.header)
+
+	if value == "" || !r.isTrustedProxy(req.RemoteAddr) {
+		return nil, false, nil
+	}
+
+	if cert, err = decodeForwardedCertificate(value); err != nil {
+		return nil, false, errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The forwarded client certificate could not be decoded.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return cert, r.verify(cert, nil), nil
+}
+
+func (r *MutualTLSCertificateResolver) verify(cert *x509.Certificate, intermediates []*x509.Certificate) bool {
+	if r == nil || r.roots == nil {
+		return false
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         r.roots,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	for _, intermediate := range intermediates {
+		opts.Intermediates.AddCert(intermediate)
+	}
+
+	_, err := cert.Verify(opts)
+
+	return err == nil
+}
+
+func (r *MutualTLSCertificateResolver) isTrustedProxy(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range r.proxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeForwardedCertificate decodes a certificate forwarded by a proxy as either a PEM block which may be URL encoded,
+// or the base64 encoded DER bytes.
+func decodeForwardedCertificate(value string) (cert *x509.Certificate, err error) {
+	if strings.Contains(value, "%") {
+		if value, err = url.QueryUnescape(value); err != nil {
+			return nil, err
+		}
+	}
+
+	var der []byte
+
+	if block, _ := pem.Decode([]byte(value)); block != nil {
+		der = block.Bytes
+	} else if der, err = base64.StdEncoding.DecodeString(value); err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(der)
+}
+
+func parseNetwork(value string) (network *net.IPNet, err error) {
+	if !strings.Contains(value, "/") {
+		if ip := net.ParseIP(value); ip != nil {
+			if ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+	}
+
+	_, network, err = net.ParseCIDR(value)
+
+	return network, err
+}
+
+// CertificateThumbprint returns the base64url encoded SHA-256 thumbprint of the DER encoding of an X.509 certificate,
+// which is the value of the x5t#S256 confirmation method.
+//
+// RFC8705: https://www.rfc-editor.org/rfc/rfc8705.html#section-3.1
+func CertificateThumbprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (s *ClientAuthenticationStrategy) authenticateClientCertificate(r *http.Request, client *Client) (err error) {
+	var (
+		cert     *x509.Certificate
+		verified bool
+	)
+
+	if cert, verified, err = s.mutualTLS.Resolve(r); err != nil {
+		return err
+	}
+
+	if cert == nil {
+		return errorsx.WithStack(fosite.ErrInvalidClient.WithHintf("The OAuth 2.0 Client supports client authentication method '%s', but the request does not include a client certificate.", client.GetTokenEndpointAuthMethod()))
+	}
+
+	switch client.GetTokenEndpointAuthMethod() {
+	case ClientAuthMethodTLSClientAuth:
+		if !verified {
+			return errorsx.WithStack(fosite.ErrInvalidClient.WithHint("The client certificate was not issued by a trusted certificate authority."))
+		}
+
+		if !client.IsTLSClientAuthCertificate(cert) {
+			return errorsx.WithStack(fosite.ErrInvalidClient.WithHint("The client certificate does not match the registered subject of the OAuth 2.0 Client."))
+		}
+	case ClientAuthMethodSelfSignedTLSClientAuth:
+		if !s.isClientJSONWebKeyCertificate(client, cert) {
+			return errorsx.WithStack(fosite.ErrInvalidClient.WithHint("The client certificate does not match any of the JSON Web Keys registered for the OAuth 2.0 Client."))
+		}
+	}
+
+	return nil
+}
+
+func (s *ClientAuthenticationStrategy) isClientJSONWebKeyCertificate(client *Client, cert *x509.Certificate) bool {
+	keys := client.GetJSONWebKeys()
+
+	if keys == nil {
+		uri := client.GetJSONWebKeysURI()
+
+		if uri == "" {
+			return false
+		}
+
+		var err error
+
+		if keys, err = s.resolveJSONWebKeys(client, uri, false); err != nil {
+			return false
+		}
+
+		if !isJSONWebKeySetCertificate(keys, cert) {
+			if keys, err = s.resolveJSONWebKeys(client, uri, true); err != nil {
+				return false
+			}
+		}
+	}
+
+	return isJSONWebKeySetCertificate(keys, cert)
+}
+
+func isJSONWebKeySetCertificate(keys *jose.JSONWebKeySet, cert *x509.Certificate) bool {
+	type publicKey interface {
+		Equal(x crypto.PublicKey) bool
+	}
+
+	for _, key := range keys.Keys {
+		for _, c := range key.Certificates {
+			if c.Equal(cert) {
+				return true
+			}
+		}
+
+		if pub, ok := key.Key.(publicKey); ok && pub.Equal(cert.PublicKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsTLSClientAuthCertificate returns true if the certificate matches the subject distinguished name or subject
+// alternative name registered for the tls_client_auth client authentication method.
+//
+// RFC8705: https://www.rfc-editor.org/rfc/rfc8705.html#section-2.1.2
+func (c *Client) IsTLSClientAuthCertificate(cert *x509.Certificate) bool {
+	switch {
+	case c.TLSClientAuthSubjectDN != "":
+		return cert.Subject.String() == c.TLSClientAuthSubjectDN
+	case c.TLSClientAuthSANDNS != "":
+		return utils.IsStringInSliceFold(c.TLSClientAuthSANDNS, cert.DNSNames)
+	case c.TLSClientAuthSANURI != "":
+		for _, uri := range cert.URIs {
+			if uri.String() == c.TLSClientAuthSANURI {
+				return true
+			}
+		}
+	case c.TLSClientAuthSANIP != "":
+		ip := net.ParseIP(c.TLSClientAuthSANIP)
+
+		for _, address := range cert.IPAddresses {
+			if address.Equal(ip) {
+				return true
+			}
+		}
+	case c.TLSClientAuthSANEmail != "":
+		return utils.IsStringInSlice(c.TLSClientAuthSANEmail, cert.EmailAddresses)
+	}
+
+	return false
+}
+
+// BindMutualTLSAccessRequest binds the tokens issued for the Access Request to the client certificate of the request
+// when the client uses certificate-bound access tokens. The refresh tokens of public clients remain bound to the
+// certificate they were originally bound to.
+//
+// RFC8705: https://www.rfc-editor.org/rfc/rfc8705.html#section-3
+func (p *OpenIDConnectProvider) BindMutualTLSAccessRequest(r *http.Request, requester fosite.AccessRequester) (err error) {
+	session, ok := requester.GetSession().(*model.OpenIDSession)
+	if !ok {
+		return errorsx.WithStack(fosite.ErrServerError.WithHint("The session does not support certificate-bound tokens."))
+	}
+
+	var cert *x509.Certificate
+
+	if cert, _, err = p.mutualTLS.Resolve(r); err != nil {
+		return err
+	}
+
+	var thumbprint string
+
+	if cert != nil {
+		thumbprint = CertificateThumbprint(cert)
+	}
+
+	client := requester.GetClient()
+
+	if requester.GetGrantTypes().ExactOne(GrantTypeRefreshToken) && client.IsPublic() && session.MutualTLSCertificateThumbprint != "" &&
+		thumbprint != session.MutualTLSCertificateThumbprint {
+		return errorsx.WithStack(fosite.ErrInvalidGrant.WithHint("The refresh token is bound to a client certificate but the request does not include that client certificate."))
+	}
+
+	if c, ok := client.(*Client); !ok || !c.GetTLSClientCertificateBoundAccessTokens() {
+		session.MutualTLSCertificateThumbprint = ""
+
+		return nil
+	}
+
+	if thumbprint == "" {
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The client is required to use certificate-bound access tokens but the request does not include a client certificate."))
+	}
+
+	session.MutualTLSCertificateThumbprint = thumbprint
+
+	return nil
+}
+
+// ValidateMutualTLSResourceRequest validates the presentation of an access token to a protected resource. Access tokens
+// bound to a client certificate must be presented over a connection using that client certificate.
+//
+// RFC8705: https://www.rfc-editor.org/rfc/rfc8705.html#section-3
+func (p *OpenIDConnectProvider) ValidateMutualTLSResourceRequest(r *http.Request, requester fosite.Requester) (err error) {
+	session, ok := requester.GetSession().(*model.OpenIDSession)
+	if !ok || session.MutualTLSCertificateThumbprint == "" {
+		return nil
+	}
+
+	var cert *x509.Certificate
+
+	if cert, _, err = p.mutualTLS.Resolve(r); err != nil {
+		return err
+	}
+
+	if cert == nil || CertificateThumbprint(cert) != session.MutualTLSCertificateThumbprint {
+		return errorsx.WithStack(ErrInvalidCertificateBoundToken.WithHint("The access token is bound to a client certificate but the request does not include that client certificate."))
+	}
+
+	return nil
+}
+
+// IsClientSecretRequired returns true if the client authenticates using a client secret, i.e. the token endpoint
+// authentication method is neither none nor one of the Mutual-TLS client authentication methods.
+func (m *ClientRegistrationMetadata) IsClientSecretRequired() bool {
+	switch m.TokenEndpointAuthMethod {
+	case ClientAuthMethodNone, ClientAuthMethodTLSClientAuth, ClientAuthMethodSelfSignedTLSClientAuth:
+		return false
+	default:
+		return true
+	}
+}
+
+func (m *ClientRegistrationMetadata) countTLSClientAuthSubjects() (n int) {
+	for _, value := range []string{m.TLSClientAuthSubjectDN, m.TLSClientAuthSANDNS, m.TLSClientAuthSANURI, m.TLSClientAuthSANIP, m.TLSClientAuthSANEmail} {
+		if value != "" {
+			n++
+		}
+	}
+
+	return n
+}
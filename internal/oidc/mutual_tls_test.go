@@ -0,0 +1,293 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+func TestMutualTLSCertificateResolver_Resolve(t *testing.T) {
+	ca, caKey := mustGenerateTestCertificate(t, nil, nil, &x509.Certificate{Subject: pkix.Name{CommonName: "Example CA"}, IsCA: true, KeyUsage: x509.KeyUsageCertSign})
+	cert, _ := mustGenerateTestCertificate(t, ca, caKey, &x509.Certificate{Subject: pkix.Name{CommonName: "app.example.com"}, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	other, _ := mustGenerateTestCertificate(t, nil, nil, &x509.Certificate{Subject: pkix.Name{CommonName: "other.example.com"}, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+
+	chain, err := schema.NewX509CertificateChain(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})))
+	require.NoError(t, err)
+
+	resolver := NewMutualTLSCertificateResolver(schema.OpenIDConnectMutualTLSConfiguration{
+		CertificateAuthorities:     *chain,
+		ForwardedCertificateHeader: "X-Forwarded-Client-Cert",
+		TrustedProxies:             []string{"10.0.0.1", "192.168.0.0/16"},
+	})
+
+	encoded := url.QueryEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+
+	testCases := []struct {
+		name     string
+		setup    func(r *http.Request)
+		expected *x509.Certificate
+		verified bool
+		err      string
+	}{
+		{
+			"ShouldResolveNoCertificate",
+			func(r *http.Request) {},
+			nil,
+			false,
+			"",
+		},
+		{
+			"ShouldResolveVerifiedTLSCertificate",
+			func(r *http.Request) {
+				r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+			},
+			cert,
+			true,
+			"",
+		},
+		{
+			"ShouldResolveUnverifiedTLSCertificate",
+			func(r *http.Request) {
+				r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{other}}
+			},
+			other,
+			false,
+			"",
+		},
+		{
+			"ShouldResolveForwardedCertificateFromTrustedProxy",
+			func(r *http.Request) {
+				r.RemoteAddr = "192.168.1.20:4000"
+				r.Header.Set("X-Forwarded-Client-Cert", encoded)
+			},
+			cert,
+			true,
+			"",
+		},
+		{
+			"ShouldResolveForwardedDERCertificateFromTrustedProxy",
+			func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.1:4000"
+				r.Header.Set("X-Forwarded-Client-Cert", base64.StdEncoding.EncodeToString(other.Raw))
+			},
+			other,
+			false,
+			"",
+		},
+		{
+			"ShouldIgnoreForwardedCertificateFromUntrustedProxy",
+			func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.2:4000"
+				r.Header.Set("X-Forwarded-Client-Cert", encoded)
+			},
+			nil,
+			false,
+			"",
+		},
+		{
+			"ShouldFailInvalidForwardedCertificate",
+			func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.1:4000"
+				r.Header.Set("X-Forwarded-Client-Cert", "abc")
+			},
+			nil,
+			false,
+			"The request is missing a required parameter, includes an invalid parameter value, includes a parameter more than once, or is otherwise malformed. The forwarded client certificate could not be decoded.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "https://auth.example.com"+EndpointPathToken, nil)
+
+			tc.setup(r)
+
+			actual, verified, err := resolver.Resolve(r)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tc.err, fosite.ErrorToRFC6749Error(err).GetDescription())
+			}
+
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.verified, verified)
+		})
+	}
+}
+
+func TestClient_IsTLSClientAuthCertificate(t *testing.T) {
+	cert, _ := mustGenerateTestCertificate(t, nil, nil, &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "app.example.com", Organization: []string{"Example"}},
+		DNSNames:       []string{"app.example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.5")},
+		EmailAddresses: []string{"app@example.com"},
+		URIs:           []*url.URL{{Scheme: "spiffe", Host: "example.com", Path: "/app"}},
+	})
+
+	testCases := []struct {
+		name     string
+		client   *Client
+		expected bool
+	}{
+		{"ShouldMatchSubjectDN", &Client{TLSClientAuthSubjectDN: "CN=app.example.com,O=Example"}, true},
+		{"ShouldNotMatchSubjectDN", &Client{TLSClientAuthSubjectDN: "CN=other.example.com,O=Example"}, false},
+		{"ShouldMatchSANDNS", &Client{TLSClientAuthSANDNS: "APP.example.com"}, true},
+		{"ShouldMatchSANURI", &Client{TLSClientAuthSANURI: "spiffe://example.com/app"}, true},
+		{"ShouldMatchSANIP", &Client{TLSClientAuthSANIP: "10.0.0.5"}, true},
+		{"ShouldNotMatchSANIP", &Client{TLSClientAuthSANIP: "10.0.0.6"}, false},
+		{"ShouldMatchSANEmail", &Client{TLSClientAuthSANEmail: "app@example.com"}, true},
+		{"ShouldNotMatchWithoutSubject", &Client{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.client.IsTLSClientAuthCertificate(cert))
+		})
+	}
+}
+
+func TestOpenIDConnectProvider_MutualTLSBoundTokens(t *testing.T) {
+	cert, _ := mustGenerateTestCertificate(t, nil, nil, &x509.Certificate{Subject: pkix.Name{CommonName: "app.example.com"}})
+	other, _ := mustGenerateTestCertificate(t, nil, nil, &x509.Certificate{Subject: pkix.Name{CommonName: "other.example.com"}})
+
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:                                    "mtls-public",
+				Public:                                true,
+				RedirectURIs:                          []string{"https://example.com/callback"},
+				TLSClientCertificateBoundAccessTokens: true,
+			},
+			{
+				ID:     "mtls-unbound",
+				Secret: MustDecodeSecret("$plaintext$a-client-secret"),
+			},
+		},
+	}, &testJTIStore{jtis: map[string]model.OAuth2BlacklistedJTI{}})
+	require.NoError(t, err)
+
+	public, err := provider.GetFullClient(context.Background(), "mtls-public")
+	require.NoError(t, err)
+
+	unbound, err := provider.GetFullClient(context.Background(), "mtls-unbound")
+	require.NoError(t, err)
+
+	request := func(cert *x509.Certificate) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "https://auth.example.com"+EndpointPathToken, nil)
+
+		if cert != nil {
+			r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+		}
+
+		return r
+	}
+
+	requester := func(client *Client, grant, thumbprint string) *fosite.AccessRequest {
+		session := NewSession()
+		session.MutualTLSCertificateThumbprint = thumbprint
+
+		ar := fosite.NewAccessRequest(session)
+		ar.Client = client
+		ar.GrantTypes = fosite.Arguments{grant}
+
+		return ar
+	}
+
+	thumbprint := CertificateThumbprint(cert)
+
+	testCases := []struct {
+		name      string
+		requester *fosite.AccessRequest
+		cert      *x509.Certificate
+		expected  string
+		err       string
+	}{
+		{"ShouldBindCertificate", requester(public, GrantTypeAuthorizationCode, ""), cert, thumbprint, ""},
+		{"ShouldRequireCertificate", requester(public, GrantTypeAuthorizationCode, ""), nil, "", "The request is missing a required parameter, includes an invalid parameter value, includes a parameter more than once, or is otherwise malformed. The client is required to use certificate-bound access tokens but the request does not include a client certificate."},
+		{"ShouldNotBindUnboundClient", requester(unbound, GrantTypeClientCredentials, ""), cert, "", ""},
+		{"ShouldKeepPublicRefreshBinding", requester(public, GrantTypeRefreshToken, thumbprint), cert, thumbprint, ""},
+		{"ShouldFailPublicRefreshWithOtherCertificate", requester(public, GrantTypeRefreshToken, thumbprint), other, thumbprint, "The provided authorization grant (e.g., authorization code, resource owner credentials) or refresh token is invalid, expired, revoked, does not match the redirection URI used in the authorization request, or was issued to another client. The refresh token is bound to a client certificate but the request does not include that client certificate."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := provider.BindMutualTLSAccessRequest(request(tc.cert), tc.requester)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tc.err, fosite.ErrorToRFC6749Error(err).GetDescription())
+			}
+
+			assert.Equal(t, tc.expected, tc.requester.GetSession().(*model.OpenIDSession).MutualTLSCertificateThumbprint)
+		})
+	}
+
+	bound := requester(public, GrantTypeAuthorizationCode, thumbprint)
+
+	assert.NoError(t, provider.ValidateMutualTLSResourceRequest(request(cert), bound))
+	assert.NoError(t, provider.ValidateMutualTLSResourceRequest(request(nil), requester(unbound, GrantTypeClientCredentials, "")))
+
+	err = provider.ValidateMutualTLSResourceRequest(request(other), bound)
+	assert.Equal(t, "The access token provided is expired, revoked, malformed, or invalid for other reasons. The access token is bound to a client certificate but the request does not include that client certificate.", fosite.ErrorToRFC6749Error(err).GetDescription())
+
+	err = provider.ValidateMutualTLSResourceRequest(request(nil), bound)
+	assert.EqualError(t, err, "invalid_token")
+}
+
+func TestOpenIDSession_GetExtraClaimsMutualTLS(t *testing.T) {
+	session := NewSession()
+
+	session.DPoPJWKThumbprint = "abc"
+	session.MutualTLSCertificateThumbprint = "xyz"
+
+	assert.Equal(t, map[string]any{ClaimConfirmation: map[string]any{ClaimConfirmationJWKThumbprint: "abc", ClaimConfirmationX509CertificateThumbprint: "xyz"}}, session.GetExtraClaims())
+}
+
+func mustGenerateTestCertificate(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, template *x509.Certificate) (cert *x509.Certificate, key *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	template.BasicConstraintsValid = true
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
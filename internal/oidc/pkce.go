@@ -0,0 +1,50 @@
+package oidc
+
+import (
+	"context"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/pkce"
+)
+
+// pkceFactory creates a PKCEHandler.
+func pkceFactory(config *compose.Config, storage any, strategy any) any {
+	return &PKCEHandler{
+		Handler: *compose.OAuth2PKCEFactory(config, storage, strategy).(*pkce.Handler),
+	}
+}
+
+// PKCEHandler is a pkce.Handler which enforces RFC7636 Proof Key for Code Exchange as configured for the client,
+// falling back to the global enforcement for clients which do not override it.
+type PKCEHandler struct {
+	pkce.Handler
+}
+
+// HandleAuthorizeEndpointRequest implements fosite.AuthorizeEndpointHandler.
+func (h *PKCEHandler) HandleAuthorizeEndpointRequest(ctx context.Context, requester fosite.AuthorizeRequester, responder fosite.AuthorizeResponder) error {
+	return h.handler(requester.GetClient()).HandleAuthorizeEndpointRequest(ctx, requester, responder)
+}
+
+// HandleTokenEndpointRequest implements fosite.TokenEndpointHandler.
+func (h *PKCEHandler) HandleTokenEndpointRequest(ctx context.Context, requester fosite.AccessRequester) error {
+	return h.handler(requester.GetClient()).HandleTokenEndpointRequest(ctx, requester)
+}
+
+func (h *PKCEHandler) handler(client fosite.Client) *pkce.Handler {
+	c, ok := client.(*Client)
+	if !ok {
+		return &h.Handler
+	}
+
+	force, forcePublic, ok := c.GetEnforcePKCE()
+	if !ok {
+		return &h.Handler
+	}
+
+	handler := h.Handler
+
+	handler.Force, handler.ForceForPublicClients = force, forcePublic
+
+	return &handler
+}
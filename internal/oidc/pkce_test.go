@@ -0,0 +1,41 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/pkce"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPKCEHandler_ShouldUseClientEnforcement(t *testing.T) {
+	handler := &PKCEHandler{
+		Handler: pkce.Handler{
+			Force:                 true,
+			ForceForPublicClients: true,
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		client             fosite.Client
+		force, forcePublic bool
+	}{
+		{"ShouldUseGlobalForOtherClients", &fosite.DefaultClient{}, true, true},
+		{"ShouldUseGlobalWhenNotConfigured", &Client{}, true, true},
+		{"ShouldUseClientNever", &Client{EnforcePKCE: "never"}, false, false},
+		{"ShouldUseClientPublicClientsOnly", &Client{EnforcePKCE: "public_clients_only"}, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := handler.handler(tc.client)
+
+			assert.Equal(t, tc.force, h.Force)
+			assert.Equal(t, tc.forcePublic, h.ForceForPublicClients)
+		})
+	}
+
+	assert.True(t, handler.Force)
+	assert.True(t, handler.ForceForPublicClients)
+}
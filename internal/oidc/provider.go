@@ -1,8 +1,10 @@
 package oidc
 
 import (
+	"context"
 	"crypto/sha512"
 	"fmt"
+	"net/http"
 
 	"github.com/ory/fosite/compose"
 	"github.com/ory/fosite/handler/oauth2"
@@ -24,6 +26,20 @@ func NewOpenIDConnectProvider(config *schema.OpenIDConnectConfiguration, store s
 	provider = &OpenIDConnectProvider{
 		JSONWriter: herodot.NewJSONWriter(nil),
 		Store:      NewOpenIDConnectStore(config, store),
+
+		httpClient:           &http.Client{Timeout: backChannelLogoutTimeout},
+		restrictedHTTPClient: NewRestrictedHTTPClient(backChannelLogoutTimeout),
+
+		pushedAuthorizationEnforce:         config.PAR.Enforce,
+		pushedAuthorizationContextLifespan: config.PAR.ContextLifespan,
+
+		deviceCodeLifespan:        config.DeviceAuthorization.CodeLifespan,
+		deviceCodePollingInterval: config.DeviceAuthorization.PollingInterval,
+
+		registration:                   config.DynamicClientRegistration.Enable,
+		registrationInitialAccessToken: config.DynamicClientRegistration.InitialAccessToken,
+
+		acrValues: NewAuthenticationContextClassReferences(config.ACRValues),
 	}
 
 	cconfig := &compose.Config{
@@ -38,29 +54,44 @@ func NewOpenIDConnectProvider(config *schema.OpenIDConnectConfiguration, store s
 		EnablePKCEPlainChallengeMethod: config.EnablePKCEPlainChallenge,
 	}
 
+	provider.responseModeHandler = &JWTSecuredResponseModeHandler{provider: provider, lifespan: jwtSecuredResponseLifespan, debug: config.EnableClientDebugMessages}
+	cconfig.ResponseModeHandlerExtension = provider.responseModeHandler
+
+	provider.mutualTLS = NewMutualTLSCertificateResolver(config.MutualTLS)
+
+	provider.clientAuthentication = NewClientAuthenticationStrategy(provider.Store, cconfig.GetJWKSFetcherStrategy(), AdaptiveHasher{}, provider.mutualTLS)
+	provider.clientAuthenticationStrategy = provider.clientAuthentication.AuthenticateClient
+	cconfig.ClientAuthenticationStrategy = provider.clientAuthenticationStrategy
+
 	if provider.KeyManager, err = NewKeyManagerWithConfiguration(config); err != nil {
 		return nil, err
 	}
 
-	jwtStrategy := provider.KeyManager.Strategy()
+	if config.KeyRotation.Enable {
+		provider.KeyRotator = NewKeyRotator(config, provider.KeyManager, store)
+	}
+
+	jwtStrategy := NewKeyManagerStrategy(provider.KeyManager)
 
 	strategy := &compose.CommonStrategy{
-		CoreStrategy: &oauth2.HMACSHAStrategy{
+		CoreStrategy: NewCoreStrategy(&oauth2.HMACSHAStrategy{
 			Enigma: &hmac.HMACStrategy{
 				GlobalSecret:         []byte(utils.HashSHA256FromString(config.HMACSecret)),
-				RotatedGlobalSecrets: nil,
+				RotatedGlobalSecrets: NewRotatedGlobalSecrets(config.RotatedHMACSecrets),
 				TokenEntropy:         cconfig.GetTokenEntropy(),
 				Hash:                 sha512.New512_256,
 			},
 			AccessTokenLifespan:   cconfig.GetAccessTokenLifespan(),
 			AuthorizeCodeLifespan: cconfig.GetAuthorizeCodeLifespan(),
 			RefreshTokenLifespan:  cconfig.GetRefreshTokenLifespan(),
-		},
-		OpenIDConnectTokenStrategy: &openid.DefaultStrategy{
-			JWTStrategy:         jwtStrategy,
-			Expiry:              cconfig.GetIDTokenLifespan(),
-			Issuer:              cconfig.IDTokenIssuer,
-			MinParameterEntropy: cconfig.GetMinParameterEntropy(),
+		}, provider.KeyManager),
+		OpenIDConnectTokenStrategy: &IDTokenStrategy{
+			DefaultStrategy: openid.DefaultStrategy{
+				JWTStrategy:         jwtStrategy,
+				Expiry:              cconfig.GetIDTokenLifespan(),
+				Issuer:              cconfig.IDTokenIssuer,
+				MinParameterEntropy: cconfig.GetMinParameterEntropy(),
+			},
 		},
 		JWTStrategy: jwtStrategy,
 	}
@@ -78,7 +109,9 @@ func NewOpenIDConnectProvider(config *schema.OpenIDConnectConfiguration, store s
 		*/
 		compose.OAuth2AuthorizeExplicitFactory,
 		compose.OAuth2AuthorizeImplicitFactory,
-		compose.OAuth2ClientCredentialsGrantFactory,
+		// This factory wraps the fosite Client Credentials handler and enforces the Client Credentials policy of the
+		// client.
+		clientCredentialsGrantFactory,
 		compose.OAuth2RefreshTokenGrantFactory,
 		// compose.OAuth2ResourceOwnerPasswordCredentialsFactory,
 		// compose.RFC7523AssertionGrantFactory,.
@@ -91,19 +124,58 @@ func NewOpenIDConnectProvider(config *schema.OpenIDConnectConfiguration, store s
 		compose.OAuth2TokenIntrospectionFactory,
 		compose.OAuth2TokenRevocationFactory,
 
-		compose.OAuth2PKCEFactory,
+		// This factory wraps the fosite PKCE handler and allows the PKCE enforcement to be configured per client.
+		pkceFactory,
+
+		// This factory is not part of fosite and handles the RFC8628 OAuth 2.0 Device Authorization Grant.
+		provider.deviceCodeGrantFactory,
+
+		// This factory is not part of fosite and handles the RFC8693 OAuth 2.0 Token Exchange grant.
+		tokenExchangeGrantFactory,
 	)
 
-	provider.discovery = NewOpenIDConnectWellKnownConfiguration(config.EnablePKCEPlainChallenge, provider.Store.clients)
+	algs := provider.KeyManager.GetAlgorithms()
+
+	if config.KeyRotation.Enable && !utils.IsStringInSlice(config.KeyRotation.Algorithm, algs) {
+		algs = append(algs, config.KeyRotation.Algorithm)
+	}
+
+	provider.discovery = NewOpenIDConnectWellKnownConfiguration(config.EnablePKCEPlainChallenge, algs, provider.Store.clients)
+	appendCustomScopesAndClaims(&provider.discovery, config)
+
+	for _, value := range provider.acrValues {
+		provider.discovery.ACRValuesSupported = append(provider.discovery.ACRValuesSupported, value.Value)
+	}
+
+	if len(provider.discovery.ACRValuesSupported) != 0 {
+		provider.discovery.ClaimsSupported = append(provider.discovery.ClaimsSupported, ClaimAuthenticationContextClassReference)
+	}
+	provider.discovery.RequirePushedAuthorizationRequests = config.PAR.Enforce
 
 	return provider, nil
 }
 
+// StartupCheck implements the startup check provider interface. When key rotation is enabled it performs the initial
+// key rotation which loads or generates the rotated keys.
+func (p *OpenIDConnectProvider) StartupCheck() (err error) {
+	if p.KeyRotator == nil {
+		return nil
+	}
+
+	return p.KeyRotator.Rotate(context.Background())
+}
+
 // GetOAuth2WellKnownConfiguration returns the discovery document for the OAuth Configuration.
 func (p *OpenIDConnectProvider) GetOAuth2WellKnownConfiguration(issuer string) OAuth2WellKnownConfiguration {
 	options := OAuth2WellKnownConfiguration{
-		CommonDiscoveryOptions: p.discovery.CommonDiscoveryOptions,
-		OAuth2DiscoveryOptions: p.discovery.OAuth2DiscoveryOptions,
+		CommonDiscoveryOptions:                         p.discovery.CommonDiscoveryOptions,
+		OAuth2DiscoveryOptions:                         p.discovery.OAuth2DiscoveryOptions,
+		OAuth2PushedAuthorizationDiscoveryOptions:      p.discovery.OAuth2PushedAuthorizationDiscoveryOptions,
+		OAuth2DeviceAuthorizationGrantDiscoveryOptions: p.discovery.OAuth2DeviceAuthorizationGrantDiscoveryOptions,
+
+		OAuth2JWTSecuredAuthorizationResponseModeDiscoveryOptions: p.discovery.OAuth2JWTSecuredAuthorizationResponseModeDiscoveryOptions,
+		OAuth2DPoPDiscoveryOptions:                                p.discovery.OAuth2DPoPDiscoveryOptions,
+		OAuth2MutualTLSClientAuthenticationDiscoveryOptions:       p.discovery.OAuth2MutualTLSClientAuthenticationDiscoveryOptions,
 	}
 
 	options.Issuer = issuer
@@ -114,6 +186,12 @@ func (p *OpenIDConnectProvider) GetOAuth2WellKnownConfiguration(issuer string) O
 
 	options.AuthorizationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathAuthorization)
 	options.RevocationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRevocation)
+	options.PushedAuthorizationRequestEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathPushedAuthorizationRequest)
+	options.DeviceAuthorizationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathDeviceAuthorization)
+
+	if p.registration {
+		options.RegistrationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRegistration)
+	}
 
 	return options
 }
@@ -123,9 +201,16 @@ func (p *OpenIDConnectProvider) GetOpenIDConnectWellKnownConfiguration(issuer st
 	options := OpenIDConnectWellKnownConfiguration{
 		CommonDiscoveryOptions:                          p.discovery.CommonDiscoveryOptions,
 		OAuth2DiscoveryOptions:                          p.discovery.OAuth2DiscoveryOptions,
+		OAuth2PushedAuthorizationDiscoveryOptions:       p.discovery.OAuth2PushedAuthorizationDiscoveryOptions,
+		OAuth2DeviceAuthorizationGrantDiscoveryOptions:  p.discovery.OAuth2DeviceAuthorizationGrantDiscoveryOptions,
 		OpenIDConnectDiscoveryOptions:                   p.discovery.OpenIDConnectDiscoveryOptions,
 		OpenIDConnectFrontChannelLogoutDiscoveryOptions: p.discovery.OpenIDConnectFrontChannelLogoutDiscoveryOptions,
 		OpenIDConnectBackChannelLogoutDiscoveryOptions:  p.discovery.OpenIDConnectBackChannelLogoutDiscoveryOptions,
+		OpenIDConnectRPInitiatedLogoutDiscoveryOptions:  p.discovery.OpenIDConnectRPInitiatedLogoutDiscoveryOptions,
+
+		OAuth2JWTSecuredAuthorizationResponseModeDiscoveryOptions: p.discovery.OAuth2JWTSecuredAuthorizationResponseModeDiscoveryOptions,
+		OAuth2DPoPDiscoveryOptions:                                p.discovery.OAuth2DPoPDiscoveryOptions,
+		OAuth2MutualTLSClientAuthenticationDiscoveryOptions:       p.discovery.OAuth2MutualTLSClientAuthenticationDiscoveryOptions,
 	}
 
 	options.Issuer = issuer
@@ -137,6 +222,13 @@ func (p *OpenIDConnectProvider) GetOpenIDConnectWellKnownConfiguration(issuer st
 	options.AuthorizationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathAuthorization)
 	options.RevocationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRevocation)
 	options.UserinfoEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathUserinfo)
+	options.EndSessionEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathEndSession)
+	options.PushedAuthorizationRequestEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathPushedAuthorizationRequest)
+	options.DeviceAuthorizationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathDeviceAuthorization)
+
+	if p.registration {
+		options.RegistrationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRegistration)
+	}
 
 	return options
 }
@@ -122,6 +122,12 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOpenIDConnectWellKnow
 	assert.Equal(t, "https://example.com/api/oidc/userinfo", disco.UserinfoEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/introspection", disco.IntrospectionEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/revocation", disco.RevocationEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/end-session", disco.EndSessionEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/pushed-authorization-request", disco.PushedAuthorizationRequestEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/device-authorization", disco.DeviceAuthorizationEndpoint)
+	assert.False(t, disco.RequirePushedAuthorizationRequests)
+	assert.True(t, disco.BackChannelLogoutSupported)
+	assert.True(t, disco.BackChannelLogoutSessionSupported)
 	assert.Equal(t, "", disco.RegistrationEndpoint)
 
 	assert.Len(t, disco.CodeChallengeMethodsSupported, 1)
@@ -134,10 +140,20 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOpenIDConnectWellKnow
 	assert.Contains(t, disco.ScopesSupported, ScopeGroups)
 	assert.Contains(t, disco.ScopesSupported, ScopeEmail)
 
-	assert.Len(t, disco.ResponseModesSupported, 3)
+	assert.Len(t, disco.ResponseModesSupported, 7)
 	assert.Contains(t, disco.ResponseModesSupported, ResponseModeFormPost)
 	assert.Contains(t, disco.ResponseModesSupported, ResponseModeQuery)
 	assert.Contains(t, disco.ResponseModesSupported, ResponseModeFragment)
+	assert.Contains(t, disco.ResponseModesSupported, ResponseModeJWT)
+	assert.Contains(t, disco.ResponseModesSupported, ResponseModeFormPostJWT)
+	assert.Contains(t, disco.ResponseModesSupported, ResponseModeQueryJWT)
+	assert.Contains(t, disco.ResponseModesSupported, ResponseModeFragmentJWT)
+
+	assert.Equal(t, []string{SigningAlgorithmRSAWithSHA256}, disco.AuthorizationSigningAlgValuesSupported)
+
+	assert.Contains(t, disco.DPoPSigningAlgValuesSupported, SigningAlgorithmECDSAWithSHA256)
+	assert.NotContains(t, disco.DPoPSigningAlgValuesSupported, SigningAlgorithmHMACWithSHA256)
+	assert.NotContains(t, disco.DPoPSigningAlgValuesSupported, SigningAlgorithmNone)
 
 	assert.Len(t, disco.SubjectTypesSupported, 1)
 	assert.Contains(t, disco.SubjectTypesSupported, SubjectTypePublic)
@@ -159,11 +175,38 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOpenIDConnectWellKnow
 	assert.Contains(t, disco.UserinfoSigningAlgValuesSupported, SigningAlgorithmRSAWithSHA256)
 	assert.Contains(t, disco.UserinfoSigningAlgValuesSupported, SigningAlgorithmNone)
 
-	assert.Len(t, disco.RequestObjectSigningAlgValuesSupported, 2)
+	assert.Len(t, disco.RequestObjectSigningAlgValuesSupported, 11)
 	assert.Contains(t, disco.RequestObjectSigningAlgValuesSupported, SigningAlgorithmRSAWithSHA256)
+	assert.Contains(t, disco.RequestObjectSigningAlgValuesSupported, SigningAlgorithmECDSAWithSHA256)
 	assert.Contains(t, disco.RequestObjectSigningAlgValuesSupported, SigningAlgorithmNone)
-
-	assert.Len(t, disco.ClaimsSupported, 18)
+	assert.True(t, disco.RequestParameterSupported)
+	assert.True(t, disco.RequestURIParameterSupported)
+	assert.True(t, disco.RequireRequestURIRegistration)
+
+	assert.Len(t, disco.TokenEndpointAuthMethodsSupported, 7)
+	assert.Contains(t, disco.TokenEndpointAuthMethodsSupported, ClientAuthMethodClientSecretBasic)
+	assert.Contains(t, disco.TokenEndpointAuthMethodsSupported, ClientAuthMethodClientSecretPost)
+	assert.Contains(t, disco.TokenEndpointAuthMethodsSupported, ClientAuthMethodClientSecretJWT)
+	assert.Contains(t, disco.TokenEndpointAuthMethodsSupported, ClientAuthMethodPrivateKeyJWT)
+	assert.Contains(t, disco.TokenEndpointAuthMethodsSupported, ClientAuthMethodTLSClientAuth)
+	assert.Contains(t, disco.TokenEndpointAuthMethodsSupported, ClientAuthMethodSelfSignedTLSClientAuth)
+	assert.Contains(t, disco.TokenEndpointAuthMethodsSupported, ClientAuthMethodNone)
+
+	assert.True(t, disco.TLSClientCertificateBoundAccessTokens)
+
+	assert.Len(t, disco.TokenEndpointAuthSigningAlgValuesSupported, 13)
+	assert.Contains(t, disco.TokenEndpointAuthSigningAlgValuesSupported, SigningAlgorithmHMACWithSHA256)
+	assert.Contains(t, disco.TokenEndpointAuthSigningAlgValuesSupported, SigningAlgorithmRSAWithSHA256)
+	assert.Contains(t, disco.TokenEndpointAuthSigningAlgValuesSupported, SigningAlgorithmECDSAWithSHA256)
+	assert.Contains(t, disco.TokenEndpointAuthSigningAlgValuesSupported, SigningAlgorithmEdDSA)
+
+	assert.Len(t, disco.IntrospectionEndpointAuthMethodsSupported, 6)
+	assert.NotContains(t, disco.IntrospectionEndpointAuthMethodsSupported, ClientAuthMethodNone)
+
+	assert.Len(t, disco.RevocationEndpointAuthMethodsSupported, 7)
+	assert.Contains(t, disco.RevocationEndpointAuthMethodsSupported, ClientAuthMethodNone)
+
+	assert.Len(t, disco.ClaimsSupported, 19)
 	assert.Contains(t, disco.ClaimsSupported, ClaimAuthenticationMethodsReference)
 	assert.Contains(t, disco.ClaimsSupported, ClaimAudience)
 	assert.Contains(t, disco.ClaimsSupported, ClaimAuthorizedParty)
@@ -173,6 +216,7 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOpenIDConnectWellKnow
 	assert.Contains(t, disco.ClaimsSupported, ClaimIssuer)
 	assert.Contains(t, disco.ClaimsSupported, ClaimJWTID)
 	assert.Contains(t, disco.ClaimsSupported, ClaimRequestedAt)
+	assert.Contains(t, disco.ClaimsSupported, ClaimSessionID)
 	assert.Contains(t, disco.ClaimsSupported, ClaimSubject)
 	assert.Contains(t, disco.ClaimsSupported, ClaimAuthenticationTime)
 	assert.Contains(t, disco.ClaimsSupported, ClaimNonce)
@@ -211,6 +255,9 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOAuth2WellKnownConfig
 	assert.Equal(t, "https://example.com/api/oidc/token", disco.TokenEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/introspection", disco.IntrospectionEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/revocation", disco.RevocationEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/device-authorization", disco.DeviceAuthorizationEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/pushed-authorization-request", disco.PushedAuthorizationRequestEndpoint)
+	assert.False(t, disco.RequirePushedAuthorizationRequests)
 	assert.Equal(t, "", disco.RegistrationEndpoint)
 
 	require.Len(t, disco.CodeChallengeMethodsSupported, 1)
@@ -223,10 +270,20 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOAuth2WellKnownConfig
 	assert.Contains(t, disco.ScopesSupported, ScopeGroups)
 	assert.Contains(t, disco.ScopesSupported, ScopeEmail)
 
-	assert.Len(t, disco.ResponseModesSupported, 3)
+	assert.Len(t, disco.ResponseModesSupported, 7)
 	assert.Contains(t, disco.ResponseModesSupported, ResponseModeFormPost)
 	assert.Contains(t, disco.ResponseModesSupported, ResponseModeQuery)
 	assert.Contains(t, disco.ResponseModesSupported, ResponseModeFragment)
+	assert.Contains(t, disco.ResponseModesSupported, ResponseModeJWT)
+	assert.Contains(t, disco.ResponseModesSupported, ResponseModeFormPostJWT)
+	assert.Contains(t, disco.ResponseModesSupported, ResponseModeQueryJWT)
+	assert.Contains(t, disco.ResponseModesSupported, ResponseModeFragmentJWT)
+
+	assert.Equal(t, []string{SigningAlgorithmRSAWithSHA256}, disco.AuthorizationSigningAlgValuesSupported)
+
+	assert.Contains(t, disco.DPoPSigningAlgValuesSupported, SigningAlgorithmECDSAWithSHA256)
+	assert.NotContains(t, disco.DPoPSigningAlgValuesSupported, SigningAlgorithmHMACWithSHA256)
+	assert.NotContains(t, disco.DPoPSigningAlgValuesSupported, SigningAlgorithmNone)
 
 	assert.Len(t, disco.SubjectTypesSupported, 1)
 	assert.Contains(t, disco.SubjectTypesSupported, SubjectTypePublic)
@@ -241,7 +298,7 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOAuth2WellKnownConfig
 	assert.Contains(t, disco.ResponseTypesSupported, "code token id_token")
 	assert.Contains(t, disco.ResponseTypesSupported, "none")
 
-	assert.Len(t, disco.ClaimsSupported, 18)
+	assert.Len(t, disco.ClaimsSupported, 19)
 	assert.Contains(t, disco.ClaimsSupported, ClaimAuthenticationMethodsReference)
 	assert.Contains(t, disco.ClaimsSupported, ClaimAudience)
 	assert.Contains(t, disco.ClaimsSupported, ClaimAuthorizedParty)
@@ -251,6 +308,7 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOAuth2WellKnownConfig
 	assert.Contains(t, disco.ClaimsSupported, ClaimIssuer)
 	assert.Contains(t, disco.ClaimsSupported, ClaimJWTID)
 	assert.Contains(t, disco.ClaimsSupported, ClaimRequestedAt)
+	assert.Contains(t, disco.ClaimsSupported, ClaimSessionID)
 	assert.Contains(t, disco.ClaimsSupported, ClaimSubject)
 	assert.Contains(t, disco.ClaimsSupported, ClaimAuthenticationTime)
 	assert.Contains(t, disco.ClaimsSupported, ClaimNonce)
@@ -0,0 +1,177 @@
+package oidc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/x/errorsx"
+
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewPushedAuthorizeRequest handles a RFC9126 OAuth 2.0 Pushed Authorization Request. The client is authenticated
+// using the same methods as the token endpoint and the pushed parameters are validated exactly as they would be at the
+// authorization endpoint, including resolving any Request Object.
+//
+// RFC9126: https://www.rfc-editor.org/rfc/rfc9126.html#section-2.1
+func (p *OpenIDConnectProvider) NewPushedAuthorizeRequest(ctx context.Context, r *http.Request) (requester fosite.AuthorizeRequester, err error) {
+	if r.Method != http.MethodPost {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("HTTP method is '%s', expected 'POST'.", r.Method))
+	}
+
+	if err = r.ParseMultipartForm(1 << 20); err != nil && err != http.ErrNotMultipart {
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("Unable to parse HTTP body, make sure to send a properly formatted form request body.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	var client fosite.Client
+
+	if client, err = p.clientAuthenticationStrategy(ctx, r, r.PostForm); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+
+	for key, values := range r.PostForm {
+		switch key {
+		case FormParameterClientSecret, FormParameterClientAssertionType, FormParameterClientAssertion:
+			continue
+		case FormParameterRequestURI:
+			return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The 'request_uri' parameter must not be included in a Pushed Authorization Request."))
+		default:
+			form[key] = values
+		}
+	}
+
+	switch clientID := form.Get(FormParameterClientID); clientID {
+	case "":
+		form.Set(FormParameterClientID, client.GetID())
+	case client.GetID():
+		break
+	default:
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("The 'client_id' parameter '%s' does not match the authenticated client.", clientID))
+	}
+
+	pushed := &http.Request{
+		Method: http.MethodGet,
+		URL:    &url.URL{RawQuery: form.Encode()},
+		Header: http.Header{},
+	}
+
+	if _, err = p.ResolveRequestObject(ctx, pushed); err != nil {
+		return nil, err
+	}
+
+	if requester, err = p.NewAuthorizeRequest(ctx, pushed); err != nil {
+		return nil, err
+	}
+
+	return requester, nil
+}
+
+// NewPushedAuthorizeResponse persists a validated Pushed Authorization Request and generates the request_uri the
+// client uses at the authorization endpoint.
+func (p *OpenIDConnectProvider) NewPushedAuthorizeResponse(ctx context.Context, requester fosite.AuthorizeRequester) (responder *PushedAuthorizeResponse, err error) {
+	id := utils.RandomString(64, utils.CharSetAlphaNumeric, true)
+
+	par := model.NewOAuth2PARContext(id, requester.GetClient().GetID(), requester.GetRequestForm(), time.Now().Add(p.pushedAuthorizationContextLifespan))
+
+	if err = p.Store.provider.SaveOAuth2PARContext(ctx, par); err != nil {
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithHint("Could not save the Pushed Authorization Request.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return &PushedAuthorizeResponse{
+		RequestURI: RequestURIPrefixPushedAuthorizationRequestURN + id,
+		ExpiresIn:  int(p.pushedAuthorizationContextLifespan.Seconds()),
+	}, nil
+}
+
+// WritePushedAuthorizeResponse writes the PushedAuthorizeResponse to the http.ResponseWriter.
+func (p *OpenIDConnectProvider) WritePushedAuthorizeResponse(rw http.ResponseWriter, responder *PushedAuthorizeResponse) {
+	rw.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("Pragma", "no-cache")
+
+	rw.WriteHeader(http.StatusCreated)
+
+	_ = json.NewEncoder(rw).Encode(responder)
+}
+
+// ResolvePushedAuthorizeRequest checks the query of a http.Request to the authorization endpoint for a request_uri
+// issued by the Pushed Authorization Request endpoint, and if present replaces the query with the pushed parameters.
+// The request_uri is returned so it can be revoked once the Authorization Request has been completed. If the request is
+// not a Pushed Authorization Request an empty request_uri is returned.
+func (p *OpenIDConnectProvider) ResolvePushedAuthorizeRequest(ctx context.Context, r *http.Request) (requestURI string, err error) {
+	query := r.URL.Query()
+
+	if requestURI = query.Get(FormParameterRequestURI); !IsPushedAuthorizeRequestURI(requestURI) {
+		return "", nil
+	}
+
+	var par *model.OAuth2PARContext
+
+	if par, err = p.Store.provider.LoadOAuth2PARContext(ctx, model.NewOAuth2PARContextSignature(strings.TrimPrefix(requestURI, RequestURIPrefixPushedAuthorizationRequestURN))); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errorsx.WithStack(fosite.ErrInvalidRequestURI.WithHint("The 'request_uri' parameter does not reference a known Pushed Authorization Request."))
+		}
+
+		return "", errorsx.WithStack(fosite.ErrServerError.WithHint("Could not load the Pushed Authorization Request.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	switch {
+	case par.Revoked:
+		return "", errorsx.WithStack(fosite.ErrInvalidRequestURI.WithHint("The 'request_uri' parameter references a Pushed Authorization Request which has already been used."))
+	case time.Now().After(par.ExpiresAt):
+		return "", errorsx.WithStack(fosite.ErrInvalidRequestURI.WithHint("The 'request_uri' parameter references a Pushed Authorization Request which has expired."))
+	case par.ClientID != query.Get(FormParameterClientID):
+		return "", errorsx.WithStack(fosite.ErrInvalidRequestURI.WithHint("The 'request_uri' parameter references a Pushed Authorization Request which was not issued to this client."))
+	}
+
+	var form url.Values
+
+	if form, err = par.GetForm(); err != nil {
+		return "", errorsx.WithStack(fosite.ErrServerError.WithHint("Could not parse the Pushed Authorization Request.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	r.URL.RawQuery = form.Encode()
+	r.Form, r.PostForm = nil, nil
+
+	return requestURI, nil
+}
+
+// RevokePushedAuthorizeRequest revokes a Pushed Authorization Request given the request_uri so it can't be used again.
+func (p *OpenIDConnectProvider) RevokePushedAuthorizeRequest(ctx context.Context, requestURI string) (err error) {
+	return p.Store.provider.RevokeOAuth2PARContext(ctx, model.NewOAuth2PARContextSignature(strings.TrimPrefix(requestURI, RequestURIPrefixPushedAuthorizationRequestURN)))
+}
+
+// IsPushedAuthorizeRequestRequired returns true if the client must use a Pushed Authorization Request either due to
+// the global enforcement option or the client option.
+func (p *OpenIDConnectProvider) IsPushedAuthorizeRequestRequired(client *Client) bool {
+	return p.pushedAuthorizationEnforce || client.GetRequirePushedAuthorizationRequests()
+}
+
+// IsPushedAuthorizeRequestURI returns true if the request_uri was issued by the Pushed Authorization Request endpoint.
+func IsPushedAuthorizeRequestURI(requestURI string) bool {
+	return strings.HasPrefix(requestURI, RequestURIPrefixPushedAuthorizationRequestURN)
+}
+
+// NewAuthorizeRequestRedirectForm returns the form values used to redirect the user-agent back to the authorization
+// endpoint. When the request was a Pushed Authorization Request only the client_id and request_uri are included as the
+// other parameters are stored server side, which keeps the URL short.
+func NewAuthorizeRequestRedirectForm(form url.Values) url.Values {
+	if requestURI := form.Get(FormParameterRequestURI); IsPushedAuthorizeRequestURI(requestURI) {
+		return url.Values{
+			FormParameterClientID:   []string{form.Get(FormParameterClientID)},
+			FormParameterRequestURI: []string{requestURI},
+		}
+	}
+
+	return form
+}
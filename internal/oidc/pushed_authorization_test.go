@@ -0,0 +1,315 @@
+package oidc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestOpenIDConnectProvider_PushedAuthorizeRequest(t *testing.T) {
+	provider, store := newTestPushedAuthorizeProvider(t)
+
+	ctx := context.Background()
+
+	requester, err := provider.NewPushedAuthorizeRequest(ctx, newTestPushedAuthorizeHTTPRequest(url.Values{
+		FormParameterClientID:     []string{"a-client"},
+		FormParameterClientSecret: []string{"a-client-secret"},
+		"response_type":           []string{"code"},
+		"redirect_uri":            []string{"https://example.com/callback"},
+		"scope":                   []string{"openid"},
+		FormParameterState:        []string{"abcdefghijklmnop"},
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, "a-client", requester.GetClient().GetID())
+	assert.Equal(t, "", requester.GetRequestForm().Get(FormParameterClientSecret))
+
+	responder, err := provider.NewPushedAuthorizeResponse(ctx, requester)
+
+	require.NoError(t, err)
+	require.True(t, IsPushedAuthorizeRequestURI(responder.RequestURI))
+	assert.Equal(t, 300, responder.ExpiresIn)
+	assert.Len(t, store.contexts, 1)
+
+	rw := httptest.NewRecorder()
+
+	provider.WritePushedAuthorizeResponse(rw, responder)
+
+	assert.Equal(t, http.StatusCreated, rw.Code)
+	assert.Equal(t, "no-store", rw.Header().Get("Cache-Control"))
+
+	actual := &PushedAuthorizeResponse{}
+
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), actual))
+	assert.Equal(t, responder, actual)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/oidc/authorization?"+url.Values{
+		FormParameterClientID:   []string{"a-client"},
+		FormParameterRequestURI: []string{responder.RequestURI},
+	}.Encode(), nil)
+
+	uri, err := provider.ResolvePushedAuthorizeRequest(ctx, r)
+
+	require.NoError(t, err)
+	assert.Equal(t, responder.RequestURI, uri)
+
+	query := r.URL.Query()
+
+	assert.Equal(t, "https://example.com/callback", query.Get("redirect_uri"))
+	assert.Equal(t, "abcdefghijklmnop", query.Get(FormParameterState))
+	assert.Equal(t, "", query.Get(FormParameterRequestURI))
+
+	authorize, err := provider.NewAuthorizeRequest(ctx, r)
+
+	require.NoError(t, err)
+	assert.Equal(t, "a-client", authorize.GetClient().GetID())
+
+	require.NoError(t, provider.RevokePushedAuthorizeRequest(ctx, uri))
+
+	r = httptest.NewRequest(http.MethodGet, "/api/oidc/authorization?"+url.Values{
+		FormParameterClientID:   []string{"a-client"},
+		FormParameterRequestURI: []string{responder.RequestURI},
+	}.Encode(), nil)
+
+	_, err = provider.ResolvePushedAuthorizeRequest(ctx, r)
+
+	assert.ErrorIs(t, err, fosite.ErrInvalidRequestURI)
+	assert.Equal(t, "The 'request_uri' parameter references a Pushed Authorization Request which has already been used.", fosite.ErrorToRFC6749Error(err).HintField)
+}
+
+func TestOpenIDConnectProvider_NewPushedAuthorizeRequestShouldFail(t *testing.T) {
+	provider, _ := newTestPushedAuthorizeProvider(t)
+
+	testCases := []struct {
+		name string
+		r    *http.Request
+		err  *fosite.RFC6749Error
+		hint string
+	}{
+		{
+			name: "ShouldRejectGET",
+			r:    httptest.NewRequest(http.MethodGet, "/api/oidc/pushed-authorization-request", nil),
+			err:  fosite.ErrInvalidRequest,
+			hint: "HTTP method is 'GET', expected 'POST'.",
+		},
+		{
+			name: "ShouldRejectBadSecret",
+			r: newTestPushedAuthorizeHTTPRequest(url.Values{
+				FormParameterClientID:     []string{"a-client"},
+				FormParameterClientSecret: []string{"bad-secret"},
+			}),
+			err: fosite.ErrInvalidClient,
+		},
+		{
+			name: "ShouldRejectRequestURI",
+			r: newTestPushedAuthorizeHTTPRequest(url.Values{
+				FormParameterClientID:     []string{"a-client"},
+				FormParameterClientSecret: []string{"a-client-secret"},
+				FormParameterRequestURI:   []string{"https://example.com/request"},
+			}),
+			err:  fosite.ErrInvalidRequest,
+			hint: "The 'request_uri' parameter must not be included in a Pushed Authorization Request.",
+		},
+		{
+			name: "ShouldRejectInvalidRedirectURI",
+			r: newTestPushedAuthorizeHTTPRequest(url.Values{
+				FormParameterClientID:     []string{"a-client"},
+				FormParameterClientSecret: []string{"a-client-secret"},
+				"response_type":           []string{"code"},
+				"redirect_uri":            []string{"https://evil.com/callback"},
+				"scope":                   []string{"openid"},
+				FormParameterState:        []string{"abcdefghijklmnop"},
+			}),
+			err: fosite.ErrInvalidRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := provider.NewPushedAuthorizeRequest(context.Background(), tc.r)
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.err)
+
+			if tc.hint != "" {
+				assert.Equal(t, tc.hint, fosite.ErrorToRFC6749Error(err).HintField)
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectProvider_ResolvePushedAuthorizeRequest(t *testing.T) {
+	provider, store := newTestPushedAuthorizeProvider(t)
+
+	store.contexts[model.NewOAuth2PARContextSignature("expired")] = model.OAuth2PARContext{ClientID: "a-client", ExpiresAt: time.Now().Add(-time.Minute)}
+	store.contexts[model.NewOAuth2PARContextSignature("valid")] = model.OAuth2PARContext{ClientID: "a-client", ExpiresAt: time.Now().Add(time.Minute), Form: "scope=openid"}
+
+	testCases := []struct {
+		name     string
+		clientID string
+		uri      string
+		expected string
+		hint     string
+	}{
+		{
+			name:     "ShouldIgnoreOtherRequestURI",
+			clientID: "a-client",
+			uri:      "https://example.com/request",
+		},
+		{
+			name:     "ShouldResolve",
+			clientID: "a-client",
+			uri:      RequestURIPrefixPushedAuthorizationRequestURN + "valid",
+			expected: RequestURIPrefixPushedAuthorizationRequestURN + "valid",
+		},
+		{
+			name:     "ShouldRejectUnknown",
+			clientID: "a-client",
+			uri:      RequestURIPrefixPushedAuthorizationRequestURN + "unknown",
+			hint:     "The 'request_uri' parameter does not reference a known Pushed Authorization Request.",
+		},
+		{
+			name:     "ShouldRejectExpired",
+			clientID: "a-client",
+			uri:      RequestURIPrefixPushedAuthorizationRequestURN + "expired",
+			hint:     "The 'request_uri' parameter references a Pushed Authorization Request which has expired.",
+		},
+		{
+			name:     "ShouldRejectOtherClient",
+			clientID: "b-client",
+			uri:      RequestURIPrefixPushedAuthorizationRequestURN + "valid",
+			hint:     "The 'request_uri' parameter references a Pushed Authorization Request which was not issued to this client.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/oidc/authorization?"+url.Values{
+				FormParameterClientID:   []string{tc.clientID},
+				FormParameterRequestURI: []string{tc.uri},
+			}.Encode(), nil)
+
+			uri, err := provider.ResolvePushedAuthorizeRequest(context.Background(), r)
+
+			if tc.hint == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, uri)
+			} else {
+				assert.ErrorIs(t, err, fosite.ErrInvalidRequestURI)
+				assert.Equal(t, tc.hint, fosite.ErrorToRFC6749Error(err).HintField)
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectProvider_IsPushedAuthorizeRequestRequired(t *testing.T) {
+	provider := &OpenIDConnectProvider{}
+
+	assert.False(t, provider.IsPushedAuthorizeRequestRequired(&Client{}))
+	assert.True(t, provider.IsPushedAuthorizeRequestRequired(&Client{RequirePushedAuthorizationRequests: true}))
+
+	provider.pushedAuthorizationEnforce = true
+
+	assert.True(t, provider.IsPushedAuthorizeRequestRequired(&Client{}))
+}
+
+func TestNewAuthorizeRequestRedirectForm(t *testing.T) {
+	form := url.Values{
+		FormParameterClientID: []string{"a-client"},
+		"scope":               []string{"openid"},
+	}
+
+	assert.Equal(t, form, NewAuthorizeRequestRedirectForm(form))
+
+	form.Set(FormParameterRequestURI, RequestURIPrefixPushedAuthorizationRequestURN+"abc")
+
+	assert.Equal(t, url.Values{
+		FormParameterClientID:   []string{"a-client"},
+		FormParameterRequestURI: []string{RequestURIPrefixPushedAuthorizationRequestURN + "abc"},
+	}, NewAuthorizeRequestRedirectForm(form))
+}
+
+func newTestPushedAuthorizeProvider(t *testing.T) (provider *OpenIDConnectProvider, store *testPARStore) {
+	t.Helper()
+
+	store = &testPARStore{contexts: map[string]model.OAuth2PARContext{}}
+
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+		PAR: schema.OpenIDConnectPARConfiguration{
+			ContextLifespan: time.Minute * 5,
+		},
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:            "a-client",
+				Secret:        MustDecodeSecret("$plaintext$a-client-secret"),
+				Policy:        "one_factor",
+				Scopes:        []string{ScopeOpenID},
+				ResponseTypes: []string{"code"},
+				RedirectURIs: []string{
+					"https://example.com/callback",
+				},
+			},
+		},
+	}, store)
+
+	require.NoError(t, err)
+
+	return provider, store
+}
+
+func newTestPushedAuthorizeHTTPRequest(form url.Values) (r *http.Request) {
+	r = httptest.NewRequest(http.MethodPost, "/api/oidc/pushed-authorization-request", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return r
+}
+
+type testPARStore struct {
+	storage.Provider
+
+	contexts map[string]model.OAuth2PARContext
+}
+
+func (s *testPARStore) SaveOAuth2PARContext(_ context.Context, par model.OAuth2PARContext) (err error) {
+	s.contexts[par.Signature] = par
+
+	return nil
+}
+
+func (s *testPARStore) LoadOAuth2PARContext(_ context.Context, signature string) (par *model.OAuth2PARContext, err error) {
+	c, ok := s.contexts[signature]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+
+	return &c, nil
+}
+
+func (s *testPARStore) RevokeOAuth2PARContext(_ context.Context, signature string) (err error) {
+	c, ok := s.contexts[signature]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	c.Revoked = true
+
+	s.contexts[signature] = c
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/ory/x/errorsx"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// ResolveRequestObject checks the query of a http.Request to the authorization endpoint for a RFC9101 JWT-Secured
+// Authorization Request Object passed by value using the request parameter or by reference using a registered
+// request_uri, and if present replaces the query with the parameters of the verified Request Object and the client_id.
+// All other query parameters are discarded as they're not integrity protected, and requests which duplicate a parameter
+// of the Request Object in the query with a different value are rejected. The parameter which referenced the Request
+// Object is returned so it can be retained on the Authorization Request. Clients which are required to use signed
+// Request Objects are rejected if they don't. Pushed Authorization Request request_uri values are ignored as they're
+// resolved by ResolvePushedAuthorizeRequest.
+//
+// RFC9101: https://www.rfc-editor.org/rfc/rfc9101.html
+func (p *OpenIDConnectProvider) ResolveRequestObject(ctx context.Context, r *http.Request) (object url.Values, err error) {
+	query := r.URL.Query()
+
+	value, requestURI := query.Get(FormParameterRequest), query.Get(FormParameterRequestURI)
+
+	if IsPushedAuthorizeRequestURI(requestURI) {
+		return nil, nil
+	}
+
+	var client *Client
+
+	if client, err = p.Store.GetFullClient(ctx, query.Get(FormParameterClientID)); err != nil {
+		if value == "" && requestURI == "" {
+			return nil, nil
+		}
+
+		return nil, errorsx.WithStack(fosite.ErrInvalidClient.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	switch {
+	case value == "" && requestURI == "":
+		if client.GetRequireSignedRequestObject() {
+			return nil, errorsx.WithStack(ErrRequestObjectRequired)
+		}
+
+		return nil, nil
+	case value != "" && requestURI != "":
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The 'request' and 'request_uri' parameters must not both be present."))
+	case requestURI != "":
+		if value, err = p.fetchRequestObject(ctx, client, requestURI); err != nil {
+			return nil, err
+		}
+
+		object = url.Values{FormParameterRequestURI: []string{requestURI}}
+	default:
+		object = url.Values{FormParameterRequest: []string{value}}
+	}
+
+	var claims jwt.MapClaims
+
+	if claims, err = p.parseRequestObject(ctx, client, value); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{FormParameterClientID: []string{client.GetID()}}
+
+	for key, claim := range claims {
+		switch key {
+		case FormParameterRequest, FormParameterRequestURI, ClaimIssuer, ClaimAudience, ClaimExpirationTime, ClaimNotBefore, ClaimIssuedAt, ClaimJWTID:
+			continue
+		}
+
+		var parameter string
+
+		if parameter, err = requestObjectParameter(claim); err != nil {
+			return nil, errorsx.WithStack(fosite.ErrInvalidRequestObject.WithHintf("The Request Object claim '%s' could not be converted to a parameter.", key).WithWrap(err).WithDebug(err.Error()))
+		}
+
+		form.Set(key, parameter)
+	}
+
+	for key, values := range query {
+		switch key {
+		case FormParameterRequest, FormParameterRequestURI:
+			continue
+		}
+
+		if parameter, ok := form[key]; ok && (len(values) != 1 || values[0] != parameter[0]) {
+			return nil, errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("The '%s' parameter conflicts with the value in the Request Object.", key))
+		}
+	}
+
+	r.URL.RawQuery = form.Encode()
+	r.Form, r.PostForm = nil, nil
+
+	return object, nil
+}
+
+func (p *OpenIDConnectProvider) fetchRequestObject(ctx context.Context, client *Client, requestURI string) (value string, err error) {
+	if !utils.IsStringInSlice(requestURI, client.GetRequestURIs()) {
+		return "", errorsx.WithStack(fosite.ErrInvalidRequestURI.WithHintf("The 'request_uri' parameter '%s' is not registered for the client.", requestURI))
+	}
+
+	var (
+		req  *http.Request
+		resp *http.Response
+	)
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, requestURI, nil); err != nil {
+		return "", errorsx.WithStack(fosite.ErrInvalidRequestURI.WithHint("Unable to fetch the Request Object from the 'request_uri'.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if resp, err = p.getHTTPClient(client).Do(req); err != nil {
+		return "", errorsx.WithStack(fosite.ErrInvalidRequestURI.WithHint("Unable to fetch the Request Object from the 'request_uri'.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errorsx.WithStack(fosite.ErrInvalidRequestURI.WithHintf("Unable to fetch the Request Object from the 'request_uri' because status code '%d' was expected but got '%d'.", http.StatusOK, resp.StatusCode))
+	}
+
+	var data []byte
+
+	if data, err = io.ReadAll(io.LimitReader(resp.Body, requestObjectMaxSize)); err != nil {
+		return "", errorsx.WithStack(fosite.ErrInvalidRequestURI.WithHint("Unable to read the Request Object from the 'request_uri'.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (p *OpenIDConnectProvider) parseRequestObject(ctx context.Context, client *Client, value string) (claims jwt.MapClaims, err error) {
+	var token *jwt.Token
+
+	token, err = jwt.ParseWithClaims(value, jwt.MapClaims{}, func(t *jwt.Token) (key any, err error) {
+		alg, _ := t.Header[JWTHeaderAlgorithm].(string)
+
+		if alg == SigningAlgorithmNone && client.GetRequireSignedRequestObject() {
+			return nil, errorsx.WithStack(ErrRequestObjectUnsigned)
+		}
+
+		if expected := client.GetRequestObjectSigningAlg(); expected != "" && alg != expected {
+			return nil, errorsx.WithStack(fosite.ErrInvalidRequestObject.WithHintf("The Request Object uses signing algorithm '%s' but the client enforces signing algorithm '%s'.", alg, expected))
+		}
+
+		switch {
+		case alg == SigningAlgorithmNone:
+			return jwt.UnsafeAllowNoneSignatureType, nil
+		case !utils.IsStringInSlice(alg, requestObjectSigningAlgs):
+			return nil, errorsx.WithStack(fosite.ErrInvalidRequestObject.WithHintf("The Request Object uses unsupported signing algorithm '%s'.", alg))
+		}
+
+		kid, _ := t.Header[JWTHeaderKeyIdentifier].(string)
+
+		if key, err = p.clientAuthentication.findClientPublicJWK(client, kid, alg); err != nil {
+			return nil, errorsx.WithStack(fosite.ErrInvalidRequestObject.WithHint("Unable to retrieve the key used to verify the Request Object.").WithWrap(err).WithDebug(err.Error()))
+		}
+
+		return key, nil
+	})
+
+	if err != nil {
+		var (
+			e   *jwt.ValidationError
+			rfc *fosite.RFC6749Error
+		)
+
+		if errors.As(err, &e) && e.Inner != nil && errors.As(e.Inner, &rfc) {
+			return nil, e.Inner
+		}
+
+		return nil, errorsx.WithStack(fosite.ErrInvalidRequestObject.WithHint("Unable to verify the integrity of the Request Object.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	claims = token.Claims
+
+	if err = p.verifyRequestObjectClaims(ctx, client, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (p *OpenIDConnectProvider) verifyRequestObjectClaims(ctx context.Context, client *Client, claims jwt.MapClaims) (err error) {
+	if clientID, ok := claims[FormParameterClientID]; ok && clientID != client.GetID() {
+		return errorsx.WithStack(fosite.ErrInvalidRequestObject.WithHint("The 'client_id' claim of the Request Object must match the 'client_id' parameter."))
+	}
+
+	if _, ok := claims[ClaimIssuer]; ok && !claims.VerifyIssuer(client.GetID(), true) {
+		return errorsx.WithStack(fosite.ErrInvalidRequestObject.WithHint("The 'iss' claim of the Request Object must match the 'client_id' of the client."))
+	}
+
+	if _, ok := claims[ClaimAudience]; !ok {
+		return nil
+	}
+
+	ictx, ok := ctx.(issuerContext)
+	if !ok {
+		return errorsx.WithStack(fosite.ErrMisconfiguration.WithHint("The authorization server's issuer could not be determined."))
+	}
+
+	var issuer *url.URL
+
+	if issuer, err = ictx.IssuerURL(); err != nil {
+		return errorsx.WithStack(ErrIssuerCouldNotDerive.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if !claims.VerifyAudience(issuer.String(), true) {
+		return errorsx.WithStack(fosite.ErrInvalidRequestObject.WithHintf("The 'aud' claim of the Request Object must contain the issuer '%s'.", issuer))
+	}
+
+	return nil
+}
+
+func requestObjectParameter(claim any) (parameter string, err error) {
+	switch value := claim.(type) {
+	case string:
+		return value, nil
+	case nil:
+		return "", nil
+	default:
+		var data []byte
+
+		if data, err = json.Marshal(value); err != nil {
+			return "", fmt.Errorf("error encoding value: %w", err)
+		}
+
+		return string(data), nil
+	}
+}
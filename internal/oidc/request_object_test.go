@@ -0,0 +1,330 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestOpenIDConnectProvider_ResolveRequestObject(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var hosted string
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/request.jwt" {
+			rw.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = rw.Write([]byte(hosted))
+	}))
+
+	defer server.Close()
+
+	jwks := []schema.OpenIDConnectClientJWK{
+		{KeyID: "key", Use: KeyUseSignature, Algorithm: SigningAlgorithmECDSAWithSHA256, Key: &key.PublicKey},
+	}
+
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:           "jar",
+				Secret:       MustDecodeSecret("$plaintext$a-client-secret"),
+				RedirectURIs: []string{"https://example.com/callback"},
+				JSONWebKeys:  jwks,
+				RequestURIs:  []string{server.URL + "/request.jwt", server.URL + "/missing.jwt"},
+			},
+			{
+				ID:                         "signed",
+				Secret:                     MustDecodeSecret("$plaintext$a-client-secret"),
+				RedirectURIs:               []string{"https://example.com/callback"},
+				JSONWebKeys:                jwks,
+				RequestObjectSigningAlg:    SigningAlgorithmECDSAWithSHA256,
+				RequireSignedRequestObject: true,
+			},
+		},
+	}, nil)
+
+	require.NoError(t, err)
+
+	provider.httpClient = server.Client()
+
+	issuer := &url.URL{Scheme: "https", Host: "auth.example.com"}
+	ctx := &testIssuerContext{Context: context.Background(), issuer: issuer}
+
+	signed := func(t *testing.T, alg jose.SignatureAlgorithm, k any, claims map[string]any) string {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: k}, (&jose.SignerOptions{}).WithHeader(JWTHeaderKeyIdentifier, "key"))
+		require.NoError(t, err)
+
+		value, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+		require.NoError(t, err)
+
+		return value
+	}
+
+	unsigned := func(t *testing.T, claims map[string]any) string {
+		payload, err := json.Marshal(claims)
+		require.NoError(t, err)
+
+		return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+	}
+
+	claims := func(clientID string) map[string]any {
+		return map[string]any{
+			ClaimIssuer:           clientID,
+			ClaimAudience:         issuer.String(),
+			ClaimExpirationTime:   time.Now().Add(time.Minute).Unix(),
+			FormParameterClientID: clientID,
+			"response_type":       "code",
+			"redirect_uri":        "https://example.com/callback",
+			FormParameterScope:    "openid profile",
+			FormParameterState:    "abcdefghijklmnop",
+			"max_age":             300,
+			FormParameterClaims:   map[string]any{"id_token": map[string]any{"email": map[string]any{"essential": true}}},
+		}
+	}
+
+	t.Run("ShouldResolveSignedRequestObjectByValue", func(t *testing.T) {
+		value := signed(t, jose.ES256, key, claims("signed"))
+
+		r := httptest.NewRequest(http.MethodGet, "/api/oidc/authorization?"+url.Values{
+			FormParameterClientID: []string{"signed"},
+			FormParameterScope:    []string{"openid profile"},
+			FormParameterRequest:  []string{value},
+		}.Encode(), nil)
+
+		object, err := provider.ResolveRequestObject(ctx, r)
+
+		require.NoError(t, err)
+		assert.Equal(t, url.Values{FormParameterRequest: []string{value}}, object)
+
+		query := r.URL.Query()
+
+		assert.Equal(t, "", query.Get(FormParameterRequest))
+		assert.Equal(t, "", query.Get(ClaimIssuer))
+		assert.Equal(t, "", query.Get(ClaimExpirationTime))
+		assert.Equal(t, "openid profile", query.Get(FormParameterScope))
+		assert.Equal(t, "abcdefghijklmnop", query.Get(FormParameterState))
+		assert.Equal(t, "300", query.Get("max_age"))
+		assert.Equal(t, `{"id_token":{"email":{"essential":true}}}`, query.Get(FormParameterClaims))
+	})
+
+	t.Run("ShouldDiscardParametersNotInRequestObject", func(t *testing.T) {
+		c := claims("signed")
+		delete(c, "max_age")
+
+		value := signed(t, jose.ES256, key, c)
+
+		r := httptest.NewRequest(http.MethodGet, "/api/oidc/authorization?"+url.Values{
+			FormParameterClientID: []string{"signed"},
+			FormParameterRequest:  []string{value},
+			FormParameterPrompt:   []string{PromptNone},
+			"max_age":             []string{"0"},
+			"response_mode":       []string{ResponseModeFormPost},
+			"login_hint":          []string{"john"},
+		}.Encode(), nil)
+
+		object, err := provider.ResolveRequestObject(ctx, r)
+
+		require.NoError(t, err)
+		assert.Equal(t, url.Values{FormParameterRequest: []string{value}}, object)
+
+		assert.Equal(t, url.Values{
+			FormParameterClientID: []string{"signed"},
+			"response_type":       []string{"code"},
+			"redirect_uri":        []string{"https://example.com/callback"},
+			FormParameterScope:    []string{"openid profile"},
+			FormParameterState:    []string{"abcdefghijklmnop"},
+			FormParameterClaims:   []string{`{"id_token":{"email":{"essential":true}}}`},
+		}, r.URL.Query())
+	})
+
+	t.Run("ShouldResolveRequestObjectByReference", func(t *testing.T) {
+		hosted = unsigned(t, claims("jar"))
+
+		r := httptest.NewRequest(http.MethodGet, "/api/oidc/authorization?"+url.Values{
+			FormParameterClientID:   []string{"jar"},
+			FormParameterRequestURI: []string{server.URL + "/request.jwt"},
+		}.Encode(), nil)
+
+		object, err := provider.ResolveRequestObject(ctx, r)
+
+		require.NoError(t, err)
+		assert.Equal(t, url.Values{FormParameterRequestURI: []string{server.URL + "/request.jwt"}}, object)
+		assert.Equal(t, "", r.URL.Query().Get(FormParameterRequestURI))
+		assert.Equal(t, "abcdefghijklmnop", r.URL.Query().Get(FormParameterState))
+	})
+
+	t.Run("ShouldIgnoreRequestsWithoutRequestObjects", func(t *testing.T) {
+		for _, query := range []url.Values{
+			{FormParameterClientID: []string{"jar"}},
+			{FormParameterClientID: []string{"signed"}, FormParameterRequestURI: []string{RequestURIPrefixPushedAuthorizationRequestURN + "abc"}},
+			{FormParameterClientID: []string{"unknown"}},
+		} {
+			r := httptest.NewRequest(http.MethodGet, "/api/oidc/authorization?"+query.Encode(), nil)
+
+			object, err := provider.ResolveRequestObject(ctx, r)
+
+			assert.NoError(t, err)
+			assert.Nil(t, object)
+			assert.Equal(t, query, r.URL.Query())
+		}
+	})
+
+	testCases := []struct {
+		name  string
+		query func(t *testing.T) url.Values
+		err   error
+		hint  string
+	}{
+		{
+			"ShouldRejectMissingRequestObjectWhenRequired",
+			func(t *testing.T) url.Values {
+				return url.Values{FormParameterClientID: []string{"signed"}}
+			},
+			fosite.ErrInvalidRequest,
+			"The client is required to use a signed Request Object.",
+		},
+		{
+			"ShouldRejectUnsignedRequestObjectWhenRequired",
+			func(t *testing.T) url.Values {
+				return url.Values{FormParameterClientID: []string{"signed"}, FormParameterRequest: []string{unsigned(t, claims("signed"))}}
+			},
+			fosite.ErrInvalidRequestObject,
+			"The client is required to use a signed Request Object but the Request Object is not signed.",
+		},
+		{
+			"ShouldRejectWrongAlgorithm",
+			func(t *testing.T) url.Values {
+				return url.Values{FormParameterClientID: []string{"signed"}, FormParameterRequest: []string{signed(t, jose.HS256, []byte("a-client-secret-which-is-long-enough"), claims("signed"))}}
+			},
+			fosite.ErrInvalidRequestObject,
+			"The Request Object uses signing algorithm 'HS256' but the client enforces signing algorithm 'ES256'.",
+		},
+		{
+			"ShouldRejectUnsupportedAlgorithm",
+			func(t *testing.T) url.Values {
+				return url.Values{FormParameterClientID: []string{"jar"}, FormParameterRequest: []string{signed(t, jose.HS256, []byte("a-client-secret-which-is-long-enough"), claims("jar"))}}
+			},
+			fosite.ErrInvalidRequestObject,
+			"The Request Object uses unsupported signing algorithm 'HS256'.",
+		},
+		{
+			"ShouldRejectWrongKey",
+			func(t *testing.T) url.Values {
+				return url.Values{FormParameterClientID: []string{"signed"}, FormParameterRequest: []string{signed(t, jose.ES256, other, claims("signed"))}}
+			},
+			fosite.ErrInvalidRequestObject,
+			"Unable to verify the integrity of the Request Object.",
+		},
+		{
+			"ShouldRejectClientIDMismatch",
+			func(t *testing.T) url.Values {
+				return url.Values{FormParameterClientID: []string{"signed"}, FormParameterRequest: []string{signed(t, jose.ES256, key, claims("jar"))}}
+			},
+			fosite.ErrInvalidRequestObject,
+			"The 'client_id' claim of the Request Object must match the 'client_id' parameter.",
+		},
+		{
+			"ShouldRejectAudienceMismatch",
+			func(t *testing.T) url.Values {
+				c := claims("signed")
+				c[ClaimAudience] = "https://example.com"
+
+				return url.Values{FormParameterClientID: []string{"signed"}, FormParameterRequest: []string{signed(t, jose.ES256, key, c)}}
+			},
+			fosite.ErrInvalidRequestObject,
+			"The 'aud' claim of the Request Object must contain the issuer 'https://auth.example.com'.",
+		},
+		{
+			"ShouldRejectExpiredRequestObject",
+			func(t *testing.T) url.Values {
+				c := claims("signed")
+				c[ClaimExpirationTime] = time.Now().Add(-time.Minute).Unix()
+
+				return url.Values{FormParameterClientID: []string{"signed"}, FormParameterRequest: []string{signed(t, jose.ES256, key, c)}}
+			},
+			fosite.ErrInvalidRequestObject,
+			"Unable to verify the integrity of the Request Object.",
+		},
+		{
+			"ShouldRejectConflictingQueryParameter",
+			func(t *testing.T) url.Values {
+				return url.Values{FormParameterClientID: []string{"signed"}, FormParameterScope: []string{"openid"}, FormParameterRequest: []string{signed(t, jose.ES256, key, claims("signed"))}}
+			},
+			fosite.ErrInvalidRequest,
+			"The 'scope' parameter conflicts with the value in the Request Object.",
+		},
+		{
+			"ShouldRejectBothParameters",
+			func(t *testing.T) url.Values {
+				return url.Values{FormParameterClientID: []string{"jar"}, FormParameterRequest: []string{unsigned(t, claims("jar"))}, FormParameterRequestURI: []string{server.URL + "/request.jwt"}}
+			},
+			fosite.ErrInvalidRequest,
+			"The 'request' and 'request_uri' parameters must not both be present.",
+		},
+		{
+			"ShouldRejectUnregisteredRequestURI",
+			func(t *testing.T) url.Values {
+				return url.Values{FormParameterClientID: []string{"jar"}, FormParameterRequestURI: []string{"https://app.example.com/request.jwt"}}
+			},
+			fosite.ErrInvalidRequestURI,
+			"The 'request_uri' parameter 'https://app.example.com/request.jwt' is not registered for the client.",
+		},
+		{
+			"ShouldRejectRequestURIWhichFails",
+			func(t *testing.T) url.Values {
+				return url.Values{FormParameterClientID: []string{"jar"}, FormParameterRequestURI: []string{server.URL + "/missing.jwt"}}
+			},
+			fosite.ErrInvalidRequestURI,
+			"Unable to fetch the Request Object from the 'request_uri' because status code '200' was expected but got '404'.",
+		},
+		{
+			"ShouldRejectUnknownClient",
+			func(t *testing.T) url.Values {
+				return url.Values{FormParameterClientID: []string{"unknown"}, FormParameterRequest: []string{unsigned(t, claims("unknown"))}}
+			},
+			fosite.ErrInvalidClient,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query := tc.query(t)
+
+			r := httptest.NewRequest(http.MethodGet, "/api/oidc/authorization?"+query.Encode(), nil)
+
+			object, err := provider.ResolveRequestObject(ctx, r)
+
+			assert.Nil(t, object)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.hint, fosite.ErrorToRFC6749Error(err).HintField)
+			assert.Equal(t, query, r.URL.Query())
+		})
+	}
+}
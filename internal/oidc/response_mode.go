@@ -0,0 +1,233 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/ory/x/errorsx"
+)
+
+// JWTSecuredResponseModeHandler is a fosite.ResponseModeHandler which handles the JWT Secured Authorization Response
+// Mode for OAuth 2.0 (JARM) response modes. The responses are signed with the KeyManager using the client's
+// authorization signing algorithm.
+//
+// JARM: https://openid.net/specs/oauth-v2-jarm.html
+type JWTSecuredResponseModeHandler struct {
+	provider *OpenIDConnectProvider
+	lifespan time.Duration
+	debug    bool
+}
+
+// ResponseModes returns the response modes handled by the JWTSecuredResponseModeHandler.
+func (h *JWTSecuredResponseModeHandler) ResponseModes() fosite.ResponseModeTypes {
+	return fosite.ResponseModeTypes{
+		ResponseModeJWT,
+		ResponseModeQueryJWT,
+		ResponseModeFormPostJWT,
+		ResponseModeFragmentJWT,
+	}
+}
+
+// WriteAuthorizeResponse implements fosite.ResponseModeHandler. The OpenIDConnectProvider.WriteAuthorizeResponse method
+// should be used instead as the issuer can't be derived without the request context.
+func (h *JWTSecuredResponseModeHandler) WriteAuthorizeResponse(rw http.ResponseWriter, requester fosite.AuthorizeRequester, responder fosite.AuthorizeResponder) {
+	h.WriteAuthorizeResponseWithContext(context.Background(), rw, requester, responder)
+}
+
+// WriteAuthorizeError implements fosite.ResponseModeHandler. The OpenIDConnectProvider.WriteAuthorizeError method
+// should be used instead as the issuer can't be derived without the request context.
+func (h *JWTSecuredResponseModeHandler) WriteAuthorizeError(rw http.ResponseWriter, requester fosite.AuthorizeRequester, err error) {
+	h.WriteAuthorizeErrorWithContext(context.Background(), rw, requester, err)
+}
+
+// WriteAuthorizeResponseWithContext writes a successful authorization response as a signed JWT.
+func (h *JWTSecuredResponseModeHandler) WriteAuthorizeResponseWithContext(ctx context.Context, rw http.ResponseWriter, requester fosite.AuthorizeRequester, responder fosite.AuthorizeResponder) {
+	header := rw.Header()
+
+	for key := range responder.GetHeader() {
+		header.Set(key, responder.GetHeader().Get(key))
+	}
+
+	header.Set("Cache-Control", "no-store")
+	header.Set("Pragma", "no-cache")
+
+	token, err := h.generate(ctx, requester, responder.GetParameters())
+	if err != nil {
+		h.writeError(rw, err)
+
+		return
+	}
+
+	h.write(rw, requester, token)
+}
+
+// WriteAuthorizeErrorWithContext writes an authorization error response as a signed JWT. If the redirect URI is not
+// valid or the JWT can't be generated the error is written as a JSON document instead.
+func (h *JWTSecuredResponseModeHandler) WriteAuthorizeErrorWithContext(ctx context.Context, rw http.ResponseWriter, requester fosite.AuthorizeRequester, err error) {
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("Pragma", "no-cache")
+
+	if !requester.IsRedirectURIValid() {
+		h.writeError(rw, err)
+
+		return
+	}
+
+	parameters := fosite.ErrorToRFC6749Error(err).WithExposeDebug(h.debug).ToValues()
+	parameters.Set(FormParameterState, requester.GetState())
+
+	token, gerr := h.generate(ctx, requester, parameters)
+	if gerr != nil {
+		h.writeError(rw, gerr)
+
+		return
+	}
+
+	h.write(rw, requester, token)
+}
+
+func (h *JWTSecuredResponseModeHandler) generate(ctx context.Context, requester fosite.AuthorizeRequester, parameters url.Values) (token string, err error) {
+	client, ok := requester.GetClient().(*Client)
+	if !ok {
+		return "", errorsx.WithStack(fosite.ErrServerError.WithHint("The client does not support JWT Secured Authorization Responses."))
+	}
+
+	ictx, ok := ctx.(issuerContext)
+	if !ok {
+		return "", errorsx.WithStack(ErrIssuerCouldNotDerive)
+	}
+
+	var issuer *url.URL
+
+	if issuer, err = ictx.IssuerURL(); err != nil {
+		return "", errorsx.WithStack(ErrIssuerCouldNotDerive.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	var strategy jwt.JWTStrategy
+
+	if strategy = h.provider.KeyManager.Strategy(); strategy == nil {
+		return "", errorsx.WithStack(fosite.ErrServerError.WithHint("Could not sign the authorization response as there is no active key."))
+	}
+
+	claims := jwt.MapClaims{
+		ClaimIssuer:         issuer.String(),
+		ClaimAudience:       client.GetID(),
+		ClaimExpirationTime: time.Now().Add(h.lifespan).Unix(),
+	}
+
+	for key := range parameters {
+		claims[key] = parameters.Get(key)
+	}
+
+	headers := jwtHeaders{
+		JWTHeaderKeyIdentifier: h.provider.KeyManager.GetKeyIDFromAlg(client.GetAuthorizationSignedResponseAlg()),
+	}
+
+	if token, _, err = strategy.Generate(ctx, claims, headers); err != nil {
+		return "", errorsx.WithStack(fosite.ErrServerError.WithHint("Could not sign the authorization response.").WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return token, nil
+}
+
+func (h *JWTSecuredResponseModeHandler) write(rw http.ResponseWriter, requester fosite.AuthorizeRequester, token string) {
+	redirectURI := requester.GetRedirectURI()
+	redirectURI.Fragment = ""
+
+	parameters := url.Values{FormParameterResponse: []string{token}}
+
+	switch GetJWTSecuredResponseMode(requester) {
+	case ResponseModeFormPostJWT:
+		rw.Header().Set("Content-Type", "text/html;charset=UTF-8")
+
+		fosite.WriteAuthorizeFormPostResponse(redirectURI.String(), parameters, fosite.FormPostDefaultTemplate, rw)
+
+		return
+	case ResponseModeFragmentJWT:
+		rw.Header().Set("Location", redirectURI.String()+"#"+parameters.Encode())
+	default:
+		query := redirectURI.Query()
+		query.Set(FormParameterResponse, token)
+
+		redirectURI.RawQuery = query.Encode()
+
+		rw.Header().Set("Location", redirectURI.String())
+	}
+
+	rw.WriteHeader(http.StatusSeeOther)
+}
+
+func (h *JWTSecuredResponseModeHandler) writeError(rw http.ResponseWriter, err error) {
+	rfc := fosite.ErrorToRFC6749Error(err).WithExposeDebug(h.debug)
+
+	data, merr := json.Marshal(rfc)
+	if merr != nil {
+		http.Error(rw, `{"error":"server_error"}`, http.StatusInternalServerError)
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	rw.WriteHeader(rfc.CodeField)
+	_, _ = rw.Write(data)
+}
+
+// IsJWTSecuredResponseMode returns true if the response mode is one of the JWT Secured Authorization Response Mode for
+// OAuth 2.0 (JARM) response modes.
+func IsJWTSecuredResponseMode(mode fosite.ResponseModeType) bool {
+	switch mode {
+	case ResponseModeJWT, ResponseModeQueryJWT, ResponseModeFormPostJWT, ResponseModeFragmentJWT:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetJWTSecuredResponseMode returns the effective JARM response mode of an authorization request. The jwt response mode
+// is resolved to query.jwt when the response type is code, or none, and to fragment.jwt otherwise.
+//
+// JARM: https://openid.net/specs/oauth-v2-jarm.html#name-response-mode-jwt
+func GetJWTSecuredResponseMode(requester fosite.AuthorizeRequester) fosite.ResponseModeType {
+	mode := requester.GetResponseMode()
+
+	if mode != ResponseModeJWT {
+		return mode
+	}
+
+	types := requester.GetResponseTypes()
+
+	if len(types) == 0 || types.ExactOne("code") || types.ExactOne("none") {
+		return ResponseModeQueryJWT
+	}
+
+	return ResponseModeFragmentJWT
+}
+
+// WriteAuthorizeResponse writes the authorization response, signing it as a JWT when a JWT Secured Authorization
+// Response Mode for OAuth 2.0 (JARM) response mode was requested.
+func (p *OpenIDConnectProvider) WriteAuthorizeResponse(ctx context.Context, rw http.ResponseWriter, requester fosite.AuthorizeRequester, responder fosite.AuthorizeResponder) {
+	if IsJWTSecuredResponseMode(requester.GetResponseMode()) {
+		p.responseModeHandler.WriteAuthorizeResponseWithContext(ctx, rw, requester, responder)
+
+		return
+	}
+
+	p.OAuth2Provider.WriteAuthorizeResponse(rw, requester, responder)
+}
+
+// WriteAuthorizeError writes the authorization error response, signing it as a JWT when a JWT Secured Authorization
+// Response Mode for OAuth 2.0 (JARM) response mode was requested.
+func (p *OpenIDConnectProvider) WriteAuthorizeError(ctx context.Context, rw http.ResponseWriter, requester fosite.AuthorizeRequester, err error) {
+	if IsJWTSecuredResponseMode(requester.GetResponseMode()) {
+		p.responseModeHandler.WriteAuthorizeErrorWithContext(ctx, rw, requester, err)
+
+		return
+	}
+
+	p.OAuth2Provider.WriteAuthorizeError(rw, requester, err)
+}
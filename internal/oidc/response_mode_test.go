@@ -0,0 +1,253 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestOpenIDConnectProvider_WriteAuthorizeResponse_JWTSecuredResponseModes(t *testing.T) {
+	key := mustParseRSAPrivateKey(exampleIssuerPrivateKey)
+
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: key,
+		HMACSecret:       "asbdhaaskmdlkamdklasmdlkams",
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:            "jarm",
+				Secret:        MustDecodeSecret("$plaintext$a-client-secret"),
+				RedirectURIs:  []string{"https://example.com/callback"},
+				Scopes:        []string{ScopeOpenID},
+				ResponseTypes: []string{"code"},
+				ResponseModes: []string{ResponseModeJWT, ResponseModeQueryJWT, ResponseModeFormPostJWT, ResponseModeFragmentJWT},
+			},
+		},
+	}, nil)
+
+	require.NoError(t, err)
+
+	client, err := provider.GetFullClient(context.Background(), "jarm")
+	require.NoError(t, err)
+
+	issuer := &url.URL{Scheme: "https", Host: "auth.example.com"}
+	ctx := &testIssuerContext{Context: context.Background(), issuer: issuer}
+
+	requester := func(mode fosite.ResponseModeType, types ...string) *fosite.AuthorizeRequest {
+		ar := fosite.NewAuthorizeRequest()
+
+		ar.Client = client
+		ar.RedirectURI = &url.URL{Scheme: "https", Host: "example.com", Path: "/callback"}
+		ar.ResponseMode = mode
+		ar.ResponseTypes = types
+		ar.State = "abcdefghijklmnop"
+		ar.HandledResponseTypes = types
+
+		return ar
+	}
+
+	verify := func(t *testing.T, token string) map[string]any {
+		parsed, err := jwt.ParseSigned(token)
+		require.NoError(t, err)
+
+		claims := map[string]any{}
+
+		require.NoError(t, parsed.Claims(&key.PublicKey, &claims))
+
+		assert.Equal(t, issuer.String(), claims[ClaimIssuer])
+		assert.Equal(t, "jarm", claims[ClaimAudience])
+		assert.NotNil(t, claims[ClaimExpirationTime])
+
+		return claims
+	}
+
+	testCases := []struct {
+		name     string
+		mode     fosite.ResponseModeType
+		types    []string
+		location func(t *testing.T, location *url.URL) string
+	}{
+		{
+			"ShouldWriteQueryJWT",
+			ResponseModeQueryJWT,
+			[]string{"code"},
+			func(t *testing.T, location *url.URL) string {
+				assert.Equal(t, "", location.Fragment)
+
+				return location.Query().Get(FormParameterResponse)
+			},
+		},
+		{
+			"ShouldWriteJWTAsQueryForCode",
+			ResponseModeJWT,
+			[]string{"code"},
+			func(t *testing.T, location *url.URL) string {
+				return location.Query().Get(FormParameterResponse)
+			},
+		},
+		{
+			"ShouldWriteJWTAsFragmentForImplicit",
+			ResponseModeJWT,
+			[]string{"id_token"},
+			func(t *testing.T, location *url.URL) string {
+				assert.Equal(t, "", location.RawQuery)
+
+				fragment, err := url.ParseQuery(location.Fragment)
+				require.NoError(t, err)
+
+				return fragment.Get(FormParameterResponse)
+			},
+		},
+		{
+			"ShouldWriteFragmentJWT",
+			ResponseModeFragmentJWT,
+			[]string{"code"},
+			func(t *testing.T, location *url.URL) string {
+				fragment, err := url.ParseQuery(location.Fragment)
+				require.NoError(t, err)
+
+				return fragment.Get(FormParameterResponse)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responder := fosite.NewAuthorizeResponse()
+			responder.AddParameter("code", "an-authorization-code")
+			responder.AddParameter(FormParameterState, "abcdefghijklmnop")
+
+			rw := httptest.NewRecorder()
+
+			provider.WriteAuthorizeResponse(ctx, rw, requester(tc.mode, tc.types...), responder)
+
+			assert.Equal(t, http.StatusSeeOther, rw.Code)
+			assert.Equal(t, "no-store", rw.Header().Get("Cache-Control"))
+
+			location, err := url.Parse(rw.Header().Get("Location"))
+			require.NoError(t, err)
+
+			assert.Equal(t, "https://example.com/callback", (&url.URL{Scheme: location.Scheme, Host: location.Host, Path: location.Path}).String())
+
+			claims := verify(t, tc.location(t, location))
+
+			assert.Equal(t, "an-authorization-code", claims["code"])
+			assert.Equal(t, "abcdefghijklmnop", claims[FormParameterState])
+		})
+	}
+
+	t.Run("ShouldWriteFormPostJWT", func(t *testing.T) {
+		responder := fosite.NewAuthorizeResponse()
+		responder.AddParameter("code", "an-authorization-code")
+
+		rw := httptest.NewRecorder()
+
+		provider.WriteAuthorizeResponse(ctx, rw, requester(ResponseModeFormPostJWT, "code"), responder)
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "text/html;charset=UTF-8", rw.Header().Get("Content-Type"))
+
+		matches := regexp.MustCompile(`name="response" value="([^"]+)"`).FindStringSubmatch(rw.Body.String())
+		require.Len(t, matches, 2)
+
+		claims := verify(t, matches[1])
+
+		assert.Equal(t, "an-authorization-code", claims["code"])
+	})
+
+	t.Run("ShouldWriteErrorJWT", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+
+		provider.WriteAuthorizeError(ctx, rw, requester(ResponseModeQueryJWT, "code"), fosite.ErrAccessDenied)
+
+		assert.Equal(t, http.StatusSeeOther, rw.Code)
+
+		location, err := url.Parse(rw.Header().Get("Location"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "", location.Query().Get("error"))
+
+		claims := verify(t, location.Query().Get(FormParameterResponse))
+
+		assert.Equal(t, "access_denied", claims["error"])
+		assert.Equal(t, "abcdefghijklmnop", claims[FormParameterState])
+	})
+
+	t.Run("ShouldWriteJSONErrorWithoutIssuer", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+
+		provider.WriteAuthorizeError(context.Background(), rw, requester(ResponseModeQueryJWT, "code"), fosite.ErrAccessDenied)
+
+		assert.Equal(t, http.StatusInternalServerError, rw.Code)
+		assert.Equal(t, "", rw.Header().Get("Location"))
+		assert.Contains(t, rw.Body.String(), `"error":"server_error"`)
+	})
+
+	t.Run("ShouldParseResponseMode", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/api/oidc/authorization?"+url.Values{
+			FormParameterClientID: []string{"jarm"},
+			"response_type":       []string{"code"},
+			"response_mode":       []string{ResponseModeJWT},
+			"redirect_uri":        []string{"https://example.com/callback"},
+			FormParameterScope:    []string{"openid"},
+			FormParameterState:    []string{"abcdefghijklmnop"},
+		}.Encode(), nil)
+
+		ar, err := provider.NewAuthorizeRequest(ctx, r)
+
+		require.NoError(t, err)
+		assert.Equal(t, fosite.ResponseModeType(ResponseModeJWT), ar.GetResponseMode())
+	})
+
+	t.Run("ShouldDelegateOtherResponseModes", func(t *testing.T) {
+		responder := fosite.NewAuthorizeResponse()
+		responder.AddParameter("code", "an-authorization-code")
+
+		rw := httptest.NewRecorder()
+
+		provider.WriteAuthorizeResponse(ctx, rw, requester(fosite.ResponseModeQuery, "code"), responder)
+
+		location, err := url.Parse(rw.Header().Get("Location"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "an-authorization-code", location.Query().Get("code"))
+		assert.Equal(t, "", location.Query().Get(FormParameterResponse))
+	})
+}
+
+func TestGetJWTSecuredResponseMode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mode     fosite.ResponseModeType
+		types    fosite.Arguments
+		expected fosite.ResponseModeType
+	}{
+		{"ShouldResolveCodeToQuery", ResponseModeJWT, fosite.Arguments{"code"}, ResponseModeQueryJWT},
+		{"ShouldResolveNoneToQuery", ResponseModeJWT, fosite.Arguments{"none"}, ResponseModeQueryJWT},
+		{"ShouldResolveHybridToFragment", ResponseModeJWT, fosite.Arguments{"code", "id_token"}, ResponseModeFragmentJWT},
+		{"ShouldResolveImplicitToFragment", ResponseModeJWT, fosite.Arguments{"token"}, ResponseModeFragmentJWT},
+		{"ShouldNotResolveExplicitMode", ResponseModeFormPostJWT, fosite.Arguments{"code"}, ResponseModeFormPostJWT},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ar := fosite.NewAuthorizeRequest()
+			ar.ResponseMode = tc.mode
+			ar.ResponseTypes = tc.types
+
+			assert.Equal(t, tc.expected, GetJWTSecuredResponseMode(ar))
+			assert.True(t, IsJWTSecuredResponseMode(tc.mode))
+		})
+	}
+
+	assert.False(t, IsJWTSecuredResponseMode(fosite.ResponseModeQuery))
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ory/fosite"
+	"github.com/sirupsen/logrus"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -25,11 +26,21 @@ func NewOpenIDConnectStore(config *schema.OpenIDConnectConfiguration, provider s
 	store = &Store{
 		provider: provider,
 		clients:  map[string]*Client{},
+
+		registrationPolicy: authorization.StringToLevel(config.DynamicClientRegistration.Policy),
+
+		claimsPolicies: map[string]*ClaimsPolicy{},
+		scopes:         NewCustomScopes(config.Scopes),
+
+		refreshTokenGracePeriod: config.RefreshTokenGracePeriod,
+	}
+
+	for _, policy := range config.ClaimsPolicies {
+		store.claimsPolicies[policy.Name] = NewClaimsPolicy(policy)
 	}
 
 	for _, client := range config.Clients {
-		policy := authorization.StringToLevel(client.Policy)
-		logger.Debugf("Registering client %s with policy %s (%v)", client.ID, client.Policy, policy)
+		logger.Debugf("Registering client %s with policy %s", client.ID, client.Policy)
 
 		store.clients[client.ID] = NewClient(client)
 	}
@@ -66,8 +77,8 @@ func (s *Store) GetSubject(ctx context.Context, sectorID, username string) (subj
 }
 
 // GetClientPolicy retrieves the policy from the client with the matching provided id.
-func (s *Store) GetClientPolicy(id string) (level authorization.Level) {
-	client, err := s.GetFullClient(id)
+func (s *Store) GetClientPolicy(ctx context.Context, id string) (level authorization.Level) {
+	client, err := s.GetFullClient(ctx, id)
 	if err != nil {
 		return authorization.TwoFactor
 	}
@@ -75,19 +86,36 @@ func (s *Store) GetClientPolicy(id string) (level authorization.Level) {
 	return client.Policy
 }
 
-// GetFullClient returns a fosite.Client asserted as an Client matching the provided id.
-func (s *Store) GetFullClient(id string) (client *Client, err error) {
+// GetFullClient returns a fosite.Client asserted as an Client matching the provided id. The clients from the
+// configuration take precedence, and the clients registered via the dynamic client registration endpoint or the CLI are
+// then loaded from the storage provider.
+func (s *Store) GetFullClient(ctx context.Context, id string) (client *Client, err error) {
 	client, ok := s.clients[id]
-	if !ok {
+	if ok {
+		return client, nil
+	}
+
+	if s.provider == nil {
 		return nil, fosite.ErrNotFound
 	}
 
-	return client, nil
+	var registered *model.OAuth2Client
+
+	if registered, err = s.provider.LoadOAuth2Client(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fosite.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return NewRegisteredClient(registered, s.registrationPolicy)
 }
 
-// IsValidClientID returns true if the provided id exists in the OpenIDConnectProvider.Clients map.
-func (s *Store) IsValidClientID(id string) (valid bool) {
-	_, err := s.GetFullClient(id)
+// IsValidClientID returns true if the provided id exists in the OpenIDConnectProvider.Clients map or is a registered
+// client.
+func (s *Store) IsValidClientID(ctx context.Context, id string) (valid bool) {
+	_, err := s.GetFullClient(ctx, id)
 
 	return err == nil
 }
@@ -112,8 +140,8 @@ func (s *Store) Rollback(ctx context.Context) (err error) {
 
 // GetClient loads the client by its ID or returns an error if the client does not exist or another error occurred.
 // This implements a portion of fosite.ClientManager.
-func (s *Store) GetClient(_ context.Context, id string) (client fosite.Client, err error) {
-	return s.GetFullClient(id)
+func (s *Store) GetClient(ctx context.Context, id string) (client fosite.Client, err error) {
+	return s.GetFullClient(ctx, id)
 }
 
 // ClientAssertionJWTValid returns an error if the JTI is known or the DB check failed and nil if the JTI is not known.
@@ -210,14 +238,19 @@ func (s *Store) DeleteRefreshTokenSession(ctx context.Context, signature string)
 // then the authorization server SHOULD also invalidate all access tokens based on the same authorization grant (see Implementation Note).
 // This implements a portion of oauth2.TokenRevocationStorage.
 func (s *Store) RevokeRefreshToken(ctx context.Context, requestID string) (err error) {
-	return s.provider.DeactivateOAuth2SessionByRequestID(ctx, storage.OAuth2SessionTypeRefreshToken, requestID)
+	return s.revokeSessionByRequestID(ctx, storage.OAuth2SessionTypeRefreshToken, requestID)
 }
 
-// RevokeRefreshTokenMaybeGracePeriod revokes an access token as specified in: https://tools.ietf.org/html/rfc7009#section-2.1
-// If the token passed to the request is an access token, the server MAY revoke the respective refresh token as well.
+// RevokeRefreshTokenMaybeGracePeriod rotates a refresh token when it's used to obtain a new one. The rotated refresh
+// token is marked inactive so that it being presented again is detected as reuse. When a grace period is configured only
+// the rotated refresh token is marked inactive, otherwise all refresh tokens sharing the request id are.
 // This implements a portion of oauth2.TokenRevocationStorage.
 func (s *Store) RevokeRefreshTokenMaybeGracePeriod(ctx context.Context, requestID string, signature string) (err error) {
-	return s.RevokeRefreshToken(ctx, requestID)
+	if s.refreshTokenGracePeriod > 0 {
+		return s.provider.DeactivateOAuth2Session(ctx, storage.OAuth2SessionTypeRefreshToken, signature)
+	}
+
+	return s.provider.DeactivateOAuth2SessionByRequestID(ctx, storage.OAuth2SessionTypeRefreshToken, requestID)
 }
 
 // GetRefreshTokenSession gets the authorization request for a given refresh token.
@@ -299,13 +332,57 @@ func (s *Store) loadSessionBySignature(ctx context.Context, sessionType storage.
 		return nil, err
 	}
 
-	if !sessionModel.Active && sessionType == storage.OAuth2SessionTypeAuthorizeCode {
-		return r, fosite.ErrInvalidatedAuthorizeCode
+	if !sessionModel.Active {
+		switch sessionType {
+		case storage.OAuth2SessionTypeAuthorizeCode:
+			return r, fosite.ErrInvalidatedAuthorizeCode
+		case storage.OAuth2SessionTypeRefreshToken:
+			return r, s.handleRefreshTokenReuse(ctx, sessionModel)
+		}
 	}
 
 	return r, nil
 }
 
+// handleRefreshTokenReuse handles a refresh token which has already been rotated being presented again. If it was rotated
+// within the grace period it's permitted in order to allow concurrent refreshes, otherwise the refresh token is
+// considered to be compromised and the entire token family (all access and refresh tokens sharing the request id) is
+// revoked.
+//
+// See: https://datatracker.ietf.org/doc/html/rfc6819#section-5.2.2.3
+func (s *Store) handleRefreshTokenReuse(ctx context.Context, session *model.OAuth2Session) (err error) {
+	if s.refreshTokenGracePeriod > 0 {
+		var rotatedAt time.Time
+
+		if rotatedAt, err = s.provider.LoadOAuth2RefreshTokenSessionRotatedAt(ctx, session.RequestID, session.RequestedAt); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if err == nil && time.Since(rotatedAt) <= s.refreshTokenGracePeriod {
+			logging.Logger().Debugf("Refresh token with request id '%s' for client with id '%s' was used again within the grace period after it was rotated", session.RequestID, session.ClientID)
+
+			return nil
+		}
+	}
+
+	logging.Logger().WithFields(logrus.Fields{
+		"event":      "refresh_token_reuse",
+		"request_id": session.RequestID,
+		"client_id":  session.ClientID,
+		"subject":    session.Subject,
+	}).Warn("Refresh token reuse detected, revoking all access and refresh tokens issued with the same request id")
+
+	if err = s.revokeSessionByRequestID(ctx, storage.OAuth2SessionTypeRefreshToken, session.RequestID); err != nil && !errors.Is(err, fosite.ErrNotFound) {
+		return err
+	}
+
+	if err = s.revokeSessionByRequestID(ctx, storage.OAuth2SessionTypeAccessToken, session.RequestID); err != nil && !errors.Is(err, fosite.ErrNotFound) {
+		return err
+	}
+
+	return fosite.ErrInactiveToken
+}
+
 func (s *Store) saveSession(ctx context.Context, sessionType storage.OAuth2SessionType, signature string, r fosite.Requester) (err error) {
 	var session *model.OAuth2Session
 
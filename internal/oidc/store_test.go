@@ -2,13 +2,18 @@ package oidc
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/ory/fosite"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
 )
 
 func TestOpenIDConnectStore_GetClientPolicy(t *testing.T) {
@@ -33,13 +38,13 @@ func TestOpenIDConnectStore_GetClientPolicy(t *testing.T) {
 		},
 	}, nil)
 
-	policyOne := s.GetClientPolicy("myclient")
+	policyOne := s.GetClientPolicy(context.Background(), "myclient")
 	assert.Equal(t, authorization.OneFactor, policyOne)
 
-	policyTwo := s.GetClientPolicy("myotherclient")
+	policyTwo := s.GetClientPolicy(context.Background(), "myotherclient")
 	assert.Equal(t, authorization.TwoFactor, policyTwo)
 
-	policyInvalid := s.GetClientPolicy("invalidclient")
+	policyInvalid := s.GetClientPolicy(context.Background(), "invalidclient")
 	assert.Equal(t, authorization.TwoFactor, policyInvalid)
 }
 
@@ -83,7 +88,7 @@ func TestOpenIDConnectStore_GetInternalClient_ValidClient(t *testing.T) {
 		Clients:                []schema.OpenIDConnectClientConfiguration{c1},
 	}, nil)
 
-	client, err := s.GetFullClient(c1.ID)
+	client, err := s.GetFullClient(context.Background(), c1.ID)
 	require.NoError(t, err)
 	require.NotNil(t, client)
 	assert.Equal(t, client.ID, c1.ID)
@@ -111,11 +116,35 @@ func TestOpenIDConnectStore_GetInternalClient_InvalidClient(t *testing.T) {
 		Clients:                []schema.OpenIDConnectClientConfiguration{c1},
 	}, nil)
 
-	client, err := s.GetFullClient("another-client")
+	client, err := s.GetFullClient(context.Background(), "another-client")
 	assert.Nil(t, client)
 	assert.EqualError(t, err, "not_found")
 }
 
+func TestOpenIDConnectStore_GetFullClientShouldLoadStoredClientsWithoutConfiguredClients(t *testing.T) {
+	store := &testClientRegistrationStore{clients: map[string]model.OAuth2Client{
+		"stored": {
+			ClientID: "stored",
+			Policy:   "one_factor",
+			Metadata: `{"client_name":"Stored","redirect_uris":["https://app.example.com/callback"],"token_endpoint_auth_method":"client_secret_basic"}`,
+		},
+	}}
+
+	s := NewOpenIDConnectStore(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey: mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+	}, store)
+
+	client, err := s.GetFullClient(context.Background(), "stored")
+
+	require.NoError(t, err)
+	assert.Equal(t, "stored", client.GetID())
+	assert.Equal(t, "Stored", client.Description)
+	assert.Equal(t, authorization.OneFactor, client.Policy)
+
+	assert.True(t, s.IsValidClientID(context.Background(), "stored"))
+	assert.False(t, s.IsValidClientID(context.Background(), "unknown"))
+}
+
 func TestOpenIDConnectStore_IsValidClientID(t *testing.T) {
 	s := NewOpenIDConnectStore(&schema.OpenIDConnectConfiguration{
 		IssuerCertificateChain: schema.X509CertificateChain{},
@@ -131,9 +160,189 @@ func TestOpenIDConnectStore_IsValidClientID(t *testing.T) {
 		},
 	}, nil)
 
-	validClient := s.IsValidClientID("myclient")
-	invalidClient := s.IsValidClientID("myinvalidclient")
+	validClient := s.IsValidClientID(context.Background(), "myclient")
+	invalidClient := s.IsValidClientID(context.Background(), "myinvalidclient")
 
 	assert.True(t, validClient)
 	assert.False(t, invalidClient)
 }
+
+func TestOpenIDConnectStore_RefreshTokenReuse(t *testing.T) {
+	testCases := []struct {
+		name        string
+		gracePeriod time.Duration
+		rotatedAgo  time.Duration
+		expectErr   error
+		revoked     bool
+	}{
+		{"ShouldRevokeFamilyWithoutGracePeriod", 0, time.Second, fosite.ErrInactiveToken, true},
+		{"ShouldAllowWithinGracePeriod", time.Minute, time.Second, nil, false},
+		{"ShouldRevokeFamilyAfterGracePeriod", time.Minute, time.Minute * 2, fosite.ErrInactiveToken, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := &testRefreshTokenStore{sessions: map[storage.OAuth2SessionType]map[string]*model.OAuth2Session{}}
+
+			s := NewOpenIDConnectStore(&schema.OpenIDConnectConfiguration{
+				RefreshTokenGracePeriod: tc.gracePeriod,
+				Clients: []schema.OpenIDConnectClientConfiguration{
+					{
+						ID:     "myclient",
+						Policy: "one_factor",
+						Secret: MustDecodeSecret("$plaintext$mysecret"),
+					},
+				},
+			}, provider)
+
+			ctx := context.Background()
+			now := time.Now().UTC()
+
+			request := &fosite.Request{
+				ID:          "family",
+				RequestedAt: now.Add(-time.Hour),
+				Client:      &Client{ID: "myclient"},
+				Session:     NewSession(),
+			}
+
+			require.NoError(t, s.CreateRefreshTokenSession(ctx, "rt1", request))
+			require.NoError(t, s.CreateAccessTokenSession(ctx, "at1", request))
+
+			require.NoError(t, s.RevokeRefreshTokenMaybeGracePeriod(ctx, "family", "rt1"))
+
+			request.RequestedAt = now.Add(-tc.rotatedAgo)
+
+			require.NoError(t, s.CreateRefreshTokenSession(ctx, "rt2", request))
+			require.NoError(t, s.CreateAccessTokenSession(ctx, "at2", request))
+
+			r, err := s.GetRefreshTokenSession(ctx, "rt1", NewSession())
+
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.NotNil(t, r)
+			assert.Equal(t, "family", r.GetID())
+
+			for _, signature := range []string{"rt1", "rt2"} {
+				assert.Equal(t, tc.revoked, provider.sessions[storage.OAuth2SessionTypeRefreshToken][signature].Revoked, signature)
+			}
+
+			for _, signature := range []string{"at1", "at2"} {
+				assert.Equal(t, tc.revoked, provider.sessions[storage.OAuth2SessionTypeAccessToken][signature].Revoked, signature)
+			}
+
+			_, err = s.GetRefreshTokenSession(ctx, "rt2", NewSession())
+
+			if tc.revoked {
+				assert.ErrorIs(t, err, fosite.ErrNotFound)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOpenIDConnectStore_RevokeRefreshTokenMaybeGracePeriod(t *testing.T) {
+	testCases := []struct {
+		name        string
+		gracePeriod time.Duration
+		expected    bool
+	}{
+		{"ShouldDeactivateFamilyWithoutGracePeriod", 0, false},
+		{"ShouldDeactivateOnlySignatureWithGracePeriod", time.Minute, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := &testRefreshTokenStore{sessions: map[storage.OAuth2SessionType]map[string]*model.OAuth2Session{}}
+
+			s := NewOpenIDConnectStore(&schema.OpenIDConnectConfiguration{RefreshTokenGracePeriod: tc.gracePeriod}, provider)
+
+			ctx := context.Background()
+
+			request := &fosite.Request{
+				ID:          "family",
+				RequestedAt: time.Now().UTC(),
+				Client:      &Client{ID: "myclient"},
+				Session:     NewSession(),
+			}
+
+			require.NoError(t, s.CreateRefreshTokenSession(ctx, "rt1", request))
+			require.NoError(t, s.CreateRefreshTokenSession(ctx, "rt2", request))
+
+			require.NoError(t, s.RevokeRefreshTokenMaybeGracePeriod(ctx, "family", "rt1"))
+
+			assert.False(t, provider.sessions[storage.OAuth2SessionTypeRefreshToken]["rt1"].Active)
+			assert.Equal(t, tc.expected, provider.sessions[storage.OAuth2SessionTypeRefreshToken]["rt2"].Active)
+		})
+	}
+}
+
+type testRefreshTokenStore struct {
+	storage.Provider
+
+	sessions map[storage.OAuth2SessionType]map[string]*model.OAuth2Session
+}
+
+func (s *testRefreshTokenStore) SaveOAuth2Session(_ context.Context, sessionType storage.OAuth2SessionType, session model.OAuth2Session) (err error) {
+	if s.sessions[sessionType] == nil {
+		s.sessions[sessionType] = map[string]*model.OAuth2Session{}
+	}
+
+	s.sessions[sessionType][session.Signature] = &session
+
+	return nil
+}
+
+func (s *testRefreshTokenStore) LoadOAuth2Session(_ context.Context, sessionType storage.OAuth2SessionType, signature string) (session *model.OAuth2Session, err error) {
+	if session = s.sessions[sessionType][signature]; session == nil || session.Revoked {
+		return nil, sql.ErrNoRows
+	}
+
+	return session, nil
+}
+
+func (s *testRefreshTokenStore) DeactivateOAuth2Session(_ context.Context, sessionType storage.OAuth2SessionType, signature string) (err error) {
+	if session := s.sessions[sessionType][signature]; session != nil {
+		session.Active = false
+	}
+
+	return nil
+}
+
+func (s *testRefreshTokenStore) DeactivateOAuth2SessionByRequestID(_ context.Context, sessionType storage.OAuth2SessionType, requestID string) (err error) {
+	for _, session := range s.sessions[sessionType] {
+		if session.RequestID == requestID {
+			session.Active = false
+		}
+	}
+
+	return nil
+}
+
+func (s *testRefreshTokenStore) RevokeOAuth2SessionByRequestID(_ context.Context, sessionType storage.OAuth2SessionType, requestID string) (err error) {
+	for _, session := range s.sessions[sessionType] {
+		if session.RequestID == requestID {
+			session.Revoked = true
+		}
+	}
+
+	return nil
+}
+
+func (s *testRefreshTokenStore) LoadOAuth2RefreshTokenSessionRotatedAt(_ context.Context, requestID string, requestedAt time.Time) (rotatedAt time.Time, err error) {
+	for _, session := range s.sessions[storage.OAuth2SessionTypeRefreshToken] {
+		if session.RequestID == requestID && session.RequestedAt.After(requestedAt) && (rotatedAt.IsZero() || session.RequestedAt.Before(rotatedAt)) {
+			rotatedAt = session.RequestedAt
+		}
+	}
+
+	if rotatedAt.IsZero() {
+		return rotatedAt, sql.ErrNoRows
+	}
+
+	return rotatedAt, nil
+}
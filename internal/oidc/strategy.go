@@ -0,0 +1,229 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/handler/openid"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/ory/x/errorsx"
+
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewRotatedGlobalSecrets returns the global secrets of the HMAC strategy derived from the previous HMAC secrets. The
+// rotated global secrets are only used to validate the opaque tokens which were issued before the HMAC secret was
+// rotated, and are derived from the HMAC secrets the same way as the current global secret.
+func NewRotatedGlobalSecrets(secrets []string) (rotated [][]byte) {
+	for _, secret := range secrets {
+		rotated = append(rotated, []byte(utils.HashSHA256FromString(secret)))
+	}
+
+	return rotated
+}
+
+// NewCoreStrategy creates a new CoreStrategy.
+func NewCoreStrategy(strategy *oauth2.HMACSHAStrategy, manager *KeyManager) (core *CoreStrategy) {
+	return &CoreStrategy{
+		HMACSHAStrategy: strategy,
+		KeyManager:      manager,
+	}
+}
+
+// CoreStrategy is a oauth2.CoreStrategy which issues RFC9068 JWT access tokens to clients configured with an access
+// token signing algorithm and opaque HMAC access tokens to all other clients. The authorize codes and refresh tokens are
+// always opaque HMAC tokens.
+//
+// The JWT access tokens are stored like the opaque access tokens using a signature derived from the JWS signature so
+// introspection and revocation behave identically for both token formats.
+type CoreStrategy struct {
+	*oauth2.HMACSHAStrategy
+
+	KeyManager *KeyManager
+}
+
+// AccessTokenSignature implements oauth2.AccessTokenStrategy.
+func (s *CoreStrategy) AccessTokenSignature(token string) (signature string) {
+	if !isJWTAccessToken(token) {
+		return s.HMACSHAStrategy.AccessTokenSignature(token)
+	}
+
+	return getJWTAccessTokenSignature(token)
+}
+
+// GenerateAccessToken implements oauth2.AccessTokenStrategy.
+func (s *CoreStrategy) GenerateAccessToken(ctx context.Context, requester fosite.Requester) (token string, signature string, err error) {
+	setEffectiveLifespan(requester, fosite.AccessToken)
+
+	client, ok := requester.GetClient().(*Client)
+
+	if !ok || client.GetAccessTokenSignedResponseAlg() == SigningAlgorithmNone {
+		return s.HMACSHAStrategy.GenerateAccessToken(ctx, requester)
+	}
+
+	alg := client.GetAccessTokenSignedResponseAlg()
+
+	headers := jwtHeaders{
+		JWTHeaderType:          JWTHeaderTypeAccessToken,
+		JWTHeaderAlgorithm:     alg,
+		JWTHeaderKeyIdentifier: s.KeyManager.GetKeyIDFromAlg(alg),
+	}
+
+	if token, _, err = NewKeyManagerStrategy(s.KeyManager).Generate(ctx, s.getJWTAccessTokenClaims(client, requester), headers); err != nil {
+		return "", "", err
+	}
+
+	return token, getJWTAccessTokenSignature(token), nil
+}
+
+// GenerateRefreshToken implements oauth2.RefreshTokenStrategy.
+func (s *CoreStrategy) GenerateRefreshToken(ctx context.Context, requester fosite.Requester) (token string, signature string, err error) {
+	setEffectiveLifespan(requester, fosite.RefreshToken)
+
+	return s.HMACSHAStrategy.GenerateRefreshToken(ctx, requester)
+}
+
+// ValidateAccessToken implements oauth2.AccessTokenStrategy.
+func (s *CoreStrategy) ValidateAccessToken(ctx context.Context, requester fosite.Requester, token string) (err error) {
+	if !isJWTAccessToken(token) {
+		return s.HMACSHAStrategy.ValidateAccessToken(ctx, requester, token)
+	}
+
+	var t *jwt.Token
+
+	if t, err = NewKeyManagerStrategy(s.KeyManager).Decode(ctx, token); err != nil {
+		var e *jwt.ValidationError
+
+		switch {
+		case errors.As(err, &e) && e.Has(jwt.ValidationErrorExpired):
+			return errorsx.WithStack(fosite.ErrTokenExpired.WithWrap(err).WithDebug(err.Error()))
+		case errors.As(err, &e) && e.Has(jwt.ValidationErrorMalformed):
+			return errorsx.WithStack(fosite.ErrInvalidTokenFormat.WithWrap(err).WithDebug(err.Error()))
+		default:
+			return errorsx.WithStack(fosite.ErrTokenSignatureMismatch.WithWrap(err).WithDebug(err.Error()))
+		}
+	}
+
+	if typ, _ := t.Header[JWTHeaderType].(string); typ != JWTHeaderTypeAccessToken {
+		return errorsx.WithStack(fosite.ErrInvalidTokenFormat.WithDebugf("The token has the type '%s' but the type '%s' is required.", typ, JWTHeaderTypeAccessToken))
+	}
+
+	return nil
+}
+
+func (s *CoreStrategy) getJWTAccessTokenClaims(client *Client, requester fosite.Requester) (claims jwt.MapClaims) {
+	now := time.Now().UTC()
+
+	session := requester.GetSession()
+
+	exp := session.GetExpiresAt(fosite.AccessToken)
+
+	if exp.IsZero() {
+		exp = now.Add(s.AccessTokenLifespan)
+	}
+
+	audience := requester.GetGrantedAudience()
+
+	if len(audience) == 0 {
+		audience = fosite.Arguments{client.GetID()}
+	}
+
+	claims = jwt.MapClaims{
+		ClaimJWTID:            uuid.New().String(),
+		ClaimIssuedAt:         now.Unix(),
+		ClaimExpirationTime:   exp.Unix(),
+		ClaimAudience:         []string(audience),
+		ClaimClientIdentifier: client.GetID(),
+		ClaimScope:            strings.Join(requester.GetGrantedScopes(), " "),
+		ClaimSubject:          client.GetID(),
+	}
+
+	if oidcSession, ok := session.(openid.Session); ok && oidcSession.IDTokenClaims() != nil {
+		idTokenClaims := oidcSession.IDTokenClaims()
+
+		if idTokenClaims.Issuer != "" {
+			claims[ClaimIssuer] = idTokenClaims.Issuer
+		}
+
+		if idTokenClaims.Subject != "" {
+			claims[ClaimSubject] = idTokenClaims.Subject
+		}
+
+		if groups, ok := idTokenClaims.Extra[ClaimGroups]; ok {
+			claims[ClaimGroups] = groups
+		}
+	}
+
+	if openIDSession, ok := session.(*model.OpenIDSession); ok {
+		if cnf := openIDSession.GetConfirmation(); cnf != nil {
+			claims[ClaimConfirmation] = cnf
+		}
+	}
+
+	return claims
+}
+
+// IDTokenStrategy is a openid.OpenIDConnectTokenStrategy which issues ID Tokens with the lifespan configured for the
+// client and grant type, falling back to the lifespan of the openid.DefaultStrategy.
+type IDTokenStrategy struct {
+	openid.DefaultStrategy
+}
+
+// GenerateIDToken implements openid.OpenIDConnectTokenStrategy.
+func (s *IDTokenStrategy) GenerateIDToken(ctx context.Context, requester fosite.Requester) (token string, err error) {
+	if session, ok := requester.GetSession().(openid.Session); ok && session.IDTokenClaims() != nil && session.IDTokenClaims().ExpiresAt.IsZero() {
+		if lifespan := getEffectiveLifespan(requester.GetClient(), getRequesterGrantType(requester), fosite.IDToken, 0); lifespan > 0 {
+			session.IDTokenClaims().ExpiresAt = time.Now().UTC().Add(lifespan)
+		}
+	}
+
+	return s.DefaultStrategy.GenerateIDToken(ctx, requester)
+}
+
+// setEffectiveLifespan sets the expiration of a token type in the session of the requester when a lifespan is
+// configured for the client and grant type. The Device Authorization and Token Exchange grants are excluded as their
+// handlers apply the client lifespans themselves.
+func setEffectiveLifespan(requester fosite.Requester, tt fosite.TokenType) {
+	gt := getRequesterGrantType(requester)
+
+	if gt == GrantTypeDeviceCode || gt == GrantTypeTokenExchange {
+		return
+	}
+
+	if lifespan := getEffectiveLifespan(requester.GetClient(), gt, tt, 0); lifespan > 0 {
+		requester.GetSession().SetExpiresAt(tt, time.Now().UTC().Add(lifespan).Round(time.Second))
+	}
+}
+
+// getRequesterGrantType returns the grant type of a token request, or the implicit grant type for tokens issued by the
+// authorization endpoint.
+func getRequesterGrantType(requester fosite.Requester) string {
+	if ar, ok := requester.(fosite.AccessRequester); ok && len(ar.GetGrantTypes()) != 0 {
+		return ar.GetGrantTypes()[0]
+	}
+
+	return GrantTypeImplicit
+}
+
+func isJWTAccessToken(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// getJWTAccessTokenSignature returns the signature used to store a JWT access token. The JWS signature itself is not
+// used as it's potentially longer than the storage allows.
+func getJWTAccessTokenSignature(token string) (signature string) {
+	sig, err := getTokenSignature(token)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(sig)))
+}
@@ -0,0 +1,252 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha512"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/handler/openid"
+	"github.com/ory/fosite/token/hmac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestCoreStrategy_GenerateAccessToken(t *testing.T) {
+	manager := NewKeyManager()
+
+	_, err := manager.AddActiveJWK(schema.X509CertificateChain{}, mustParseRSAPrivateKey(exampleIssuerPrivateKey))
+	require.NoError(t, err)
+
+	strategy := NewCoreStrategy(&oauth2.HMACSHAStrategy{
+		Enigma: &hmac.HMACStrategy{
+			GlobalSecret: []byte("zpeRfUVDVvmrqXgLCXAkdevCtdEkjvPA"),
+			Hash:         sha512.New512_256,
+		},
+		AccessTokenLifespan: time.Hour,
+	}, manager)
+
+	session := NewSession()
+	session.Claims.Issuer = "https://auth.example.com"
+	session.Claims.Subject = "a-subject"
+	session.Claims.Extra[ClaimGroups] = []string{"admin", "dev"}
+	session.DPoPJWKThumbprint = "0ZcOCORZNYy-DWpqq30jZyJGHTN0d2HglBV3uiguA4I"
+
+	requester := fosite.NewAccessRequest(session)
+	requester.Client = &Client{ID: "jwt-client", AccessTokenSignedResponseAlg: SigningAlgorithmRSAWithSHA256}
+	requester.GrantScope(ScopeOpenID)
+	requester.GrantScope(ScopeGroups)
+	requester.GrantAudience("https://api.example.com")
+
+	ctx := context.Background()
+
+	token, signature, err := strategy.GenerateAccessToken(ctx, requester)
+	require.NoError(t, err)
+
+	assert.Len(t, signature, 64)
+	assert.Equal(t, signature, strategy.AccessTokenSignature(token))
+	assert.NoError(t, strategy.ValidateAccessToken(ctx, requester, token))
+
+	decoded, err := NewKeyManagerStrategy(manager).Decode(ctx, token)
+	require.NoError(t, err)
+
+	assert.Equal(t, JWTHeaderTypeAccessToken, decoded.Header[JWTHeaderType])
+	assert.Equal(t, manager.GetActiveKeyID(), decoded.Header[JWTHeaderKeyIdentifier])
+
+	assert.Equal(t, "https://auth.example.com", decoded.Claims[ClaimIssuer])
+	assert.Equal(t, "a-subject", decoded.Claims[ClaimSubject])
+	assert.Equal(t, "jwt-client", decoded.Claims[ClaimClientIdentifier])
+	assert.Equal(t, "openid groups", decoded.Claims[ClaimScope])
+	assert.Equal(t, []any{"admin", "dev"}, decoded.Claims[ClaimGroups])
+	assert.Equal(t, []any{"https://api.example.com"}, decoded.Claims[ClaimAudience])
+	assert.NotEmpty(t, decoded.Claims[ClaimJWTID])
+	assert.Equal(t, map[string]any{ClaimConfirmationJWKThumbprint: "0ZcOCORZNYy-DWpqq30jZyJGHTN0d2HglBV3uiguA4I"}, decoded.Claims[ClaimConfirmation])
+
+	requester.Client = &Client{ID: "opaque-client"}
+
+	token, signature, err = strategy.GenerateAccessToken(ctx, requester)
+	require.NoError(t, err)
+
+	assert.Equal(t, signature, strategy.AccessTokenSignature(token))
+	assert.Equal(t, strategy.HMACSHAStrategy.AccessTokenSignature(token), signature)
+	assert.NoError(t, strategy.ValidateAccessToken(ctx, requester, token))
+}
+
+func TestCoreStrategy_ValidateAccessToken(t *testing.T) {
+	manager := NewKeyManager()
+
+	_, err := manager.AddActiveJWK(schema.X509CertificateChain{}, mustParseRSAPrivateKey(exampleIssuerPrivateKey))
+	require.NoError(t, err)
+
+	strategy := NewCoreStrategy(&oauth2.HMACSHAStrategy{}, manager)
+
+	ctx := context.Background()
+
+	token, _, err := NewKeyManagerStrategy(manager).Generate(ctx, map[string]any{ClaimSubject: "a-subject"}, jwtHeaders{})
+	require.NoError(t, err)
+
+	err = strategy.ValidateAccessToken(ctx, nil, token)
+
+	assert.ErrorIs(t, err, fosite.ErrInvalidTokenFormat)
+	assert.Equal(t, "The token has the type 'JWT' but the type 'at+jwt' is required.", fosite.ErrorToRFC6749Error(err).DebugField)
+
+	token, _, err = NewKeyManagerStrategy(manager).Generate(ctx, map[string]any{ClaimExpirationTime: time.Now().Add(-time.Minute).Unix()}, jwtHeaders{JWTHeaderType: JWTHeaderTypeAccessToken})
+	require.NoError(t, err)
+
+	err = strategy.ValidateAccessToken(ctx, nil, token)
+
+	assert.ErrorIs(t, err, fosite.ErrTokenExpired)
+	assert.Equal(t, fosite.ErrTokenExpired.HintField, fosite.ErrorToRFC6749Error(err).HintField)
+
+	assert.ErrorIs(t, strategy.ValidateAccessToken(ctx, nil, token[:len(token)-4]+"AAAA"), fosite.ErrTokenSignatureMismatch)
+}
+
+func TestCoreStrategy_ShouldValidateTokensWithRotatedSecrets(t *testing.T) {
+	previous := NewCoreStrategy(&oauth2.HMACSHAStrategy{
+		Enigma: &hmac.HMACStrategy{
+			GlobalSecret: NewRotatedGlobalSecrets([]string{"zpeRfUVDVvmrqXgLCXAkdevCtdEkjvPA"})[0],
+			Hash:         sha512.New512_256,
+		},
+		AccessTokenLifespan:  time.Hour,
+		RefreshTokenLifespan: time.Hour,
+	}, NewKeyManager())
+
+	current := NewCoreStrategy(&oauth2.HMACSHAStrategy{
+		Enigma: &hmac.HMACStrategy{
+			GlobalSecret:         NewRotatedGlobalSecrets([]string{"QpCsPMjUvmGeqLbmcyYkeHsvHsKsvmTF"})[0],
+			RotatedGlobalSecrets: NewRotatedGlobalSecrets([]string{"zpeRfUVDVvmrqXgLCXAkdevCtdEkjvPA"}),
+			Hash:                 sha512.New512_256,
+		},
+		AccessTokenLifespan:  time.Hour,
+		RefreshTokenLifespan: time.Hour,
+	}, NewKeyManager())
+
+	unrelated := NewCoreStrategy(&oauth2.HMACSHAStrategy{
+		Enigma: &hmac.HMACStrategy{
+			GlobalSecret: NewRotatedGlobalSecrets([]string{"QpCsPMjUvmGeqLbmcyYkeHsvHsKsvmTF"})[0],
+			Hash:         sha512.New512_256,
+		},
+		AccessTokenLifespan:  time.Hour,
+		RefreshTokenLifespan: time.Hour,
+	}, NewKeyManager())
+
+	ctx := context.Background()
+
+	requester := fosite.NewAccessRequest(NewSession())
+	requester.Client = &Client{ID: "opaque-client"}
+	requester.RequestedAt = time.Now()
+
+	token, signature, err := previous.GenerateAccessToken(ctx, requester)
+	require.NoError(t, err)
+
+	assert.Equal(t, signature, current.AccessTokenSignature(token))
+	assert.NoError(t, current.ValidateAccessToken(ctx, requester, token))
+	assert.ErrorIs(t, unrelated.ValidateAccessToken(ctx, requester, token), fosite.ErrTokenSignatureMismatch)
+
+	token, _, err = previous.GenerateRefreshToken(ctx, requester)
+	require.NoError(t, err)
+
+	assert.NoError(t, current.ValidateRefreshToken(ctx, requester, token))
+	assert.ErrorIs(t, unrelated.ValidateRefreshToken(ctx, requester, token), fosite.ErrTokenSignatureMismatch)
+
+	token, _, err = current.GenerateAccessToken(ctx, requester)
+	require.NoError(t, err)
+
+	assert.NoError(t, unrelated.ValidateAccessToken(ctx, requester, token))
+	assert.ErrorIs(t, previous.ValidateAccessToken(ctx, requester, token), fosite.ErrTokenSignatureMismatch)
+}
+
+func TestCoreStrategy_ShouldApplyClientLifespans(t *testing.T) {
+	strategy := NewCoreStrategy(&oauth2.HMACSHAStrategy{
+		Enigma: &hmac.HMACStrategy{
+			GlobalSecret: []byte("zpeRfUVDVvmrqXgLCXAkdevCtdEkjvPA"),
+			Hash:         sha512.New512_256,
+		},
+		AccessTokenLifespan: time.Hour,
+	}, NewKeyManager())
+
+	client := &Client{
+		ID: "kiosk",
+		Lifespans: schema.OpenIDConnectClientLifespans{
+			OpenIDConnectLifespans: schema.OpenIDConnectLifespans{
+				AccessToken: time.Minute * 5,
+			},
+			Grants: schema.OpenIDConnectClientGrantLifespans{
+				RefreshToken: schema.OpenIDConnectLifespans{
+					RefreshToken: time.Hour * 2,
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+
+	testCases := []struct {
+		name                 string
+		grant                string
+		access, refresh, def time.Duration
+	}{
+		{"ShouldApplyClientLifespan", GrantTypeAuthorizationCode, time.Minute * 5, time.Hour * 24, time.Hour * 24},
+		{"ShouldApplyGrantLifespan", GrantTypeRefreshToken, time.Minute * 5, time.Hour * 2, time.Hour * 24},
+		{"ShouldNotApplyToTokenExchange", GrantTypeTokenExchange, time.Hour * 24, time.Hour * 24, time.Hour * 24},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			session := NewSession()
+
+			now := time.Now().UTC()
+
+			session.SetExpiresAt(fosite.AccessToken, now.Add(time.Hour*24))
+			session.SetExpiresAt(fosite.RefreshToken, now.Add(time.Hour*24))
+
+			requester := fosite.NewAccessRequest(session)
+			requester.Client = client
+			requester.GrantTypes = fosite.Arguments{tc.grant}
+
+			_, _, err := strategy.GenerateAccessToken(ctx, requester)
+			require.NoError(t, err)
+
+			_, _, err = strategy.GenerateRefreshToken(ctx, requester)
+			require.NoError(t, err)
+
+			assert.WithinDuration(t, now.Add(tc.access), session.GetExpiresAt(fosite.AccessToken), time.Second*2)
+			assert.WithinDuration(t, now.Add(tc.refresh), session.GetExpiresAt(fosite.RefreshToken), time.Second*2)
+		})
+	}
+}
+
+func TestIDTokenStrategy_ShouldApplyClientLifespan(t *testing.T) {
+	manager := NewKeyManager()
+
+	_, err := manager.AddActiveJWK(schema.X509CertificateChain{}, mustParseRSAPrivateKey(exampleIssuerPrivateKey))
+	require.NoError(t, err)
+
+	strategy := &IDTokenStrategy{
+		DefaultStrategy: openid.DefaultStrategy{
+			JWTStrategy: NewKeyManagerStrategy(manager),
+			Expiry:      time.Hour,
+		},
+	}
+
+	session := NewSession()
+	session.Claims.Subject = "a-subject"
+	session.Claims.RequestedAt = time.Now().UTC()
+	session.Claims.AuthTime = time.Now().UTC()
+
+	requester := fosite.NewAccessRequest(session)
+	requester.Client = &Client{ID: "kiosk", Lifespans: schema.OpenIDConnectClientLifespans{OpenIDConnectLifespans: schema.OpenIDConnectLifespans{IDToken: time.Minute * 5}}}
+	requester.GrantTypes = fosite.Arguments{GrantTypeAuthorizationCode}
+	requester.Form.Set("nonce", "a-nonce-which-is-long-enough")
+
+	now := time.Now().UTC()
+
+	_, err = strategy.GenerateIDToken(context.Background(), requester)
+	require.NoError(t, err)
+
+	assert.WithinDuration(t, now.Add(time.Minute*5), session.Claims.ExpiresAt, time.Second*2)
+}
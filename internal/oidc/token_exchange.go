@@ -0,0 +1,193 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/x/errorsx"
+
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// tokenExchangeGrantFactory is a compose.Factory which creates the TokenExchangeGrantHandler.
+func tokenExchangeGrantFactory(config *compose.Config, store any, strategy any) any {
+	return &TokenExchangeGrantHandler{
+		store:               store.(*Store),
+		AccessTokenStrategy: strategy.(oauth2.AccessTokenStrategy),
+		AccessTokenLifespan: config.GetAccessTokenLifespan(),
+	}
+}
+
+// HandleTokenEndpointRequest implements fosite.TokenEndpointHandler. It validates the subject_token and ensures the
+// requested audience and scopes are permitted by the policy of the client and are a subset of the scopes granted to the
+// subject_token.
+//
+// RFC8693: https://www.rfc-editor.org/rfc/rfc8693.html#section-2.1
+func (h *TokenExchangeGrantHandler) HandleTokenEndpointRequest(ctx context.Context, requester fosite.AccessRequester) (err error) {
+	if !h.CanHandleTokenEndpointRequest(requester) {
+		return errorsx.WithStack(fosite.ErrUnknownRequest)
+	}
+
+	client, ok := requester.GetClient().(*Client)
+	if !ok || !client.GetGrantTypes().Has(GrantTypeTokenExchange) {
+		return errorsx.WithStack(fosite.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use the authorization grant '%s'.", GrantTypeTokenExchange))
+	}
+
+	form := requester.GetRequestForm()
+
+	switch {
+	case form.Get(FormParameterActorToken) != "" || form.Get(FormParameterActorTokenType) != "":
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The 'actor_token' parameter is not supported."))
+	case form.Get(FormParameterRequestedTokenType) != "" && form.Get(FormParameterRequestedTokenType) != TokenTypeAccessToken:
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("The 'requested_token_type' parameter value '%s' is not supported.", form.Get(FormParameterRequestedTokenType)))
+	case form.Get(FormParameterSubjectToken) == "":
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The 'subject_token' parameter is required."))
+	case form.Get(FormParameterSubjectTokenType) != TokenTypeAccessToken:
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHintf("The 'subject_token_type' parameter value '%s' is not supported.", form.Get(FormParameterSubjectTokenType)))
+	}
+
+	var subject fosite.Requester
+
+	if subject, err = h.getSubjectTokenRequester(ctx, form.Get(FormParameterSubjectToken)); err != nil {
+		return err
+	}
+
+	if subject.GetClient().GetID() != client.GetID() && !subject.GetGrantedAudience().Has(client.GetID()) {
+		return errorsx.WithStack(fosite.ErrInvalidGrant.WithHint("The 'subject_token' parameter was not issued to or for this client."))
+	}
+
+	if err = h.handleAudience(client, requester); err != nil {
+		return err
+	}
+
+	if err = h.handleScopes(client, subject, requester); err != nil {
+		return err
+	}
+
+	session := subject.GetSession().Clone()
+
+	if s, ok := session.(*model.OpenIDSession); ok {
+		s.ClientID = client.GetID()
+	}
+
+	exp := time.Now().UTC().Add(client.GetEffectiveLifespan(GrantTypeTokenExchange, fosite.AccessToken, h.AccessTokenLifespan)).Round(time.Second)
+
+	// The exchanged token must never outlive the subject_token.
+	if subjectExp := subject.GetSession().GetExpiresAt(fosite.AccessToken); !subjectExp.IsZero() && subjectExp.Before(exp) {
+		exp = subjectExp
+	}
+
+	session.SetExpiresAt(fosite.AccessToken, exp)
+
+	requester.SetSession(session)
+
+	return nil
+}
+
+func (h *TokenExchangeGrantHandler) getSubjectTokenRequester(ctx context.Context, token string) (subject fosite.Requester, err error) {
+	if subject, err = h.store.GetAccessTokenSession(ctx, h.AccessTokenStrategy.AccessTokenSignature(token), NewSession()); err != nil {
+		if errors.Is(err, fosite.ErrNotFound) {
+			return nil, errorsx.WithStack(ErrTokenExchangeSubjectTokenInvalid)
+		}
+
+		return nil, errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if err = h.AccessTokenStrategy.ValidateAccessToken(ctx, subject, token); err != nil {
+		return nil, errorsx.WithStack(ErrTokenExchangeSubjectTokenInvalid.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	return subject, nil
+}
+
+func (h *TokenExchangeGrantHandler) handleAudience(client *Client, requester fosite.AccessRequester) (err error) {
+	audience := requester.GetRequestedAudience()
+
+	if len(audience) == 0 {
+		return errorsx.WithStack(fosite.ErrInvalidRequest.WithHint("The 'audience' parameter is required."))
+	}
+
+	for _, aud := range audience {
+		if !client.IsTokenExchangeAudienceAllowed(aud) {
+			return errorsx.WithStack(ErrTokenExchangeInvalidTarget.WithHintf("The OAuth 2.0 Client is not allowed to exchange a token for the audience '%s'.", aud))
+		}
+	}
+
+	for _, aud := range audience {
+		requester.GrantAudience(aud)
+	}
+
+	return nil
+}
+
+func (h *TokenExchangeGrantHandler) handleScopes(client *Client, subject fosite.Requester, requester fosite.AccessRequester) (err error) {
+	scopes := requester.GetRequestedScopes()
+
+	// When no scopes are requested the exchanged token receives the scopes of the subject_token permitted by the policy.
+	if len(scopes) == 0 {
+		for _, scope := range subject.GetGrantedScopes() {
+			if client.IsTokenExchangeScopeAllowed(scope) {
+				requester.GrantScope(scope)
+			}
+		}
+
+		return nil
+	}
+
+	for _, scope := range scopes {
+		switch {
+		case !subject.GetGrantedScopes().Has(scope):
+			return errorsx.WithStack(fosite.ErrInvalidScope.WithHintf("The scope '%s' was not granted to the 'subject_token'.", scope))
+		case !client.IsTokenExchangeScopeAllowed(scope):
+			return errorsx.WithStack(fosite.ErrInvalidScope.WithHintf("The OAuth 2.0 Client is not allowed to exchange a token for the scope '%s'.", scope))
+		}
+	}
+
+	for _, scope := range scopes {
+		requester.GrantScope(scope)
+	}
+
+	return nil
+}
+
+// PopulateTokenEndpointResponse implements fosite.TokenEndpointHandler. It issues the exchanged access token and
+// stores the resulting session.
+//
+// RFC8693: https://www.rfc-editor.org/rfc/rfc8693.html#section-2.2.1
+func (h *TokenExchangeGrantHandler) PopulateTokenEndpointResponse(ctx context.Context, requester fosite.AccessRequester, responder fosite.AccessResponder) (err error) {
+	if !h.CanHandleTokenEndpointRequest(requester) {
+		return errorsx.WithStack(fosite.ErrUnknownRequest)
+	}
+
+	var token, signature string
+
+	if token, signature, err = h.AccessTokenStrategy.GenerateAccessToken(ctx, requester); err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	if err = h.store.CreateAccessTokenSession(ctx, signature, requester.Sanitize([]string{})); err != nil {
+		return errorsx.WithStack(fosite.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+	}
+
+	responder.SetAccessToken(token)
+	responder.SetTokenType("bearer")
+	responder.SetExpiresIn(time.Until(requester.GetSession().GetExpiresAt(fosite.AccessToken)))
+	responder.SetScopes(requester.GetGrantedScopes())
+	responder.SetExtra("issued_token_type", TokenTypeAccessToken)
+
+	return nil
+}
+
+// CanSkipClientAuth implements fosite.TokenEndpointHandler.
+func (h *TokenExchangeGrantHandler) CanSkipClientAuth(_ fosite.AccessRequester) bool {
+	return false
+}
+
+// CanHandleTokenEndpointRequest implements fosite.TokenEndpointHandler.
+func (h *TokenExchangeGrantHandler) CanHandleTokenEndpointRequest(requester fosite.AccessRequester) bool {
+	return requester.GetGrantTypes().ExactOne(GrantTypeTokenExchange)
+}
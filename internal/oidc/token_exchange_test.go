@@ -0,0 +1,359 @@
+package oidc
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestOpenIDConnectProvider_TokenExchangeGrant(t *testing.T) {
+	provider, store := newTestTokenExchangeProvider(t)
+
+	ctx := context.Background()
+
+	subject := newTestTokenExchangeSubjectToken(t, provider, "a-client", "a-client-secret")
+
+	requester, err := provider.NewAccessRequest(ctx, newTestTokenExchangeHTTPRequest("b-client", "b-client-secret", url.Values{
+		FormParameterSubjectToken:     []string{subject},
+		FormParameterSubjectTokenType: []string{TokenTypeAccessToken},
+		"audience":                    []string{"https://api.example.com"},
+		FormParameterScope:            []string{ScopeProfile},
+	}), NewSession())
+
+	require.NoError(t, err)
+	assert.Equal(t, "b-client", requester.GetClient().GetID())
+	assert.Equal(t, fosite.Arguments{ScopeProfile}, requester.GetGrantedScopes())
+	assert.Equal(t, fosite.Arguments{"https://api.example.com"}, requester.GetGrantedAudience())
+
+	responder, err := provider.NewAccessResponse(ctx, requester)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, responder.GetAccessToken())
+	assert.NotEqual(t, subject, responder.GetAccessToken())
+	assert.Equal(t, TokenTypeAccessToken, responder.GetExtra("issued_token_type"))
+	assert.Nil(t, responder.GetExtra("refresh_token"))
+
+	var session *model.OAuth2Session
+
+	for _, s := range store.sessions {
+		if s.ClientID == "b-client" {
+			s := s
+
+			session = &s
+		}
+	}
+
+	require.NotNil(t, session)
+	assert.Equal(t, "b-client", session.ClientID)
+	assert.Equal(t, model.StringSlicePipeDelimited{ScopeProfile}, session.GrantedScopes)
+	assert.NotContains(t, session.Form, FormParameterSubjectToken)
+	assert.NotContains(t, session.Form, FormParameterClientSecret)
+}
+
+func TestOpenIDConnectProvider_TokenExchangeGrantDefaultScopes(t *testing.T) {
+	provider, _ := newTestTokenExchangeProvider(t)
+
+	subject := newTestTokenExchangeSubjectToken(t, provider, "a-client", "a-client-secret")
+
+	requester, err := provider.NewAccessRequest(context.Background(), newTestTokenExchangeHTTPRequest("b-client", "b-client-secret", url.Values{
+		FormParameterSubjectToken:     []string{subject},
+		FormParameterSubjectTokenType: []string{TokenTypeAccessToken},
+		"audience":                    []string{"https://api.example.com"},
+	}), NewSession())
+
+	require.NoError(t, err)
+	assert.Equal(t, fosite.Arguments{ScopeProfile}, requester.GetGrantedScopes())
+}
+
+func TestOpenIDConnectProvider_TokenExchangeGrantShouldFail(t *testing.T) {
+	provider, _ := newTestTokenExchangeProvider(t)
+
+	subject := newTestTokenExchangeSubjectToken(t, provider, "a-client", "a-client-secret")
+	other := newTestTokenExchangeSubjectToken(t, provider, "c-client", "c-client-secret")
+
+	testCases := []struct {
+		name   string
+		client string
+		form   url.Values
+		err    error
+		hint   string
+	}{
+		{
+			name:   "ShouldRejectClientWithoutGrantType",
+			client: "a-client",
+			form: url.Values{
+				FormParameterSubjectToken:     []string{subject},
+				FormParameterSubjectTokenType: []string{TokenTypeAccessToken},
+				"audience":                    []string{"https://api.example.com"},
+			},
+			err:  fosite.ErrUnauthorizedClient,
+			hint: "The OAuth 2.0 Client is not allowed to use the authorization grant 'urn:ietf:params:oauth:grant-type:token-exchange'.",
+		},
+		{
+			name:   "ShouldRejectMissingSubjectToken",
+			client: "b-client",
+			form: url.Values{
+				FormParameterSubjectTokenType: []string{TokenTypeAccessToken},
+				"audience":                    []string{"https://api.example.com"},
+			},
+			err:  fosite.ErrInvalidRequest,
+			hint: "The 'subject_token' parameter is required.",
+		},
+		{
+			name:   "ShouldRejectSubjectTokenType",
+			client: "b-client",
+			form: url.Values{
+				FormParameterSubjectToken:     []string{subject},
+				FormParameterSubjectTokenType: []string{"urn:ietf:params:oauth:token-type:id_token"},
+				"audience":                    []string{"https://api.example.com"},
+			},
+			err:  fosite.ErrInvalidRequest,
+			hint: "The 'subject_token_type' parameter value 'urn:ietf:params:oauth:token-type:id_token' is not supported.",
+		},
+		{
+			name:   "ShouldRejectActorToken",
+			client: "b-client",
+			form: url.Values{
+				FormParameterSubjectToken:     []string{subject},
+				FormParameterSubjectTokenType: []string{TokenTypeAccessToken},
+				FormParameterActorToken:       []string{subject},
+				"audience":                    []string{"https://api.example.com"},
+			},
+			err:  fosite.ErrInvalidRequest,
+			hint: "The 'actor_token' parameter is not supported.",
+		},
+		{
+			name:   "ShouldRejectRequestedTokenType",
+			client: "b-client",
+			form: url.Values{
+				FormParameterSubjectToken:       []string{subject},
+				FormParameterSubjectTokenType:   []string{TokenTypeAccessToken},
+				FormParameterRequestedTokenType: []string{"urn:ietf:params:oauth:token-type:refresh_token"},
+				"audience":                      []string{"https://api.example.com"},
+			},
+			err:  fosite.ErrInvalidRequest,
+			hint: "The 'requested_token_type' parameter value 'urn:ietf:params:oauth:token-type:refresh_token' is not supported.",
+		},
+		{
+			name:   "ShouldRejectUnknownSubjectToken",
+			client: "b-client",
+			form: url.Values{
+				FormParameterSubjectToken:     []string{"abc.123"},
+				FormParameterSubjectTokenType: []string{TokenTypeAccessToken},
+				"audience":                    []string{"https://api.example.com"},
+			},
+			err:  fosite.ErrInvalidGrant,
+			hint: "The 'subject_token' parameter is not a valid access token.",
+		},
+		{
+			name:   "ShouldRejectSubjectTokenForOtherClient",
+			client: "b-client",
+			form: url.Values{
+				FormParameterSubjectToken:     []string{other},
+				FormParameterSubjectTokenType: []string{TokenTypeAccessToken},
+				"audience":                    []string{"https://api.example.com"},
+			},
+			err:  fosite.ErrInvalidGrant,
+			hint: "The 'subject_token' parameter was not issued to or for this client.",
+		},
+		{
+			name:   "ShouldRejectMissingAudience",
+			client: "b-client",
+			form: url.Values{
+				FormParameterSubjectToken:     []string{subject},
+				FormParameterSubjectTokenType: []string{TokenTypeAccessToken},
+			},
+			err:  fosite.ErrInvalidRequest,
+			hint: "The 'audience' parameter is required.",
+		},
+		{
+			name:   "ShouldRejectAudienceNotInPolicy",
+			client: "b-client",
+			form: url.Values{
+				FormParameterSubjectToken:     []string{subject},
+				FormParameterSubjectTokenType: []string{TokenTypeAccessToken},
+				"audience":                    []string{"https://other.example.com"},
+			},
+			err:  ErrTokenExchangeInvalidTarget,
+			hint: "The OAuth 2.0 Client is not allowed to exchange a token for the audience 'https://other.example.com'.",
+		},
+		{
+			name:   "ShouldRejectScopeNotGrantedToSubjectToken",
+			client: "b-client",
+			form: url.Values{
+				FormParameterSubjectToken:     []string{subject},
+				FormParameterSubjectTokenType: []string{TokenTypeAccessToken},
+				"audience":                    []string{"https://api.example.com"},
+				FormParameterScope:            []string{ScopeGroups},
+			},
+			err:  fosite.ErrInvalidScope,
+			hint: "The scope 'groups' was not granted to the 'subject_token'.",
+		},
+		{
+			name:   "ShouldRejectScopeNotInPolicy",
+			client: "b-client",
+			form: url.Values{
+				FormParameterSubjectToken:     []string{subject},
+				FormParameterSubjectTokenType: []string{TokenTypeAccessToken},
+				"audience":                    []string{"https://api.example.com"},
+				FormParameterScope:            []string{ScopeEmail},
+			},
+			err:  fosite.ErrInvalidScope,
+			hint: "The OAuth 2.0 Client is not allowed to exchange a token for the scope 'email'.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := provider.NewAccessRequest(context.Background(), newTestTokenExchangeHTTPRequest(tc.client, tc.client+"-secret", tc.form), NewSession())
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.hint, fosite.ErrorToRFC6749Error(err).HintField)
+		})
+	}
+}
+
+func TestClient_IsTokenExchangeAllowed(t *testing.T) {
+	client := NewClient(schema.OpenIDConnectClientConfiguration{
+		TokenExchange: schema.OpenIDConnectClientTokenExchangeConfiguration{
+			Audience: []string{"https://api.example.com"},
+			Scopes:   []string{ScopeProfile},
+		},
+	})
+
+	assert.True(t, client.IsTokenExchangeAudienceAllowed("https://api.example.com"))
+	assert.False(t, client.IsTokenExchangeAudienceAllowed("https://other.example.com"))
+	assert.True(t, client.IsTokenExchangeScopeAllowed(ScopeProfile))
+	assert.False(t, client.IsTokenExchangeScopeAllowed(ScopeEmail))
+}
+
+func newTestTokenExchangeProvider(t *testing.T) (provider *OpenIDConnectProvider, store *testTokenExchangeStore) {
+	t.Helper()
+
+	store = &testTokenExchangeStore{sessions: map[string]model.OAuth2Session{}}
+
+	provider, err := NewOpenIDConnectProvider(&schema.OpenIDConnectConfiguration{
+		IssuerPrivateKey:    mustParseRSAPrivateKey(exampleIssuerPrivateKey),
+		HMACSecret:          "asbdhaaskmdlkamdklasmdlkams",
+		AccessTokenLifespan: time.Hour,
+		Clients: []schema.OpenIDConnectClientConfiguration{
+			{
+				ID:         "a-client",
+				Secret:     MustDecodeSecret("$plaintext$a-client-secret"),
+				Policy:     "one_factor",
+				Scopes:     []string{ScopeProfile, ScopeEmail},
+				Audience:   []string{"b-client"},
+				GrantTypes: []string{GrantTypeClientCredentials},
+			},
+			{
+				ID:         "b-client",
+				Secret:     MustDecodeSecret("$plaintext$b-client-secret"),
+				Policy:     "one_factor",
+				Scopes:     []string{ScopeProfile},
+				GrantTypes: []string{GrantTypeTokenExchange},
+				TokenExchange: schema.OpenIDConnectClientTokenExchangeConfiguration{
+					Audience: []string{"https://api.example.com"},
+					Scopes:   []string{ScopeProfile, ScopeGroups},
+				},
+			},
+			{
+				ID:         "c-client",
+				Secret:     MustDecodeSecret("$plaintext$c-client-secret"),
+				Policy:     "one_factor",
+				Scopes:     []string{ScopeProfile},
+				GrantTypes: []string{GrantTypeClientCredentials},
+			},
+		},
+	}, store)
+
+	require.NoError(t, err)
+
+	return provider, store
+}
+
+// newTestTokenExchangeSubjectToken issues an access token using the client_credentials grant with all of the scopes
+// and audience the client is permitted to request, which is used as the subject_token.
+func newTestTokenExchangeSubjectToken(t *testing.T, provider *OpenIDConnectProvider, id, secret string) string {
+	t.Helper()
+
+	ctx := context.Background()
+
+	client, err := provider.Store.GetFullClient(context.Background(), id)
+
+	require.NoError(t, err)
+
+	form := url.Values{
+		"grant_type":              []string{GrantTypeClientCredentials},
+		FormParameterClientID:     []string{id},
+		FormParameterClientSecret: []string{secret},
+		FormParameterScope:        []string{strings.Join(client.Scopes, " ")},
+		"audience":                []string{strings.Join(client.Audience, " ")},
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/api/oidc/token", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	requester, err := provider.NewAccessRequest(ctx, r, NewSession())
+
+	require.NoError(t, err)
+
+	for _, scope := range requester.GetRequestedScopes() {
+		requester.GrantScope(scope)
+	}
+
+	for _, audience := range requester.GetRequestedAudience() {
+		requester.GrantAudience(audience)
+	}
+
+	responder, err := provider.NewAccessResponse(ctx, requester)
+
+	require.NoError(t, err)
+
+	return responder.GetAccessToken()
+}
+
+func newTestTokenExchangeHTTPRequest(id, secret string, form url.Values) (r *http.Request) {
+	form.Set("grant_type", GrantTypeTokenExchange)
+	form.Set(FormParameterClientID, id)
+	form.Set(FormParameterClientSecret, secret)
+
+	r = httptest.NewRequest(http.MethodPost, "/api/oidc/token", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return r
+}
+
+type testTokenExchangeStore struct {
+	storage.Provider
+
+	sessions map[string]model.OAuth2Session
+}
+
+func (s *testTokenExchangeStore) SaveOAuth2Session(_ context.Context, _ storage.OAuth2SessionType, session model.OAuth2Session) (err error) {
+	s.sessions[session.Signature] = session
+
+	return nil
+}
+
+func (s *testTokenExchangeStore) LoadOAuth2Session(_ context.Context, _ storage.OAuth2SessionType, signature string) (session *model.OAuth2Session, err error) {
+	c, ok := s.sessions[signature]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+
+	return &c, nil
+}
@@ -1,17 +1,22 @@
 package oidc
 
 import (
+	"context"
+	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/go-crypt/crypt"
 	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
 	"github.com/ory/fosite/handler/openid"
 	"github.com/ory/fosite/token/jwt"
 	"github.com/ory/herodot"
 	jose "gopkg.in/square/go-jose.v2"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -33,7 +38,7 @@ func NewSession() (session *model.OpenIDSession) {
 }
 
 // NewSessionWithAuthorizeRequest uses details from an AuthorizeRequester to generate an OpenIDSession.
-func NewSessionWithAuthorizeRequest(issuer *url.URL, kid, username string, amr []string, extra map[string]any,
+func NewSessionWithAuthorizeRequest(issuer *url.URL, kid, alg, sid, username string, amr []string, extra map[string]any,
 	authTime time.Time, consent *model.OAuth2ConsentSession, requester fosite.AuthorizeRequester) (session *model.OpenIDSession) {
 	if extra == nil {
 		extra = map[string]any{}
@@ -56,6 +61,7 @@ func NewSessionWithAuthorizeRequest(issuer *url.URL, kid, username string, amr [
 			Headers: &jwt.Headers{
 				Extra: map[string]any{
 					JWTHeaderKeyIdentifier: kid,
+					JWTHeaderAlgorithm:     alg,
 				},
 			},
 			Subject:  consent.Subject.UUID.String(),
@@ -74,6 +80,10 @@ func NewSessionWithAuthorizeRequest(issuer *url.URL, kid, username string, amr [
 	session.Claims.Add(ClaimAuthorizedParty, session.ClientID)
 	session.Claims.Add(ClaimClientIdentifier, session.ClientID)
 
+	if sid != "" {
+		session.Claims.Add(ClaimSessionID, sid)
+	}
+
 	return session
 }
 
@@ -84,8 +94,138 @@ type OpenIDConnectProvider struct {
 	*Store
 
 	KeyManager *KeyManager
+	KeyRotator *KeyRotator
 
 	discovery OpenIDConnectWellKnownConfiguration
+
+	clientAuthentication         *ClientAuthenticationStrategy
+	clientAuthenticationStrategy fosite.ClientAuthenticationStrategy
+
+	responseModeHandler *JWTSecuredResponseModeHandler
+
+	mutualTLS *MutualTLSCertificateResolver
+
+	pushedAuthorizationEnforce         bool
+	pushedAuthorizationContextLifespan time.Duration
+
+	deviceCodeLifespan        time.Duration
+	deviceCodePollingInterval time.Duration
+
+	registration                   bool
+	registrationInitialAccessToken string
+
+	acrValues []AuthenticationContextClassReference
+
+	httpClient           *http.Client
+	restrictedHTTPClient *http.Client
+}
+
+// PushedAuthorizeResponse represents a RFC9126 OAuth 2.0 Pushed Authorization Response.
+//
+// RFC9126: https://www.rfc-editor.org/rfc/rfc9126.html#section-2.2
+type PushedAuthorizeResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  int    `json:"expires_in"`
+}
+
+// DeviceAuthorizeResponse represents a RFC8628 OAuth 2.0 Device Authorization Response.
+//
+// RFC8628: https://www.rfc-editor.org/rfc/rfc8628.html#section-3.2
+type DeviceAuthorizeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// ClientRegistrationMetadata represents the RFC7591 OAuth 2.0 Dynamic Client Registration client metadata supported by
+// Authelia. It's used as both the registration request and the persisted representation of a dynamically registered
+// client.
+//
+// RFC7591: https://www.rfc-editor.org/rfc/rfc7591.html#section-2
+type ClientRegistrationMetadata struct {
+	ClientName                   string              `json:"client_name,omitempty"`
+	RedirectURIs                 []string            `json:"redirect_uris,omitempty"`
+	PostLogoutRedirectURIs       []string            `json:"post_logout_redirect_uris,omitempty"`
+	BackChannelLogoutURI         string              `json:"backchannel_logout_uri,omitempty"`
+	TokenEndpointAuthMethod      string              `json:"token_endpoint_auth_method,omitempty"`
+	TokenEndpointAuthSigningAlg  string              `json:"token_endpoint_auth_signing_alg,omitempty"`
+	GrantTypes                   []string            `json:"grant_types,omitempty"`
+	ResponseTypes                []string            `json:"response_types,omitempty"`
+	Scope                        string              `json:"scope,omitempty"`
+	JSONWebKeysURI               string              `json:"jwks_uri,omitempty"`
+	JSONWebKeys                  *jose.JSONWebKeySet `json:"jwks,omitempty"`
+	IDTokenSignedResponseAlg     string              `json:"id_token_signed_response_alg,omitempty"`
+	AccessTokenSignedResponseAlg string              `json:"access_token_signed_response_alg,omitempty"`
+	UserinfoSignedResponseAlg    string              `json:"userinfo_signed_response_alg,omitempty"`
+	RequestURIs                  []string            `json:"request_uris,omitempty"`
+	RequestObjectSigningAlg      string              `json:"request_object_signing_alg,omitempty"`
+	RequireSignedRequestObject   bool                `json:"require_signed_request_object,omitempty"`
+	DPoPBoundAccessTokens        bool                `json:"dpop_bound_access_tokens,omitempty"`
+
+	TLSClientAuthSubjectDN                string `json:"tls_client_auth_subject_dn,omitempty"`
+	TLSClientAuthSANDNS                   string `json:"tls_client_auth_san_dns,omitempty"`
+	TLSClientAuthSANURI                   string `json:"tls_client_auth_san_uri,omitempty"`
+	TLSClientAuthSANIP                    string `json:"tls_client_auth_san_ip,omitempty"`
+	TLSClientAuthSANEmail                 string `json:"tls_client_auth_san_email,omitempty"`
+	TLSClientCertificateBoundAccessTokens bool   `json:"tls_client_certificate_bound_access_tokens,omitempty"`
+}
+
+// ClientRegistrationResponse represents a RFC7591 OAuth 2.0 Client Information Response and a RFC7592 OAuth 2.0 Client
+// Configuration Endpoint response. The client_secret is only included when it's issued as it's only stored as a digest.
+//
+// RFC7591: https://www.rfc-editor.org/rfc/rfc7591.html#section-3.2.1
+//
+// RFC7592: https://www.rfc-editor.org/rfc/rfc7592.html#section-3
+type ClientRegistrationResponse struct {
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64  `json:"client_id_issued_at"`
+	ClientSecretExpiresAt   *int64 `json:"client_secret_expires_at,omitempty"`
+	RegistrationAccessToken string `json:"registration_access_token"`
+	RegistrationClientURI   string `json:"registration_client_uri"`
+
+	ClientRegistrationMetadata
+}
+
+// ClientRegistrationUpdateRequest represents a RFC7592 OAuth 2.0 Client Update Request.
+//
+// RFC7592: https://www.rfc-editor.org/rfc/rfc7592.html#section-2.2
+type ClientRegistrationUpdateRequest struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret,omitempty"`
+
+	ClientRegistrationMetadata
+}
+
+// DeviceCodeGrantHandler is a fosite.TokenEndpointHandler which handles the RFC8628 OAuth 2.0 Device Authorization
+// Grant at the token endpoint.
+type DeviceCodeGrantHandler struct {
+	*openid.IDTokenHandleHelper
+
+	store *Store
+
+	AccessTokenStrategy  oauth2.AccessTokenStrategy
+	RefreshTokenStrategy oauth2.RefreshTokenStrategy
+
+	AccessTokenLifespan  time.Duration
+	RefreshTokenLifespan time.Duration
+	RefreshTokenScopes   []string
+
+	PollingInterval time.Duration
+}
+
+// TokenExchangeGrantHandler is a fosite.TokenEndpointHandler which handles the RFC8693 OAuth 2.0 Token Exchange grant at
+// the token endpoint. It exchanges an access token for a new access token with a downscoped set of scopes for another
+// audience in accordance with the policy of the client.
+type TokenExchangeGrantHandler struct {
+	store *Store
+
+	AccessTokenStrategy oauth2.AccessTokenStrategy
+
+	AccessTokenLifespan time.Duration
 }
 
 // Store is Authelia's internal representation of the fosite.Storage interface. It maps the following
@@ -96,6 +236,61 @@ type OpenIDConnectProvider struct {
 type Store struct {
 	provider storage.Provider
 	clients  map[string]*Client
+
+	registrationPolicy authorization.Level
+
+	claimsPolicies map[string]*ClaimsPolicy
+	scopes         map[string][]string
+
+	refreshTokenGracePeriod time.Duration
+}
+
+// ClaimsPolicy represents a schema.OpenIDConnectClaimsPolicy. The CustomClaims map the custom claim names to the user
+// attributes they're resolved from, and IDToken are the custom claims which are also included in the ID Token.
+type ClaimsPolicy struct {
+	IDToken      []string
+	CustomClaims map[string]string
+}
+
+// ClaimsRequests represents the OpenID Connect 1.0 claims request parameter which is used to request individual claims
+// be included in the ID Token or the UserInfo response.
+//
+// See: https://openid.net/specs/openid-connect-core-1_0.html#ClaimsParameter
+type ClaimsRequests struct {
+	IDToken  map[string]*ClaimRequest `json:"id_token,omitempty"`
+	UserInfo map[string]*ClaimRequest `json:"userinfo,omitempty"`
+}
+
+// ClaimRequest represents an individual claim request from the ClaimsRequests. A nil *ClaimRequest represents a
+// voluntary claim request with the default behaviour.
+type ClaimRequest struct {
+	Essential bool  `json:"essential,omitempty"`
+	Value     any   `json:"value,omitempty"`
+	Values    []any `json:"values,omitempty"`
+}
+
+// CustomClaim represents a custom claim which is released to a client.
+type CustomClaim struct {
+	Name      string
+	Attribute string
+	IDToken   bool
+}
+
+// ClientAuthenticationStrategy is Authelia's implementation of the fosite.ClientAuthenticationStrategy which in addition
+// to the standard client authentication methods supports the client_secret_jwt and private_key_jwt methods.
+type ClientAuthenticationStrategy struct {
+	store             *Store
+	fetcher           fosite.JWKSFetcherStrategy
+	registeredFetcher fosite.JWKSFetcherStrategy
+	hasher            fosite.Hasher
+	mutualTLS         *MutualTLSCertificateResolver
+}
+
+// issuerContext is a context.Context which is able to derive the issuer URL for the current request.
+type issuerContext interface {
+	context.Context
+
+	IssuerURL() (issuerURL *url.URL, err error)
 }
 
 // Client represents the client internally.
@@ -106,18 +301,66 @@ type Client struct {
 	SectorIdentifier string
 	Public           bool
 
-	Audience      []string
-	Scopes        []string
-	RedirectURIs  []string
-	GrantTypes    []string
-	ResponseTypes []string
-	ResponseModes []fosite.ResponseModeType
+	Audience               []string
+	Scopes                 []string
+	RedirectURIs           []string
+	PostLogoutRedirectURIs []string
+	BackChannelLogoutURI   string
+	GrantTypes             []string
+	ResponseTypes          []string
+	ResponseModes          []fosite.ResponseModeType
+
+	RequirePushedAuthorizationRequests bool
+
+	EnforcePKCE string
+
+	Lifespans schema.OpenIDConnectClientLifespans
+
+	ClientCredentials ClientClientCredentials
+	TokenExchange     ClientTokenExchange
+
+	IDTokenSignedResponseAlg     string
+	AccessTokenSignedResponseAlg string
+	UserinfoSigningAlgorithm     string
 
-	UserinfoSigningAlgorithm string
+	AuthorizationSignedResponseAlg string
 
-	Policy authorization.Level
+	TokenEndpointAuthMethod     string
+	TokenEndpointAuthSigningAlg string
+	JSONWebKeysURI              string
+	JSONWebKeys                 *jose.JSONWebKeySet
+
+	RequestURIs                []string
+	RequestObjectSigningAlg    string
+	RequireSignedRequestObject bool
+
+	DPoPBoundAccessTokens bool
+
+	TLSClientAuthSubjectDN                string
+	TLSClientAuthSANDNS                   string
+	TLSClientAuthSANURI                   string
+	TLSClientAuthSANIP                    string
+	TLSClientAuthSANEmail                 string
+	TLSClientCertificateBoundAccessTokens bool
+
+	Policy              authorization.Level
+	AuthorizationPolicy string
+	ClaimsPolicy        string
 
 	Consent ClientConsent
+
+	// Registered is true when the client was loaded from the storage provider, i.e. it was registered using the
+	// dynamic client registration endpoint or the CLI, rather than from the configuration file. The URIs of these
+	// clients are provided by parties other than the administrator so Authelia only requests them using a
+	// NewRestrictedHTTPClient.
+	Registered bool
+}
+
+// SessionClient represents an OpenID Connect 1.0 client a user session has been authenticated to and the subject the
+// client knows the user by.
+type SessionClient struct {
+	ClientID string
+	Subject  string
 }
 
 // NewClientConsent converts the schema.OpenIDConnectClientConsentConfig into a oidc.ClientConsent.
@@ -134,6 +377,21 @@ func NewClientConsent(mode string, duration *time.Duration) ClientConsent {
 	}
 }
 
+// ClientClientCredentials is the Client Credentials grant policy for a client. It describes the service account subject
+// of the tokens issued to the client, and the audiences and scopes the client is permitted to obtain a token for.
+type ClientClientCredentials struct {
+	Subject  string
+	Audience []string
+	Scopes   []string
+}
+
+// ClientTokenExchange is the RFC8693 OAuth 2.0 Token Exchange policy for a client. It describes the audiences and
+// scopes the client is permitted to exchange a subject token for.
+type ClientTokenExchange struct {
+	Audience []string
+	Scopes   []string
+}
+
 // ClientConsent is the consent configuration for a client.
 type ClientConsent struct {
 	Mode     ClientConsentMode
@@ -176,11 +434,21 @@ func (c ClientConsentMode) String() string {
 	}
 }
 
-// KeyManager keeps track of all of the active/inactive rsa keys and provides them to services requiring them.
+// KeyManager keeps track of all of the active/inactive keys and provides them to services requiring them.
 // It additionally allows us to add keys for the purpose of key rotation in the future.
 type KeyManager struct {
+	mu sync.RWMutex
+
 	jwk  *JWK
+	kids map[string]*JWK
+	algs map[string]*JWK
 	jwks *jose.JSONWebKeySet
+
+	keys   []*JWK
+	active *JWK
+
+	current *JWK
+	rotated []*JWK
 }
 
 // AdaptiveHasher implements the fosite.Hasher interface without an actual hashing algo.
@@ -192,7 +460,10 @@ type ConsentGetResponseBody struct {
 	ClientDescription string   `json:"client_description"`
 	Scopes            []string `json:"scopes"`
 	Audience          []string `json:"audience"`
+	Claims            []string `json:"claims"`
+	EssentialClaims   []string `json:"essential_claims"`
 	PreConfiguration  bool     `json:"pre_configuration"`
+	UserCode          string   `json:"user_code,omitempty"`
 }
 
 // ConsentPostRequestBody schema of the request body of the consent POST endpoint.
@@ -208,6 +479,66 @@ type ConsentPostResponseBody struct {
 	RedirectURI string `json:"redirect_uri"`
 }
 
+// EndSessionConfirmation represents an OpenID Connect 1.0 End Session Request which did not include an id_token_hint
+// and is awaiting the confirmation of the user.
+type EndSessionConfirmation struct {
+	ID                    string
+	ClientID              string
+	PostLogoutRedirectURI string
+	State                 string
+}
+
+// EndSessionConfirmationGetResponseBody schema of the response body of the end session confirmation GET endpoint.
+type EndSessionConfirmationGetResponseBody struct {
+	ClientID          string `json:"client_id"`
+	ClientDescription string `json:"client_description"`
+}
+
+// EndSessionConfirmationPostRequestBody schema of the request body of the end session confirmation POST endpoint.
+type EndSessionConfirmationPostRequestBody struct {
+	ID      string `json:"id"`
+	Confirm bool   `json:"confirm"`
+}
+
+// EndSessionConfirmationPostResponseBody schema of the response body of the end session confirmation POST endpoint.
+type EndSessionConfirmationPostResponseBody struct {
+	RedirectURI string `json:"redirect_uri"`
+}
+
+// UserConsentsClient schema of the consents of a client in the response body of the user consents GET endpoint.
+type UserConsentsClient struct {
+	ClientID          string                        `json:"client_id"`
+	ClientDescription string                        `json:"client_description"`
+	Consents          []UserConsent                 `json:"consents"`
+	PreConfigurations []UserConsentPreConfiguration `json:"pre_configurations"`
+}
+
+// UserConsent schema of a granted consent in the response body of the user consents GET endpoint.
+type UserConsent struct {
+	ID        int        `json:"id"`
+	Scopes    []string   `json:"scopes"`
+	Audience  []string   `json:"audience"`
+	GrantedAt *time.Time `json:"granted_at,omitempty"`
+}
+
+// UserConsentPreConfiguration schema of a pre-configured consent in the response body of the user consents GET
+// endpoint.
+type UserConsentPreConfiguration struct {
+	ID        int64      `json:"id"`
+	Scopes    []string   `json:"scopes"`
+	Audience  []string   `json:"audience"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// UserConsentsDeleteRequestBody schema of the request body of the user consents DELETE endpoint. All consents of the
+// client are revoked unless either the consent id or pre-configuration id is provided.
+type UserConsentsDeleteRequestBody struct {
+	ClientID           string `json:"client_id" valid:"required"`
+	ConsentID          *int   `json:"consent_id,omitempty"`
+	PreConfigurationID *int64 `json:"pre_configuration_id,omitempty"`
+}
+
 /*
 CommonDiscoveryOptions represents the discovery options used in both OAuth 2.0 and OpenID Connect.
 See Also:
@@ -547,6 +878,12 @@ type OpenIDConnectDiscoveryOptions struct {
 	*/
 	ClaimLocalesSupported []string `json:"claims_locales_supported,omitempty"`
 
+	/*
+		OPTIONAL. Boolean value specifying whether the OP supports use of the request parameter, with true indicating
+		support. If omitted, the default value is false.
+	*/
+	RequestParameterSupported bool `json:"request_parameter_supported"`
+
 	/*
 		OPTIONAL. Boolean value specifying whether the OP supports use of the request_uri parameter, with true indicating
 		support. If omitted, the default value is true.
@@ -607,17 +944,111 @@ type OpenIDConnectBackChannelLogoutDiscoveryOptions struct {
 	BackChannelLogoutSessionSupported bool `json:"backchannel_logout_session_supported"`
 }
 
+// OpenIDConnectRPInitiatedLogoutDiscoveryOptions represents the discovery options specific to
+// OpenID Connect RP-Initiated Logout 1.0 functionality.
+// See Also:
+//
+//	OpenID Connect RP-Initiated Logout: https://openid.net/specs/openid-connect-rpinitiated-1_0.html#OPMetadata
+type OpenIDConnectRPInitiatedLogoutDiscoveryOptions struct {
+	/*
+		REQUIRED. URL at the OP to which an RP can perform a redirect to request that the End-User be logged out at the
+		OP. This URL MUST use the https scheme and MAY contain port, path, and query parameter components.
+	*/
+	EndSessionEndpoint string `json:"end_session_endpoint,omitempty"`
+}
+
+// OAuth2PushedAuthorizationDiscoveryOptions represents the discovery options specific to
+// OAuth 2.0 Pushed Authorization Requests.
+// See Also:
+//
+//	OAuth 2.0 Pushed Authorization Requests: https://www.rfc-editor.org/rfc/rfc9126.html#section-5
+type OAuth2PushedAuthorizationDiscoveryOptions struct {
+	/*
+		The URL of the pushed authorization request endpoint at which a client can post an authorization request to
+		exchange for a "request_uri" value usable at the authorization server.
+	*/
+	PushedAuthorizationRequestEndpoint string `json:"pushed_authorization_request_endpoint,omitempty"`
+
+	/*
+		Boolean parameter indicating whether the authorization server accepts authorization request data only via PAR.
+		If omitted, the default value is "false".
+	*/
+	RequirePushedAuthorizationRequests bool `json:"require_pushed_authorization_requests"`
+}
+
+// OAuth2JWTSecuredAuthorizationResponseModeDiscoveryOptions represents the discovery options specific to JWT Secured
+// Authorization Response Mode for OAuth 2.0 (JARM).
+// See Also:
+//
+//	JARM: https://openid.net/specs/oauth-v2-jarm.html#name-authorization-server-metada
+type OAuth2JWTSecuredAuthorizationResponseModeDiscoveryOptions struct {
+	/*
+		OPTIONAL. JSON array containing a list of the JWS signing algorithms (alg values) supported by the
+		authorization endpoint to sign the response.
+	*/
+	AuthorizationSigningAlgValuesSupported []string `json:"authorization_signing_alg_values_supported,omitempty"`
+}
+
+// OAuth2DPoPDiscoveryOptions represents the discovery options specific to OAuth 2.0 Demonstrating Proof of Possession
+// (DPoP).
+// See Also:
+//
+//	RFC9449: https://www.rfc-editor.org/rfc/rfc9449.html#section-5.1
+type OAuth2DPoPDiscoveryOptions struct {
+	/*
+		OPTIONAL. JSON array containing a list of the JWS alg values supported by the authorization server for DPoP
+		proof JWTs.
+	*/
+	DPoPSigningAlgValuesSupported []string `json:"dpop_signing_alg_values_supported,omitempty"`
+}
+
+// OAuth2MutualTLSClientAuthenticationDiscoveryOptions represents the discovery options specific to OAuth 2.0 Mutual-TLS
+// Client Authentication and Certificate-Bound Access Tokens.
+// See Also:
+//
+//	RFC8705: https://www.rfc-editor.org/rfc/rfc8705.html#section-3.3
+type OAuth2MutualTLSClientAuthenticationDiscoveryOptions struct {
+	/*
+		OPTIONAL. Boolean value indicating server support for mutual-TLS client certificate-bound access tokens. If
+		omitted, the default value is false.
+	*/
+	TLSClientCertificateBoundAccessTokens bool `json:"tls_client_certificate_bound_access_tokens"`
+}
+
+// OAuth2DeviceAuthorizationGrantDiscoveryOptions represents the discovery options specific to the OAuth 2.0 Device
+// Authorization Grant.
+// See Also:
+//
+//	OAuth 2.0 Device Authorization Grant: https://www.rfc-editor.org/rfc/rfc8628.html#section-4
+type OAuth2DeviceAuthorizationGrantDiscoveryOptions struct {
+	/*
+		URL of the authorization server's device authorization endpoint.
+	*/
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint,omitempty"`
+}
+
 // OAuth2WellKnownConfiguration represents the well known discovery document specific to OAuth 2.0.
 type OAuth2WellKnownConfiguration struct {
 	CommonDiscoveryOptions
 	OAuth2DiscoveryOptions
+	OAuth2PushedAuthorizationDiscoveryOptions
+	OAuth2DeviceAuthorizationGrantDiscoveryOptions
+	OAuth2JWTSecuredAuthorizationResponseModeDiscoveryOptions
+	OAuth2DPoPDiscoveryOptions
+	OAuth2MutualTLSClientAuthenticationDiscoveryOptions
 }
 
 // OpenIDConnectWellKnownConfiguration represents the well known discovery document specific to OpenID Connect.
 type OpenIDConnectWellKnownConfiguration struct {
 	CommonDiscoveryOptions
 	OAuth2DiscoveryOptions
+	OAuth2PushedAuthorizationDiscoveryOptions
+	OAuth2DeviceAuthorizationGrantDiscoveryOptions
+	OAuth2JWTSecuredAuthorizationResponseModeDiscoveryOptions
+	OAuth2DPoPDiscoveryOptions
+	OAuth2MutualTLSClientAuthenticationDiscoveryOptions
 	OpenIDConnectDiscoveryOptions
 	OpenIDConnectFrontChannelLogoutDiscoveryOptions
 	OpenIDConnectBackChannelLogoutDiscoveryOptions
+	OpenIDConnectRPInitiatedLogoutDiscoveryOptions
 }
@@ -59,7 +59,7 @@ func TestNewSessionWithAuthorizeRequest(t *testing.T) {
 		Subject:     uuid.NullUUID{UUID: subject, Valid: true},
 	}
 
-	session := NewSessionWithAuthorizeRequest(MustParseRequestURI(issuer), "primary", "john", amr, extra, authAt, consent, request)
+	session := NewSessionWithAuthorizeRequest(MustParseRequestURI(issuer), "primary", SigningAlgorithmRSAWithSHA256, "b7d3d6a2-8e27-4a8a-9f8a-4f1c1a3b5e62", "john", amr, extra, authAt, consent, request)
 
 	require.NotNil(t, session)
 	require.NotNil(t, session.Extra)
@@ -81,19 +81,22 @@ func TestNewSessionWithAuthorizeRequest(t *testing.T) {
 	assert.Equal(t, requested, session.Claims.RequestedAt)
 	assert.Equal(t, issuer, session.Claims.Issuer)
 	assert.Equal(t, "john", session.Claims.Extra[ClaimPreferredUsername])
+	assert.Equal(t, "b7d3d6a2-8e27-4a8a-9f8a-4f1c1a3b5e62", session.Claims.Extra[ClaimSessionID])
 
 	assert.Equal(t, "primary", session.Headers.Get(JWTHeaderKeyIdentifier))
+	assert.Equal(t, SigningAlgorithmRSAWithSHA256, session.Headers.Get(JWTHeaderAlgorithm))
 
 	consent = &model.OAuth2ConsentSession{
 		ChallengeID: uuid.New(),
 		RequestedAt: requested,
 	}
 
-	session = NewSessionWithAuthorizeRequest(MustParseRequestURI(issuer), "primary", "john", nil, nil, authAt, consent, request)
+	session = NewSessionWithAuthorizeRequest(MustParseRequestURI(issuer), "primary", SigningAlgorithmRSAWithSHA256, "", "john", nil, nil, authAt, consent, request)
 
 	require.NotNil(t, session)
 	require.NotNil(t, session.Claims)
 	assert.NotNil(t, session.Claims.Extra)
+	assert.NotContains(t, session.Claims.Extra, ClaimSessionID)
 	assert.Nil(t, session.Claims.AuthenticationMethodsReferences)
 }
 
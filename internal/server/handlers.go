@@ -245,6 +245,12 @@ func handleRouter(config schema.Configuration, providers middlewares.Providers)
 		r.GET("/api/oidc/consent", middlewareOIDC(handlers.OpenIDConnectConsentGET))
 		r.POST("/api/oidc/consent", middlewareOIDC(handlers.OpenIDConnectConsentPOST))
 
+		r.GET("/api/oidc/end-session/confirmation", middlewareOIDC(handlers.OpenIDConnectEndSessionConfirmationGET))
+		r.POST("/api/oidc/end-session/confirmation", middlewareOIDC(handlers.OpenIDConnectEndSessionConfirmationPOST))
+
+		r.GET("/api/user/oidc/consents", middleware1FA(handlers.UserOpenIDConnectConsentsGET))
+		r.DELETE("/api/user/oidc/consents", middleware1FA(handlers.UserOpenIDConnectConsentsDELETE))
+
 		allowedOrigins := utils.StringSliceFromURLs(config.IdentityProviders.OIDC.CORS.AllowedOrigins)
 
 		r.OPTIONS(oidc.EndpointPathWellKnownOpenIDConfiguration, policyCORSPublicGET.HandleOPTIONS)
@@ -283,6 +289,28 @@ func handleRouter(config schema.Configuration, providers middlewares.Providers)
 		r.OPTIONS(oidc.EndpointPathToken, policyCORSToken.HandleOPTIONS)
 		r.POST(oidc.EndpointPathToken, policyCORSToken.Middleware(middlewareOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectTokenPOST))))
 
+		policyCORSPAR := middlewares.NewCORSPolicyBuilder().
+			WithAllowCredentials(true).
+			WithAllowedMethods("OPTIONS", "POST").
+			WithAllowedOrigins(allowedOrigins...).
+			WithEnabled(utils.IsStringInSlice(oidc.EndpointPushedAuthorizationRequest, config.IdentityProviders.OIDC.CORS.Endpoints)).
+			Build()
+
+		r.OPTIONS(oidc.EndpointPathPushedAuthorizationRequest, policyCORSPAR.HandleOPTIONS)
+		r.POST(oidc.EndpointPathPushedAuthorizationRequest, policyCORSPAR.Middleware(middlewareOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectPushedAuthorizationRequest))))
+
+		policyCORSDeviceAuthorization := middlewares.NewCORSPolicyBuilder().
+			WithAllowCredentials(true).
+			WithAllowedMethods("OPTIONS", "POST").
+			WithAllowedOrigins(allowedOrigins...).
+			WithEnabled(utils.IsStringInSlice(oidc.EndpointDeviceAuthorization, config.IdentityProviders.OIDC.CORS.Endpoints)).
+			Build()
+
+		r.OPTIONS(oidc.EndpointPathDeviceAuthorization, policyCORSDeviceAuthorization.HandleOPTIONS)
+		r.POST(oidc.EndpointPathDeviceAuthorization, policyCORSDeviceAuthorization.Middleware(middlewareOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectDeviceAuthorizationPOST))))
+
+		r.GET(oidc.EndpointPathDeviceVerification, middlewareOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectDeviceVerificationGET)))
+
 		policyCORSUserinfo := middlewares.NewCORSPolicyBuilder().
 			WithAllowCredentials(true).
 			WithAllowedMethods("OPTIONS", "GET", "POST").
@@ -321,6 +349,28 @@ func handleRouter(config schema.Configuration, providers middlewares.Providers)
 		// TODO (james-d-elliott): Remove in GA. This is a legacy implementation of the above endpoint.
 		r.OPTIONS("/api/oidc/revoke", policyCORSRevocation.HandleOPTIONS)
 		r.POST("/api/oidc/revoke", policyCORSRevocation.Middleware(middlewareOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OAuthRevocationPOST))))
+
+		r.GET(oidc.EndpointPathEndSession, middlewareOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectEndSession)))
+		r.POST(oidc.EndpointPathEndSession, middlewareOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectEndSession)))
+
+		if config.IdentityProviders.OIDC.DynamicClientRegistration.Enable {
+			policyCORSRegistration := middlewares.NewCORSPolicyBuilder().
+				WithAllowCredentials(true).
+				WithAllowedMethods("OPTIONS", "GET", "POST", "PUT", "DELETE").
+				WithAllowedOrigins(allowedOrigins...).
+				WithEnabled(utils.IsStringInSlice(oidc.EndpointRegistration, config.IdentityProviders.OIDC.CORS.Endpoints)).
+				Build()
+
+			pathRegistrationClient := oidc.EndpointPathRegistration + "/{" + oidc.FormParameterClientID + "}"
+
+			r.OPTIONS(oidc.EndpointPathRegistration, policyCORSRegistration.HandleOPTIONS)
+			r.POST(oidc.EndpointPathRegistration, policyCORSRegistration.Middleware(middlewareOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectRegistrationPOST))))
+
+			r.OPTIONS(pathRegistrationClient, policyCORSRegistration.HandleOPTIONS)
+			r.GET(pathRegistrationClient, policyCORSRegistration.Middleware(middlewareOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectRegistrationClientGET))))
+			r.PUT(pathRegistrationClient, policyCORSRegistration.Middleware(middlewareOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectRegistrationClientPUT))))
+			r.DELETE(pathRegistrationClient, policyCORSRegistration.Middleware(middlewareOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectRegistrationClientDELETE))))
+		}
 	}
 
 	r.HandleMethodNotAllowed = true
@@ -36,6 +36,16 @@ type UserSession struct {
 
 	AuthenticationMethodRefs oidc.AuthenticationMethodsReferences
 
+	// OpenIDConnectSessionID is the identifier of this session used as the sid claim for OpenID Connect 1.0 clients.
+	OpenIDConnectSessionID string
+
+	// OpenIDConnectClients are the OpenID Connect 1.0 clients this session has been authenticated to.
+	OpenIDConnectClients []oidc.SessionClient
+
+	// OpenIDConnectEndSessionConfirmation is the OpenID Connect 1.0 End Session Request which did not include an
+	// id_token_hint and is awaiting the confirmation of the user in the portal.
+	OpenIDConnectEndSessionConfirmation *oidc.EndSessionConfirmation
+
 	// Webauthn holds the session registration data for this session.
 	Webauthn *webauthn.SessionData
 
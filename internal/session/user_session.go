@@ -4,8 +4,11 @@ import (
 	"errors"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/oidc"
 )
 
 // NewDefaultUserSession create a default user session.
@@ -76,3 +79,52 @@ func (s *UserSession) AuthenticatedTime(level authorization.Level) (authenticate
 		return time.Unix(0, 0), errors.New("invalid authorization level")
 	}
 }
+
+// GetOpenIDConnectSessionID returns the OpenID Connect 1.0 session identifier of this session, generating it if it has
+// not yet been generated.
+func (s *UserSession) GetOpenIDConnectSessionID() (sid string, err error) {
+	if s.OpenIDConnectSessionID == "" {
+		var id uuid.UUID
+
+		if id, err = uuid.NewRandom(); err != nil {
+			return "", err
+		}
+
+		s.OpenIDConnectSessionID = id.String()
+	}
+
+	return s.OpenIDConnectSessionID, nil
+}
+
+// AddOpenIDConnectClient records an OpenID Connect 1.0 client and the subject it knows the user by as having been
+// authenticated to by this session.
+func (s *UserSession) AddOpenIDConnectClient(clientID, subject string) {
+	for _, client := range s.OpenIDConnectClients {
+		if client.ClientID == clientID && client.Subject == subject {
+			return
+		}
+	}
+
+	s.OpenIDConnectClients = append(s.OpenIDConnectClients, oidc.SessionClient{ClientID: clientID, Subject: subject})
+}
+
+// IsOpenIDConnectEndSessionHintValid returns true if an ID Token issued to the provided client with the provided subject
+// and sid claims was issued for this session. When the sid claim is present it must match the session identifier,
+// otherwise the client must have been authenticated to by this session with the subject.
+func (s *UserSession) IsOpenIDConnectEndSessionHintValid(clientID, subject, sid string) bool {
+	if sid != "" {
+		return s.OpenIDConnectSessionID != "" && s.OpenIDConnectSessionID == sid
+	}
+
+	if subject == "" {
+		return false
+	}
+
+	for _, client := range s.OpenIDConnectClients {
+		if client.ClientID == clientID && client.Subject == subject {
+			return true
+		}
+	}
+
+	return false
+}
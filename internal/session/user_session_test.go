@@ -0,0 +1,25 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserSession_IsOpenIDConnectEndSessionHintValid(t *testing.T) {
+	userSession := &UserSession{OpenIDConnectSessionID: "sid"}
+
+	userSession.AddOpenIDConnectClient("app", "subject")
+
+	assert.True(t, userSession.IsOpenIDConnectEndSessionHintValid("app", "subject", "sid"))
+	assert.True(t, userSession.IsOpenIDConnectEndSessionHintValid("app", "subject", ""))
+	assert.True(t, userSession.IsOpenIDConnectEndSessionHintValid("other", "other", "sid"))
+	assert.False(t, userSession.IsOpenIDConnectEndSessionHintValid("app", "subject", "other"))
+	assert.False(t, userSession.IsOpenIDConnectEndSessionHintValid("app", "other", ""))
+	assert.False(t, userSession.IsOpenIDConnectEndSessionHintValid("other", "subject", ""))
+	assert.False(t, userSession.IsOpenIDConnectEndSessionHintValid("app", "", ""))
+
+	userSession = &UserSession{}
+
+	assert.False(t, userSession.IsOpenIDConnectEndSessionHintValid("app", "subject", "sid"))
+}
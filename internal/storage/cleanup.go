@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewCleaner creates a new Cleaner which purges the expired data from the provided Provider. The recorder is optional
+// and records the number of rows purged from each table when provided.
+func NewCleaner(config schema.StorageCleanupConfiguration, provider Provider, recorder CleanupMetricsRecorder) (cleaner *Cleaner) {
+	return &Cleaner{
+		config:   config,
+		provider: provider,
+		recorder: recorder,
+		clock:    utils.RealClock{},
+	}
+}
+
+// Cleaner periodically purges the expired OAuth2 sessions, consent sessions, blacklisted JTIs, device code sessions,
+// pushed authorization request contexts, back-channel logouts, identity verifications, and authentication logs from the
+// storage provider according to the configured retention.
+type Cleaner struct {
+	config schema.StorageCleanupConfiguration
+
+	provider Provider
+	recorder CleanupMetricsRecorder
+	clock    utils.Clock
+}
+
+// CleanupMetricsRecorder represents the methods used to record the storage cleanup.
+type CleanupMetricsRecorder interface {
+	RecordStorageCleanup(table string, purged int64)
+}
+
+// CleanupResult represents the number of rows purged from a table by the Cleaner.
+type CleanupResult struct {
+	Table  string
+	Purged int64
+}
+
+// Run performs the cleanup periodically until the context is done.
+func (c *Cleaner) Run(ctx context.Context) {
+	logger := logging.Logger()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.clock.After(c.config.Interval):
+			results, err := c.Cleanup(ctx)
+
+			for _, result := range results {
+				logger.Debugf("Storage cleanup purged %d rows from the '%s' table", result.Purged, result.Table)
+			}
+
+			if err != nil {
+				logger.WithError(err).Error("Error occurred purging the expired data from the storage")
+			}
+		}
+	}
+}
+
+// Cleanup purges the expired data from the storage provider once and returns the number of rows purged from each
+// table. The results of the tables purged before an error occurred are returned alongside the error.
+func (c *Cleaner) Cleanup(ctx context.Context) (results []CleanupResult, err error) {
+	now := c.clock.Now().UTC()
+	retention := c.config.Retention
+
+	sessions := now.Add(-retention.OAuth2Sessions)
+
+	purges := []struct {
+		table  string
+		before time.Time
+		purge  func(ctx context.Context, before time.Time) (purged int64, err error)
+	}{
+		{tableOAuth2AuthorizeCodeSession, sessions, c.purgeOAuth2Sessions(OAuth2SessionTypeAuthorizeCode)},
+		{tableOAuth2AccessTokenSession, sessions, c.purgeOAuth2Sessions(OAuth2SessionTypeAccessToken)},
+		{tableOAuth2RefreshTokenSession, sessions, c.purgeOAuth2Sessions(OAuth2SessionTypeRefreshToken)},
+		{tableOAuth2PKCERequestSession, sessions, c.purgeOAuth2Sessions(OAuth2SessionTypePKCEChallenge)},
+		{tableOAuth2OpenIDConnectSession, sessions, c.purgeOAuth2Sessions(OAuth2SessionTypeOpenIDConnect)},
+		{tableOAuth2ConsentSession, now.Add(-retention.OAuth2ConsentSessions), c.provider.PurgeOAuth2ConsentSessions},
+		{tableOAuth2BlacklistedJTI, now, c.provider.PurgeOAuth2BlacklistedJTIs},
+		{tableOAuth2DeviceCodeSession, now, c.provider.PurgeOAuth2DeviceCodeSessions},
+		{tableOAuth2PARContext, now, c.provider.PurgeOAuth2PARContexts},
+		{tableOAuth2BackChannelLogout, now.Add(-retention.OAuth2BackChannelLogouts), c.provider.PurgeOAuth2BackChannelLogouts},
+		{tableIdentityVerification, now.Add(-retention.IdentityVerifications), c.provider.PurgeIdentityVerifications},
+		{tableAuthenticationLogs, now.Add(-retention.AuthenticationLogs), c.provider.PurgeAuthenticationLogs},
+	}
+
+	var purged int64
+
+	for _, p := range purges {
+		if purged, err = p.purge(ctx, p.before); err != nil {
+			return results, err
+		}
+
+		if c.recorder != nil {
+			c.recorder.RecordStorageCleanup(p.table, purged)
+		}
+
+		results = append(results, CleanupResult{Table: p.table, Purged: purged})
+	}
+
+	return results, nil
+}
+
+func (c *Cleaner) purgeOAuth2Sessions(sessionType OAuth2SessionType) func(ctx context.Context, before time.Time) (purged int64, err error) {
+	return func(ctx context.Context, before time.Time) (purged int64, err error) {
+		return c.provider.PurgeOAuth2Sessions(ctx, sessionType, before)
+	}
+}
@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestCleaner_Cleanup(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+
+	store := &testCleanupStore{purged: 2}
+	recorder := &testCleanupRecorder{}
+
+	cleaner := NewCleaner(schema.DefaultStorageConfiguration.Cleanup, store, recorder)
+	cleaner.clock = &testCleanupClock{now: now}
+
+	results, err := cleaner.Cleanup(context.Background())
+	require.NoError(t, err)
+
+	retention := schema.DefaultStorageConfiguration.Cleanup.Retention
+
+	expected := map[string]time.Time{
+		tableOAuth2AuthorizeCodeSession: now.Add(-retention.OAuth2Sessions),
+		tableOAuth2AccessTokenSession:   now.Add(-retention.OAuth2Sessions),
+		tableOAuth2RefreshTokenSession:  now.Add(-retention.OAuth2Sessions),
+		tableOAuth2PKCERequestSession:   now.Add(-retention.OAuth2Sessions),
+		tableOAuth2OpenIDConnectSession: now.Add(-retention.OAuth2Sessions),
+		tableOAuth2ConsentSession:       now.Add(-retention.OAuth2ConsentSessions),
+		tableOAuth2BlacklistedJTI:       now,
+		tableOAuth2DeviceCodeSession:    now,
+		tableOAuth2PARContext:           now,
+		tableOAuth2BackChannelLogout:    now.Add(-retention.OAuth2BackChannelLogouts),
+		tableIdentityVerification:       now.Add(-retention.IdentityVerifications),
+		tableAuthenticationLogs:         now.Add(-retention.AuthenticationLogs),
+	}
+
+	assert.Equal(t, expected, store.before)
+	assert.Len(t, results, len(expected))
+
+	for _, result := range results {
+		assert.Equal(t, int64(2), result.Purged)
+		assert.Equal(t, int64(2), recorder.purged[result.Table])
+	}
+}
+
+func TestCleaner_CleanupShouldReturnStorageErrors(t *testing.T) {
+	store := &testCleanupStore{err: errors.New("bad conn")}
+
+	cleaner := NewCleaner(schema.DefaultStorageConfiguration.Cleanup, store, nil)
+
+	results, err := cleaner.Cleanup(context.Background())
+
+	assert.EqualError(t, err, "bad conn")
+	assert.Len(t, results, 0)
+}
+
+type testCleanupStore struct {
+	Provider
+
+	before map[string]time.Time
+	purged int64
+	err    error
+}
+
+func (s *testCleanupStore) record(table string, before time.Time) (purged int64, err error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	if s.before == nil {
+		s.before = map[string]time.Time{}
+	}
+
+	s.before[table] = before
+
+	return s.purged, nil
+}
+
+func (s *testCleanupStore) PurgeOAuth2Sessions(_ context.Context, sessionType OAuth2SessionType, before time.Time) (purged int64, err error) {
+	switch sessionType {
+	case OAuth2SessionTypeAuthorizeCode:
+		return s.record(tableOAuth2AuthorizeCodeSession, before)
+	case OAuth2SessionTypeAccessToken:
+		return s.record(tableOAuth2AccessTokenSession, before)
+	case OAuth2SessionTypeRefreshToken:
+		return s.record(tableOAuth2RefreshTokenSession, before)
+	case OAuth2SessionTypePKCEChallenge:
+		return s.record(tableOAuth2PKCERequestSession, before)
+	default:
+		return s.record(tableOAuth2OpenIDConnectSession, before)
+	}
+}
+
+func (s *testCleanupStore) PurgeOAuth2ConsentSessions(_ context.Context, before time.Time) (purged int64, err error) {
+	return s.record(tableOAuth2ConsentSession, before)
+}
+
+func (s *testCleanupStore) PurgeOAuth2BlacklistedJTIs(_ context.Context, before time.Time) (purged int64, err error) {
+	return s.record(tableOAuth2BlacklistedJTI, before)
+}
+
+func (s *testCleanupStore) PurgeOAuth2DeviceCodeSessions(_ context.Context, before time.Time) (purged int64, err error) {
+	return s.record(tableOAuth2DeviceCodeSession, before)
+}
+
+func (s *testCleanupStore) PurgeOAuth2PARContexts(_ context.Context, before time.Time) (purged int64, err error) {
+	return s.record(tableOAuth2PARContext, before)
+}
+
+func (s *testCleanupStore) PurgeOAuth2BackChannelLogouts(_ context.Context, before time.Time) (purged int64, err error) {
+	return s.record(tableOAuth2BackChannelLogout, before)
+}
+
+func (s *testCleanupStore) PurgeIdentityVerifications(_ context.Context, before time.Time) (purged int64, err error) {
+	return s.record(tableIdentityVerification, before)
+}
+
+func (s *testCleanupStore) PurgeAuthenticationLogs(_ context.Context, before time.Time) (purged int64, err error) {
+	return s.record(tableAuthenticationLogs, before)
+}
+
+type testCleanupRecorder struct {
+	purged map[string]int64
+}
+
+func (r *testCleanupRecorder) RecordStorageCleanup(table string, purged int64) {
+	if r.purged == nil {
+		r.purged = map[string]int64{}
+	}
+
+	r.purged[table] += purged
+}
+
+type testCleanupClock struct {
+	now time.Time
+}
+
+func (c *testCleanupClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testCleanupClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
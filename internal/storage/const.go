@@ -21,6 +21,11 @@ const (
 	tableOAuth2PKCERequestSession      = "oauth2_pkce_request_session"
 	tableOAuth2OpenIDConnectSession    = "oauth2_openid_connect_session"
 	tableOAuth2BlacklistedJTI          = "oauth2_blacklisted_jti"
+	tableOAuth2BackChannelLogout       = "oauth2_backchannel_logout"
+	tableOAuth2IssuerKey               = "oauth2_issuer_key"
+	tableOAuth2PARContext              = "oauth2_par_context"
+	tableOAuth2DeviceCodeSession       = "oauth2_device_code_session"
+	tableOAuth2Client                  = "oauth2_client"
 
 	tableMigrations = "migrations"
 	tableEncryption = "encryption"
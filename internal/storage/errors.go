@@ -17,6 +17,12 @@ var (
 	// ErrNoDuoDevice error thrown when no Duo device and method has been found in DB.
 	ErrNoDuoDevice = errors.New("no Duo device and method saved")
 
+	// ErrNoApprovedOAuth2DeviceCodeSession error thrown when no approved OAuth2 device code session could be consumed.
+	ErrNoApprovedOAuth2DeviceCodeSession = errors.New("no approved oauth2 device code session found")
+
+	// ErrNoPendingOAuth2DeviceCodeSession error thrown when no pending OAuth2 device code session could be updated.
+	ErrNoPendingOAuth2DeviceCodeSession = errors.New("no pending oauth2 device code session found")
+
 	// ErrNoAvailableMigrations is returned when no available migrations can be found.
 	ErrNoAvailableMigrations = errors.New("no available migrations")
 
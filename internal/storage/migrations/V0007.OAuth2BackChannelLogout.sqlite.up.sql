@@ -0,0 +1,20 @@
+CREATE TABLE oauth2_backchannel_logout (
+    id INTEGER,
+    jti CHAR(36) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    subject CHAR(36) NOT NULL,
+    session_id CHAR(36) NOT NULL,
+    uri TEXT NOT NULL,
+    logout_token TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempted_at TIMESTAMP NULL DEFAULT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    delivered BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (id),
+    CONSTRAINT oauth2_backchannel_logout_subject_fkey
+        FOREIGN KEY(subject)
+            REFERENCES user_opaque_identifier(identifier) ON UPDATE RESTRICT ON DELETE RESTRICT
+);
+
+CREATE UNIQUE INDEX oauth2_backchannel_logout_jti_key ON oauth2_backchannel_logout (jti);
+CREATE INDEX oauth2_backchannel_logout_delivered_idx ON oauth2_backchannel_logout (delivered);
@@ -0,0 +1,13 @@
+CREATE TABLE oauth2_issuer_key (
+    id SERIAL,
+    kid VARCHAR(100) NOT NULL,
+    algorithm VARCHAR(10) NOT NULL,
+    private_key BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    not_before TIMESTAMP WITH TIME ZONE NOT NULL,
+    not_after TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX oauth2_issuer_key_kid_key ON oauth2_issuer_key (kid);
+CREATE UNIQUE INDEX oauth2_issuer_key_algorithm_not_before_key ON oauth2_issuer_key (algorithm, not_before);
@@ -0,0 +1,12 @@
+CREATE TABLE oauth2_par_context (
+    id INTEGER,
+    signature VARCHAR(255) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    form_data TEXT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX oauth2_par_context_signature_key ON oauth2_par_context (signature);
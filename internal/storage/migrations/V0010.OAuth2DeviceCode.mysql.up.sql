@@ -0,0 +1,31 @@
+CREATE TABLE oauth2_device_code_session (
+    id INTEGER AUTO_INCREMENT,
+    challenge_id CHAR(36) NULL DEFAULT NULL,
+    request_id VARCHAR(40) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    signature VARCHAR(255) NOT NULL,
+    user_code_signature VARCHAR(255) NOT NULL,
+    status INTEGER NOT NULL DEFAULT 0,
+    subject CHAR(36) NULL DEFAULT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checked_at TIMESTAMP NULL DEFAULT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    requested_scopes TEXT NOT NULL,
+    granted_scopes TEXT NOT NULL,
+    requested_audience TEXT NULL,
+    granted_audience TEXT NULL,
+    form_data TEXT NOT NULL,
+    session_data BLOB NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT oauth2_device_code_session_challenge_id_fkey
+        FOREIGN KEY(challenge_id)
+            REFERENCES oauth2_consent_session(challenge_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT oauth2_device_code_session_subject_fkey
+        FOREIGN KEY(subject)
+            REFERENCES user_opaque_identifier(identifier) ON UPDATE RESTRICT ON DELETE RESTRICT
+);
+
+CREATE UNIQUE INDEX oauth2_device_code_session_signature_key ON oauth2_device_code_session (signature);
+CREATE UNIQUE INDEX oauth2_device_code_session_user_code_signature_key ON oauth2_device_code_session (user_code_signature);
+CREATE INDEX oauth2_device_code_session_request_id_idx ON oauth2_device_code_session (request_id);
+CREATE INDEX oauth2_device_code_session_client_id_idx ON oauth2_device_code_session (client_id);
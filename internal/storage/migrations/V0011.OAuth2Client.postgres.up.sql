@@ -0,0 +1,13 @@
+CREATE TABLE oauth2_client (
+    id SERIAL,
+    client_id VARCHAR(255) NOT NULL,
+    client_secret TEXT NULL DEFAULT NULL,
+    registration_access_token_signature VARCHAR(255) NOT NULL,
+    authorization_policy VARCHAR(25) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    metadata TEXT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX oauth2_client_client_id_key ON oauth2_client (client_id);
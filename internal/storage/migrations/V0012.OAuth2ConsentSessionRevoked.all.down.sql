@@ -0,0 +1 @@
+ALTER TABLE oauth2_consent_session DROP COLUMN revoked;
@@ -0,0 +1 @@
+ALTER TABLE oauth2_consent_session ADD COLUMN revoked BOOLEAN NOT NULL DEFAULT FALSE;
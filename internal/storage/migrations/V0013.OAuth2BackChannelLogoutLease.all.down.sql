@@ -0,0 +1,2 @@
+ALTER TABLE oauth2_backchannel_logout DROP COLUMN lease_expires_at;
+ALTER TABLE oauth2_backchannel_logout DROP COLUMN issuer;
@@ -0,0 +1,2 @@
+ALTER TABLE oauth2_backchannel_logout ADD COLUMN issuer VARCHAR(512) NOT NULL DEFAULT '';
+ALTER TABLE oauth2_backchannel_logout ADD COLUMN lease_expires_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL;
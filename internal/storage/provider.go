@@ -31,6 +31,7 @@ type Provider interface {
 	SaveIdentityVerification(ctx context.Context, verification model.IdentityVerification) (err error)
 	ConsumeIdentityVerification(ctx context.Context, jti string, ip model.NullIP) (err error)
 	FindIdentityVerification(ctx context.Context, jti string) (found bool, err error)
+	PurgeIdentityVerifications(ctx context.Context, before time.Time) (purged int64, err error)
 
 	SaveTOTPConfiguration(ctx context.Context, config model.TOTPConfiguration) (err error)
 	UpdateTOTPConfigurationSignIn(ctx context.Context, id int, lastUsedAt sql.NullTime) (err error)
@@ -51,12 +52,17 @@ type Provider interface {
 
 	SaveOAuth2ConsentPreConfiguration(ctx context.Context, config model.OAuth2ConsentPreConfig) (insertedID int64, err error)
 	LoadOAuth2ConsentPreConfigurations(ctx context.Context, clientID string, subject uuid.UUID) (rows *ConsentPreConfigRows, err error)
+	LoadOAuth2ConsentPreConfigurationsByUsername(ctx context.Context, username string) (configs []model.OAuth2ConsentPreConfig, err error)
+	RevokeOAuth2ConsentPreConfiguration(ctx context.Context, id int64) (err error)
 
 	SaveOAuth2ConsentSession(ctx context.Context, consent model.OAuth2ConsentSession) (err error)
 	SaveOAuth2ConsentSessionSubject(ctx context.Context, consent model.OAuth2ConsentSession) (err error)
 	SaveOAuth2ConsentSessionResponse(ctx context.Context, consent model.OAuth2ConsentSession, rejection bool) (err error)
 	SaveOAuth2ConsentSessionGranted(ctx context.Context, id int) (err error)
 	LoadOAuth2ConsentSessionByChallengeID(ctx context.Context, challengeID uuid.UUID) (consent *model.OAuth2ConsentSession, err error)
+	LoadOAuth2ConsentSessionsByUsername(ctx context.Context, username string) (consents []model.OAuth2ConsentSession, err error)
+	RevokeOAuth2ConsentSession(ctx context.Context, id int) (err error)
+	PurgeOAuth2ConsentSessions(ctx context.Context, before time.Time) (purged int64, err error)
 
 	SaveOAuth2Session(ctx context.Context, sessionType OAuth2SessionType, session model.OAuth2Session) (err error)
 	RevokeOAuth2Session(ctx context.Context, sessionType OAuth2SessionType, signature string) (err error)
@@ -64,9 +70,47 @@ type Provider interface {
 	DeactivateOAuth2Session(ctx context.Context, sessionType OAuth2SessionType, signature string) (err error)
 	DeactivateOAuth2SessionByRequestID(ctx context.Context, sessionType OAuth2SessionType, requestID string) (err error)
 	LoadOAuth2Session(ctx context.Context, sessionType OAuth2SessionType, signature string) (session *model.OAuth2Session, err error)
+	LoadOAuth2SessionRequestIDsByChallengeID(ctx context.Context, sessionType OAuth2SessionType, challengeID uuid.UUID) (requestIDs []string, err error)
+	LoadOAuth2RefreshTokenSessionRotatedAt(ctx context.Context, requestID string, requestedAt time.Time) (rotatedAt time.Time, err error)
+	PurgeOAuth2Sessions(ctx context.Context, sessionType OAuth2SessionType, before time.Time) (purged int64, err error)
 
 	SaveOAuth2BlacklistedJTI(ctx context.Context, blacklistedJTI model.OAuth2BlacklistedJTI) (err error)
 	LoadOAuth2BlacklistedJTI(ctx context.Context, signature string) (blacklistedJTI *model.OAuth2BlacklistedJTI, err error)
+	PurgeOAuth2BlacklistedJTIs(ctx context.Context, before time.Time) (purged int64, err error)
+
+	SaveOAuth2BackChannelLogout(ctx context.Context, logout model.OAuth2BackChannelLogout) (err error)
+	UpdateOAuth2BackChannelLogoutAttempt(ctx context.Context, jti string, delivered bool) (err error)
+	LoadOAuth2BackChannelLogoutsPending(ctx context.Context, attempts int) (logouts []model.OAuth2BackChannelLogout, err error)
+	UpdateOAuth2BackChannelLogoutLease(ctx context.Context, jti string, attempts int, now, until time.Time) (claimed bool, err error)
+	PurgeOAuth2BackChannelLogouts(ctx context.Context, before time.Time) (purged int64, err error)
+
+	SaveOAuth2IssuerKey(ctx context.Context, key model.OAuth2IssuerKey) (err error)
+	LoadOAuth2IssuerKeys(ctx context.Context) (keys []model.OAuth2IssuerKey, err error)
+	DeleteOAuth2IssuerKey(ctx context.Context, kid string) (err error)
+
+	SaveOAuth2PARContext(ctx context.Context, par model.OAuth2PARContext) (err error)
+	LoadOAuth2PARContext(ctx context.Context, signature string) (par *model.OAuth2PARContext, err error)
+	RevokeOAuth2PARContext(ctx context.Context, signature string) (err error)
+	PurgeOAuth2PARContexts(ctx context.Context, before time.Time) (purged int64, err error)
+
+	SaveOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error)
+	UpdateOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error)
+	UpdateOAuth2DeviceCodeSessionStatus(ctx context.Context, signature string, status model.OAuth2DeviceCodeStatus) (err error)
+	UpdateOAuth2DeviceCodeSessionChallengeID(ctx context.Context, signature string, challengeID uuid.UUID) (err error)
+	ConsumeOAuth2DeviceCodeSession(ctx context.Context, signature string) (err error)
+	UpdateOAuth2DeviceCodeSessionCheckedAt(ctx context.Context, signature string, checkedAt time.Time) (err error)
+	LoadOAuth2DeviceCodeSession(ctx context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error)
+	LoadOAuth2DeviceCodeSessionByUserCode(ctx context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error)
+	LoadOAuth2DeviceCodeSessionByChallengeID(ctx context.Context, challengeID uuid.UUID) (session *model.OAuth2DeviceCodeSession, err error)
+	PurgeOAuth2DeviceCodeSessions(ctx context.Context, before time.Time) (purged int64, err error)
+
+	SaveOAuth2Client(ctx context.Context, client model.OAuth2Client) (err error)
+	UpdateOAuth2Client(ctx context.Context, client model.OAuth2Client) (err error)
+	LoadOAuth2Client(ctx context.Context, clientID string) (client *model.OAuth2Client, err error)
+	LoadOAuth2Clients(ctx context.Context, limit, page int) (clients []model.OAuth2Client, err error)
+	DeleteOAuth2Client(ctx context.Context, clientID string) (err error)
+
+	PurgeAuthenticationLogs(ctx context.Context, before time.Time) (purged int64, err error)
 
 	SchemaTables(ctx context.Context) (tables []string, err error)
 	SchemaVersion(ctx context.Context) (version int, err error)
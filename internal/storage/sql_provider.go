@@ -33,10 +33,12 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 
 		sqlInsertAuthenticationAttempt:            fmt.Sprintf(queryFmtInsertAuthenticationLogEntry, tableAuthenticationLogs),
 		sqlSelectAuthenticationAttemptsByUsername: fmt.Sprintf(queryFmtSelect1FAAuthenticationLogEntryByUsername, tableAuthenticationLogs),
+		sqlPurgeAuthenticationAttempts:            fmt.Sprintf(queryFmtPurgeAuthenticationLogEntries, tableAuthenticationLogs),
 
 		sqlInsertIdentityVerification:  fmt.Sprintf(queryFmtInsertIdentityVerification, tableIdentityVerification),
 		sqlConsumeIdentityVerification: fmt.Sprintf(queryFmtConsumeIdentityVerification, tableIdentityVerification),
 		sqlSelectIdentityVerification:  fmt.Sprintf(queryFmtSelectIdentityVerification, tableIdentityVerification),
+		sqlPurgeIdentityVerifications:  fmt.Sprintf(queryFmtPurgeIdentityVerifications, tableIdentityVerification),
 
 		sqlUpsertTOTPConfig:  fmt.Sprintf(queryFmtUpsertTOTPConfiguration, tableTOTPConfigurations),
 		sqlDeleteTOTPConfig:  fmt.Sprintf(queryFmtDeleteTOTPConfiguration, tableTOTPConfigurations),
@@ -74,52 +76,102 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlSelectUserOpaqueIdentifiers:           fmt.Sprintf(queryFmtSelectUserOpaqueIdentifiers, tableUserOpaqueIdentifier),
 		sqlSelectUserOpaqueIdentifierBySignature: fmt.Sprintf(queryFmtSelectUserOpaqueIdentifierBySignature, tableUserOpaqueIdentifier),
 
-		sqlInsertOAuth2ConsentPreConfiguration:  fmt.Sprintf(queryFmtInsertOAuth2ConsentPreConfiguration, tableOAuth2ConsentPreConfiguration),
-		sqlSelectOAuth2ConsentPreConfigurations: fmt.Sprintf(queryFmtSelectOAuth2ConsentPreConfigurations, tableOAuth2ConsentPreConfiguration),
+		sqlInsertOAuth2ConsentPreConfiguration:            fmt.Sprintf(queryFmtInsertOAuth2ConsentPreConfiguration, tableOAuth2ConsentPreConfiguration),
+		sqlSelectOAuth2ConsentPreConfigurations:           fmt.Sprintf(queryFmtSelectOAuth2ConsentPreConfigurations, tableOAuth2ConsentPreConfiguration),
+		sqlSelectOAuth2ConsentPreConfigurationsByUsername: fmt.Sprintf(queryFmtSelectOAuth2ConsentPreConfigurationsByUsername, tableOAuth2ConsentPreConfiguration, tableUserOpaqueIdentifier),
+		sqlRevokeOAuth2ConsentPreConfiguration:            fmt.Sprintf(queryFmtRevokeOAuth2ConsentPreConfiguration, tableOAuth2ConsentPreConfiguration),
 
 		sqlInsertOAuth2ConsentSession:              fmt.Sprintf(queryFmtInsertOAuth2ConsentSession, tableOAuth2ConsentSession),
 		sqlUpdateOAuth2ConsentSessionSubject:       fmt.Sprintf(queryFmtUpdateOAuth2ConsentSessionSubject, tableOAuth2ConsentSession),
 		sqlUpdateOAuth2ConsentSessionResponse:      fmt.Sprintf(queryFmtUpdateOAuth2ConsentSessionResponse, tableOAuth2ConsentSession),
 		sqlUpdateOAuth2ConsentSessionGranted:       fmt.Sprintf(queryFmtUpdateOAuth2ConsentSessionGranted, tableOAuth2ConsentSession),
 		sqlSelectOAuth2ConsentSessionByChallengeID: fmt.Sprintf(queryFmtSelectOAuth2ConsentSessionByChallengeID, tableOAuth2ConsentSession),
-
-		sqlInsertOAuth2AuthorizeCodeSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2AuthorizeCodeSession),
-		sqlSelectOAuth2AuthorizeCodeSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2AuthorizeCodeSession),
-		sqlRevokeOAuth2AuthorizeCodeSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2AuthorizeCodeSession),
-		sqlRevokeOAuth2AuthorizeCodeSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2AuthorizeCodeSession),
-		sqlDeactivateOAuth2AuthorizeCodeSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2AuthorizeCodeSession),
-		sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID: fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2AuthorizeCodeSession),
-
-		sqlInsertOAuth2AccessTokenSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2AccessTokenSession),
-		sqlSelectOAuth2AccessTokenSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2AccessTokenSession),
-		sqlRevokeOAuth2AccessTokenSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2AccessTokenSession),
-		sqlRevokeOAuth2AccessTokenSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2AccessTokenSession),
-		sqlDeactivateOAuth2AccessTokenSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2AccessTokenSession),
-		sqlDeactivateOAuth2AccessTokenSessionByRequestID: fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2AccessTokenSession),
-
-		sqlInsertOAuth2RefreshTokenSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2RefreshTokenSession),
-		sqlSelectOAuth2RefreshTokenSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2RefreshTokenSession),
-		sqlRevokeOAuth2RefreshTokenSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2RefreshTokenSession),
-		sqlRevokeOAuth2RefreshTokenSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2RefreshTokenSession),
-		sqlDeactivateOAuth2RefreshTokenSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2RefreshTokenSession),
-		sqlDeactivateOAuth2RefreshTokenSessionByRequestID: fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2RefreshTokenSession),
-
-		sqlInsertOAuth2PKCERequestSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2PKCERequestSession),
-		sqlSelectOAuth2PKCERequestSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2PKCERequestSession),
-		sqlRevokeOAuth2PKCERequestSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2PKCERequestSession),
-		sqlRevokeOAuth2PKCERequestSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2PKCERequestSession),
-		sqlDeactivateOAuth2PKCERequestSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2PKCERequestSession),
-		sqlDeactivateOAuth2PKCERequestSessionByRequestID: fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2PKCERequestSession),
-
-		sqlInsertOAuth2OpenIDConnectSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2OpenIDConnectSession),
-		sqlSelectOAuth2OpenIDConnectSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2OpenIDConnectSession),
-		sqlRevokeOAuth2OpenIDConnectSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2OpenIDConnectSession),
-		sqlRevokeOAuth2OpenIDConnectSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2OpenIDConnectSession),
-		sqlDeactivateOAuth2OpenIDConnectSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2OpenIDConnectSession),
-		sqlDeactivateOAuth2OpenIDConnectSessionByRequestID: fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2OpenIDConnectSession),
+		sqlSelectOAuth2ConsentSessionsByUsername:   fmt.Sprintf(queryFmtSelectOAuth2ConsentSessionsByUsername, tableOAuth2ConsentSession, tableUserOpaqueIdentifier),
+		sqlRevokeOAuth2ConsentSession:              fmt.Sprintf(queryFmtRevokeOAuth2ConsentSession, tableOAuth2ConsentSession),
+		sqlPurgeOAuth2ConsentSessions:              fmt.Sprintf(queryFmtPurgeOAuth2ConsentSessions, tableOAuth2ConsentSession),
+
+		sqlInsertOAuth2AuthorizeCodeSession:                        fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2AuthorizeCodeSession),
+		sqlSelectOAuth2AuthorizeCodeSession:                        fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2AuthorizeCodeSession),
+		sqlSelectOAuth2AuthorizeCodeSessionRequestIDsByChallengeID: fmt.Sprintf(queryFmtSelectOAuth2SessionRequestIDsByChallengeID, tableOAuth2AuthorizeCodeSession),
+		sqlRevokeOAuth2AuthorizeCodeSession:                        fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2AuthorizeCodeSession),
+		sqlRevokeOAuth2AuthorizeCodeSessionByRequestID:             fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2AuthorizeCodeSession),
+		sqlDeactivateOAuth2AuthorizeCodeSession:                    fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2AuthorizeCodeSession),
+		sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID:         fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2AuthorizeCodeSession),
+		sqlPurgeOAuth2AuthorizeCodeSessions:                        fmt.Sprintf(queryFmtPurgeOAuth2Sessions, tableOAuth2AuthorizeCodeSession),
+
+		sqlInsertOAuth2AccessTokenSession:                        fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2AccessTokenSession),
+		sqlSelectOAuth2AccessTokenSession:                        fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2AccessTokenSession),
+		sqlSelectOAuth2AccessTokenSessionRequestIDsByChallengeID: fmt.Sprintf(queryFmtSelectOAuth2SessionRequestIDsByChallengeID, tableOAuth2AccessTokenSession),
+		sqlRevokeOAuth2AccessTokenSession:                        fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2AccessTokenSession),
+		sqlRevokeOAuth2AccessTokenSessionByRequestID:             fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2AccessTokenSession),
+		sqlDeactivateOAuth2AccessTokenSession:                    fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2AccessTokenSession),
+		sqlDeactivateOAuth2AccessTokenSessionByRequestID:         fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2AccessTokenSession),
+		sqlPurgeOAuth2AccessTokenSessions:                        fmt.Sprintf(queryFmtPurgeOAuth2Sessions, tableOAuth2AccessTokenSession),
+
+		sqlInsertOAuth2RefreshTokenSession:                        fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2RefreshTokenSession),
+		sqlSelectOAuth2RefreshTokenSession:                        fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2RefreshTokenSession),
+		sqlSelectOAuth2RefreshTokenSessionRequestIDsByChallengeID: fmt.Sprintf(queryFmtSelectOAuth2SessionRequestIDsByChallengeID, tableOAuth2RefreshTokenSession),
+		sqlSelectOAuth2RefreshTokenSessionRotatedAt:               fmt.Sprintf(queryFmtSelectOAuth2SessionRotatedAt, tableOAuth2RefreshTokenSession),
+		sqlRevokeOAuth2RefreshTokenSession:                        fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2RefreshTokenSession),
+		sqlRevokeOAuth2RefreshTokenSessionByRequestID:             fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2RefreshTokenSession),
+		sqlDeactivateOAuth2RefreshTokenSession:                    fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2RefreshTokenSession),
+		sqlDeactivateOAuth2RefreshTokenSessionByRequestID:         fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2RefreshTokenSession),
+		sqlPurgeOAuth2RefreshTokenSessions:                        fmt.Sprintf(queryFmtPurgeOAuth2Sessions, tableOAuth2RefreshTokenSession),
+
+		sqlInsertOAuth2PKCERequestSession:                        fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2PKCERequestSession),
+		sqlSelectOAuth2PKCERequestSession:                        fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2PKCERequestSession),
+		sqlSelectOAuth2PKCERequestSessionRequestIDsByChallengeID: fmt.Sprintf(queryFmtSelectOAuth2SessionRequestIDsByChallengeID, tableOAuth2PKCERequestSession),
+		sqlRevokeOAuth2PKCERequestSession:                        fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2PKCERequestSession),
+		sqlRevokeOAuth2PKCERequestSessionByRequestID:             fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2PKCERequestSession),
+		sqlDeactivateOAuth2PKCERequestSession:                    fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2PKCERequestSession),
+		sqlDeactivateOAuth2PKCERequestSessionByRequestID:         fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2PKCERequestSession),
+		sqlPurgeOAuth2PKCERequestSessions:                        fmt.Sprintf(queryFmtPurgeOAuth2Sessions, tableOAuth2PKCERequestSession),
+
+		sqlInsertOAuth2OpenIDConnectSession:                        fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2OpenIDConnectSession),
+		sqlSelectOAuth2OpenIDConnectSession:                        fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2OpenIDConnectSession),
+		sqlSelectOAuth2OpenIDConnectSessionRequestIDsByChallengeID: fmt.Sprintf(queryFmtSelectOAuth2SessionRequestIDsByChallengeID, tableOAuth2OpenIDConnectSession),
+		sqlRevokeOAuth2OpenIDConnectSession:                        fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2OpenIDConnectSession),
+		sqlRevokeOAuth2OpenIDConnectSessionByRequestID:             fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2OpenIDConnectSession),
+		sqlDeactivateOAuth2OpenIDConnectSession:                    fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2OpenIDConnectSession),
+		sqlDeactivateOAuth2OpenIDConnectSessionByRequestID:         fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2OpenIDConnectSession),
+		sqlPurgeOAuth2OpenIDConnectSessions:                        fmt.Sprintf(queryFmtPurgeOAuth2Sessions, tableOAuth2OpenIDConnectSession),
 
 		sqlUpsertOAuth2BlacklistedJTI: fmt.Sprintf(queryFmtUpsertOAuth2BlacklistedJTI, tableOAuth2BlacklistedJTI),
 		sqlSelectOAuth2BlacklistedJTI: fmt.Sprintf(queryFmtSelectOAuth2BlacklistedJTI, tableOAuth2BlacklistedJTI),
+		sqlPurgeOAuth2BlacklistedJTIs: fmt.Sprintf(queryFmtPurgeOAuth2BlacklistedJTIs, tableOAuth2BlacklistedJTI),
+
+		sqlInsertOAuth2BackChannelLogout:        fmt.Sprintf(queryFmtInsertOAuth2BackChannelLogout, tableOAuth2BackChannelLogout),
+		sqlUpdateOAuth2BackChannelLogoutAttempt: fmt.Sprintf(queryFmtUpdateOAuth2BackChannelLogoutAttempt, tableOAuth2BackChannelLogout),
+		sqlSelectOAuth2BackChannelLogoutPending: fmt.Sprintf(queryFmtSelectOAuth2BackChannelLogoutPending, tableOAuth2BackChannelLogout),
+		sqlUpdateOAuth2BackChannelLogoutLease:   fmt.Sprintf(queryFmtUpdateOAuth2BackChannelLogoutLease, tableOAuth2BackChannelLogout),
+		sqlPurgeOAuth2BackChannelLogouts:        fmt.Sprintf(queryFmtPurgeOAuth2BackChannelLogouts, tableOAuth2BackChannelLogout),
+
+		sqlInsertOAuth2IssuerKey:           fmt.Sprintf(queryFmtInsertOAuth2IssuerKey, tableOAuth2IssuerKey),
+		sqlSelectOAuth2IssuerKeys:          fmt.Sprintf(queryFmtSelectOAuth2IssuerKeys, tableOAuth2IssuerKey),
+		sqlUpdateOAuth2IssuerKeyPrivateKey: fmt.Sprintf(queryFmtUpdateOAuth2IssuerKeyPrivateKey, tableOAuth2IssuerKey),
+		sqlDeleteOAuth2IssuerKey:           fmt.Sprintf(queryFmtDeleteOAuth2IssuerKey, tableOAuth2IssuerKey),
+
+		sqlInsertOAuth2PARContext: fmt.Sprintf(queryFmtInsertOAuth2PARContext, tableOAuth2PARContext),
+		sqlSelectOAuth2PARContext: fmt.Sprintf(queryFmtSelectOAuth2PARContext, tableOAuth2PARContext),
+		sqlRevokeOAuth2PARContext: fmt.Sprintf(queryFmtRevokeOAuth2PARContext, tableOAuth2PARContext),
+		sqlPurgeOAuth2PARContexts: fmt.Sprintf(queryFmtPurgeOAuth2PARContexts, tableOAuth2PARContext),
+
+		sqlInsertOAuth2DeviceCodeSession:              fmt.Sprintf(queryFmtInsertOAuth2DeviceCodeSession, tableOAuth2DeviceCodeSession),
+		sqlSelectOAuth2DeviceCodeSession:              fmt.Sprintf(queryFmtSelectOAuth2DeviceCodeSession, tableOAuth2DeviceCodeSession),
+		sqlSelectOAuth2DeviceCodeSessionByUserCode:    fmt.Sprintf(queryFmtSelectOAuth2DeviceCodeSessionByUserCode, tableOAuth2DeviceCodeSession),
+		sqlSelectOAuth2DeviceCodeSessionByChallengeID: fmt.Sprintf(queryFmtSelectOAuth2DeviceCodeSessionByChallengeID, tableOAuth2DeviceCodeSession),
+		sqlUpdateOAuth2DeviceCodeSession:              fmt.Sprintf(queryFmtUpdateOAuth2DeviceCodeSession, tableOAuth2DeviceCodeSession),
+		sqlUpdateOAuth2DeviceCodeSessionStatus:        fmt.Sprintf(queryFmtUpdateOAuth2DeviceCodeSessionStatus, tableOAuth2DeviceCodeSession),
+		sqlUpdateOAuth2DeviceCodeSessionChallengeID:   fmt.Sprintf(queryFmtUpdateOAuth2DeviceCodeSessionChallengeID, tableOAuth2DeviceCodeSession),
+		sqlConsumeOAuth2DeviceCodeSession:             fmt.Sprintf(queryFmtConsumeOAuth2DeviceCodeSession, tableOAuth2DeviceCodeSession),
+		sqlUpdateOAuth2DeviceCodeSessionCheckedAt:     fmt.Sprintf(queryFmtUpdateOAuth2DeviceCodeSessionCheckedAt, tableOAuth2DeviceCodeSession),
+		sqlPurgeOAuth2DeviceCodeSessions:              fmt.Sprintf(queryFmtPurgeOAuth2DeviceCodeSessions, tableOAuth2DeviceCodeSession),
+
+		sqlInsertOAuth2Client:  fmt.Sprintf(queryFmtInsertOAuth2Client, tableOAuth2Client),
+		sqlSelectOAuth2Client:  fmt.Sprintf(queryFmtSelectOAuth2Client, tableOAuth2Client),
+		sqlSelectOAuth2Clients: fmt.Sprintf(queryFmtSelectOAuth2Clients, tableOAuth2Client),
+		sqlUpdateOAuth2Client:  fmt.Sprintf(queryFmtUpdateOAuth2Client, tableOAuth2Client),
+		sqlDeleteOAuth2Client:  fmt.Sprintf(queryFmtDeleteOAuth2Client, tableOAuth2Client),
 
 		sqlInsertMigration:       fmt.Sprintf(queryFmtInsertMigration, tableMigrations),
 		sqlSelectMigrations:      fmt.Sprintf(queryFmtSelectMigrations, tableMigrations),
@@ -149,11 +201,13 @@ type SQLProvider struct {
 	// Table: authentication_logs.
 	sqlInsertAuthenticationAttempt            string
 	sqlSelectAuthenticationAttemptsByUsername string
+	sqlPurgeAuthenticationAttempts            string
 
 	// Table: identity_verification.
 	sqlInsertIdentityVerification  string
 	sqlConsumeIdentityVerification string
 	sqlSelectIdentityVerification  string
+	sqlPurgeIdentityVerifications  string
 
 	// Table: totp_configurations.
 	sqlUpsertTOTPConfig  string
@@ -206,8 +260,10 @@ type SQLProvider struct {
 	sqlSelectEncryptionValue string
 
 	// Table: oauth2_consent_preconfiguration.
-	sqlInsertOAuth2ConsentPreConfiguration  string
-	sqlSelectOAuth2ConsentPreConfigurations string
+	sqlInsertOAuth2ConsentPreConfiguration            string
+	sqlSelectOAuth2ConsentPreConfigurations           string
+	sqlSelectOAuth2ConsentPreConfigurationsByUsername string
+	sqlRevokeOAuth2ConsentPreConfiguration            string
 
 	// Table: oauth2_consent_session.
 	sqlInsertOAuth2ConsentSession              string
@@ -215,49 +271,102 @@ type SQLProvider struct {
 	sqlUpdateOAuth2ConsentSessionResponse      string
 	sqlUpdateOAuth2ConsentSessionGranted       string
 	sqlSelectOAuth2ConsentSessionByChallengeID string
+	sqlSelectOAuth2ConsentSessionsByUsername   string
+	sqlRevokeOAuth2ConsentSession              string
+	sqlPurgeOAuth2ConsentSessions              string
 
 	// Table: oauth2_authorization_code_session.
-	sqlInsertOAuth2AuthorizeCodeSession                string
-	sqlSelectOAuth2AuthorizeCodeSession                string
-	sqlRevokeOAuth2AuthorizeCodeSession                string
-	sqlRevokeOAuth2AuthorizeCodeSessionByRequestID     string
-	sqlDeactivateOAuth2AuthorizeCodeSession            string
-	sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID string
+	sqlInsertOAuth2AuthorizeCodeSession                        string
+	sqlSelectOAuth2AuthorizeCodeSession                        string
+	sqlSelectOAuth2AuthorizeCodeSessionRequestIDsByChallengeID string
+	sqlRevokeOAuth2AuthorizeCodeSession                        string
+	sqlRevokeOAuth2AuthorizeCodeSessionByRequestID             string
+	sqlDeactivateOAuth2AuthorizeCodeSession                    string
+	sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID         string
+	sqlPurgeOAuth2AuthorizeCodeSessions                        string
 
 	// Table: oauth2_access_token_session.
-	sqlInsertOAuth2AccessTokenSession                string
-	sqlSelectOAuth2AccessTokenSession                string
-	sqlRevokeOAuth2AccessTokenSession                string
-	sqlRevokeOAuth2AccessTokenSessionByRequestID     string
-	sqlDeactivateOAuth2AccessTokenSession            string
-	sqlDeactivateOAuth2AccessTokenSessionByRequestID string
+	sqlInsertOAuth2AccessTokenSession                        string
+	sqlSelectOAuth2AccessTokenSession                        string
+	sqlSelectOAuth2AccessTokenSessionRequestIDsByChallengeID string
+	sqlRevokeOAuth2AccessTokenSession                        string
+	sqlRevokeOAuth2AccessTokenSessionByRequestID             string
+	sqlDeactivateOAuth2AccessTokenSession                    string
+	sqlDeactivateOAuth2AccessTokenSessionByRequestID         string
+	sqlPurgeOAuth2AccessTokenSessions                        string
 
 	// Table: oauth2_refresh_token_session.
-	sqlInsertOAuth2RefreshTokenSession                string
-	sqlSelectOAuth2RefreshTokenSession                string
-	sqlRevokeOAuth2RefreshTokenSession                string
-	sqlRevokeOAuth2RefreshTokenSessionByRequestID     string
-	sqlDeactivateOAuth2RefreshTokenSession            string
-	sqlDeactivateOAuth2RefreshTokenSessionByRequestID string
+	sqlInsertOAuth2RefreshTokenSession                        string
+	sqlSelectOAuth2RefreshTokenSession                        string
+	sqlSelectOAuth2RefreshTokenSessionRequestIDsByChallengeID string
+	sqlSelectOAuth2RefreshTokenSessionRotatedAt               string
+	sqlRevokeOAuth2RefreshTokenSession                        string
+	sqlRevokeOAuth2RefreshTokenSessionByRequestID             string
+	sqlDeactivateOAuth2RefreshTokenSession                    string
+	sqlDeactivateOAuth2RefreshTokenSessionByRequestID         string
+	sqlPurgeOAuth2RefreshTokenSessions                        string
 
 	// Table: oauth2_pkce_request_session.
-	sqlInsertOAuth2PKCERequestSession                string
-	sqlSelectOAuth2PKCERequestSession                string
-	sqlRevokeOAuth2PKCERequestSession                string
-	sqlRevokeOAuth2PKCERequestSessionByRequestID     string
-	sqlDeactivateOAuth2PKCERequestSession            string
-	sqlDeactivateOAuth2PKCERequestSessionByRequestID string
+	sqlInsertOAuth2PKCERequestSession                        string
+	sqlSelectOAuth2PKCERequestSession                        string
+	sqlSelectOAuth2PKCERequestSessionRequestIDsByChallengeID string
+	sqlRevokeOAuth2PKCERequestSession                        string
+	sqlRevokeOAuth2PKCERequestSessionByRequestID             string
+	sqlDeactivateOAuth2PKCERequestSession                    string
+	sqlDeactivateOAuth2PKCERequestSessionByRequestID         string
+	sqlPurgeOAuth2PKCERequestSessions                        string
 
 	// Table: oauth2_openid_connect_session.
-	sqlInsertOAuth2OpenIDConnectSession                string
-	sqlSelectOAuth2OpenIDConnectSession                string
-	sqlRevokeOAuth2OpenIDConnectSession                string
-	sqlRevokeOAuth2OpenIDConnectSessionByRequestID     string
-	sqlDeactivateOAuth2OpenIDConnectSession            string
-	sqlDeactivateOAuth2OpenIDConnectSessionByRequestID string
+	sqlInsertOAuth2OpenIDConnectSession                        string
+	sqlSelectOAuth2OpenIDConnectSession                        string
+	sqlSelectOAuth2OpenIDConnectSessionRequestIDsByChallengeID string
+	sqlRevokeOAuth2OpenIDConnectSession                        string
+	sqlRevokeOAuth2OpenIDConnectSessionByRequestID             string
+	sqlDeactivateOAuth2OpenIDConnectSession                    string
+	sqlDeactivateOAuth2OpenIDConnectSessionByRequestID         string
+	sqlPurgeOAuth2OpenIDConnectSessions                        string
 
 	sqlUpsertOAuth2BlacklistedJTI string
 	sqlSelectOAuth2BlacklistedJTI string
+	sqlPurgeOAuth2BlacklistedJTIs string
+
+	// Table: oauth2_backchannel_logout.
+	sqlInsertOAuth2BackChannelLogout        string
+	sqlUpdateOAuth2BackChannelLogoutAttempt string
+	sqlSelectOAuth2BackChannelLogoutPending string
+	sqlUpdateOAuth2BackChannelLogoutLease   string
+	sqlPurgeOAuth2BackChannelLogouts        string
+
+	// Table: oauth2_issuer_key.
+	sqlInsertOAuth2IssuerKey           string
+	sqlSelectOAuth2IssuerKeys          string
+	sqlUpdateOAuth2IssuerKeyPrivateKey string
+	sqlDeleteOAuth2IssuerKey           string
+
+	// Table: oauth2_par_context.
+	sqlInsertOAuth2PARContext string
+	sqlSelectOAuth2PARContext string
+	sqlRevokeOAuth2PARContext string
+	sqlPurgeOAuth2PARContexts string
+
+	// Table: oauth2_device_code_session.
+	sqlInsertOAuth2DeviceCodeSession              string
+	sqlSelectOAuth2DeviceCodeSession              string
+	sqlSelectOAuth2DeviceCodeSessionByUserCode    string
+	sqlSelectOAuth2DeviceCodeSessionByChallengeID string
+	sqlUpdateOAuth2DeviceCodeSession              string
+	sqlUpdateOAuth2DeviceCodeSessionStatus        string
+	sqlUpdateOAuth2DeviceCodeSessionChallengeID   string
+	sqlConsumeOAuth2DeviceCodeSession             string
+	sqlUpdateOAuth2DeviceCodeSessionCheckedAt     string
+	sqlPurgeOAuth2DeviceCodeSessions              string
+
+	// Table: oauth2_client.
+	sqlInsertOAuth2Client  string
+	sqlSelectOAuth2Client  string
+	sqlSelectOAuth2Clients string
+	sqlUpdateOAuth2Client  string
+	sqlDeleteOAuth2Client  string
 
 	// Utility.
 	sqlSelectExistingTables string
@@ -456,6 +565,41 @@ func (p *SQLProvider) LoadOAuth2ConsentSessionByChallengeID(ctx context.Context,
 	return consent, nil
 }
 
+// LoadOAuth2ConsentSessionsByUsername returns the OAuth2.0 consents which have been granted by a user and have not
+// been revoked.
+func (p *SQLProvider) LoadOAuth2ConsentSessionsByUsername(ctx context.Context, username string) (consents []model.OAuth2ConsentSession, err error) {
+	consents = []model.OAuth2ConsentSession{}
+
+	if err = p.db.SelectContext(ctx, &consents, p.sqlSelectOAuth2ConsentSessionsByUsername, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting oauth2 consent sessions for user '%s': %w", username, err)
+	}
+
+	return consents, nil
+}
+
+// RevokeOAuth2ConsentSession marks an OAuth2.0 consent as revoked.
+func (p *SQLProvider) RevokeOAuth2ConsentSession(ctx context.Context, id int) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlRevokeOAuth2ConsentSession, id); err != nil {
+		return fmt.Errorf("error revoking oauth2 consent session with id '%d': %w", id, err)
+	}
+
+	return nil
+}
+
+// PurgeOAuth2ConsentSessions deletes the OAuth2.0 consent sessions requested before the provided time which were never
+// responded to, were rejected, or were revoked.
+func (p *SQLProvider) PurgeOAuth2ConsentSessions(ctx context.Context, before time.Time) (purged int64, err error) {
+	if purged, err = p.purge(ctx, p.sqlPurgeOAuth2ConsentSessions, before); err != nil {
+		return 0, fmt.Errorf("error purging oauth2 consent sessions requested before '%s': %w", before, err)
+	}
+
+	return purged, nil
+}
+
 // SaveOAuth2ConsentPreConfiguration inserts an OAuth2.0 consent pre-configuration.
 func (p *SQLProvider) SaveOAuth2ConsentPreConfiguration(ctx context.Context, config model.OAuth2ConsentPreConfig) (insertedID int64, err error) {
 	switch p.name {
@@ -495,6 +639,31 @@ func (p *SQLProvider) LoadOAuth2ConsentPreConfigurations(ctx context.Context, cl
 	return &ConsentPreConfigRows{rows: r}, nil
 }
 
+// LoadOAuth2ConsentPreConfigurationsByUsername returns the OAuth2.0 consent pre-configurations of a user which have not
+// expired or been revoked.
+func (p *SQLProvider) LoadOAuth2ConsentPreConfigurationsByUsername(ctx context.Context, username string) (configs []model.OAuth2ConsentPreConfig, err error) {
+	configs = []model.OAuth2ConsentPreConfig{}
+
+	if err = p.db.SelectContext(ctx, &configs, p.sqlSelectOAuth2ConsentPreConfigurationsByUsername, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting oauth2 consent pre-configurations for user '%s': %w", username, err)
+	}
+
+	return configs, nil
+}
+
+// RevokeOAuth2ConsentPreConfiguration marks an OAuth2.0 consent pre-configuration as revoked.
+func (p *SQLProvider) RevokeOAuth2ConsentPreConfiguration(ctx context.Context, id int64) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlRevokeOAuth2ConsentPreConfiguration, id); err != nil {
+		return fmt.Errorf("error revoking oauth2 consent pre-configuration with id '%d': %w", id, err)
+	}
+
+	return nil
+}
+
 // SaveOAuth2Session saves a OAuth2Session to the database.
 func (p *SQLProvider) SaveOAuth2Session(ctx context.Context, sessionType OAuth2SessionType, session model.OAuth2Session) (err error) {
 	var query string
@@ -667,6 +836,69 @@ func (p *SQLProvider) LoadOAuth2Session(ctx context.Context, sessionType OAuth2S
 	return session, nil
 }
 
+// LoadOAuth2SessionRequestIDsByChallengeID returns the request ids of the OAuth2Session's which have not been revoked
+// and were issued as a result of the consent with the challenge id.
+func (p *SQLProvider) LoadOAuth2SessionRequestIDsByChallengeID(ctx context.Context, sessionType OAuth2SessionType, challengeID uuid.UUID) (requestIDs []string, err error) {
+	var query string
+
+	switch sessionType {
+	case OAuth2SessionTypeAuthorizeCode:
+		query = p.sqlSelectOAuth2AuthorizeCodeSessionRequestIDsByChallengeID
+	case OAuth2SessionTypeAccessToken:
+		query = p.sqlSelectOAuth2AccessTokenSessionRequestIDsByChallengeID
+	case OAuth2SessionTypeRefreshToken:
+		query = p.sqlSelectOAuth2RefreshTokenSessionRequestIDsByChallengeID
+	case OAuth2SessionTypePKCEChallenge:
+		query = p.sqlSelectOAuth2PKCERequestSessionRequestIDsByChallengeID
+	case OAuth2SessionTypeOpenIDConnect:
+		query = p.sqlSelectOAuth2OpenIDConnectSessionRequestIDsByChallengeID
+	default:
+		return nil, fmt.Errorf("error selecting oauth2 session request ids with challenge id '%s': unknown oauth2 session type '%s'", challengeID, sessionType)
+	}
+
+	if err = p.db.SelectContext(ctx, &requestIDs, query, challengeID); err != nil {
+		return nil, fmt.Errorf("error selecting oauth2 %s session request ids with challenge id '%s': %w", sessionType, challengeID, err)
+	}
+
+	return requestIDs, nil
+}
+
+// LoadOAuth2RefreshTokenSessionRotatedAt loads the time a refresh token issued at the provided time was rotated, i.e.
+// the time the next refresh token sharing the same request id was issued.
+func (p *SQLProvider) LoadOAuth2RefreshTokenSessionRotatedAt(ctx context.Context, requestID string, requestedAt time.Time) (rotatedAt time.Time, err error) {
+	if err = p.db.GetContext(ctx, &rotatedAt, p.sqlSelectOAuth2RefreshTokenSessionRotatedAt, requestID, requestedAt); err != nil {
+		return time.Time{}, fmt.Errorf("error selecting oauth2 refresh token session rotation time with request id '%s': %w", requestID, err)
+	}
+
+	return rotatedAt, nil
+}
+
+// PurgeOAuth2Sessions deletes the OAuth2Session's requested before the provided time from the database.
+func (p *SQLProvider) PurgeOAuth2Sessions(ctx context.Context, sessionType OAuth2SessionType, before time.Time) (purged int64, err error) {
+	var query string
+
+	switch sessionType {
+	case OAuth2SessionTypeAuthorizeCode:
+		query = p.sqlPurgeOAuth2AuthorizeCodeSessions
+	case OAuth2SessionTypeAccessToken:
+		query = p.sqlPurgeOAuth2AccessTokenSessions
+	case OAuth2SessionTypeRefreshToken:
+		query = p.sqlPurgeOAuth2RefreshTokenSessions
+	case OAuth2SessionTypePKCEChallenge:
+		query = p.sqlPurgeOAuth2PKCERequestSessions
+	case OAuth2SessionTypeOpenIDConnect:
+		query = p.sqlPurgeOAuth2OpenIDConnectSessions
+	default:
+		return 0, fmt.Errorf("error purging oauth2 sessions: unknown oauth2 session type '%s'", sessionType)
+	}
+
+	if purged, err = p.purge(ctx, query, before); err != nil {
+		return 0, fmt.Errorf("error purging oauth2 %s sessions requested before '%s': %w", sessionType, before, err)
+	}
+
+	return purged, nil
+}
+
 // SaveOAuth2BlacklistedJTI saves a OAuth2BlacklistedJTI to the database.
 func (p *SQLProvider) SaveOAuth2BlacklistedJTI(ctx context.Context, blacklistedJTI model.OAuth2BlacklistedJTI) (err error) {
 	if _, err = p.db.ExecContext(ctx, p.sqlUpsertOAuth2BlacklistedJTI, blacklistedJTI.Signature, blacklistedJTI.ExpiresAt); err != nil {
@@ -687,6 +919,366 @@ func (p *SQLProvider) LoadOAuth2BlacklistedJTI(ctx context.Context, signature st
 	return blacklistedJTI, nil
 }
 
+// PurgeOAuth2BlacklistedJTIs deletes the OAuth2BlacklistedJTI's which expired before the provided time from the database.
+func (p *SQLProvider) PurgeOAuth2BlacklistedJTIs(ctx context.Context, before time.Time) (purged int64, err error) {
+	if purged, err = p.purge(ctx, p.sqlPurgeOAuth2BlacklistedJTIs, before); err != nil {
+		return 0, fmt.Errorf("error purging oauth2 blacklisted JTIs which expired before '%s': %w", before, err)
+	}
+
+	return purged, nil
+}
+
+// SaveOAuth2BackChannelLogout saves a OAuth2BackChannelLogout to the database.
+func (p *SQLProvider) SaveOAuth2BackChannelLogout(ctx context.Context, logout model.OAuth2BackChannelLogout) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertOAuth2BackChannelLogout,
+		logout.JTI, logout.Issuer, logout.ClientID, logout.Subject, logout.SessionID, logout.URI, logout.LogoutToken, logout.CreatedAt, logout.LeaseExpiresAt); err != nil {
+		return fmt.Errorf("error inserting oauth2 back-channel logout with jti '%s' for client with id '%s': %w", logout.JTI, logout.ClientID, err)
+	}
+
+	return nil
+}
+
+// UpdateOAuth2BackChannelLogoutAttempt records a delivery attempt of a OAuth2BackChannelLogout to the database.
+func (p *SQLProvider) UpdateOAuth2BackChannelLogoutAttempt(ctx context.Context, jti string, delivered bool) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2BackChannelLogoutAttempt, delivered, jti); err != nil {
+		return fmt.Errorf("error updating oauth2 back-channel logout attempt with jti '%s': %w", jti, err)
+	}
+
+	return nil
+}
+
+// LoadOAuth2BackChannelLogoutsPending loads the OAuth2BackChannelLogout's which have not been delivered and which have
+// been attempted fewer than the provided number of times from the database.
+func (p *SQLProvider) LoadOAuth2BackChannelLogoutsPending(ctx context.Context, attempts int) (logouts []model.OAuth2BackChannelLogout, err error) {
+	if err = p.db.SelectContext(ctx, &logouts, p.sqlSelectOAuth2BackChannelLogoutPending, attempts); err != nil {
+		return nil, fmt.Errorf("error selecting pending oauth2 back-channel logouts: %w", err)
+	}
+
+	return logouts, nil
+}
+
+// UpdateOAuth2BackChannelLogoutLease atomically claims a pending OAuth2BackChannelLogout which has been attempted fewer
+// than the provided number of times until the provided time so only a single instance delivers it. The claim only
+// succeeds if the OAuth2BackChannelLogout is not already claimed by a lease which expires after the provided now.
+func (p *SQLProvider) UpdateOAuth2BackChannelLogoutLease(ctx context.Context, jti string, attempts int, now, until time.Time) (claimed bool, err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2BackChannelLogoutLease, until, jti, attempts, now); err != nil {
+		return false, fmt.Errorf("error updating oauth2 back-channel logout lease with jti '%s': %w", jti, err)
+	}
+
+	var affected int64
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return false, fmt.Errorf("error updating oauth2 back-channel logout lease with jti '%s': %w", jti, err)
+	}
+
+	return affected == 1, nil
+}
+
+// PurgeOAuth2BackChannelLogouts deletes the OAuth2BackChannelLogout's created before the provided time from the
+// database. These have either been delivered or failed to be delivered.
+func (p *SQLProvider) PurgeOAuth2BackChannelLogouts(ctx context.Context, before time.Time) (purged int64, err error) {
+	if purged, err = p.purge(ctx, p.sqlPurgeOAuth2BackChannelLogouts, before); err != nil {
+		return 0, fmt.Errorf("error purging oauth2 back-channel logouts created before '%s': %w", before, err)
+	}
+
+	return purged, nil
+}
+
+// SaveOAuth2IssuerKey saves a OAuth2IssuerKey to the database with the private key encrypted.
+func (p *SQLProvider) SaveOAuth2IssuerKey(ctx context.Context, key model.OAuth2IssuerKey) (err error) {
+	if key.PrivateKey, err = p.encrypt(key.PrivateKey); err != nil {
+		return fmt.Errorf("error encrypting the oauth2 issuer key with key id '%s': %w", key.KeyID, err)
+	}
+
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertOAuth2IssuerKey,
+		key.KeyID, key.Algorithm, key.PrivateKey, key.CreatedAt, key.NotBefore, key.NotAfter); err != nil {
+		return fmt.Errorf("error inserting oauth2 issuer key with key id '%s': %w", key.KeyID, err)
+	}
+
+	return nil
+}
+
+// LoadOAuth2IssuerKeys loads all OAuth2IssuerKey's from the database ordered by when they become active, decrypting
+// the private keys.
+func (p *SQLProvider) LoadOAuth2IssuerKeys(ctx context.Context) (keys []model.OAuth2IssuerKey, err error) {
+	if keys, err = p.loadOAuth2IssuerKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		if keys[i].PrivateKey, err = p.decrypt(key.PrivateKey); err != nil {
+			return nil, fmt.Errorf("error decrypting the oauth2 issuer key with key id '%s': %w", key.KeyID, err)
+		}
+	}
+
+	return keys, nil
+}
+
+// DeleteOAuth2IssuerKey deletes a OAuth2IssuerKey from the database given a key id.
+func (p *SQLProvider) DeleteOAuth2IssuerKey(ctx context.Context, kid string) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteOAuth2IssuerKey, kid); err != nil {
+		return fmt.Errorf("error deleting oauth2 issuer key with key id '%s': %w", kid, err)
+	}
+
+	return nil
+}
+
+func (p *SQLProvider) loadOAuth2IssuerKeys(ctx context.Context) (keys []model.OAuth2IssuerKey, err error) {
+	keys = []model.OAuth2IssuerKey{}
+
+	if err = p.db.SelectContext(ctx, &keys, p.sqlSelectOAuth2IssuerKeys); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting oauth2 issuer keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (p *SQLProvider) updateOAuth2IssuerKeyPrivateKey(ctx context.Context, key model.OAuth2IssuerKey) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2IssuerKeyPrivateKey, key.PrivateKey, key.ID); err != nil {
+		return fmt.Errorf("error updating oauth2 issuer key private key with key id '%s': %w", key.KeyID, err)
+	}
+
+	return nil
+}
+
+// SaveOAuth2PARContext saves a OAuth2PARContext to the database.
+func (p *SQLProvider) SaveOAuth2PARContext(ctx context.Context, par model.OAuth2PARContext) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertOAuth2PARContext,
+		par.Signature, par.ClientID, par.RequestedAt, par.ExpiresAt, par.Revoked, par.Form); err != nil {
+		return fmt.Errorf("error inserting oauth2 pushed authorization request context with signature '%s' for client with id '%s': %w", par.Signature, par.ClientID, err)
+	}
+
+	return nil
+}
+
+// LoadOAuth2PARContext loads a OAuth2PARContext from the database.
+func (p *SQLProvider) LoadOAuth2PARContext(ctx context.Context, signature string) (par *model.OAuth2PARContext, err error) {
+	par = &model.OAuth2PARContext{}
+
+	if err = p.db.GetContext(ctx, par, p.sqlSelectOAuth2PARContext, signature); err != nil {
+		return nil, fmt.Errorf("error selecting oauth2 pushed authorization request context with signature '%s': %w", signature, err)
+	}
+
+	return par, nil
+}
+
+// RevokeOAuth2PARContext marks a OAuth2PARContext as revoked in the database.
+func (p *SQLProvider) RevokeOAuth2PARContext(ctx context.Context, signature string) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlRevokeOAuth2PARContext, signature); err != nil {
+		return fmt.Errorf("error revoking oauth2 pushed authorization request context with signature '%s': %w", signature, err)
+	}
+
+	return nil
+}
+
+// PurgeOAuth2PARContexts deletes the OAuth2PARContext's which expired before the provided time or which were revoked
+// from the database.
+func (p *SQLProvider) PurgeOAuth2PARContexts(ctx context.Context, before time.Time) (purged int64, err error) {
+	if purged, err = p.purge(ctx, p.sqlPurgeOAuth2PARContexts, before); err != nil {
+		return 0, fmt.Errorf("error purging oauth2 pushed authorization request contexts which expired before '%s' or were revoked: %w", before, err)
+	}
+
+	return purged, nil
+}
+
+// SaveOAuth2DeviceCodeSession saves a OAuth2DeviceCodeSession to the database.
+func (p *SQLProvider) SaveOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error) {
+	if session.Session, err = p.encrypt(session.Session); err != nil {
+		return fmt.Errorf("error encrypting the oauth2 device code session data with signature '%s' for client with id '%s' and request id '%s': %w", session.Signature, session.ClientID, session.RequestID, err)
+	}
+
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertOAuth2DeviceCodeSession,
+		session.ChallengeID, session.RequestID, session.ClientID, session.Signature, session.UserCodeSignature,
+		session.Status, session.Subject, session.RequestedAt, session.ExpiresAt, session.RequestedScopes,
+		session.GrantedScopes, session.RequestedAudience, session.GrantedAudience, session.Form, session.Session); err != nil {
+		return fmt.Errorf("error inserting oauth2 device code session with signature '%s' for client with id '%s' and request id '%s': %w", session.Signature, session.ClientID, session.RequestID, err)
+	}
+
+	return nil
+}
+
+// UpdateOAuth2DeviceCodeSession updates a OAuth2DeviceCodeSession in the database with the response of the user.
+func (p *SQLProvider) UpdateOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error) {
+	if session.Session, err = p.encrypt(session.Session); err != nil {
+		return fmt.Errorf("error encrypting the oauth2 device code session data with signature '%s' for subject '%s' and request id '%s': %w", session.Signature, session.Subject.UUID, session.RequestID, err)
+	}
+
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2DeviceCodeSession,
+		session.ChallengeID, session.Status, session.Subject, session.GrantedScopes, session.GrantedAudience,
+		session.Session, session.Signature); err != nil {
+		return fmt.Errorf("error updating oauth2 device code session with signature '%s' for subject '%s' and request id '%s': %w", session.Signature, session.Subject.UUID, session.RequestID, err)
+	}
+
+	return nil
+}
+
+// UpdateOAuth2DeviceCodeSessionStatus updates the status of a OAuth2DeviceCodeSession in the database.
+func (p *SQLProvider) UpdateOAuth2DeviceCodeSessionStatus(ctx context.Context, signature string, status model.OAuth2DeviceCodeStatus) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2DeviceCodeSessionStatus, status, signature); err != nil {
+		return fmt.Errorf("error updating oauth2 device code session status with signature '%s' to '%s': %w", signature, status, err)
+	}
+
+	return nil
+}
+
+// UpdateOAuth2DeviceCodeSessionChallengeID records the challenge id of the consent session the user is responding to
+// for a pending OAuth2DeviceCodeSession in the database. It returns ErrNoPendingOAuth2DeviceCodeSession if the
+// OAuth2DeviceCodeSession is no longer pending.
+func (p *SQLProvider) UpdateOAuth2DeviceCodeSessionChallengeID(ctx context.Context, signature string, challengeID uuid.UUID) (err error) {
+	var (
+		result   sql.Result
+		affected int64
+	)
+
+	if result, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2DeviceCodeSessionChallengeID, challengeID, signature, model.OAuth2DeviceCodeStatusPending); err != nil {
+		return fmt.Errorf("error updating oauth2 device code session challenge id with signature '%s': %w", signature, err)
+	}
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("error updating oauth2 device code session challenge id with signature '%s': %w", signature, err)
+	}
+
+	if affected != 1 {
+		return ErrNoPendingOAuth2DeviceCodeSession
+	}
+
+	return nil
+}
+
+// ConsumeOAuth2DeviceCodeSession marks an approved OAuth2DeviceCodeSession as used in the database. It returns
+// ErrNoApprovedOAuth2DeviceCodeSession if the OAuth2DeviceCodeSession is not approved or has already been used.
+func (p *SQLProvider) ConsumeOAuth2DeviceCodeSession(ctx context.Context, signature string) (err error) {
+	var (
+		result   sql.Result
+		affected int64
+	)
+
+	if result, err = p.db.ExecContext(ctx, p.sqlConsumeOAuth2DeviceCodeSession, model.OAuth2DeviceCodeStatusUsed, signature, model.OAuth2DeviceCodeStatusApproved); err != nil {
+		return fmt.Errorf("error consuming oauth2 device code session with signature '%s': %w", signature, err)
+	}
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("error consuming oauth2 device code session with signature '%s': %w", signature, err)
+	}
+
+	if affected != 1 {
+		return ErrNoApprovedOAuth2DeviceCodeSession
+	}
+
+	return nil
+}
+
+// UpdateOAuth2DeviceCodeSessionCheckedAt updates the time a OAuth2DeviceCodeSession was last polled by the client.
+func (p *SQLProvider) UpdateOAuth2DeviceCodeSessionCheckedAt(ctx context.Context, signature string, checkedAt time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2DeviceCodeSessionCheckedAt, checkedAt, signature); err != nil {
+		return fmt.Errorf("error updating oauth2 device code session checked at time with signature '%s': %w", signature, err)
+	}
+
+	return nil
+}
+
+// LoadOAuth2DeviceCodeSession loads a OAuth2DeviceCodeSession from the database given the device code signature.
+func (p *SQLProvider) LoadOAuth2DeviceCodeSession(ctx context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	return p.loadOAuth2DeviceCodeSession(ctx, p.sqlSelectOAuth2DeviceCodeSession, "signature", signature)
+}
+
+// LoadOAuth2DeviceCodeSessionByUserCode loads a OAuth2DeviceCodeSession from the database given the user code signature.
+func (p *SQLProvider) LoadOAuth2DeviceCodeSessionByUserCode(ctx context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	return p.loadOAuth2DeviceCodeSession(ctx, p.sqlSelectOAuth2DeviceCodeSessionByUserCode, "user code signature", signature)
+}
+
+// PurgeOAuth2DeviceCodeSessions deletes the OAuth2DeviceCodeSession's which expired before the provided time from the
+// database.
+func (p *SQLProvider) PurgeOAuth2DeviceCodeSessions(ctx context.Context, before time.Time) (purged int64, err error) {
+	if purged, err = p.purge(ctx, p.sqlPurgeOAuth2DeviceCodeSessions, before); err != nil {
+		return 0, fmt.Errorf("error purging oauth2 device code sessions which expired before '%s': %w", before, err)
+	}
+
+	return purged, nil
+}
+
+// LoadOAuth2DeviceCodeSessionByChallengeID loads a OAuth2DeviceCodeSession from the database given the challenge id of
+// the consent session the user is responding to.
+func (p *SQLProvider) LoadOAuth2DeviceCodeSessionByChallengeID(ctx context.Context, challengeID uuid.UUID) (session *model.OAuth2DeviceCodeSession, err error) {
+	return p.loadOAuth2DeviceCodeSession(ctx, p.sqlSelectOAuth2DeviceCodeSessionByChallengeID, "challenge id", challengeID.String())
+}
+
+func (p *SQLProvider) loadOAuth2DeviceCodeSession(ctx context.Context, query, kind, signature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	session = &model.OAuth2DeviceCodeSession{}
+
+	if err = p.db.GetContext(ctx, session, query, signature); err != nil {
+		return nil, fmt.Errorf("error selecting oauth2 device code session with %s '%s': %w", kind, signature, err)
+	}
+
+	if session.Session, err = p.decrypt(session.Session); err != nil {
+		return nil, fmt.Errorf("error decrypting the oauth2 device code session data with %s '%s' for client with id '%s' and request id '%s': %w", kind, signature, session.ClientID, session.RequestID, err)
+	}
+
+	return session, nil
+}
+
+// SaveOAuth2Client saves a OAuth2Client to the database.
+func (p *SQLProvider) SaveOAuth2Client(ctx context.Context, client model.OAuth2Client) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertOAuth2Client,
+		client.ClientID, client.Secret, client.RegistrationAccessTokenSignature, client.Policy, client.CreatedAt, client.UpdatedAt, client.Metadata); err != nil {
+		return fmt.Errorf("error inserting oauth2 client with id '%s': %w", client.ClientID, err)
+	}
+
+	return nil
+}
+
+// UpdateOAuth2Client updates the secret, registration access token signature, authorization policy, and metadata of a
+// OAuth2Client in the database.
+func (p *SQLProvider) UpdateOAuth2Client(ctx context.Context, client model.OAuth2Client) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2Client,
+		client.Secret, client.RegistrationAccessTokenSignature, client.Policy, client.UpdatedAt, client.Metadata, client.ClientID); err != nil {
+		return fmt.Errorf("error updating oauth2 client with id '%s': %w", client.ClientID, err)
+	}
+
+	return nil
+}
+
+// LoadOAuth2Client loads a OAuth2Client from the database given the client id.
+func (p *SQLProvider) LoadOAuth2Client(ctx context.Context, clientID string) (client *model.OAuth2Client, err error) {
+	client = &model.OAuth2Client{}
+
+	if err = p.db.GetContext(ctx, client, p.sqlSelectOAuth2Client, clientID); err != nil {
+		return nil, fmt.Errorf("error selecting oauth2 client with id '%s': %w", clientID, err)
+	}
+
+	return client, nil
+}
+
+// LoadOAuth2Clients loads a page of OAuth2Client's from the database.
+func (p *SQLProvider) LoadOAuth2Clients(ctx context.Context, limit, page int) (clients []model.OAuth2Client, err error) {
+	clients = make([]model.OAuth2Client, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &clients, p.sqlSelectOAuth2Clients, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting oauth2 clients: %w", err)
+	}
+
+	return clients, nil
+}
+
+// DeleteOAuth2Client deletes a OAuth2Client from the database given the client id.
+func (p *SQLProvider) DeleteOAuth2Client(ctx context.Context, clientID string) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteOAuth2Client, clientID); err != nil {
+		return fmt.Errorf("error deleting oauth2 client with id '%s': %w", clientID, err)
+	}
+
+	return nil
+}
+
 // SavePreferred2FAMethod save the preferred method for 2FA to the database.
 func (p *SQLProvider) SavePreferred2FAMethod(ctx context.Context, username string, method string) (err error) {
 	if _, err = p.db.ExecContext(ctx, p.sqlUpsertPreferred2FAMethod, username, method); err != nil {
@@ -763,6 +1355,16 @@ func (p *SQLProvider) FindIdentityVerification(ctx context.Context, jti string)
 	}
 }
 
+// PurgeIdentityVerifications deletes the identity verification records which expired before the provided time from the
+// database.
+func (p *SQLProvider) PurgeIdentityVerifications(ctx context.Context, before time.Time) (purged int64, err error) {
+	if purged, err = p.purge(ctx, p.sqlPurgeIdentityVerifications, before); err != nil {
+		return 0, fmt.Errorf("error purging identity verifications which expired before '%s': %w", before, err)
+	}
+
+	return purged, nil
+}
+
 // SaveTOTPConfiguration save a TOTP configuration of a given user in the database.
 func (p *SQLProvider) SaveTOTPConfiguration(ctx context.Context, config model.TOTPConfiguration) (err error) {
 	if config.Secret, err = p.encrypt(config.Secret); err != nil {
@@ -1020,3 +1622,22 @@ func (p *SQLProvider) LoadAuthenticationLogs(ctx context.Context, username strin
 
 	return attempts, nil
 }
+
+// PurgeAuthenticationLogs deletes the authentication logs recorded before the provided time from the database.
+func (p *SQLProvider) PurgeAuthenticationLogs(ctx context.Context, before time.Time) (purged int64, err error) {
+	if purged, err = p.purge(ctx, p.sqlPurgeAuthenticationAttempts, before); err != nil {
+		return 0, fmt.Errorf("error purging authentication logs recorded before '%s': %w", before, err)
+	}
+
+	return purged, nil
+}
+
+func (p *SQLProvider) purge(ctx context.Context, query string, before time.Time) (purged int64, err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, query, before); err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -47,6 +47,7 @@ func NewPostgreSQLProvider(config *schema.Configuration) (provider *PostgreSQLPr
 	provider.sqlSelectIdentityVerification = provider.db.Rebind(provider.sqlSelectIdentityVerification)
 	provider.sqlInsertIdentityVerification = provider.db.Rebind(provider.sqlInsertIdentityVerification)
 	provider.sqlConsumeIdentityVerification = provider.db.Rebind(provider.sqlConsumeIdentityVerification)
+	provider.sqlPurgeIdentityVerifications = provider.db.Rebind(provider.sqlPurgeIdentityVerifications)
 
 	provider.sqlSelectTOTPConfig = provider.db.Rebind(provider.sqlSelectTOTPConfig)
 	provider.sqlUpdateTOTPConfigRecordSignIn = provider.db.Rebind(provider.sqlUpdateTOTPConfigRecordSignIn)
@@ -71,6 +72,7 @@ func NewPostgreSQLProvider(config *schema.Configuration) (provider *PostgreSQLPr
 
 	provider.sqlInsertAuthenticationAttempt = provider.db.Rebind(provider.sqlInsertAuthenticationAttempt)
 	provider.sqlSelectAuthenticationAttemptsByUsername = provider.db.Rebind(provider.sqlSelectAuthenticationAttemptsByUsername)
+	provider.sqlPurgeAuthenticationAttempts = provider.db.Rebind(provider.sqlPurgeAuthenticationAttempts)
 
 	provider.sqlInsertMigration = provider.db.Rebind(provider.sqlInsertMigration)
 	provider.sqlSelectMigrations = provider.db.Rebind(provider.sqlSelectMigrations)
@@ -79,49 +81,98 @@ func NewPostgreSQLProvider(config *schema.Configuration) (provider *PostgreSQLPr
 	provider.sqlSelectEncryptionValue = provider.db.Rebind(provider.sqlSelectEncryptionValue)
 
 	provider.sqlSelectOAuth2ConsentPreConfigurations = provider.db.Rebind(provider.sqlSelectOAuth2ConsentPreConfigurations)
+	provider.sqlSelectOAuth2ConsentPreConfigurationsByUsername = provider.db.Rebind(provider.sqlSelectOAuth2ConsentPreConfigurationsByUsername)
+	provider.sqlRevokeOAuth2ConsentPreConfiguration = provider.db.Rebind(provider.sqlRevokeOAuth2ConsentPreConfiguration)
 
 	provider.sqlInsertOAuth2ConsentSession = provider.db.Rebind(provider.sqlInsertOAuth2ConsentSession)
 	provider.sqlUpdateOAuth2ConsentSessionSubject = provider.db.Rebind(provider.sqlUpdateOAuth2ConsentSessionSubject)
 	provider.sqlUpdateOAuth2ConsentSessionResponse = provider.db.Rebind(provider.sqlUpdateOAuth2ConsentSessionResponse)
 	provider.sqlUpdateOAuth2ConsentSessionGranted = provider.db.Rebind(provider.sqlUpdateOAuth2ConsentSessionGranted)
 	provider.sqlSelectOAuth2ConsentSessionByChallengeID = provider.db.Rebind(provider.sqlSelectOAuth2ConsentSessionByChallengeID)
+	provider.sqlSelectOAuth2ConsentSessionsByUsername = provider.db.Rebind(provider.sqlSelectOAuth2ConsentSessionsByUsername)
+	provider.sqlRevokeOAuth2ConsentSession = provider.db.Rebind(provider.sqlRevokeOAuth2ConsentSession)
+	provider.sqlPurgeOAuth2ConsentSessions = provider.db.Rebind(provider.sqlPurgeOAuth2ConsentSessions)
 
 	provider.sqlInsertOAuth2AuthorizeCodeSession = provider.db.Rebind(provider.sqlInsertOAuth2AuthorizeCodeSession)
 	provider.sqlRevokeOAuth2AuthorizeCodeSession = provider.db.Rebind(provider.sqlRevokeOAuth2AuthorizeCodeSession)
 	provider.sqlRevokeOAuth2AuthorizeCodeSessionByRequestID = provider.db.Rebind(provider.sqlRevokeOAuth2AuthorizeCodeSessionByRequestID)
 	provider.sqlDeactivateOAuth2AuthorizeCodeSession = provider.db.Rebind(provider.sqlDeactivateOAuth2AuthorizeCodeSession)
 	provider.sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID)
+	provider.sqlPurgeOAuth2AuthorizeCodeSessions = provider.db.Rebind(provider.sqlPurgeOAuth2AuthorizeCodeSessions)
 	provider.sqlSelectOAuth2AuthorizeCodeSession = provider.db.Rebind(provider.sqlSelectOAuth2AuthorizeCodeSession)
+	provider.sqlSelectOAuth2AuthorizeCodeSessionRequestIDsByChallengeID = provider.db.Rebind(provider.sqlSelectOAuth2AuthorizeCodeSessionRequestIDsByChallengeID)
 
 	provider.sqlInsertOAuth2AccessTokenSession = provider.db.Rebind(provider.sqlInsertOAuth2AccessTokenSession)
 	provider.sqlRevokeOAuth2AccessTokenSession = provider.db.Rebind(provider.sqlRevokeOAuth2AccessTokenSession)
 	provider.sqlRevokeOAuth2AccessTokenSessionByRequestID = provider.db.Rebind(provider.sqlRevokeOAuth2AccessTokenSessionByRequestID)
 	provider.sqlDeactivateOAuth2AccessTokenSession = provider.db.Rebind(provider.sqlDeactivateOAuth2AccessTokenSession)
 	provider.sqlDeactivateOAuth2AccessTokenSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2AccessTokenSessionByRequestID)
+	provider.sqlPurgeOAuth2AccessTokenSessions = provider.db.Rebind(provider.sqlPurgeOAuth2AccessTokenSessions)
 	provider.sqlSelectOAuth2AccessTokenSession = provider.db.Rebind(provider.sqlSelectOAuth2AccessTokenSession)
+	provider.sqlSelectOAuth2AccessTokenSessionRequestIDsByChallengeID = provider.db.Rebind(provider.sqlSelectOAuth2AccessTokenSessionRequestIDsByChallengeID)
 
 	provider.sqlInsertOAuth2RefreshTokenSession = provider.db.Rebind(provider.sqlInsertOAuth2RefreshTokenSession)
 	provider.sqlRevokeOAuth2RefreshTokenSession = provider.db.Rebind(provider.sqlRevokeOAuth2RefreshTokenSession)
 	provider.sqlRevokeOAuth2RefreshTokenSessionByRequestID = provider.db.Rebind(provider.sqlRevokeOAuth2RefreshTokenSessionByRequestID)
 	provider.sqlDeactivateOAuth2RefreshTokenSession = provider.db.Rebind(provider.sqlDeactivateOAuth2RefreshTokenSession)
 	provider.sqlDeactivateOAuth2RefreshTokenSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2RefreshTokenSessionByRequestID)
+	provider.sqlPurgeOAuth2RefreshTokenSessions = provider.db.Rebind(provider.sqlPurgeOAuth2RefreshTokenSessions)
 	provider.sqlSelectOAuth2RefreshTokenSession = provider.db.Rebind(provider.sqlSelectOAuth2RefreshTokenSession)
+	provider.sqlSelectOAuth2RefreshTokenSessionRequestIDsByChallengeID = provider.db.Rebind(provider.sqlSelectOAuth2RefreshTokenSessionRequestIDsByChallengeID)
+	provider.sqlSelectOAuth2RefreshTokenSessionRotatedAt = provider.db.Rebind(provider.sqlSelectOAuth2RefreshTokenSessionRotatedAt)
 
 	provider.sqlInsertOAuth2PKCERequestSession = provider.db.Rebind(provider.sqlInsertOAuth2PKCERequestSession)
 	provider.sqlRevokeOAuth2PKCERequestSession = provider.db.Rebind(provider.sqlRevokeOAuth2PKCERequestSession)
 	provider.sqlRevokeOAuth2PKCERequestSessionByRequestID = provider.db.Rebind(provider.sqlRevokeOAuth2PKCERequestSessionByRequestID)
 	provider.sqlDeactivateOAuth2PKCERequestSession = provider.db.Rebind(provider.sqlDeactivateOAuth2PKCERequestSession)
 	provider.sqlDeactivateOAuth2PKCERequestSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2PKCERequestSessionByRequestID)
+	provider.sqlPurgeOAuth2PKCERequestSessions = provider.db.Rebind(provider.sqlPurgeOAuth2PKCERequestSessions)
 	provider.sqlSelectOAuth2PKCERequestSession = provider.db.Rebind(provider.sqlSelectOAuth2PKCERequestSession)
+	provider.sqlSelectOAuth2PKCERequestSessionRequestIDsByChallengeID = provider.db.Rebind(provider.sqlSelectOAuth2PKCERequestSessionRequestIDsByChallengeID)
 
 	provider.sqlInsertOAuth2OpenIDConnectSession = provider.db.Rebind(provider.sqlInsertOAuth2OpenIDConnectSession)
 	provider.sqlRevokeOAuth2OpenIDConnectSession = provider.db.Rebind(provider.sqlRevokeOAuth2OpenIDConnectSession)
 	provider.sqlRevokeOAuth2OpenIDConnectSessionByRequestID = provider.db.Rebind(provider.sqlRevokeOAuth2OpenIDConnectSessionByRequestID)
 	provider.sqlDeactivateOAuth2OpenIDConnectSession = provider.db.Rebind(provider.sqlDeactivateOAuth2OpenIDConnectSession)
 	provider.sqlDeactivateOAuth2OpenIDConnectSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2OpenIDConnectSessionByRequestID)
+	provider.sqlPurgeOAuth2OpenIDConnectSessions = provider.db.Rebind(provider.sqlPurgeOAuth2OpenIDConnectSessions)
 	provider.sqlSelectOAuth2OpenIDConnectSession = provider.db.Rebind(provider.sqlSelectOAuth2OpenIDConnectSession)
+	provider.sqlSelectOAuth2OpenIDConnectSessionRequestIDsByChallengeID = provider.db.Rebind(provider.sqlSelectOAuth2OpenIDConnectSessionRequestIDsByChallengeID)
 
 	provider.sqlSelectOAuth2BlacklistedJTI = provider.db.Rebind(provider.sqlSelectOAuth2BlacklistedJTI)
+	provider.sqlPurgeOAuth2BlacklistedJTIs = provider.db.Rebind(provider.sqlPurgeOAuth2BlacklistedJTIs)
+
+	provider.sqlInsertOAuth2BackChannelLogout = provider.db.Rebind(provider.sqlInsertOAuth2BackChannelLogout)
+	provider.sqlUpdateOAuth2BackChannelLogoutAttempt = provider.db.Rebind(provider.sqlUpdateOAuth2BackChannelLogoutAttempt)
+	provider.sqlSelectOAuth2BackChannelLogoutPending = provider.db.Rebind(provider.sqlSelectOAuth2BackChannelLogoutPending)
+	provider.sqlUpdateOAuth2BackChannelLogoutLease = provider.db.Rebind(provider.sqlUpdateOAuth2BackChannelLogoutLease)
+	provider.sqlPurgeOAuth2BackChannelLogouts = provider.db.Rebind(provider.sqlPurgeOAuth2BackChannelLogouts)
+
+	provider.sqlInsertOAuth2IssuerKey = provider.db.Rebind(provider.sqlInsertOAuth2IssuerKey)
+	provider.sqlUpdateOAuth2IssuerKeyPrivateKey = provider.db.Rebind(provider.sqlUpdateOAuth2IssuerKeyPrivateKey)
+	provider.sqlDeleteOAuth2IssuerKey = provider.db.Rebind(provider.sqlDeleteOAuth2IssuerKey)
+
+	provider.sqlInsertOAuth2PARContext = provider.db.Rebind(provider.sqlInsertOAuth2PARContext)
+	provider.sqlSelectOAuth2PARContext = provider.db.Rebind(provider.sqlSelectOAuth2PARContext)
+	provider.sqlRevokeOAuth2PARContext = provider.db.Rebind(provider.sqlRevokeOAuth2PARContext)
+	provider.sqlPurgeOAuth2PARContexts = provider.db.Rebind(provider.sqlPurgeOAuth2PARContexts)
+
+	provider.sqlInsertOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlInsertOAuth2DeviceCodeSession)
+	provider.sqlSelectOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlSelectOAuth2DeviceCodeSession)
+	provider.sqlSelectOAuth2DeviceCodeSessionByUserCode = provider.db.Rebind(provider.sqlSelectOAuth2DeviceCodeSessionByUserCode)
+	provider.sqlSelectOAuth2DeviceCodeSessionByChallengeID = provider.db.Rebind(provider.sqlSelectOAuth2DeviceCodeSessionByChallengeID)
+	provider.sqlUpdateOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlUpdateOAuth2DeviceCodeSession)
+	provider.sqlUpdateOAuth2DeviceCodeSessionStatus = provider.db.Rebind(provider.sqlUpdateOAuth2DeviceCodeSessionStatus)
+	provider.sqlUpdateOAuth2DeviceCodeSessionChallengeID = provider.db.Rebind(provider.sqlUpdateOAuth2DeviceCodeSessionChallengeID)
+	provider.sqlConsumeOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlConsumeOAuth2DeviceCodeSession)
+	provider.sqlUpdateOAuth2DeviceCodeSessionCheckedAt = provider.db.Rebind(provider.sqlUpdateOAuth2DeviceCodeSessionCheckedAt)
+	provider.sqlPurgeOAuth2DeviceCodeSessions = provider.db.Rebind(provider.sqlPurgeOAuth2DeviceCodeSessions)
+
+	provider.sqlInsertOAuth2Client = provider.db.Rebind(provider.sqlInsertOAuth2Client)
+	provider.sqlSelectOAuth2Client = provider.db.Rebind(provider.sqlSelectOAuth2Client)
+	provider.sqlSelectOAuth2Clients = provider.db.Rebind(provider.sqlSelectOAuth2Clients)
+	provider.sqlUpdateOAuth2Client = provider.db.Rebind(provider.sqlUpdateOAuth2Client)
+	provider.sqlDeleteOAuth2Client = provider.db.Rebind(provider.sqlDeleteOAuth2Client)
 
 	provider.schema = config.Storage.PostgreSQL.Schema
 
@@ -15,6 +15,11 @@ import (
 // SchemaEncryptionChangeKey uses the currently configured key to decrypt values in the database and the key provided
 // by this command to encrypt the values again and update them using a transaction.
 func (p *SQLProvider) SchemaEncryptionChangeKey(ctx context.Context, encryptionKey string) (err error) {
+	version, err := p.SchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("error determining schema version to change encryption key: %w", err)
+	}
+
 	tx, err := p.db.Beginx()
 	if err != nil {
 		return fmt.Errorf("error beginning transaction to change encryption key: %w", err)
@@ -30,6 +35,12 @@ func (p *SQLProvider) SchemaEncryptionChangeKey(ctx context.Context, encryptionK
 		return err
 	}
 
+	if version >= 8 {
+		if err = p.schemaEncryptionChangeKeyOAuth2IssuerKeys(ctx, tx, key); err != nil {
+			return err
+		}
+	}
+
 	if err = p.setNewEncryptionCheckValue(ctx, &key, tx); err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			return fmt.Errorf("rollback error %v: rollback due to error: %w", rollbackErr, err)
@@ -117,6 +128,38 @@ func (p *SQLProvider) schemaEncryptionChangeKeyWebauthn(ctx context.Context, tx
 	return nil
 }
 
+func (p *SQLProvider) schemaEncryptionChangeKeyOAuth2IssuerKeys(ctx context.Context, tx *sqlx.Tx, key [32]byte) (err error) {
+	var keys []model.OAuth2IssuerKey
+
+	if keys, err = p.LoadOAuth2IssuerKeys(ctx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("rollback error %v: rollback due to error: %w", rollbackErr, err)
+		}
+
+		return fmt.Errorf("rollback due to error: %w", err)
+	}
+
+	for _, issuerKey := range keys {
+		if issuerKey.PrivateKey, err = utils.Encrypt(issuerKey.PrivateKey, &key); err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return fmt.Errorf("rollback error %v: rollback due to error: %w", rollbackErr, err)
+			}
+
+			return fmt.Errorf("rollback due to error: %w", err)
+		}
+
+		if err = p.updateOAuth2IssuerKeyPrivateKey(ctx, issuerKey); err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return fmt.Errorf("rollback error %v: rollback due to error: %w", rollbackErr, err)
+			}
+
+			return fmt.Errorf("rollback due to error: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // SchemaEncryptionCheckKey checks the encryption key configured is valid for the database.
 func (p *SQLProvider) SchemaEncryptionCheckKey(ctx context.Context, verbose bool) (err error) {
 	version, err := p.SchemaVersion(ctx)
@@ -142,6 +185,12 @@ func (p *SQLProvider) SchemaEncryptionCheckKey(ctx context.Context, verbose bool
 		if err = p.schemaEncryptionCheckWebauthn(ctx); err != nil {
 			errs = append(errs, err)
 		}
+
+		if version >= 8 {
+			if err = p.schemaEncryptionCheckOAuth2IssuerKeys(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
 	}
 
 	if len(errs) != 0 {
@@ -259,6 +308,29 @@ func (p *SQLProvider) schemaEncryptionCheckWebauthn(ctx context.Context) (err er
 	return nil
 }
 
+func (p *SQLProvider) schemaEncryptionCheckOAuth2IssuerKeys(ctx context.Context) (err error) {
+	var (
+		keys    []model.OAuth2IssuerKey
+		invalid int
+	)
+
+	if keys, err = p.loadOAuth2IssuerKeys(ctx); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if _, err = p.decrypt(key.PrivateKey); err != nil {
+			invalid++
+		}
+	}
+
+	if invalid != 0 {
+		return fmt.Errorf("%d of %d total OAuth2 issuer keys were invalid", invalid, len(keys))
+	}
+
+	return nil
+}
+
 func (p *SQLProvider) encrypt(clearText []byte) (cipherText []byte, err error) {
 	return utils.Encrypt(clearText, &p.key)
 }
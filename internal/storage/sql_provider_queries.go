@@ -69,6 +69,10 @@ const (
 		UPDATE %s
 		SET consumed = CURRENT_TIMESTAMP, consumed_ip = ?
 		WHERE jti = ?;`
+
+	queryFmtPurgeIdentityVerifications = `
+		DELETE FROM %s
+		WHERE exp < ?;`
 )
 
 const (
@@ -207,6 +211,10 @@ const (
 		INSERT INTO %s (time, successful, banned, username, auth_type, remote_ip, request_uri, request_method)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
 
+	queryFmtPurgeAuthenticationLogEntries = `
+		DELETE FROM %s
+		WHERE time < ?;`
+
 	queryFmtSelect1FAAuthenticationLogEntryByUsername = `
 		SELECT time, successful, username
 		FROM %s
@@ -240,6 +248,18 @@ const (
 		WHERE client_id = ? AND subject = ? AND
 			  revoked = FALSE AND (expires_at IS NULL OR expires_at >= CURRENT_TIMESTAMP);`
 
+	queryFmtSelectOAuth2ConsentPreConfigurationsByUsername = `
+		SELECT id, client_id, subject, created_at, expires_at, revoked, scopes, audience
+		FROM %s
+		WHERE subject IN (SELECT identifier FROM %s WHERE username = ?) AND
+			  revoked = FALSE AND (expires_at IS NULL OR expires_at >= CURRENT_TIMESTAMP)
+		ORDER BY created_at DESC;`
+
+	queryFmtRevokeOAuth2ConsentPreConfiguration = `
+		UPDATE %s
+		SET revoked = TRUE
+		WHERE id = ?;`
+
 	queryFmtInsertOAuth2ConsentPreConfiguration = `
 		INSERT INTO %s (client_id, subject, created_at, expires_at, revoked, scopes, audience)
 		VALUES(?, ?, ?, ?, ?, ?, ?);`
@@ -255,6 +275,23 @@ const (
 		FROM %s
 		WHERE challenge_id = ?;`
 
+	queryFmtSelectOAuth2ConsentSessionsByUsername = `
+		SELECT id, challenge_id, client_id, subject, authorized, granted, requested_at, responded_at,
+		form_data, requested_scopes, granted_scopes, requested_audience, granted_audience, preconfiguration, revoked
+		FROM %s
+		WHERE subject IN (SELECT identifier FROM %s WHERE username = ?) AND
+			  authorized = TRUE AND granted = TRUE AND revoked = FALSE
+		ORDER BY responded_at DESC;`
+
+	queryFmtRevokeOAuth2ConsentSession = `
+		UPDATE %s
+		SET revoked = TRUE
+		WHERE id = ?;`
+
+	queryFmtPurgeOAuth2ConsentSessions = `
+		DELETE FROM %s
+		WHERE requested_at < ? AND (responded_at IS NULL OR authorized = FALSE OR revoked = TRUE);`
+
 	queryFmtInsertOAuth2ConsentSession = `
 		INSERT INTO %s (challenge_id, client_id, subject, authorized, granted, requested_at, responded_at,
 		form_data, requested_scopes, granted_scopes, requested_audience, granted_audience, preconfiguration)
@@ -293,6 +330,18 @@ const (
 		SET revoked = TRUE
 		WHERE signature = ?;`
 
+	queryFmtSelectOAuth2SessionRequestIDsByChallengeID = `
+		SELECT DISTINCT request_id
+		FROM %s
+		WHERE challenge_id = ? AND revoked = FALSE;`
+
+	queryFmtSelectOAuth2SessionRotatedAt = `
+		SELECT requested_at
+		FROM %s
+		WHERE request_id = ? AND requested_at > ?
+		ORDER BY requested_at ASC
+		LIMIT 1;`
+
 	queryFmtRevokeOAuth2SessionByRequestID = `
 		UPDATE %s
 		SET revoked = TRUE
@@ -308,6 +357,10 @@ const (
 		SET active = FALSE
 		WHERE request_id = ?;`
 
+	queryFmtPurgeOAuth2Sessions = `
+		DELETE FROM %s
+		WHERE requested_at < ?;`
+
 	queryFmtSelectOAuth2BlacklistedJTI = `
 		SELECT id, signature, expires_at
 		FROM %s
@@ -322,6 +375,147 @@ const (
 		VALUES ($1, $2)
 			ON CONFLICT (signature)
 			DO UPDATE SET expires_at = $2;`
+
+	queryFmtPurgeOAuth2BlacklistedJTIs = `
+		DELETE FROM %s
+		WHERE expires_at < ?;`
+
+	queryFmtInsertOAuth2BackChannelLogout = `
+		INSERT INTO %s (jti, issuer, client_id, subject, session_id, uri, logout_token, created_at, lease_expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtUpdateOAuth2BackChannelLogoutAttempt = `
+		UPDATE %s
+		SET attempts = attempts + 1, attempted_at = CURRENT_TIMESTAMP, delivered = ?
+		WHERE jti = ?;`
+
+	queryFmtSelectOAuth2BackChannelLogoutPending = `
+		SELECT id, jti, issuer, client_id, subject, session_id, uri, logout_token, created_at, attempted_at, attempts, delivered, lease_expires_at
+		FROM %s
+		WHERE delivered = FALSE AND attempts < ?
+		ORDER BY id;`
+
+	queryFmtUpdateOAuth2BackChannelLogoutLease = `
+		UPDATE %s
+		SET lease_expires_at = ?
+		WHERE jti = ? AND delivered = FALSE AND attempts < ? AND (lease_expires_at IS NULL OR lease_expires_at < ?);`
+
+	queryFmtPurgeOAuth2BackChannelLogouts = `
+		DELETE FROM %s
+		WHERE created_at < ?;`
+
+	queryFmtInsertOAuth2IssuerKey = `
+		INSERT INTO %s (kid, algorithm, private_key, created_at, not_before, not_after)
+		VALUES (?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectOAuth2IssuerKeys = `
+		SELECT id, kid, algorithm, private_key, created_at, not_before, not_after
+		FROM %s
+		ORDER BY not_before ASC;`
+
+	queryFmtUpdateOAuth2IssuerKeyPrivateKey = `
+		UPDATE %s
+		SET private_key = ?
+		WHERE id = ?;`
+
+	queryFmtDeleteOAuth2IssuerKey = `
+		DELETE FROM %s
+		WHERE kid = ?;`
+
+	queryFmtInsertOAuth2PARContext = `
+		INSERT INTO %s (signature, client_id, requested_at, expires_at, revoked, form_data)
+		VALUES (?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectOAuth2PARContext = `
+		SELECT id, signature, client_id, requested_at, expires_at, revoked, form_data
+		FROM %s
+		WHERE signature = ?;`
+
+	queryFmtRevokeOAuth2PARContext = `
+		UPDATE %s
+		SET revoked = TRUE
+		WHERE signature = ?;`
+
+	queryFmtPurgeOAuth2PARContexts = `
+		DELETE FROM %s
+		WHERE expires_at < ? OR revoked = TRUE;`
+
+	queryFmtInsertOAuth2DeviceCodeSession = `
+		INSERT INTO %s (challenge_id, request_id, client_id, signature, user_code_signature, status, subject, requested_at,
+		expires_at, requested_scopes, granted_scopes, requested_audience, granted_audience, form_data, session_data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectOAuth2DeviceCodeSession = `
+		SELECT id, challenge_id, request_id, client_id, signature, user_code_signature, status, subject, requested_at,
+		checked_at, expires_at, requested_scopes, granted_scopes, requested_audience, granted_audience, form_data, session_data
+		FROM %s
+		WHERE signature = ?;`
+
+	queryFmtSelectOAuth2DeviceCodeSessionByUserCode = `
+		SELECT id, challenge_id, request_id, client_id, signature, user_code_signature, status, subject, requested_at,
+		checked_at, expires_at, requested_scopes, granted_scopes, requested_audience, granted_audience, form_data, session_data
+		FROM %s
+		WHERE user_code_signature = ?;`
+
+	queryFmtSelectOAuth2DeviceCodeSessionByChallengeID = `
+		SELECT id, challenge_id, request_id, client_id, signature, user_code_signature, status, subject, requested_at,
+		checked_at, expires_at, requested_scopes, granted_scopes, requested_audience, granted_audience, form_data, session_data
+		FROM %s
+		WHERE challenge_id = ?;`
+
+	queryFmtUpdateOAuth2DeviceCodeSession = `
+		UPDATE %s
+		SET challenge_id = ?, status = ?, subject = ?, granted_scopes = ?, granted_audience = ?, session_data = ?
+		WHERE signature = ?;`
+
+	queryFmtUpdateOAuth2DeviceCodeSessionStatus = `
+		UPDATE %s
+		SET status = ?
+		WHERE signature = ?;`
+
+	queryFmtUpdateOAuth2DeviceCodeSessionChallengeID = `
+		UPDATE %s
+		SET challenge_id = ?
+		WHERE signature = ? AND status = ?;`
+
+	queryFmtConsumeOAuth2DeviceCodeSession = `
+		UPDATE %s
+		SET status = ?
+		WHERE signature = ? AND status = ?;`
+
+	queryFmtUpdateOAuth2DeviceCodeSessionCheckedAt = `
+		UPDATE %s
+		SET checked_at = ?
+		WHERE signature = ?;`
+
+	queryFmtPurgeOAuth2DeviceCodeSessions = `
+		DELETE FROM %s
+		WHERE expires_at < ?;`
+
+	queryFmtInsertOAuth2Client = `
+		INSERT INTO %s (client_id, client_secret, registration_access_token_signature, authorization_policy, created_at, updated_at, metadata)
+		VALUES (?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectOAuth2Client = `
+		SELECT id, client_id, client_secret, registration_access_token_signature, authorization_policy, created_at, updated_at, metadata
+		FROM %s
+		WHERE client_id = ?;`
+
+	queryFmtSelectOAuth2Clients = `
+		SELECT id, client_id, client_secret, registration_access_token_signature, authorization_policy, created_at, updated_at, metadata
+		FROM %s
+		ORDER BY id ASC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtUpdateOAuth2Client = `
+		UPDATE %s
+		SET client_secret = ?, registration_access_token_signature = ?, authorization_policy = ?, updated_at = ?, metadata = ?
+		WHERE client_id = ?;`
+
+	queryFmtDeleteOAuth2Client = `
+		DELETE FROM %s
+		WHERE client_id = ?;`
 )
 
 const (
@@ -0,0 +1,259 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+func TestSQLProvider_ConsumeOAuth2DeviceCodeSessionConcurrently(t *testing.T) {
+	provider := newTestSQLiteProvider(t)
+
+	ctx := context.Background()
+
+	session := model.OAuth2DeviceCodeSession{
+		RequestID:         uuid.NewString(),
+		ClientID:          "a-client",
+		Signature:         "a-signature",
+		UserCodeSignature: "a-user-code-signature",
+		Status:            model.OAuth2DeviceCodeStatusPending,
+		RequestedAt:       time.Now(),
+		ExpiresAt:         time.Now().Add(time.Minute),
+		Session:           []byte("{}"),
+	}
+
+	require.NoError(t, provider.SaveOAuth2DeviceCodeSession(ctx, session))
+
+	assert.ErrorIs(t, provider.ConsumeOAuth2DeviceCodeSession(ctx, session.Signature), ErrNoApprovedOAuth2DeviceCodeSession)
+
+	require.NoError(t, provider.UpdateOAuth2DeviceCodeSessionStatus(ctx, session.Signature, model.OAuth2DeviceCodeStatusApproved))
+
+	const consumers = 10
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, consumers)
+	)
+
+	for i := 0; i < consumers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			errs[i] = provider.ConsumeOAuth2DeviceCodeSession(ctx, session.Signature)
+		}(i)
+	}
+
+	wg.Wait()
+
+	consumed := 0
+
+	for _, err := range errs {
+		if err == nil {
+			consumed++
+
+			continue
+		}
+
+		assert.ErrorIs(t, err, ErrNoApprovedOAuth2DeviceCodeSession)
+	}
+
+	assert.Equal(t, 1, consumed)
+
+	actual, err := provider.LoadOAuth2DeviceCodeSession(ctx, session.Signature)
+
+	require.NoError(t, err)
+	assert.Equal(t, model.OAuth2DeviceCodeStatusUsed, actual.Status)
+}
+
+func TestSQLProvider_PurgeOAuth2DeviceCodeSessionsPARContextsAndBackChannelLogouts(t *testing.T) {
+	provider := newTestSQLiteProvider(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for _, session := range []model.OAuth2DeviceCodeSession{
+		{Signature: "expired", ExpiresAt: now.Add(-time.Minute)},
+		{Signature: "active", ExpiresAt: now.Add(time.Minute)},
+	} {
+		session.RequestID = uuid.NewString()
+		session.ClientID = "a-client"
+		session.UserCodeSignature = session.Signature
+		session.Status = model.OAuth2DeviceCodeStatusPending
+		session.RequestedAt = now.Add(-time.Hour)
+		session.Session = []byte("{}")
+
+		require.NoError(t, provider.SaveOAuth2DeviceCodeSession(ctx, session))
+	}
+
+	for _, par := range []model.OAuth2PARContext{
+		{Signature: "expired", ExpiresAt: now.Add(-time.Minute)},
+		{Signature: "revoked", ExpiresAt: now.Add(time.Minute), Revoked: true},
+		{Signature: "active", ExpiresAt: now.Add(time.Minute)},
+	} {
+		par.ClientID = "a-client"
+		par.RequestedAt = now.Add(-time.Hour)
+		par.Form = "client_id=a-client"
+
+		require.NoError(t, provider.SaveOAuth2PARContext(ctx, par))
+	}
+
+	subject, err := model.NewUserOpaqueIdentifier("openid", "", "john")
+
+	require.NoError(t, err)
+	require.NoError(t, provider.SaveUserOpaqueIdentifier(ctx, *subject))
+
+	for _, logout := range []model.OAuth2BackChannelLogout{
+		{JTI: uuid.NewString(), CreatedAt: now.Add(-time.Hour * 25)},
+		{JTI: uuid.NewString(), CreatedAt: now.Add(-time.Hour * 25)},
+		{JTI: uuid.NewString(), CreatedAt: now},
+	} {
+		logout.ClientID = "a-client"
+		logout.Subject = subject.Identifier.String()
+		logout.SessionID = uuid.NewString()
+		logout.URI = "https://app.example.com/logout"
+		logout.LogoutToken = "a-logout-token"
+
+		require.NoError(t, provider.SaveOAuth2BackChannelLogout(ctx, logout))
+	}
+
+	purged, err := provider.PurgeOAuth2DeviceCodeSessions(ctx, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	_, err = provider.LoadOAuth2DeviceCodeSession(ctx, "expired")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = provider.LoadOAuth2DeviceCodeSession(ctx, "active")
+	assert.NoError(t, err)
+
+	purged, err = provider.PurgeOAuth2PARContexts(ctx, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+
+	for _, signature := range []string{"expired", "revoked"} {
+		_, err = provider.LoadOAuth2PARContext(ctx, signature)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	}
+
+	_, err = provider.LoadOAuth2PARContext(ctx, "active")
+	assert.NoError(t, err)
+
+	purged, err = provider.PurgeOAuth2BackChannelLogouts(ctx, now.Add(-time.Hour*24))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+
+	logouts, err := provider.LoadOAuth2BackChannelLogoutsPending(ctx, 3)
+
+	require.NoError(t, err)
+	require.Len(t, logouts, 1)
+	assert.Equal(t, now, logouts[0].CreatedAt.UTC())
+}
+
+func TestSQLProvider_UpdateOAuth2BackChannelLogoutLeaseConcurrently(t *testing.T) {
+	provider := newTestSQLiteProvider(t)
+
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	subject, err := model.NewUserOpaqueIdentifier("openid", "", "john")
+
+	require.NoError(t, err)
+	require.NoError(t, provider.SaveUserOpaqueIdentifier(ctx, *subject))
+
+	logout := model.OAuth2BackChannelLogout{
+		JTI:         uuid.NewString(),
+		Issuer:      "https://auth.example.com",
+		ClientID:    "a-client",
+		Subject:     subject.Identifier.String(),
+		SessionID:   uuid.NewString(),
+		URI:         "https://app.example.com/logout",
+		LogoutToken: "a-logout-token",
+		CreatedAt:   now,
+	}
+
+	require.NoError(t, provider.SaveOAuth2BackChannelLogout(ctx, logout))
+
+	const instances = 10
+
+	var (
+		wg      sync.WaitGroup
+		claimed = make([]bool, instances)
+		errs    = make([]error, instances)
+	)
+
+	for i := 0; i < instances; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			claimed[i], errs[i] = provider.UpdateOAuth2BackChannelLogoutLease(ctx, logout.JTI, 3, now, now.Add(time.Minute*5))
+		}(i)
+	}
+
+	wg.Wait()
+
+	leases := 0
+
+	for i := 0; i < instances; i++ {
+		require.NoError(t, errs[i])
+
+		if claimed[i] {
+			leases++
+		}
+	}
+
+	assert.Equal(t, 1, leases)
+
+	logouts, err := provider.LoadOAuth2BackChannelLogoutsPending(ctx, 3)
+
+	require.NoError(t, err)
+	require.Len(t, logouts, 1)
+	assert.Equal(t, "https://auth.example.com", logouts[0].Issuer)
+	assert.True(t, logouts[0].LeaseExpiresAt.Valid)
+
+	claimed[0], err = provider.UpdateOAuth2BackChannelLogoutLease(ctx, logout.JTI, 3, now.Add(time.Minute*6), now.Add(time.Minute*11))
+
+	require.NoError(t, err)
+	assert.True(t, claimed[0], "the back-channel logout should be claimable once the lease has expired")
+
+	require.NoError(t, provider.UpdateOAuth2BackChannelLogoutAttempt(ctx, logout.JTI, true))
+
+	claimed[0], err = provider.UpdateOAuth2BackChannelLogoutLease(ctx, logout.JTI, 3, now.Add(time.Minute*12), now.Add(time.Minute*17))
+
+	require.NoError(t, err)
+	assert.False(t, claimed[0], "a delivered back-channel logout should not be claimable")
+}
+
+func newTestSQLiteProvider(t *testing.T) *SQLiteProvider {
+	provider := NewSQLiteProvider(&schema.Configuration{
+		Storage: schema.StorageConfiguration{
+			EncryptionKey: "a-very-long-encryption-key-for-testing",
+			Local:         &schema.LocalStorageConfiguration{Path: filepath.Join(t.TempDir(), "db.sqlite3")},
+		},
+	})
+
+	require.NoError(t, provider.StartupCheck())
+
+	t.Cleanup(func() {
+		_ = provider.Close()
+	})
+
+	return provider
+}
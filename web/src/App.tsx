@@ -9,6 +9,9 @@ import { Route, BrowserRouter as Router, Routes } from "react-router-dom";
 import NotificationBar from "@components/NotificationBar";
 import {
     ConsentRoute,
+    ConsentsRoute,
+    DeviceRoute,
+    EndSessionRoute,
     IndexRoute,
     LogoutRoute,
     RegisterOneTimePasswordRoute,
@@ -31,6 +34,9 @@ import RegisterOneTimePassword from "@views/DeviceRegistration/RegisterOneTimePa
 import RegisterWebauthn from "@views/DeviceRegistration/RegisterWebauthn";
 import BaseLoadingPage from "@views/LoadingPage/BaseLoadingPage";
 import ConsentView from "@views/LoginPortal/ConsentView/ConsentView";
+import ConsentsView from "@views/LoginPortal/ConsentsView/ConsentsView";
+import DeviceView from "@views/LoginPortal/DeviceView/DeviceView";
+import EndSessionView from "@views/LoginPortal/EndSessionView/EndSessionView";
 import LoginPortal from "@views/LoginPortal/LoginPortal";
 import SignOut from "@views/LoginPortal/SignOut/SignOut";
 import ResetPasswordStep1 from "@views/ResetPassword/ResetPasswordStep1";
@@ -93,6 +99,9 @@ const App: React.FC<Props> = (props: Props) => {
                                 <Route path={RegisterOneTimePasswordRoute} element={<RegisterOneTimePassword />} />
                                 <Route path={LogoutRoute} element={<SignOut />} />
                                 <Route path={ConsentRoute} element={<ConsentView />} />
+                                <Route path={ConsentsRoute} element={<ConsentsView />} />
+                                <Route path={DeviceRoute} element={<DeviceView />} />
+                                <Route path={EndSessionRoute} element={<EndSessionView />} />
                                 <Route
                                     path={`${IndexRoute}*`}
                                     element={
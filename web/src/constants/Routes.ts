@@ -1,6 +1,9 @@
 export const IndexRoute: string = "/";
 export const AuthenticatedRoute: string = "/authenticated";
 export const ConsentRoute: string = "/consent";
+export const ConsentsRoute: string = "/consents";
+export const DeviceRoute: string = "/device";
+export const EndSessionRoute: string = "/end-session";
 
 export const SecondFactorRoute: string = "/2fa/";
 export const SecondFactorWebauthnSubRoute: string = "webauthn";
@@ -1 +1,4 @@
 export const Identifier = "id";
+export const UserCode = "user_code";
+export const Result = "result";
+export const Prompt = "prompt";
@@ -6,6 +6,8 @@ const basePath = getBasePath();
 
 // Note: If you change this const you must also do so in the backend at internal/handlers/cost.go.
 export const ConsentPath = basePath + "/api/oidc/consent";
+export const DeviceVerificationPath = basePath + "/api/oidc/device-verification";
+export const EndSessionConfirmationPath = basePath + "/api/oidc/end-session/confirmation";
 
 export const FirstFactorPath = basePath + "/api/firstfactor";
 export const InitiateTOTPRegistrationPath = basePath + "/api/secondfactor/totp/identity/start";
@@ -35,6 +37,7 @@ export const StatePath = basePath + "/api/state";
 export const UserInfoPath = basePath + "/api/user/info";
 export const UserInfo2FAMethodPath = basePath + "/api/user/info/2fa_method";
 export const UserInfoTOTPConfigurationPath = basePath + "/api/user/info/totp";
+export const UserOpenIDConnectConsentsPath = basePath + "/api/user/oidc/consents";
 
 export const ConfigurationPath = basePath + "/api/configuration";
 export const PasswordPolicyConfigurationPath = basePath + "/api/configuration/password-policy";
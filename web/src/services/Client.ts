@@ -19,6 +19,15 @@ export async function Post<T>(path: string, body?: any) {
     return res;
 }
 
+export async function Delete<T = undefined>(path: string, body?: any): Promise<T | undefined> {
+    const res = await axios.delete<ServiceResponse<T>>(path, { data: body });
+
+    if (res.status !== 200 || hasServiceError(res).errored) {
+        throw new Error(`Failed DELETE to ${path}. Code: ${res.status}. Message: ${hasServiceError(res).message}`);
+    }
+    return toData<T>(res);
+}
+
 export async function Get<T = undefined>(path: string): Promise<T> {
     const res = await axios.get<ServiceResponse<T>>(path);
 
@@ -17,7 +17,10 @@ export interface ConsentGetResponseBody {
     client_description: string;
     scopes: string[];
     audience: string[];
+    claims: string[] | null;
+    essential_claims: string[] | null;
     pre_configuration: boolean;
+    user_code?: string;
 }
 
 export function getConsentResponse(consentID: string) {
@@ -0,0 +1,36 @@
+import { EndSessionConfirmationPath } from "@services/Api";
+import { Get, Post } from "@services/Client";
+
+interface EndSessionConfirmationPostRequestBody {
+    id: string;
+    confirm: boolean;
+}
+
+interface EndSessionConfirmationPostResponseBody {
+    redirect_uri: string;
+}
+
+export interface EndSessionConfirmationGetResponseBody {
+    client_id: string;
+    client_description: string;
+}
+
+export function getEndSessionConfirmation(id: string) {
+    return Get<EndSessionConfirmationGetResponseBody>(EndSessionConfirmationPath + "?id=" + id);
+}
+
+export function confirmEndSession(id: string) {
+    const body: EndSessionConfirmationPostRequestBody = {
+        id: id,
+        confirm: true,
+    };
+    return Post<EndSessionConfirmationPostResponseBody>(EndSessionConfirmationPath, body);
+}
+
+export function cancelEndSession(id: string) {
+    const body: EndSessionConfirmationPostRequestBody = {
+        id: id,
+        confirm: false,
+    };
+    return Post<EndSessionConfirmationPostResponseBody>(EndSessionConfirmationPath, body);
+}
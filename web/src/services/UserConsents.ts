@@ -0,0 +1,52 @@
+import { UserOpenIDConnectConsentsPath } from "@services/Api";
+import { Delete, Get } from "@services/Client";
+
+export interface UserConsent {
+    id: number;
+    scopes: string[];
+    audience: string[];
+    granted_at?: string;
+}
+
+export interface UserConsentPreConfiguration {
+    id: number;
+    scopes: string[];
+    audience: string[];
+    created_at: string;
+    expires_at?: string;
+}
+
+export interface UserConsentsClient {
+    client_id: string;
+    client_description: string;
+    consents: UserConsent[];
+    pre_configurations: UserConsentPreConfiguration[];
+}
+
+interface UserConsentsDeleteRequestBody {
+    client_id: string;
+    consent_id?: number;
+    pre_configuration_id?: number;
+}
+
+export function getUserConsents() {
+    return Get<UserConsentsClient[]>(UserOpenIDConnectConsentsPath);
+}
+
+export function revokeUserConsents(clientID: string) {
+    const body: UserConsentsDeleteRequestBody = { client_id: clientID };
+
+    return Delete(UserOpenIDConnectConsentsPath, body);
+}
+
+export function revokeUserConsent(clientID: string, consentID: number) {
+    const body: UserConsentsDeleteRequestBody = { client_id: clientID, consent_id: consentID };
+
+    return Delete(UserOpenIDConnectConsentsPath, body);
+}
+
+export function revokeUserConsentPreConfiguration(clientID: string, preConfigurationID: number) {
+    const body: UserConsentsDeleteRequestBody = { client_id: clientID, pre_configuration_id: preConfigurationID };
+
+    return Delete(UserOpenIDConnectConsentsPath, body);
+}
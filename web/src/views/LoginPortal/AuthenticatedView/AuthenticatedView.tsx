@@ -5,7 +5,7 @@ import makeStyles from "@mui/styles/makeStyles";
 import { useTranslation } from "react-i18next";
 import { useNavigate } from "react-router-dom";
 
-import { LogoutRoute as SignOutRoute } from "@constants/Routes";
+import { ConsentsRoute, LogoutRoute as SignOutRoute } from "@constants/Routes";
 import LoginLayout from "@layouts/LoginLayout";
 import Authenticated from "@views/LoginPortal/Authenticated";
 
@@ -22,6 +22,10 @@ const AuthenticatedView = function (props: Props) {
         navigate(SignOutRoute);
     };
 
+    const handleConsentsClick = () => {
+        navigate(ConsentsRoute);
+    };
+
     return (
         <LoginLayout id="authenticated-stage" title={`${translate("Hi")} ${props.name}`} showBrand>
             <Grid container>
@@ -33,6 +37,11 @@ const AuthenticatedView = function (props: Props) {
                 <Grid item xs={12} className={styles.mainContainer}>
                     <Authenticated />
                 </Grid>
+                <Grid item xs={12}>
+                    <Button color="primary" onClick={handleConsentsClick} id="consents-button">
+                        {translate("Manage authorized applications")}
+                    </Button>
+                </Grid>
             </Grid>
         </LoginLayout>
     );
@@ -160,6 +160,20 @@ const ConsentView = function (props: Props) {
                             </Tooltip>
                         </div>
                     </Grid>
+                    {response?.user_code ? (
+                        <Fragment>
+                            <Grid item xs={12}>
+                                <div>
+                                    {translate("Only accept if this code matches the code displayed on your device")}:
+                                </div>
+                            </Grid>
+                            <Grid item xs={12}>
+                                <Typography id="user-code" className={styles.userCode}>
+                                    {response.user_code}
+                                </Typography>
+                            </Grid>
+                        </Fragment>
+                    ) : null}
                     <Grid item xs={12}>
                         <div>{translate("The above application is requesting the following permissions")}:</div>
                     </Grid>
@@ -177,6 +191,36 @@ const ConsentView = function (props: Props) {
                             </List>
                         </div>
                     </Grid>
+                    {response?.claims && response.claims.length !== 0 ? (
+                        <Fragment>
+                            <Grid item xs={12}>
+                                <div>{translate("The above application is also requesting the following claims")}:</div>
+                            </Grid>
+                            <Grid item xs={12}>
+                                <div className={styles.scopesListContainer}>
+                                    <List className={styles.scopesList}>
+                                        {response.claims.map((claim: string) => (
+                                            <Tooltip title={"Claim " + claim}>
+                                                <ListItem id={"claim-" + claim} dense>
+                                                    <ListItemIcon>
+                                                        <CheckBox />
+                                                    </ListItemIcon>
+                                                    <ListItemText
+                                                        primary={claim}
+                                                        secondary={
+                                                            response.essential_claims?.includes(claim)
+                                                                ? translate("Required")
+                                                                : undefined
+                                                        }
+                                                    />
+                                                </ListItem>
+                                            </Tooltip>
+                                        ))}
+                                    </List>
+                                </div>
+                            </Grid>
+                        </Fragment>
+                    ) : null}
                     {response?.pre_configuration ? (
                         <Grid item xs={12}>
                             <Tooltip
@@ -245,6 +289,12 @@ const useStyles = makeStyles((theme: Theme) => ({
     clientDescription: {
         fontWeight: 600,
     },
+    userCode: {
+        fontFamily: "monospace",
+        fontSize: "1.5rem",
+        fontWeight: 600,
+        letterSpacing: "0.2rem",
+    },
     scopesListContainer: {
         textAlign: "center",
     },
@@ -0,0 +1,167 @@
+import React, { useCallback, useEffect, useState } from "react";
+
+import { Button, Grid, List, ListItem, ListItemText, Theme, Typography } from "@mui/material";
+import makeStyles from "@mui/styles/makeStyles";
+import { useTranslation } from "react-i18next";
+import { useNavigate } from "react-router-dom";
+
+import { IndexRoute } from "@constants/Routes";
+import { useNotifications } from "@hooks/NotificationsContext";
+import LoginLayout from "@layouts/LoginLayout";
+import {
+    UserConsentsClient,
+    getUserConsents,
+    revokeUserConsent,
+    revokeUserConsentPreConfiguration,
+    revokeUserConsents,
+} from "@services/UserConsents";
+import LoadingPage from "@views/LoadingPage/LoadingPage";
+
+export interface Props {}
+
+const ConsentsView = function (props: Props) {
+    const styles = useStyles();
+    const { t: translate } = useTranslation();
+    const navigate = useNavigate();
+    const { createSuccessNotification, createErrorNotification } = useNotifications();
+    const [clients, setClients] = useState<UserConsentsClient[] | undefined>(undefined);
+
+    const fetchClients = useCallback(() => {
+        getUserConsents()
+            .then((r) => {
+                setClients(r);
+            })
+            .catch((error) => {
+                console.error(`Unable to load the authorized applications: ${error.message}`);
+                createErrorNotification(translate("There was an issue loading the authorized applications"));
+                navigate(IndexRoute);
+            });
+    }, [createErrorNotification, navigate, translate]);
+
+    useEffect(() => {
+        fetchClients();
+    }, [fetchClients]);
+
+    const handleRevoke = async (revoke: () => Promise<any>) => {
+        try {
+            await revoke();
+            createSuccessNotification(translate("Access has been revoked"));
+        } catch (err) {
+            console.error(err);
+            createErrorNotification(translate("There was an issue revoking access"));
+        }
+
+        fetchClients();
+    };
+
+    const formatDate = (value: string) => new Date(value).toLocaleString();
+
+    if (clients === undefined) {
+        return <LoadingPage />;
+    }
+
+    return (
+        <LoginLayout id="consents-stage" title={translate("Authorized Applications")} showBrand>
+            <Grid container className={styles.root} spacing={2}>
+                {clients.length === 0 ? (
+                    <Grid item xs={12}>
+                        <Typography id="consents-empty" className={styles.typo}>
+                            {translate("You have not authorized any applications")}
+                        </Typography>
+                    </Grid>
+                ) : null}
+                {clients.map((client) => (
+                    <Grid item xs={12} key={client.client_id} id={`consents-client-${client.client_id}`}>
+                        <div className={styles.clientContainer}>
+                            <Typography variant="h6">{client.client_description}</Typography>
+                            <Typography variant="caption">
+                                {translate("Client ID", { client_id: client.client_id })}
+                            </Typography>
+                            <List dense>
+                                {client.pre_configurations.map((config) => (
+                                    <ListItem
+                                        key={`pre-configuration-${config.id}`}
+                                        secondaryAction={
+                                            <Button
+                                                color="secondary"
+                                                onClick={() =>
+                                                    handleRevoke(() =>
+                                                        revokeUserConsentPreConfiguration(client.client_id, config.id),
+                                                    )
+                                                }
+                                            >
+                                                {translate("Revoke")}
+                                            </Button>
+                                        }
+                                    >
+                                        <ListItemText
+                                            primary={config.scopes.join(", ")}
+                                            secondary={
+                                                config.expires_at
+                                                    ? translate("Remembered until", {
+                                                          date: formatDate(config.expires_at),
+                                                      })
+                                                    : translate("Remembered")
+                                            }
+                                        />
+                                    </ListItem>
+                                ))}
+                                {client.consents.map((consent) => (
+                                    <ListItem
+                                        key={`consent-${consent.id}`}
+                                        secondaryAction={
+                                            <Button
+                                                color="secondary"
+                                                onClick={() =>
+                                                    handleRevoke(() => revokeUserConsent(client.client_id, consent.id))
+                                                }
+                                            >
+                                                {translate("Revoke")}
+                                            </Button>
+                                        }
+                                    >
+                                        <ListItemText
+                                            primary={consent.scopes.join(", ")}
+                                            secondary={
+                                                consent.granted_at
+                                                    ? translate("Granted", { date: formatDate(consent.granted_at) })
+                                                    : undefined
+                                            }
+                                        />
+                                    </ListItem>
+                                ))}
+                            </List>
+                            <Button
+                                id={`revoke-button-${client.client_id}`}
+                                variant="contained"
+                                color="primary"
+                                fullWidth
+                                onClick={() => handleRevoke(() => revokeUserConsents(client.client_id))}
+                            >
+                                {translate("Revoke")}
+                            </Button>
+                        </div>
+                    </Grid>
+                ))}
+            </Grid>
+        </LoginLayout>
+    );
+};
+
+export default ConsentsView;
+
+const useStyles = makeStyles((theme: Theme) => ({
+    root: {
+        marginTop: theme.spacing(2),
+        marginBottom: theme.spacing(2),
+    },
+    typo: {
+        padding: theme.spacing(),
+    },
+    clientContainer: {
+        border: "1px solid #d6d6d6",
+        borderRadius: "10px",
+        padding: theme.spacing(2),
+        textAlign: "left",
+    },
+}));
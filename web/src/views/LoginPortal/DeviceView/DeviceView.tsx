@@ -0,0 +1,98 @@
+import React, { useState } from "react";
+
+import { Button, Grid, Theme, Typography } from "@mui/material";
+import makeStyles from "@mui/styles/makeStyles";
+import { useTranslation } from "react-i18next";
+import { useSearchParams } from "react-router-dom";
+
+import FixedTextField from "@components/FixedTextField";
+import { Result, UserCode } from "@constants/SearchParams";
+import { useRedirector } from "@hooks/Redirector";
+import LoginLayout from "@layouts/LoginLayout";
+import { DeviceVerificationPath } from "@services/Api";
+
+export interface Props {}
+
+const DeviceView = function (props: Props) {
+    const styles = useStyles();
+    const { t: translate } = useTranslation();
+    const [searchParams] = useSearchParams();
+    const redirect = useRedirector();
+    const result = searchParams.get(Result);
+    const [userCode, setUserCode] = useState(searchParams.get(UserCode) || "");
+    const [error, setError] = useState(false);
+
+    const handleSubmit = () => {
+        if (userCode.trim() === "") {
+            setError(true);
+            return;
+        }
+
+        redirect(`${DeviceVerificationPath}?${UserCode}=${encodeURIComponent(userCode.trim())}`);
+    };
+
+    if (result === "approved" || result === "denied") {
+        return (
+            <LoginLayout id="device-stage" title={translate("Device Authorization")} showBrand>
+                <Typography id="device-result" className={styles.typo}>
+                    {result === "approved"
+                        ? translate("The device has been authorized, you may now close this window")
+                        : translate("The device authorization has been denied, you may now close this window")}
+                </Typography>
+            </LoginLayout>
+        );
+    }
+
+    return (
+        <LoginLayout
+            id="device-stage"
+            title={translate("Device Authorization")}
+            subtitle={translate("Enter the code displayed on your device")}
+            showBrand
+        >
+            <Grid container className={styles.root} spacing={2}>
+                {result === "invalid" ? (
+                    <Grid item xs={12}>
+                        <Typography id="device-result" color="error">
+                            {translate("The code is invalid or has expired")}
+                        </Typography>
+                    </Grid>
+                ) : null}
+                <Grid item xs={12}>
+                    <FixedTextField
+                        id="user-code-textfield"
+                        label={translate("Code")}
+                        variant="outlined"
+                        fullWidth
+                        error={error}
+                        value={userCode}
+                        onChange={(e) => setUserCode(e.target.value)}
+                        onKeyPress={(ev) => {
+                            if (ev.key === "Enter") {
+                                handleSubmit();
+                                ev.preventDefault();
+                            }
+                        }}
+                    />
+                </Grid>
+                <Grid item xs={12}>
+                    <Button id="submit-button" variant="contained" color="primary" fullWidth onClick={handleSubmit}>
+                        {translate("Continue")}
+                    </Button>
+                </Grid>
+            </Grid>
+        </LoginLayout>
+    );
+};
+
+export default DeviceView;
+
+const useStyles = makeStyles((theme: Theme) => ({
+    root: {
+        marginTop: theme.spacing(2),
+        marginBottom: theme.spacing(2),
+    },
+    typo: {
+        padding: theme.spacing(),
+    },
+}));
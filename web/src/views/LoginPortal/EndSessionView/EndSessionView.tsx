@@ -0,0 +1,143 @@
+import React, { useEffect, useState } from "react";
+
+import { Button, Grid, Theme, Tooltip, Typography } from "@mui/material";
+import makeStyles from "@mui/styles/makeStyles";
+import { useTranslation } from "react-i18next";
+import { useNavigate, useSearchParams } from "react-router-dom";
+
+import { IndexRoute } from "@constants/Routes";
+import { Identifier } from "@constants/SearchParams";
+import { useRedirector } from "@hooks/Redirector";
+import { useUserInfoGET } from "@hooks/UserInfo";
+import LoginLayout from "@layouts/LoginLayout";
+import {
+    EndSessionConfirmationGetResponseBody,
+    cancelEndSession,
+    confirmEndSession,
+    getEndSessionConfirmation,
+} from "@services/EndSession";
+import LoadingPage from "@views/LoadingPage/LoadingPage";
+
+export interface Props {}
+
+const EndSessionView = function (props: Props) {
+    const styles = useStyles();
+    const { t: translate } = useTranslation();
+    const navigate = useNavigate();
+    const [searchParams] = useSearchParams();
+    const redirect = useRedirector();
+    const id = searchParams.get(Identifier);
+    const [response, setResponse] = useState<EndSessionConfirmationGetResponseBody | undefined>(undefined);
+    const [error, setError] = useState<any>(undefined);
+
+    const [userInfo, fetchUserInfo, , fetchUserInfoError] = useUserInfoGET();
+
+    useEffect(() => {
+        fetchUserInfo();
+    }, [fetchUserInfo]);
+
+    useEffect(() => {
+        if (id === null) {
+            setError(new Error("The logout request identifier is missing"));
+            return;
+        }
+
+        getEndSessionConfirmation(id)
+            .then((r) => {
+                setResponse(r);
+            })
+            .catch((error) => {
+                setError(error);
+            });
+    }, [id]);
+
+    useEffect(() => {
+        if (error || fetchUserInfoError) {
+            navigate(IndexRoute);
+            console.error(`Unable to display logout confirmation screen: ${(error || fetchUserInfoError).message}`);
+        }
+    }, [navigate, error, fetchUserInfoError]);
+
+    const handleResponse = async (confirm: boolean) => {
+        // This case should not happen in theory because the buttons are disabled when response is undefined.
+        if (!response || id === null) {
+            return;
+        }
+        const res = confirm ? await confirmEndSession(id) : await cancelEndSession(id);
+        if (res.redirect_uri) {
+            redirect(res.redirect_uri);
+        } else {
+            throw new Error("Unable to redirect the user");
+        }
+    };
+
+    if (response === undefined || userInfo === undefined) {
+        return <LoadingPage />;
+    }
+
+    return (
+        <LoginLayout
+            id="end-session-stage"
+            title={`${translate("Hi")} ${userInfo.display_name}`}
+            subtitle={translate("Logout Request")}
+            showBrand
+        >
+            <Grid container className={styles.root} spacing={2}>
+                {response.client_id !== "" ? (
+                    <Grid item xs={12}>
+                        <Tooltip
+                            title={
+                                translate("Client ID", { client_id: response.client_id }) ||
+                                "Client ID: " + response.client_id
+                            }
+                        >
+                            <Typography className={styles.clientDescription}>
+                                {response.client_description !== "" ? response.client_description : response.client_id}
+                            </Typography>
+                        </Tooltip>
+                    </Grid>
+                ) : null}
+                <Grid item xs={12}>
+                    <Typography>{translate("Do you want to log out of all applications?")}</Typography>
+                </Grid>
+                <Grid item xs={6}>
+                    <Button
+                        id="logout-button"
+                        className={styles.button}
+                        onClick={() => handleResponse(true)}
+                        color="primary"
+                        variant="contained"
+                    >
+                        {translate("Logout")}
+                    </Button>
+                </Grid>
+                <Grid item xs={6}>
+                    <Button
+                        id="cancel-button"
+                        className={styles.button}
+                        onClick={() => handleResponse(false)}
+                        color="secondary"
+                        variant="contained"
+                    >
+                        {translate("Cancel")}
+                    </Button>
+                </Grid>
+            </Grid>
+        </LoginLayout>
+    );
+};
+
+export default EndSessionView;
+
+const useStyles = makeStyles((theme: Theme) => ({
+    root: {
+        marginTop: theme.spacing(2),
+        marginBottom: theme.spacing(2),
+    },
+    clientDescription: {
+        fontWeight: 600,
+    },
+    button: {
+        width: "100%",
+    },
+}));
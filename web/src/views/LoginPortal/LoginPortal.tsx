@@ -1,4 +1,4 @@
-import React, { Fragment, ReactNode, useCallback, useEffect, useState } from "react";
+import React, { Fragment, ReactNode, useCallback, useEffect, useMemo, useState } from "react";
 
 import { Route, Routes, useLocation, useNavigate, useSearchParams } from "react-router-dom";
 
@@ -10,6 +10,7 @@ import {
     SecondFactorTOTPSubRoute,
     SecondFactorWebauthnSubRoute,
 } from "@constants/Routes";
+import { Prompt } from "@constants/SearchParams";
 import { useConfiguration } from "@hooks/Configuration";
 import { useNotifications } from "@hooks/NotificationsContext";
 import { useRedirectionURL } from "@hooks/RedirectionURL";
@@ -44,10 +45,21 @@ const LoginPortal = function (props: Props) {
     const [broadcastRedirect, setBroadcastRedirect] = useState(false);
     const redirector = useRedirector();
 
-    const [state, fetchState, , fetchStateError] = useAutheliaState();
+    const [currentState, fetchState, , fetchStateError] = useAutheliaState();
     const [userInfo, fetchUserInfo, , fetchUserInfoError] = useUserInfoPOST();
     const [configuration, fetchConfiguration, , fetchConfigurationError] = useConfiguration();
     const [searchParams] = useSearchParams();
+    const [reauthenticate, setReauthenticate] = useState(searchParams.get(Prompt) === "login");
+
+    // The user is treated as unauthenticated until they authenticate again when the OpenID Connect 1.0 authorization
+    // request requires it, their existing session is left untouched until then.
+    const state = useMemo(
+        () =>
+            currentState && reauthenticate
+                ? { ...currentState, authentication_level: AuthenticationLevel.Unauthenticated }
+                : currentState,
+        [currentState, reauthenticate],
+    );
 
     const redirect = useCallback(
         (
@@ -170,6 +182,8 @@ const LoginPortal = function (props: Props) {
     };
 
     const handleAuthSuccess = async (redirectionURL: string | undefined) => {
+        setReauthenticate(false);
+
         if (redirectionURL) {
             // Do an external redirection pushed by the server.
             redirector(redirectionURL);